@@ -0,0 +1,37 @@
+package client
+
+import (
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// Requester for having the daemon attest to its node's identity for a hosted service
+type AttestationRequester struct {
+	context IRequesterContext
+}
+
+// Creates a new attestation requester
+func NewAttestationRequester(context IRequesterContext) *AttestationRequester {
+	return &AttestationRequester{
+		context: context,
+	}
+}
+
+func (r *AttestationRequester) GetName() string {
+	return "Attestation"
+}
+func (r *AttestationRequester) GetRoute() string {
+	return "attestation"
+}
+func (r *AttestationRequester) GetContext() IRequesterContext {
+	return r.context
+}
+
+// Sign an attestation for a service's challenge nonce. Pass the result back to the service, which can check it with
+// wallet.VerifyNodeAttestation.
+func (r *AttestationRequester) Attest(service string, nonce []byte) (*types.ApiResponse[types.NodeAttestationData], error) {
+	body := types.NodeAttestationBody{
+		Service: service,
+		Nonce:   nonce,
+	}
+	return SendPostRequest[types.NodeAttestationData](r, "attest", "Attest", body)
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/goccy/go-json"
+	"github.com/google/uuid"
 	"github.com/rocket-pool/node-manager-core/api/types"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/log"
@@ -54,13 +55,19 @@ func RawGetRequest[DataType any](context IRequesterContext, path string, params
 
 // Submit a POST request to the API server
 func SendPostRequest[DataType any](r IRequester, method string, requestName string, body any) (*types.ApiResponse[DataType], error) {
+	return SendIdempotentPostRequest[DataType](r, method, requestName, body, "")
+}
+
+// Submit a POST request to the API server with an idempotency key, so the server returns the original response
+// instead of running the request again if it's retried with the same key. Use an empty key to send it without one.
+func SendIdempotentPostRequest[DataType any](r IRequester, method string, requestName string, body any, idempotencyKey string) (*types.ApiResponse[DataType], error) {
 	// Serialize the body
 	bytes, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("error serializing request body for %s %s: %w", r.GetName(), requestName, err)
 	}
 
-	response, err := RawPostRequest[DataType](r.GetContext(), fmt.Sprintf("%s/%s", r.GetRoute(), method), string(bytes))
+	response, err := RawIdempotentPostRequest[DataType](r.GetContext(), fmt.Sprintf("%s/%s", r.GetRoute(), method), string(bytes), idempotencyKey)
 	if err != nil {
 		return nil, fmt.Errorf("error during %s %s request: %w", r.GetName(), requestName, err)
 	}
@@ -69,12 +76,20 @@ func SendPostRequest[DataType any](r IRequester, method string, requestName stri
 
 // Submit a POST request to the API server
 func RawPostRequest[DataType any](context IRequesterContext, path string, body string) (*types.ApiResponse[DataType], error) {
+	return RawIdempotentPostRequest[DataType](context, path, body, "")
+}
+
+// Submit a POST request to the API server with an idempotency key. Use an empty key to send it without one.
+func RawIdempotentPostRequest[DataType any](context IRequesterContext, path string, body string, idempotencyKey string) (*types.ApiResponse[DataType], error) {
 	// Create the request
 	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s", context.GetAddressBase(), path), strings.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP request: %w", err)
 	}
 	req.Header.Set("Content-Type", jsonContentType)
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
 
 	// Debug log
 	context.GetLogger().Debug("API Request", slog.String(log.MethodKey, http.MethodPost), slog.String(log.PathKey, path), slog.String(log.BodyKey, body))
@@ -84,6 +99,11 @@ func RawPostRequest[DataType any](context IRequesterContext, path string, body s
 	return HandleResponse[DataType](context, resp, path, err)
 }
 
+// Create a new random idempotency key for a request. Reuse it when retrying the same request.
+func NewIdempotencyKey() string {
+	return uuid.New().String()
+}
+
 // Processes a response to a request
 func HandleResponse[DataType any](context IRequesterContext, resp *http.Response, path string, err error) (*types.ApiResponse[DataType], error) {
 	if err != nil {
@@ -115,7 +135,10 @@ func HandleResponse[DataType any](context IRequesterContext, resp *http.Response
 	// Check if the request failed
 	if resp.StatusCode != http.StatusOK {
 		logger.Debug("API Response", slog.String(log.PathKey, path), slog.String(log.CodeKey, resp.Status), slog.String("err", parsedResponse.Error))
-		return nil, fmt.Errorf(parsedResponse.Error)
+		return nil, &types.ApiError{
+			Code:    parsedResponse.Code,
+			Message: parsedResponse.Error,
+		}
 	}
 
 	// Debug log
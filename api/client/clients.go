@@ -0,0 +1,52 @@
+package client
+
+import (
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// Requester for pinning the daemon's client managers to one of their clients
+type ClientPinRequester struct {
+	context IRequesterContext
+}
+
+// Creates a new client pin requester
+func NewClientPinRequester(context IRequesterContext) *ClientPinRequester {
+	return &ClientPinRequester{
+		context: context,
+	}
+}
+
+func (r *ClientPinRequester) GetName() string {
+	return "Clients"
+}
+func (r *ClientPinRequester) GetRoute() string {
+	return "clients"
+}
+func (r *ClientPinRequester) GetContext() IRequesterContext {
+	return r.context
+}
+
+// Pin the provided client managers to the primary or fallback client for a duration
+func (r *ClientPinRequester) Pin(kind types.ClientManagerKind, target types.ClientPinTarget, duration time.Duration) (*types.ApiResponse[types.ClientPinData], error) {
+	body := types.ClientPinBody{
+		Client:   kind,
+		Target:   target,
+		Duration: duration.String(),
+	}
+	return SendPostRequest[types.ClientPinData](r, "pin", "Pin", body)
+}
+
+// Remove the pin from the provided client managers
+func (r *ClientPinRequester) Unpin(kind types.ClientManagerKind) (*types.ApiResponse[types.ClientPinData], error) {
+	body := types.ClientUnpinBody{
+		Client: kind,
+	}
+	return SendPostRequest[types.ClientPinData](r, "unpin", "Unpin", body)
+}
+
+// Get the pins of the client managers
+func (r *ClientPinRequester) GetPinStatus() (*types.ApiResponse[types.ClientPinData], error) {
+	return SendGetRequest[types.ClientPinData](r, "pin-status", "GetPinStatus", nil)
+}
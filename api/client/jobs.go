@@ -0,0 +1,82 @@
+package client
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// Requester for the status of background jobs on the daemon
+type JobRequester struct {
+	context IRequesterContext
+}
+
+// Creates a new job requester
+func NewJobRequester(context IRequesterContext) *JobRequester {
+	return &JobRequester{
+		context: context,
+	}
+}
+
+func (r *JobRequester) GetName() string {
+	return "Jobs"
+}
+func (r *JobRequester) GetRoute() string {
+	return "jobs"
+}
+func (r *JobRequester) GetContext() IRequesterContext {
+	return r.context
+}
+
+// Get the status of a job
+func (r *JobRequester) GetJob(id string) (*types.ApiResponse[types.JobData], error) {
+	args := map[string]string{
+		"id": id,
+	}
+	return SendGetRequest[types.JobData](r, "status", "GetJob", args)
+}
+
+// Get the status of all jobs
+func (r *JobRequester) ListJobs() (*types.ApiResponse[types.JobListData], error) {
+	return SendGetRequest[types.JobListData](r, "list", "ListJobs", nil)
+}
+
+// Wait for a job to change past the provided version, returning its status once it does or the timeout elapses
+func (r *JobRequester) WaitForUpdate(id string, sinceVersion uint64, timeout time.Duration) (*types.ApiResponse[types.JobData], error) {
+	args := map[string]string{
+		"id":      id,
+		"version": strconv.FormatUint(sinceVersion, 10),
+		"timeout": timeout.String(),
+	}
+	return SendGetRequest[types.JobData](r, "wait", "WaitForUpdate", args)
+}
+
+// Cancel a job
+func (r *JobRequester) CancelJob(id string) (*types.ApiResponse[types.JobData], error) {
+	body := types.JobCancelBody{
+		ID: id,
+	}
+	return SendPostRequest[types.JobData](r, "cancel", "CancelJob", body)
+}
+
+// Wait for a job to finish, calling the optional callback each time its status changes. Returns the job's final status.
+func (r *JobRequester) WaitForJob(id string, callback func(job types.JobInfo)) (types.JobInfo, error) {
+	var version uint64
+	for {
+		response, err := r.WaitForUpdate(id, version, time.Minute)
+		if err != nil {
+			return types.JobInfo{}, err
+		}
+		job := response.Data.Job
+		if job.Version > version {
+			version = job.Version
+			if callback != nil {
+				callback(job)
+			}
+		}
+		if job.State.IsFinished() {
+			return job, nil
+		}
+	}
+}
@@ -2,11 +2,18 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptrace"
 	"net/url"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/auth"
 )
 
 // The context passed into a requester
@@ -22,6 +29,16 @@ type NetworkRequesterContext struct {
 
 	// Tracer for HTTP requests
 	tracer *httptrace.ClientTrace
+
+	// Bearer token for servers that require authentication
+	authToken string
+
+	// Shared secret credentials for servers that use HMAC or JWT authentication
+	authKeyId   string
+	authSecret  []byte
+	useJwt      bool
+	jwtRole     string
+	jwtLifetime time.Duration
 }
 
 // Creates a new API client requester context for network-based
@@ -58,8 +75,78 @@ func (r *NetworkRequesterContext) SetLogger(logger *slog.Logger) {
 	r.logger = logger
 }
 
+// Set the bearer token to send with each request, for servers that require authentication. Use an empty token
+// to stop sending one.
+func (r *NetworkRequesterContext) SetAuthToken(token string) {
+	r.authToken = token
+}
+
+// Set the TLS settings for connecting to servers with HTTPS URLs. The CA certificate is only needed if the server's
+// certificate isn't trusted by the system, and the client certificate and key are only needed if the server requires
+// mutual TLS; leave them empty otherwise.
+func (r *NetworkRequesterContext) SetTlsConfig(caCertPath string, clientCertPath string, clientKeyPath string) error {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caCertPath != "" {
+		caCert, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return fmt.Errorf("error reading API server CA certificate [%s]: %w", caCertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return fmt.Errorf("API server CA certificate [%s] doesn't contain any PEM certificates", caCertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCertPath != "" || clientKeyPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+		if err != nil {
+			return fmt.Errorf("error loading API client certificate [%s] and key [%s]: %w", clientCertPath, clientKeyPath, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	r.client.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+	return nil
+}
+
+// Sign each request with the provided shared secret, for servers that use an HmacAuthenticator. Use an empty key ID
+// to stop signing requests.
+func (r *NetworkRequesterContext) SetHmacCredentials(keyId string, secret []byte) {
+	r.authKeyId = keyId
+	r.authSecret = secret
+	r.useJwt = false
+}
+
+// Send a fresh JWT signed with the provided shared secret with each request, for servers that use a JwtAuthenticator.
+// The role can be empty to get the key's role, or lower it to "read-only". Use an empty key ID to stop sending tokens.
+func (r *NetworkRequesterContext) SetJwtCredentials(keyId string, secret []byte, role string, lifetime time.Duration) {
+	r.authKeyId = keyId
+	r.authSecret = secret
+	r.useJwt = true
+	r.jwtRole = role
+	r.jwtLifetime = lifetime
+}
+
 // Send an HTTP request to the server
 func (r *NetworkRequesterContext) SendRequest(request *http.Request) (*http.Response, error) {
+	if r.authToken != "" {
+		request.Header.Set("Authorization", "Bearer "+r.authToken)
+	}
+	if r.authKeyId != "" {
+		if r.useJwt {
+			token, err := auth.CreateJwt(r.authKeyId, r.authSecret, r.jwtRole, r.jwtLifetime)
+			if err != nil {
+				return nil, err
+			}
+			request.Header.Set("Authorization", "Bearer "+token)
+		} else {
+			err := auth.SignRequest(request, r.authKeyId, r.authSecret, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("error signing request: %w", err)
+			}
+		}
+	}
 	if r.tracer != nil {
 		request = request.WithContext(httptrace.WithClientTrace(request.Context(), r.tracer))
 	}
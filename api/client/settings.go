@@ -1,5 +1,6 @@
 package client
 
 const (
-	jsonContentType string = "application/json"
+	jsonContentType      string = "application/json"
+	idempotencyKeyHeader string = "Idempotency-Key"
 )
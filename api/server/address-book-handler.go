@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// Serves routes for viewing and editing the address book
+type AddressBookHandler struct {
+	logger      *slog.Logger
+	addressBook *eth.AddressBook
+}
+
+// Creates a new address book handler
+func NewAddressBookHandler(logger *slog.Logger, addressBook *eth.AddressBook) *AddressBookHandler {
+	return &AddressBookHandler{
+		logger:      logger,
+		addressBook: addressBook,
+	}
+}
+
+// Register the address book routes with the router
+func (h *AddressBookHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/address-book").Subrouter()
+	RegisterGet(subrouter, "entries", h.logger, h.getEntries)
+	RegisterPost(subrouter, "set", h.logger, h.setEntry)
+	RegisterPost(subrouter, "delete", h.logger, h.deleteEntry)
+}
+
+// Describe the address book routes for the API's OpenAPI spec
+func (h *AddressBookHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribeRoute[types.AddressBookData](http.MethodGet, "/address-book/entries", "Get the entries in the address book",
+			DescribeParameter[string]("tag", "Only get the entries with this tag", false),
+		),
+		DescribePostRoute[eth.AddressBookEntry, types.SuccessData]("/address-book/set", "Label an address, replacing its existing label if it has one"),
+		DescribePostRoute[types.AddressBookDeleteBody, types.SuccessData]("/address-book/delete", "Remove an address's label"),
+	}
+}
+
+// Get the entries in the address book, optionally only the ones with a tag
+func (h *AddressBookHandler) getEntries(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	var tag string
+	_ = GetOptionalStringFromVars("tag", args, &tag)
+	return types.ResponseStatus_Success, types.AddressBookData{
+		Entries: h.addressBook.GetEntries(tag),
+	}, nil
+}
+
+// Label an address, replacing its existing label if it has one
+func (h *AddressBookHandler) setEntry(ctx context.Context, body eth.AddressBookEntry) (types.ResponseStatus, any, error) {
+	if body.Address == (common.Address{}) {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'address'")
+	}
+	var tags []string
+	for _, tag := range body.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	body.Tags = tags
+
+	err := h.addressBook.SetEntry(body)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+	return types.ResponseStatus_Success, nil, nil
+}
+
+// Remove an address's label
+func (h *AddressBookHandler) deleteEntry(ctx context.Context, body types.AddressBookDeleteBody) (types.ResponseStatus, any, error) {
+	if body.Address == (common.Address{}) {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'address'")
+	}
+	deleted, err := h.addressBook.DeleteEntry(body.Address)
+	if err != nil {
+		return types.ResponseStatus_Error, nil, err
+	}
+	if !deleted {
+		return types.ResponseStatus_ResourceNotFound, nil, fmt.Errorf("address %s isn't in the address book", body.Address.Hex())
+	}
+	return types.ResponseStatus_Success, nil, nil
+}
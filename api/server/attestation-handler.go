@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	nodewallet "github.com/rocket-pool/node-manager-core/node/wallet"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// Serves the route for answering a hosted service's challenge with a signed attestation of the node's identity
+type AttestationHandler struct {
+	logger *slog.Logger
+	wallet *nodewallet.Wallet
+}
+
+// Creates a new attestation handler
+func NewAttestationHandler(logger *slog.Logger, wallet *nodewallet.Wallet) *AttestationHandler {
+	return &AttestationHandler{
+		logger: logger,
+		wallet: wallet,
+	}
+}
+
+// Register the attestation routes with the router
+func (h *AttestationHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/attestation").Subrouter()
+	RegisterPost(subrouter, "attest", h.logger, h.attest)
+}
+
+// Describe the attestation routes for the API's OpenAPI spec
+func (h *AttestationHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribePostRoute[types.NodeAttestationBody, types.NodeAttestationData]("/attestation/attest", "Sign an attestation of the node's identity for a service's challenge"),
+	}
+}
+
+// Sign an attestation for a service's challenge
+func (h *AttestationHandler) attest(ctx context.Context, body types.NodeAttestationBody) (types.ResponseStatus, any, error) {
+	err := wallet.ValidateNodeAttestationChallenge(body.Service, body.Nonce)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+
+	attestation, err := h.wallet.AttestIdentity(body.Service, body.Nonce)
+	if err != nil {
+		return getWalletErrorStatus(err), nil, err
+	}
+	h.logger.Info("Signed node attestation", slog.String(log.ServiceKey, body.Service), slog.String(log.AddressKey, attestation.Attestation.Address.Hex()))
+	return types.ResponseStatus_Success, types.NodeAttestationData{
+		SignedNodeAttestation: attestation,
+	}, nil
+}
@@ -0,0 +1,354 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rocket-pool/node-manager-core/api/auth"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// How far a signed request's timestamp can be from the server's clock
+	DefaultHmacMaxClockSkew time.Duration = 5 * time.Minute
+
+	// The largest request body the HMAC authenticator will read to check a signature
+	maxSignedBodySize int64 = 16 * 1024 * 1024
+
+	// The longest request nonce the HMAC authenticator will accept
+	maxNonceLength int = 128
+)
+
+// ====================
+// === AuthKeyStore ===
+// ====================
+
+// A shared secret for authenticating API requests
+type AuthKey struct {
+	// The key's unique ID, which clients send so the server knows which key to check against
+	ID string `json:"id"`
+
+	// The shared secret
+	Secret utils.ByteArray `json:"secret"`
+
+	// The role requests authenticated with this key get. JWTs can ask for a lower role, but not a higher one.
+	Role ApiRole `json:"role"`
+
+	// The time the key stops being accepted, if it expires
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+}
+
+// The format of an API key file
+type authKeyFile struct {
+	Keys []AuthKey `json:"keys"`
+}
+
+// The shared secrets API clients authenticate with, loaded from a key file. To rotate a key, add the new one to the
+// file, switch the clients over to it, and then remove the old one; the store picks up changes to the file without
+// restarting the daemon.
+type AuthKeyStore struct {
+	path    string
+	keys    map[string]AuthKey
+	modTime time.Time
+	lock    sync.RWMutex
+}
+
+// Creates a new key store, loading its keys from the provided file
+func NewAuthKeyStore(path string) (*AuthKeyStore, error) {
+	store := &AuthKeyStore{
+		path: path,
+	}
+	err := store.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Load the keys from the key file again
+func (s *AuthKeyStore) Reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("error checking API key file [%s]: %w", s.path, err)
+	}
+	bytes, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("error reading API key file [%s]: %w", s.path, err)
+	}
+	var file authKeyFile
+	err = json.Unmarshal(bytes, &file)
+	if err != nil {
+		return fmt.Errorf("error deserializing API key file [%s]: %w", s.path, err)
+	}
+
+	keys := map[string]AuthKey{}
+	for _, key := range file.Keys {
+		if key.ID == "" {
+			return fmt.Errorf("API key file [%s] has a key without an ID", s.path)
+		}
+		if _, exists := keys[key.ID]; exists {
+			return fmt.Errorf("API key file [%s] has more than one key with ID [%s]", s.path, key.ID)
+		}
+		if len(key.Secret) < 32 {
+			return fmt.Errorf("API key [%s] must have a secret of at least 32 bytes", key.ID)
+		}
+		if key.Role != ApiRole_Admin && key.Role != ApiRole_ReadOnly {
+			return fmt.Errorf("API key [%s] has unknown role [%s]", key.ID, key.Role)
+		}
+		keys[key.ID] = key
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keys = keys
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// Check the key file for changes periodically, reloading it when it's modified, until the context is cancelled. If a
+// reload fails, the previous keys stay in use.
+func (s *AuthKeyStore) Watch(ctx context.Context, logger *slog.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(s.path)
+		if err != nil {
+			logger.Warn("Error checking API key file", slog.String(log.PathKey, s.path), log.Err(err))
+			continue
+		}
+		s.lock.RLock()
+		modified := !info.ModTime().Equal(s.modTime)
+		s.lock.RUnlock()
+		if !modified {
+			continue
+		}
+		err = s.Reload()
+		if err != nil {
+			logger.Warn("Error reloading API key file", slog.String(log.PathKey, s.path), log.Err(err))
+			continue
+		}
+		logger.Info("Reloaded API key file", slog.String(log.PathKey, s.path))
+	}
+}
+
+// Get a key that's currently valid
+func (s *AuthKeyStore) getKey(id string) (AuthKey, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	key, exists := s.keys[id]
+	if !exists {
+		return AuthKey{}, fmt.Errorf("unknown key [%s]", id)
+	}
+	if key.NotAfter != nil && time.Now().After(*key.NotAfter) {
+		return AuthKey{}, fmt.Errorf("key [%s] has expired", id)
+	}
+	return key, nil
+}
+
+// =========================
+// === HmacAuthenticator ===
+// =========================
+
+// Authenticates requests signed with a shared secret, using the headers set by auth.SignRequest. Each nonce is only
+// accepted once per key, so captured requests can't be replayed.
+type HmacAuthenticator struct {
+	logger       *slog.Logger
+	keys         *AuthKeyStore
+	maxClockSkew time.Duration
+	seen         map[string]time.Time
+	seenLock     sync.Mutex
+}
+
+// Creates a new HMAC authenticator. Requests with timestamps further than the max clock skew from the server's clock
+// are rejected.
+func NewHmacAuthenticator(logger *slog.Logger, keys *AuthKeyStore, maxClockSkew time.Duration) *HmacAuthenticator {
+	return &HmacAuthenticator{
+		logger:       logger,
+		keys:         keys,
+		maxClockSkew: maxClockSkew,
+		seen:         map[string]time.Time{},
+	}
+}
+
+// Reject requests without a valid signature, and assign the signing key's role to the others
+func (a *HmacAuthenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, keyId, err := a.authenticate(r)
+		if err != nil {
+			handleAuthError(a.logger, HandleUnauthorized(a.logger, w, err))
+			return
+		}
+		next.ServeHTTP(w, withApiCaller(r, role, keyId))
+	})
+}
+
+// Check a request's signature and get its role and the ID of the key that signed it
+func (a *HmacAuthenticator) authenticate(r *http.Request) (ApiRole, string, error) {
+	keyId := r.Header.Get(auth.KeyIdHeader)
+	timestamp := r.Header.Get(auth.TimestampHeader)
+	nonce := r.Header.Get(auth.NonceHeader)
+	signature, err := hex.DecodeString(r.Header.Get(auth.SignatureHeader))
+	if keyId == "" || timestamp == "" || nonce == "" || len(nonce) > maxNonceLength || err != nil || len(signature) == 0 {
+		return "", "", fmt.Errorf("missing or malformed request signature")
+	}
+	key, err := a.keys.getKey(keyId)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Check the timestamp
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid request timestamp")
+	}
+	signedAt := time.Unix(unixTime, 0)
+	skew := time.Since(signedAt).Abs()
+	if skew > a.maxClockSkew {
+		return "", "", fmt.Errorf("request timestamp is %s away from the server's clock", skew.Truncate(time.Second))
+	}
+
+	// Read the body so it can be hashed, then put it back for the handler
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(io.LimitReader(r.Body, maxSignedBodySize+1))
+		if err != nil {
+			return "", "", fmt.Errorf("error reading request body: %w", err)
+		}
+		if int64(len(body)) > maxSignedBodySize {
+			return "", "", fmt.Errorf("request body is too large")
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	expected := auth.GetHmacSignature(key.Secret, r.Method, r.URL.RequestURI(), timestamp, nonce, body)
+	if !hmac.Equal(signature, expected) {
+		return "", "", fmt.Errorf("invalid request signature")
+	}
+
+	// Reject replays
+	if !a.markSeen(key.ID+"\n"+nonce, signedAt) {
+		return "", "", fmt.Errorf("request nonce has already been used")
+	}
+	return key.Role, key.ID, nil
+}
+
+// Record a key's nonce as used, returning false if it already was. Nonces are forgotten once their timestamps are too
+// old to be accepted anyway.
+func (a *HmacAuthenticator) markSeen(nonceKey string, signedAt time.Time) bool {
+	a.seenLock.Lock()
+	defer a.seenLock.Unlock()
+	now := time.Now()
+	for candidate, candidateTime := range a.seen {
+		if now.Sub(candidateTime) > a.maxClockSkew {
+			delete(a.seen, candidate)
+		}
+	}
+	if _, exists := a.seen[nonceKey]; exists {
+		return false
+	}
+	a.seen[nonceKey] = signedAt
+	return true
+}
+
+// ========================
+// === JwtAuthenticator ===
+// ========================
+
+// Authenticates requests with HS256 JWT bearer tokens signed with a shared secret, such as ones made by
+// auth.CreateJwt. Tokens must name their key in the "kid" header and have an expiration time.
+type JwtAuthenticator struct {
+	logger *slog.Logger
+	keys   *AuthKeyStore
+	parser *jwt.Parser
+}
+
+// Creates a new JWT authenticator
+func NewJwtAuthenticator(logger *slog.Logger, keys *AuthKeyStore) *JwtAuthenticator {
+	return &JwtAuthenticator{
+		logger: logger,
+		keys:   keys,
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})),
+	}
+}
+
+// Reject requests without a valid token, and assign the token's role to the others
+func (a *JwtAuthenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, keyId, err := a.authenticate(r)
+		if err != nil {
+			handleAuthError(a.logger, HandleUnauthorized(a.logger, w, err))
+			return
+		}
+		next.ServeHTTP(w, withApiCaller(r, role, keyId))
+	})
+}
+
+// Check a request's token and get its role and the ID of the key that signed it
+func (a *JwtAuthenticator) authenticate(r *http.Request) (ApiRole, string, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", "", fmt.Errorf("missing bearer token")
+	}
+
+	var key AuthKey
+	var claims auth.JwtClaims
+	_, err := a.parser.ParseWithClaims(strings.TrimPrefix(header, bearerPrefix), &claims, func(token *jwt.Token) (any, error) {
+		keyId, isString := token.Header["kid"].(string)
+		if !isString || keyId == "" {
+			return nil, fmt.Errorf("token doesn't have a key ID")
+		}
+		var err error
+		key, err = a.keys.getKey(keyId)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(key.Secret), nil
+	})
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Inner != nil {
+			err = validationErr.Inner
+		}
+		return "", "", fmt.Errorf("invalid token: %w", err)
+	}
+	if claims.ExpiresAt == nil {
+		return "", "", fmt.Errorf("token doesn't have an expiration time")
+	}
+
+	// Tokens can ask for a lower role than their key's, but not a higher one
+	switch ApiRole(claims.Role) {
+	case "", key.Role:
+		return key.Role, key.ID, nil
+	case ApiRole_ReadOnly:
+		return ApiRole_ReadOnly, key.ID, nil
+	default:
+		return "", "", fmt.Errorf("key [%s] can't grant the %s role", key.ID, claims.Role)
+	}
+}
+
+// Log an error from writing an authentication failure response
+func handleAuthError(logger *slog.Logger, err error) {
+	if err != nil {
+		logger.Error("Error handling response", log.Err(err))
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/node/services"
+)
+
+// Serves routes for pinning the client managers to one of their clients, so operators can take a client down for
+// maintenance without the daemon probing it and logging failures
+type ClientPinHandler struct {
+	logger    *slog.Logger
+	ecManager *services.ExecutionClientManager
+	bcManager *services.BeaconClientManager
+}
+
+// Creates a new client pin handler
+func NewClientPinHandler(logger *slog.Logger, ecManager *services.ExecutionClientManager, bcManager *services.BeaconClientManager) *ClientPinHandler {
+	return &ClientPinHandler{
+		logger:    logger,
+		ecManager: ecManager,
+		bcManager: bcManager,
+	}
+}
+
+// Register the client pin routes with the router
+func (h *ClientPinHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/clients").Subrouter()
+	RegisterPost(subrouter, "pin", h.logger, h.pin)
+	RegisterPost(subrouter, "unpin", h.logger, h.unpin)
+	RegisterGet(subrouter, "pin-status", h.logger, h.getPinStatus)
+}
+
+// Describe the client pin routes for the API's OpenAPI spec
+func (h *ClientPinHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribePostRoute[types.ClientPinBody, types.ClientPinData]("/clients/pin", "Pin one or both client managers to a client for a duration"),
+		DescribePostRoute[types.ClientUnpinBody, types.ClientPinData]("/clients/unpin", "Remove the pin from one or both client managers"),
+		DescribeRoute[types.ClientPinData](http.MethodGet, "/clients/pin-status", "Get the pins of both client managers"),
+	}
+}
+
+// Pin one or both managers to a client for a duration
+func (h *ClientPinHandler) pin(ctx context.Context, body types.ClientPinBody) (types.ResponseStatus, any, error) {
+	kind, err := getClientManagerKind(body.Client)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+	if body.Target == "" {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'target'")
+	}
+	if body.Duration == "" {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'duration'")
+	}
+	duration, err := time.ParseDuration(body.Duration)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("invalid duration '%s': %w", body.Duration, err)
+	}
+
+	// Pin the managers, undoing the first one if the second can't be pinned
+	if kind != types.ClientManagerKind_Beacon {
+		_, err = h.ecManager.PinClient(body.Target, duration)
+		if err != nil {
+			return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("error pinning the Execution Client manager: %w", err)
+		}
+	}
+	if kind != types.ClientManagerKind_Execution {
+		_, err = h.bcManager.PinClient(body.Target, duration)
+		if err != nil {
+			if kind == types.ClientManagerKind_All {
+				h.ecManager.UnpinClient()
+			}
+			return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("error pinning the Beacon Node manager: %w", err)
+		}
+	}
+	h.logger.Warn("Pinned client managers", slog.String(log.ClientKey, string(kind)), slog.String(log.TargetKey, string(body.Target)), slog.Duration(log.DurationKey, duration))
+	return types.ResponseStatus_Success, h.getPinData(), nil
+}
+
+// Remove the pin from one or both managers
+func (h *ClientPinHandler) unpin(ctx context.Context, body types.ClientUnpinBody) (types.ResponseStatus, any, error) {
+	kind, err := getClientManagerKind(body.Client)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+	if kind != types.ClientManagerKind_Beacon {
+		h.ecManager.UnpinClient()
+	}
+	if kind != types.ClientManagerKind_Execution {
+		h.bcManager.UnpinClient()
+	}
+	h.logger.Info("Unpinned client managers", slog.String(log.ClientKey, string(kind)))
+	return types.ResponseStatus_Success, h.getPinData(), nil
+}
+
+// Get the pins of both managers
+func (h *ClientPinHandler) getPinStatus(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	return types.ResponseStatus_Success, h.getPinData(), nil
+}
+
+// Get the pins of both managers
+func (h *ClientPinHandler) getPinData() types.ClientPinData {
+	return types.ClientPinData{
+		ExecutionClient: h.ecManager.GetClientPin(),
+		BeaconNode:      h.bcManager.GetClientPin(),
+	}
+}
+
+// Parse the manager kind argument, which defaults to both managers
+func getClientManagerKind(kind types.ClientManagerKind) (types.ClientManagerKind, error) {
+	switch kind {
+	case "":
+		return types.ClientManagerKind_All, nil
+	case types.ClientManagerKind_All, types.ClientManagerKind_Execution, types.ClientManagerKind_Beacon:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("unknown client type [%s]", kind)
+	}
+}
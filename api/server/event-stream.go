@@ -0,0 +1,109 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rocket-pool/node-manager-core/events"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// The route the API servers stream events on over a WebSocket
+	EventStreamRoute string = "/api/ws"
+
+	// How many events can be waiting to be sent to a client before new ones are dropped
+	eventStreamBufferSize int = 256
+
+	// How long writing a frame to a client can take before the connection is dropped
+	eventStreamWriteTimeout time.Duration = 10 * time.Second
+
+	// How often clients are pinged to keep the connection alive
+	eventStreamPingInterval time.Duration = 30 * time.Second
+
+	// How long a client can go without responding to a ping before the connection is dropped
+	eventStreamPongTimeout time.Duration = 2 * eventStreamPingInterval
+
+	// The largest message a client can send; clients don't need to send anything but control frames
+	eventStreamMaxMessageSize int64 = 512
+)
+
+// Create the handler for the event stream route, which upgrades requests to WebSockets and sends each event published
+// to the bus as a JSON text frame. Clients can ask for specific event types with a comma-separated "types" query
+// parameter.
+func newEventStreamHandler(logger *slog.Logger, bus *events.EventBus) http.HandlerFunc {
+	upgrader := websocket.Upgrader{}
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Info("New request", slog.String(log.MethodKey, r.Method), slog.String(log.PathKey, r.URL.Path))
+		if r.Method != http.MethodGet {
+			err := HandleInvalidMethod(logger, w)
+			if err != nil {
+				logger.Error("Error handling response", log.Err(err))
+			}
+			return
+		}
+
+		// Get the requested event types
+		var eventTypes []events.EventType
+		for _, eventType := range strings.Split(r.URL.Query().Get("types"), ",") {
+			eventType = strings.TrimSpace(eventType)
+			if eventType != "" {
+				eventTypes = append(eventTypes, events.EventType(eventType))
+			}
+		}
+
+		// The upgrader writes its own error response if this fails
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Warn("Error upgrading event stream connection", log.Err(err))
+			return
+		}
+		defer conn.Close()
+		subscription := bus.Subscribe(eventStreamBufferSize, eventTypes...)
+		defer subscription.Close()
+
+		// Read from the connection so control frames are handled and closes are noticed
+		closed := make(chan struct{})
+		conn.SetReadLimit(eventStreamMaxMessageSize)
+		_ = conn.SetReadDeadline(time.Now().Add(eventStreamPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(eventStreamPongTimeout))
+		})
+		go func() {
+			defer close(closed)
+			for {
+				_, _, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		// Send events until the client leaves or the bus is closed. Hijacked connections aren't closed when the server
+		// shuts down, so closing the bus is what ends the stream then.
+		ticker := time.NewTicker(eventStreamPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-closed:
+				return
+			case <-ticker.C:
+				err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventStreamWriteTimeout))
+			case event, ok := <-subscription.Events():
+				if !ok {
+					_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "daemon is shutting down"), time.Now().Add(eventStreamWriteTimeout))
+					return
+				}
+				_ = conn.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout))
+				err = conn.WriteJSON(event)
+			}
+			if err != nil {
+				logger.Debug("Event stream connection closed", log.Err(err))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// The query argument for the export format
+	ExportFormatArg string = "format"
+
+	// The query argument for the comma-separated list of fields to export
+	ExportFieldsArg string = "fields"
+)
+
+// The format to export tabular data in
+type ExportFormat string
+
+const (
+	// Export as an indented JSON array of objects
+	ExportFormat_Json ExportFormat = "json"
+
+	// Export as CSV with a header row
+	ExportFormat_Csv ExportFormat = "csv"
+)
+
+// A column of an exported table
+type exportColumn struct {
+	name  string
+	index []int
+}
+
+// Get the export format and field selection from a request's query arguments. The format defaults to JSON, and
+// an empty field selection means all fields are exported.
+func GetExportArgs(args url.Values) (ExportFormat, []string, error) {
+	format := ExportFormat_Json
+	var formatArg string
+	if GetOptionalStringFromVars(ExportFormatArg, args, &formatArg) {
+		switch ExportFormat(strings.ToLower(formatArg)) {
+		case ExportFormat_Json:
+			format = ExportFormat_Json
+		case ExportFormat_Csv:
+			format = ExportFormat_Csv
+		default:
+			return "", nil, fmt.Errorf("invalid export format '%s'", formatArg)
+		}
+	}
+
+	fields := []string{}
+	var fieldsArg string
+	if GetOptionalStringFromVars(ExportFieldsArg, args, &fieldsArg) {
+		for _, field := range strings.Split(fieldsArg, ",") {
+			field = strings.TrimSpace(field)
+			if field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return format, fields, nil
+}
+
+// Write a slice of structs as a table. Columns are named after each field's JSON name, and the selected fields are
+// written in the order provided (use an empty selection for all fields).
+func HandleExport[RowType any](logger *slog.Logger, w http.ResponseWriter, format ExportFormat, rows []RowType, fields []string) error {
+	columns, err := getExportColumns[RowType](fields)
+	if err != nil {
+		return HandleInputError(logger, w, err)
+	}
+
+	// Set the content type before the body is streamed
+	switch format {
+	case ExportFormat_Json:
+		w.Header().Add("Content-Type", "application/json")
+	case ExportFormat_Csv:
+		w.Header().Add("Content-Type", "text/csv")
+	default:
+		return HandleInputError(logger, w, fmt.Errorf("invalid export format '%s'", format))
+	}
+	logger.Info("Responded with:",
+		slog.String(log.CodeKey, fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK))),
+	)
+	w.WriteHeader(http.StatusOK)
+
+	if format == ExportFormat_Csv {
+		return writeCsvRows(w, rows, columns)
+	}
+	return writeJsonRows(w, rows, columns)
+}
+
+// Write a slice of structs as CSV with a header row. See HandleExport for how fields are selected.
+func ExportCsv[RowType any](writer io.Writer, rows []RowType, fields []string) error {
+	columns, err := getExportColumns[RowType](fields)
+	if err != nil {
+		return err
+	}
+	return writeCsvRows(writer, rows, columns)
+}
+
+// Write a slice of structs as an indented JSON array of objects. See HandleExport for how fields are selected.
+func ExportJson[RowType any](writer io.Writer, rows []RowType, fields []string) error {
+	columns, err := getExportColumns[RowType](fields)
+	if err != nil {
+		return err
+	}
+	return writeJsonRows(writer, rows, columns)
+}
+
+// Get the columns for a row type, in the order of the selected fields
+func getExportColumns[RowType any](fields []string) ([]exportColumn, error) {
+	rowType := reflect.TypeOf((*RowType)(nil)).Elem()
+	for rowType.Kind() == reflect.Pointer {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can't export rows of type %s, only structs are supported", rowType)
+	}
+
+	// Get all of the exportable fields, including those of embedded structs
+	allColumns := []exportColumn{}
+	for _, field := range reflect.VisibleFields(rowType) {
+		if !field.IsExported() || (field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		name := field.Name
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if tagName, _, _ := strings.Cut(tag, ","); tagName != "" {
+			name = tagName
+		}
+		allColumns = append(allColumns, exportColumn{
+			name:  name,
+			index: field.Index,
+		})
+	}
+	if len(fields) == 0 {
+		return allColumns, nil
+	}
+
+	// Filter them by the selection
+	columns := make([]exportColumn, len(fields))
+	for i, field := range fields {
+		found := false
+		for _, column := range allColumns {
+			if strings.EqualFold(column.name, field) {
+				columns[i] = column
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown field '%s'", field)
+		}
+	}
+	return columns, nil
+}
+
+// Get the value of a column in a row, or an invalid value if it's behind a nil embedded pointer
+func getColumnValue(row reflect.Value, column exportColumn) reflect.Value {
+	for row.Kind() == reflect.Pointer {
+		if row.IsNil() {
+			return reflect.Value{}
+		}
+		row = row.Elem()
+	}
+	value, err := row.FieldByIndexErr(column.index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return value
+}
+
+// Write rows as CSV
+func writeCsvRows[RowType any](writer io.Writer, rows []RowType, columns []exportColumn) error {
+	csvWriter := csv.NewWriter(writer)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	err := csvWriter.Write(header)
+	if err != nil {
+		return fmt.Errorf("error writing CSV header: %w", err)
+	}
+
+	record := make([]string, len(columns))
+	for i, row := range rows {
+		rowValue := reflect.ValueOf(row)
+		for j, column := range columns {
+			record[j], err = formatCsvValue(getColumnValue(rowValue, column))
+			if err != nil {
+				return fmt.Errorf("error formatting field '%s' of row %d: %w", column.name, i, err)
+			}
+		}
+		err = csvWriter.Write(record)
+		if err != nil {
+			return fmt.Errorf("error writing CSV row %d: %w", i, err)
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// Write rows as an indented JSON array, keeping the fields of each object in column order
+func writeJsonRows[RowType any](writer io.Writer, rows []RowType, columns []exportColumn) error {
+	var builder strings.Builder
+	builder.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("\n  {")
+		rowValue := reflect.ValueOf(row)
+		for j, column := range columns {
+			if j > 0 {
+				builder.WriteString(",")
+			}
+			name, _ := json.Marshal(column.name)
+			var value []byte
+			fieldValue := getColumnValue(rowValue, column)
+			if fieldValue.IsValid() {
+				var err error
+				value, err = json.MarshalIndent(fieldValue.Interface(), "    ", "  ")
+				if err != nil {
+					return fmt.Errorf("error serializing field '%s' of row %d: %w", column.name, i, err)
+				}
+			} else {
+				value = []byte("null")
+			}
+			builder.WriteString("\n    ")
+			builder.Write(name)
+			builder.WriteString(": ")
+			builder.Write(value)
+		}
+		if len(columns) > 0 {
+			builder.WriteString("\n  ")
+		}
+		builder.WriteString("}")
+
+		// Flush each row so large exports are streamed
+		_, err := io.WriteString(writer, builder.String())
+		if err != nil {
+			return fmt.Errorf("error writing JSON row %d: %w", i, err)
+		}
+		builder.Reset()
+	}
+	if len(rows) > 0 {
+		builder.WriteString("\n")
+	}
+	builder.WriteString("]\n")
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// Format a value for a CSV cell. Scalars are written as-is, and anything else is written as compact JSON.
+func formatCsvValue(value reflect.Value) (string, error) {
+	if !value.IsValid() {
+		return "", nil
+	}
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		if value.IsNil() {
+			return "", nil
+		}
+	}
+
+	// Handle types with a canonical string form
+	switch typed := value.Interface().(type) {
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339), nil
+	case *big.Int:
+		return typed.String(), nil
+	case big.Int:
+		return typed.String(), nil
+	case encoding.TextMarshaler:
+		text, err := typed.MarshalText()
+		return string(text), err
+	case fmt.Stringer:
+		return typed.String(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		return formatCsvValue(value.Elem())
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	}
+
+	bytes, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -2,12 +2,14 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/rocket-pool/node-manager-core/api/types"
 	"github.com/rocket-pool/node-manager-core/log"
+	nodewallet "github.com/rocket-pool/node-manager-core/node/wallet"
 )
 
 const (
@@ -17,6 +19,9 @@ const (
 	resourceNotFoundMessage  string = "The requested resource could not be found: %s"
 	clientsNotSyncedMessage  string = "The Execution Client and/or Beacon Node aren't finished syncing yet. Please try again once they've finished."
 	invalidChainStateMessage string = "The Ethereum chain's state is not correct for the request: %s"
+	busyMessage              string = "The node is too busy to accept the request, please try again later: %s"
+	unauthorizedMessage      string = "The request is not authenticated: %s"
+	forbiddenMessage         string = "The caller doesn't have permission for this request: %s"
 )
 
 // Handle routes called with an invalid method
@@ -27,49 +32,82 @@ func HandleInvalidMethod(logger *slog.Logger, w http.ResponseWriter) error {
 // Handles an error related to parsing the input parameters of a request
 func HandleInputError(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := err.Error()
-	return writeResponse(w, logger, http.StatusBadRequest, "", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusBadRequest, "", err, formatError(types.ErrorCode_InvalidArguments, msg))
 }
 
 // The request couldn't complete because the node requires an address but one wasn't present
 func HandleAddressNotPresent(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := fmt.Sprintf(addressNotPresentMessage, err.Error())
-	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Address not present", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Address not present", err, formatError(types.ErrorCode_AddressNotPresent, msg))
 }
 
 // The request couldn't complete because the node requires a wallet but one isn't present or useable
 func HandleWalletNotReady(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := fmt.Sprintf(walletNotReadyMessage, err.Error())
-	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Wallet not ready", err, formatError(msg))
+	code := types.ErrorCode_WalletNotReady
+	if errors.Is(err, nodewallet.ErrWalletNotLoaded) {
+		code = types.ErrorCode_WalletNotLoaded
+	}
+	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Wallet not ready", err, formatError(code, msg))
 }
 
 // The request couldn't complete because it's trying to create a resource that already exists, or use a resource that conflicts with what's requested
 func HandleResourceConflict(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := fmt.Sprintf(resourceConflictMessage, err.Error())
-	return writeResponse(w, logger, http.StatusConflict, "Resource conflict", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusConflict, "Resource conflict", err, formatError(types.ErrorCode_ResourceConflict, msg))
 }
 
 // The request couldn't complete because it's trying to access a resource that didn't exist or couldn't be found
 func HandleResourceNotFound(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := fmt.Sprintf(resourceNotFoundMessage, err.Error())
-	return writeResponse(w, logger, http.StatusNotFound, "Resource not found", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusNotFound, "Resource not found", err, formatError(types.ErrorCode_ResourceNotFound, msg))
 }
 
 // The request couldn't complete because the clients aren't synced yet
 func HandleClientNotSynced(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := clientsNotSyncedMessage
-	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Clients not synced", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Clients not synced", err, formatError(types.ErrorCode_ClientsNotSynced, msg))
 }
 
 // The request couldn't complete because the chain state is preventing the request (it will revert if submitted)
 func HandleInvalidChainState(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := fmt.Sprintf(invalidChainStateMessage, err.Error())
-	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Invalid chain state", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusUnprocessableEntity, "Invalid chain state", err, formatError(types.ErrorCode_InvalidChainState, msg))
+}
+
+// The request couldn't be accepted because the server is at capacity
+func HandleBusy(logger *slog.Logger, w http.ResponseWriter, err error) error {
+	msg := fmt.Sprintf(busyMessage, err.Error())
+	return writeResponse(w, logger, http.StatusServiceUnavailable, "Busy", err, formatError(types.ErrorCode_Busy, msg))
+}
+
+// The request couldn't complete because the caller didn't provide valid credentials
+func HandleUnauthorized(logger *slog.Logger, w http.ResponseWriter, err error) error {
+	msg := fmt.Sprintf(unauthorizedMessage, err.Error())
+	return writeResponse(w, logger, http.StatusUnauthorized, "Unauthorized", err, formatError(types.ErrorCode_Unauthorized, msg))
+}
+
+// The request couldn't complete because the caller's role isn't allowed to use the route
+func HandleForbidden(logger *slog.Logger, w http.ResponseWriter, err error) error {
+	msg := fmt.Sprintf(forbiddenMessage, err.Error())
+	return writeResponse(w, logger, http.StatusForbidden, "Forbidden", err, formatError(types.ErrorCode_Forbidden, msg))
 }
 
 // The request couldn't complete because of a server error
 func HandleServerError(logger *slog.Logger, w http.ResponseWriter, err error) error {
 	msg := err.Error()
-	return writeResponse(w, logger, http.StatusInternalServerError, "", err, formatError(msg))
+	return writeResponse(w, logger, http.StatusInternalServerError, "", err, formatError(types.ErrorCode_InternalError, msg))
+}
+
+// The request couldn't complete because of an error. If the error has a registered error code (such as an ApiError
+// returned by a handler), the response uses that code and its HTTP status; otherwise it's handled as a server error.
+func HandleError(logger *slog.Logger, w http.ResponseWriter, err error) error {
+	code := getErrorCode(err)
+	info, exists := types.GetErrorCodeInfo(code)
+	if !exists || code == types.ErrorCode_InternalError {
+		return HandleServerError(logger, w, err)
+	}
+	return writeResponse(w, logger, info.HttpStatus, info.Description, err, formatError(code, err.Error()))
 }
 
 // The request completed successfully
@@ -102,8 +140,10 @@ func HandleFailedResponse(logger *slog.Logger, w http.ResponseWriter, status typ
 		return HandleClientNotSynced(logger, w, err)
 	case types.ResponseStatus_InvalidChainState:
 		return HandleInvalidChainState(logger, w, err)
+	case types.ResponseStatus_Busy:
+		return HandleBusy(logger, w, err)
 	case types.ResponseStatus_Error:
-		return HandleServerError(logger, w, err)
+		return HandleError(logger, w, err)
 	default:
 		return HandleServerError(logger, w, fmt.Errorf("unknown response status: %d", status))
 	}
@@ -119,6 +159,20 @@ func HandleResponse(logger *slog.Logger, w http.ResponseWriter, status types.Res
 	}
 }
 
+// Handles an API response whose data hasn't been wrapped in an ApiResponse yet, such as one from a handler's own route
+func HandleDataResponse(logger *slog.Logger, w http.ResponseWriter, status types.ResponseStatus, data any, err error) error {
+	if status != types.ResponseStatus_Success {
+		return HandleFailedResponse(logger, w, status, err)
+	}
+	response := types.ApiResponse[any]{
+		Success: true,
+	}
+	if data != nil {
+		response.Data = &data
+	}
+	return HandleSuccess(logger, w, response)
+}
+
 // Writes a response to an HTTP request back to the client and logs it
 func writeResponse(w http.ResponseWriter, logger *slog.Logger, statusCode int, cause string, err error, message []byte) error {
 	// Prep the log attributes
@@ -151,10 +205,22 @@ func writeResponse(w http.ResponseWriter, logger *slog.Logger, statusCode int, c
 	return writeErr
 }
 
+// Get the error code for an error, including the errors from NMC's services that don't carry a code themselves
+func getErrorCode(err error) types.ErrorCode {
+	if code := types.GetErrorCode(err); code != "" {
+		return code
+	}
+	if errors.Is(err, nodewallet.ErrWalletNotLoaded) {
+		return types.ErrorCode_WalletNotLoaded
+	}
+	return ""
+}
+
 // JSONifies an error for responding to requests
-func formatError(message string) []byte {
+func formatError(code types.ErrorCode, message string) []byte {
 	msg := types.ApiResponse[any]{
 		Error: message,
+		Code:  code,
 	}
 
 	bytes, _ := json.Marshal(msg)
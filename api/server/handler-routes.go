@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/goccy/go-json"
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Registers a new route with the router, which will run the provided function with the request's query arguments when
+// it's called via GET and respond with the data it returns. Use this for routes that are served by a handler's own
+// services instead of a call context.
+func RegisterGet(
+	router *mux.Router,
+	functionName string,
+	logger *slog.Logger,
+	run func(ctx context.Context, args url.Values) (types.ResponseStatus, any, error),
+) {
+	router.HandleFunc(fmt.Sprintf("/%s", functionName), func(w http.ResponseWriter, r *http.Request) {
+		// Log
+		logger.Info("New request", slog.String(log.MethodKey, r.Method), slog.String(log.PathKey, r.URL.Path))
+		logger.Debug("Request params:", slog.String(log.QueryKey, r.URL.RawQuery))
+
+		// Check the method
+		if r.Method != http.MethodGet {
+			handleRouteError(logger, HandleInvalidMethod(logger, w))
+			return
+		}
+
+		// Run the route
+		status, data, err := run(r.Context(), r.URL.Query())
+		handleRouteError(logger, HandleDataResponse(logger, w, status, data, err))
+	})
+}
+
+// Registers a new route with the router, which will deserialize the request's JSON body and run the provided function
+// with it when it's called via POST, then respond with the data it returns. An empty body is treated as the zero
+// value of BodyType. Use this for routes that are served by a handler's own services instead of a call context.
+func RegisterPost[BodyType any](
+	router *mux.Router,
+	functionName string,
+	logger *slog.Logger,
+	run func(ctx context.Context, body BodyType) (types.ResponseStatus, any, error),
+) {
+	router.HandleFunc(fmt.Sprintf("/%s", functionName), func(w http.ResponseWriter, r *http.Request) {
+		// Log
+		logger.Info("New request", slog.String(log.MethodKey, r.Method), slog.String(log.PathKey, r.URL.Path))
+
+		// Check the method
+		if r.Method != http.MethodPost {
+			handleRouteError(logger, HandleInvalidMethod(logger, w))
+			return
+		}
+
+		// Read the body
+		bodyBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			handleRouteError(logger, HandleInputError(logger, w, fmt.Errorf("error reading request body: %w", err)))
+			return
+		}
+		logger.Debug("Request body:", slog.String(log.BodyKey, string(bodyBytes)))
+
+		// Deserialize the body
+		var body BodyType
+		if len(bodyBytes) > 0 {
+			err = json.Unmarshal(bodyBytes, &body)
+			if err != nil {
+				handleRouteError(logger, HandleInputError(logger, w, fmt.Errorf("error deserializing request body: %w", err)))
+				return
+			}
+		}
+
+		// Run the route
+		status, data, err := run(r.Context(), body)
+		handleRouteError(logger, HandleDataResponse(logger, w, status, data, err))
+	})
+}
+
+// Log an error from writing a route's response
+func handleRouteError(logger *slog.Logger, err error) {
+	if err != nil {
+		logger.Error("Error handling response", log.Err(err))
+	}
+}
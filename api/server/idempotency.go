@@ -0,0 +1,276 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// The header clients use to provide an idempotency key
+	IdempotencyKeyHeader string = "Idempotency-Key"
+
+	// The header set on responses that were replayed from the cache
+	IdempotentReplayedHeader string = "Idempotent-Replayed"
+
+	// The default time responses are cached for
+	DefaultIdempotencyTtl time.Duration = 24 * time.Hour
+
+	// The default limit on the number of cached responses
+	DefaultIdempotencyMaxEntries int = 10000
+
+	// The largest request body that's read for fingerprinting
+	maxIdempotentRequestSize int64 = 10 * 1024 * 1024
+
+	// The longest idempotency key that's accepted
+	maxIdempotencyKeyLength int = 255
+
+	idempotencyKeyReusedMessage string = "The idempotency key [%s] was already used for a different request"
+)
+
+// A response cached for an idempotency key
+type idempotentResponse struct {
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	statusCode  int
+	header      http.Header
+	body        []byte
+	expiration  time.Time
+}
+
+// Caches the responses of POST requests that have an idempotency key, so a client retrying a request (such as after
+// a timeout) gets the original response back instead of running it again. Requests without a key, and requests
+// with other methods, are passed through unchanged. Keys are scoped to the caller's role and credentials, and server
+// errors aren't cached so they can be retried.
+type IdempotencyCache struct {
+	logger     *slog.Logger
+	ttl        time.Duration
+	maxEntries int
+	responses  map[string]*idempotentResponse
+	lock       sync.Mutex
+}
+
+// Creates a new idempotency cache that keeps responses for the provided duration
+func NewIdempotencyCache(logger *slog.Logger, ttl time.Duration) *IdempotencyCache {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTtl
+	}
+	return &IdempotencyCache{
+		logger:     logger,
+		ttl:        ttl,
+		maxEntries: DefaultIdempotencyMaxEntries,
+		responses:  map[string]*idempotentResponse{},
+	}
+}
+
+// Set the limit on the number of cached responses. Once it's reached, the oldest responses are evicted to make room.
+// This must be called before the cache is used.
+func (c *IdempotencyCache) SetMaxEntries(maxEntries int) {
+	if maxEntries <= 0 {
+		maxEntries = DefaultIdempotencyMaxEntries
+	}
+	c.maxEntries = maxEntries
+}
+
+// Wrap a handler so its POST requests are deduplicated by idempotency key. This can be used with the API servers'
+// Use method, or on individual routes.
+func (c *IdempotencyCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.handleError(HandleInputError(c.logger, w, fmt.Errorf("idempotency key is longer than %d characters", maxIdempotencyKeyLength)))
+			return
+		}
+
+		// Read the body so it can be fingerprinted, then restore it for the handler
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestSize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				err = fmt.Errorf("request body is larger than %d bytes", maxBytesErr.Limit)
+			}
+			c.handleError(HandleInputError(c.logger, w, fmt.Errorf("error reading request body: %w", err)))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := getRequestFingerprint(r, body)
+
+		// Check for an existing response
+		cacheKey := getCallerKey(r) + "\x00" + r.URL.Path + "\x00" + key
+		c.lock.Lock()
+		c.removeExpired()
+		response, exists := c.responses[cacheKey]
+		if !exists {
+			if len(c.responses) >= c.maxEntries && !c.evictOldest() {
+				// Everything cached is still running, so just run this one without caching it
+				c.lock.Unlock()
+				c.logger.Warn("Idempotency cache is full, not caching response", slog.String(log.PathKey, r.URL.Path), slog.String(log.IdempotencyKeyKey, key))
+				next.ServeHTTP(w, r)
+				return
+			}
+			response = &idempotentResponse{
+				fingerprint: fingerprint,
+				done:        make(chan struct{}),
+			}
+			c.responses[cacheKey] = response
+		}
+		c.lock.Unlock()
+
+		if exists {
+			if response.fingerprint != fingerprint {
+				err := fmt.Errorf(idempotencyKeyReusedMessage, key)
+				c.handleError(HandleResourceConflict(c.logger, w, err))
+				return
+			}
+
+			// Wait for the original request to finish if it's still running, then replay its response
+			select {
+			case <-response.done:
+			case <-r.Context().Done():
+				return
+			}
+			if response.statusCode == 0 {
+				err := fmt.Errorf("the original request with idempotency key [%s] didn't complete", key)
+				c.handleError(HandleServerError(c.logger, w, err))
+				return
+			}
+			c.logger.Info("Replaying cached response", slog.String(log.PathKey, r.URL.Path), slog.String(log.IdempotencyKeyKey, key))
+			for name, values := range response.header {
+				w.Header()[name] = values
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(response.statusCode)
+			_, err := w.Write(response.body)
+			c.handleError(err)
+			return
+		}
+
+		// Run the request and record its response
+		recorder := &responseRecorder{
+			ResponseWriter: w,
+			statusCode:     http.StatusOK,
+		}
+		completed := false
+		defer func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			if !completed {
+				// The handler panicked, so let the client retry it
+				delete(c.responses, cacheKey)
+				close(response.done)
+				return
+			}
+			response.statusCode = recorder.statusCode
+			response.header = w.Header().Clone()
+			response.body = recorder.body.Bytes()
+			response.expiration = time.Now().Add(c.ttl)
+			if response.statusCode >= http.StatusInternalServerError {
+				// Requests already waiting on this one get its response, but later retries run again
+				delete(c.responses, cacheKey)
+			}
+			close(response.done)
+		}()
+		next.ServeHTTP(recorder, r)
+		completed = true
+	})
+}
+
+// Remove the responses that have expired; this must be called while holding the lock
+func (c *IdempotencyCache) removeExpired() {
+	now := time.Now()
+	for key, response := range c.responses {
+		// Responses that are still in progress don't have an expiration yet
+		if !response.expiration.IsZero() && now.After(response.expiration) {
+			delete(c.responses, key)
+		}
+	}
+}
+
+// Remove the completed response that expires soonest to make room for a new one, returning false if every response
+// is still in progress; this must be called while holding the lock
+func (c *IdempotencyCache) evictOldest() bool {
+	oldestKey := ""
+	var oldest *idempotentResponse
+	for key, response := range c.responses {
+		if response.expiration.IsZero() {
+			continue
+		}
+		if oldest == nil || response.expiration.Before(oldest.expiration) {
+			oldestKey = key
+			oldest = response
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	delete(c.responses, oldestKey)
+	return true
+}
+
+// Log an error from writing a response
+func (c *IdempotencyCache) handleError(err error) {
+	if err != nil {
+		c.logger.Error("Error handling response", log.Err(err))
+	}
+}
+
+// Get a key identifying who sent a request, so callers with different roles or credentials can't see each other's
+// cached responses even if they use the same idempotency key
+func getCallerKey(r *http.Request) string {
+	role, _ := GetApiRole(r.Context())
+	callerId, _ := GetApiCallerId(r.Context())
+	callerKey := string(role) + "\x00" + callerId
+	if creds, exists := GetPeerCredentials(r.Context()); exists {
+		callerKey += fmt.Sprintf("\x00%d", creds.Uid)
+	}
+	return callerKey
+}
+
+// Get a hash of the parts of a request that must match for a cached response to be reused
+func getRequestFingerprint(r *http.Request, body []byte) [sha256.Size]byte {
+	hasher := sha256.New()
+	hasher.Write([]byte(r.URL.Path))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(r.URL.RawQuery))
+	hasher.Write([]byte{0})
+	hasher.Write(body)
+	var fingerprint [sha256.Size]byte
+	copy(fingerprint[:], hasher.Sum(nil))
+	return fingerprint
+}
+
+// Passes a response through to the client while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// Record the status code and send it to the client
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Record part of the body and send it to the client
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
@@ -2,25 +2,44 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/events"
 	"github.com/rocket-pool/node-manager-core/log"
 )
 
+const (
+	// How long clients have to send a request's headers, so idle connections can't tie the server up
+	networkReadHeaderTimeout time.Duration = 10 * time.Second
+)
+
+// Authenticates API requests, rejecting the ones that don't have valid credentials
+type IApiAuthenticator interface {
+	// Get middleware that rejects unauthenticated requests and assigns an ApiRole to the others
+	Middleware(next http.Handler) http.Handler
+}
+
 type NetworkSocketApiServer struct {
-	logger   *slog.Logger
-	handlers []IHandler
-	ip       string
-	port     uint16
-	socket   net.Listener
-	server   http.Server
-	router   *mux.Router
+	logger        *slog.Logger
+	handlers      []IHandler
+	baseRoute     string
+	ip            string
+	port          uint16
+	socket        net.Listener
+	server        http.Server
+	router        *mux.Router
+	tlsConfig     *tls.Config
+	authenticated bool
 }
 
 func NewNetworkSocketApiServer(logger *slog.Logger, ip string, port uint16, handlers []IHandler, baseRoute string, apiVersion string) (*NetworkSocketApiServer, error) {
@@ -29,39 +48,109 @@ func NewNetworkSocketApiServer(logger *slog.Logger, ip string, port uint16, hand
 
 	// Create the manager
 	server := &NetworkSocketApiServer{
-		logger:   logger,
-		handlers: handlers,
-		ip:       ip,
-		port:     port,
-		router:   router,
+		logger:    logger,
+		handlers:  handlers,
+		baseRoute: baseRoute,
+		ip:        ip,
+		port:      port,
+		router:    router,
 		server: http.Server{
-			Handler: router,
+			Handler:           router,
+			ReadHeaderTimeout: networkReadHeaderTimeout,
 		},
 	}
 
 	// Register each route
-	nmcRouter := router.PathPrefix("/" + baseRoute + "/api/v" + apiVersion).Subrouter()
+	basePath := "/" + baseRoute + "/api/v" + apiVersion
+	nmcRouter := router.PathPrefix(basePath).Subrouter()
 	for _, handler := range server.handlers {
 		handler.RegisterRoutes(nmcRouter)
 	}
 
+	// Serve the OpenAPI spec for the routes
+	router.Path("/" + baseRoute + OpenApiSpecRoute).HandlerFunc(newOpenApiSpecHandler(logger, handlers, baseRoute, apiVersion, basePath))
+
 	return server, nil
 }
 
+// Adds middleware that runs before every route, such as an IdempotencyCache. This must be called before the
+// server is started.
+func (s *NetworkSocketApiServer) Use(middleware ...mux.MiddlewareFunc) {
+	s.router.Use(middleware...)
+}
+
+// Stream the events published to the bus to WebSocket clients on the event stream route. Requests to it go through
+// the server's middleware like any other route, so they're authenticated if the server has an authenticator. This must
+// be called before the server is started.
+func (s *NetworkSocketApiServer) EnableEventStream(bus *events.EventBus) {
+	s.router.Path("/" + s.baseRoute + EventStreamRoute).HandlerFunc(newEventStreamHandler(s.logger, bus))
+}
+
+// Require every request to be authenticated by the provided authenticator, such as a TokenAuthenticator. Its
+// middleware runs before any other middleware added afterwards, so add it before a PermissionPolicy. This must be
+// called before the server is started.
+func (s *NetworkSocketApiServer) SetAuthenticator(authenticator IApiAuthenticator) {
+	s.router.Use(authenticator.Middleware)
+	s.authenticated = true
+}
+
+// Serve HTTPS with the provided certificate and key instead of plain HTTP. If a client CA certificate is provided,
+// clients must also present a certificate signed by it (mutual TLS). This must be called before the server is started.
+func (s *NetworkSocketApiServer) EnableTls(certPath string, keyPath string, clientCaPath string) error {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return fmt.Errorf("error loading API server certificate [%s] and key [%s]: %w", certPath, keyPath, err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if clientCaPath != "" {
+		caCert, err := os.ReadFile(clientCaPath)
+		if err != nil {
+			return fmt.Errorf("error reading API client CA certificate [%s]: %w", clientCaPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return fmt.Errorf("API client CA certificate [%s] doesn't contain any PEM certificates", clientCaPath)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	s.tlsConfig = tlsConfig
+	return nil
+}
+
 // Starts listening for incoming HTTP requests
 func (s *NetworkSocketApiServer) Start(wg *sync.WaitGroup) error {
 	// Create the socket
-	socket, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.ip, s.port))
+	socket, err := net.Listen("tcp", net.JoinHostPort(s.ip, fmt.Sprint(s.port)))
 	if err != nil {
 		return fmt.Errorf("error creating socket: %w", err)
 	}
-	s.socket = socket
 
 	// Get the port if random
 	if s.port == 0 {
 		s.port = uint16(socket.Addr().(*net.TCPAddr).Port)
 	}
 
+	// Wrap it in TLS if enabled
+	if s.tlsConfig != nil {
+		socket = tls.NewListener(socket, s.tlsConfig)
+	}
+	s.socket = socket
+
+	// Anything that can reach a non-loopback address can reach the daemon, so warn if it isn't protected
+	ip := net.ParseIP(s.ip)
+	if ip == nil || !ip.IsLoopback() {
+		if s.tlsConfig == nil {
+			s.logger.Warn("API server is listening on a network address without TLS", slog.String(log.AddressKey, s.ip))
+		}
+		if !s.authenticated {
+			s.logger.Warn("API server is listening on a network address without authentication", slog.String(log.AddressKey, s.ip))
+		}
+	}
+
 	// Start listening
 	wg.Add(1)
 	go func() {
@@ -84,6 +173,11 @@ func (s *NetworkSocketApiServer) Stop() error {
 	return nil
 }
 
+// Check if the server uses TLS
+func (s *NetworkSocketApiServer) IsTlsEnabled() bool {
+	return s.tlsConfig != nil
+}
+
 // Get the port the server is running on - useful if the port was automatically assigned
 func (s *NetworkSocketApiServer) GetPort() uint16 {
 	return s.port
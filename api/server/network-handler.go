@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/node/network"
+)
+
+// Serves routes for the node's external IP address and the reachability of its P2P ports, so operators can diagnose
+// NAT and firewall problems from the daemon
+type NetworkHandler struct {
+	logger  *slog.Logger
+	checker *network.ReachabilityChecker
+}
+
+// Creates a new network handler
+func NewNetworkHandler(logger *slog.Logger, checker *network.ReachabilityChecker) *NetworkHandler {
+	return &NetworkHandler{
+		logger:  logger,
+		checker: checker,
+	}
+}
+
+// Register the network routes with the router
+func (h *NetworkHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/network").Subrouter()
+	RegisterGet(subrouter, "reachability", h.logger, h.getReachability)
+	RegisterPost(subrouter, "reachability/check", h.logger, h.check)
+}
+
+// Describe the network routes for the API's OpenAPI spec
+func (h *NetworkHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribeRoute[types.NetworkReachabilityStatus](http.MethodGet, "/network/reachability", "Get the results of the latest reachability check"),
+		DescribeRoute[types.NetworkReachabilityStatus](http.MethodPost, "/network/reachability/check", "Run a reachability check now and get its results"),
+	}
+}
+
+// Get the results of the latest reachability check
+func (h *NetworkHandler) getReachability(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	status, exists := h.checker.GetStatus()
+	if !exists {
+		return types.ResponseStatus_ResourceNotFound, nil, fmt.Errorf("reachability hasn't been checked yet")
+	}
+	return types.ResponseStatus_Success, status, nil
+}
+
+// Run a reachability check now and get its results
+func (h *NetworkHandler) check(ctx context.Context, body struct{}) (types.ResponseStatus, any, error) {
+	err := h.checker.Check(ctx)
+	if err != nil {
+		// The status records why the external IP address couldn't be found, so it's returned instead of the error
+		h.logger.Warn("Reachability check failed", log.Err(err))
+	}
+	status, _ := h.checker.GetStatus()
+	return types.ResponseStatus_Success, status, nil
+}
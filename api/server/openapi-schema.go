@@ -0,0 +1,214 @@
+package server
+
+import (
+	"encoding"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// Matches the package paths in the names of generic types, such as "github.com/org/repo/types." in
+	// "DataBatch[github.com/org/repo/types.Info]"
+	typeNamePackageRegex = regexp.MustCompile(`[\w./-]+\.`)
+
+	// Matches the characters OpenAPI doesn't allow in schema names
+	invalidSchemaNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+	// Types whose JSON encoding doesn't follow from their kind
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// Schemas for common types that can't be determined by reflection
+	knownOpenApiSchemas = map[reflect.Type]OpenApiSchema{
+		reflect.TypeOf(big.Int{}):   {Type: "integer"},
+		reflect.TypeOf(time.Time{}): {Type: "string", Format: "date-time"},
+	}
+)
+
+// Builds the schemas for Go types, adding the ones for named structs to the document's components
+type openApiSchemaBuilder struct {
+	// The schemas in the document's components, by name
+	schemas map[string]*OpenApiSchema
+
+	// The names of the component schemas, by the type they describe
+	names map[reflect.Type]string
+}
+
+// Creates a new schema builder
+func newOpenApiSchemaBuilder() *openApiSchemaBuilder {
+	return &openApiSchemaBuilder{
+		schemas: map[string]*OpenApiSchema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// Get the schema for a type, matching the way it's serialized to JSON
+func (b *openApiSchemaBuilder) getSchema(t reflect.Type) *OpenApiSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if known, exists := knownOpenApiSchemas[t]; exists {
+		return &known
+	}
+
+	// Types that serialize themselves take precedence over their kind, like they do in the JSON encoder
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return getMarshalerSchema(t)
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &OpenApiSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenApiSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenApiSchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &OpenApiSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &OpenApiSchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenApiSchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenApiSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &OpenApiSchema{Type: "string", Format: "byte"}
+		}
+		return &OpenApiSchema{
+			Type:  "array",
+			Items: b.getSchema(t.Elem()),
+		}
+	case reflect.Map:
+		return &OpenApiSchema{
+			Type:                 "object",
+			AdditionalProperties: b.getSchema(t.Elem()),
+		}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.getStructSchema(t)
+		}
+		return &OpenApiSchema{Ref: getOpenApiSchemaRef(b.addComponent(t))}
+	default:
+		// Interfaces and anything else can hold any value
+		return &OpenApiSchema{}
+	}
+}
+
+// Add the schema for a named struct to the components if it isn't there yet, returning its name
+func (b *openApiSchemaBuilder) addComponent(t reflect.Type) string {
+	if name, exists := b.names[t]; exists {
+		return name
+	}
+
+	// Prefix the package name if another type already has the name
+	name := getSchemaName(t)
+	if _, exists := b.schemas[name]; exists {
+		packagePath := strings.Split(t.PkgPath(), "/")
+		name = packagePath[len(packagePath)-1] + "." + name
+	}
+
+	// Register the name before building the schema so recursive types refer to it instead of looping
+	b.names[t] = name
+	b.schemas[name] = &OpenApiSchema{}
+	*b.schemas[name] = *b.getStructSchema(t)
+	return name
+}
+
+// Get the schema for a struct's fields
+func (b *openApiSchemaBuilder) getStructSchema(t reflect.Type) *OpenApiSchema {
+	schema := &OpenApiSchema{
+		Type:       "object",
+		Properties: map[string]*OpenApiSchema{},
+	}
+	b.addStructFields(t, schema)
+	return schema
+}
+
+// Add the fields of a struct to an object schema, including the ones promoted from embedded structs
+func (b *openApiSchemaBuilder) addStructFields(t reflect.Type, schema *OpenApiSchema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// Embedded structs without a name have their fields promoted
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				b.addStructFields(fieldType, schema)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := b.getSchema(field.Type)
+		if strings.Contains(options, "string") {
+			fieldSchema = &OpenApiSchema{Type: "string"}
+		}
+		if field.Type.Kind() == reflect.Pointer && fieldSchema.Ref == "" {
+			fieldSchema.Nullable = true
+		}
+		schema.Properties[name] = fieldSchema
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// Get the schema for a type that serializes itself, based on what its zero value serializes to
+func getMarshalerSchema(t reflect.Type) (schema *OpenApiSchema) {
+	schema = &OpenApiSchema{}
+	defer func() {
+		// Some types can't serialize their zero value, so leave the schema open if that happens
+		_ = recover()
+	}()
+	marshaler, isMarshaler := reflect.New(t).Interface().(json.Marshaler)
+	if !isMarshaler {
+		return schema
+	}
+	bytes, err := marshaler.MarshalJSON()
+	if err != nil || len(bytes) == 0 {
+		return schema
+	}
+	switch bytes[0] {
+	case '"':
+		schema.Type = "string"
+	case '[':
+		schema.Type = "array"
+		schema.Items = &OpenApiSchema{}
+	case '{':
+		schema.Type = "object"
+	case 't', 'f':
+		schema.Type = "boolean"
+	case 'n':
+	default:
+		schema.Type = "number"
+	}
+	return schema
+}
+
+// Get the name of a struct's schema, such as "DataBatch_Info" for DataBatch[types.Info]
+func getSchemaName(t reflect.Type) string {
+	name := typeNamePackageRegex.ReplaceAllString(t.Name(), "")
+	name = strings.NewReplacer("[", "_", ",", "_", "]", "").Replace(name)
+	return invalidSchemaNameRegex.ReplaceAllString(name, "")
+}
@@ -0,0 +1,378 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// The route the API servers serve their OpenAPI spec on
+	OpenApiSpecRoute string = "/api/spec"
+
+	// The version of the OpenAPI specification the generated documents follow
+	openApiVersion string = "3.0.3"
+
+	// The name of the schema for error responses
+	errorResponseSchemaName string = "ErrorResponse"
+)
+
+// ==========================
+// === Route Descriptions ===
+// ==========================
+
+// Handlers can implement this to describe their routes, so they're included in the API's OpenAPI spec
+type IDescribedHandler interface {
+	IHandler
+
+	// Describe the routes the handler registers
+	DescribeRoutes() []RouteDescription
+}
+
+// A description of a route for the OpenAPI spec
+type RouteDescription struct {
+	// The HTTP method the route accepts
+	Method string
+
+	// The route's path, relative to the API's versioned base route (such as "/wallet/accounts/list")
+	Path string
+
+	// A short summary of what the route does
+	Summary string
+
+	// The route's query parameters
+	Parameters []ParameterDescription
+
+	// The type of the route's JSON request body, or nil if it doesn't take one
+	BodyType reflect.Type
+
+	// The type of the data in the route's ApiResponse
+	DataType reflect.Type
+}
+
+// A description of a route's query parameter
+type ParameterDescription struct {
+	// The name of the parameter
+	Name string
+
+	// What the parameter is for
+	Description string
+
+	// True if the route fails without the parameter
+	Required bool
+
+	// The type the parameter is parsed into, which determines its schema
+	Type reflect.Type
+}
+
+// Describe a route that responds with DataType. Use types.SuccessData for routes that don't return any data.
+func DescribeRoute[DataType any](method string, path string, summary string, parameters ...ParameterDescription) RouteDescription {
+	return RouteDescription{
+		Method:     method,
+		Path:       path,
+		Summary:    summary,
+		Parameters: parameters,
+		DataType:   getReflectType[DataType](),
+	}
+}
+
+// Describe a POST route that takes a JSON body of BodyType and responds with DataType
+func DescribePostRoute[BodyType any, DataType any](path string, summary string, parameters ...ParameterDescription) RouteDescription {
+	route := DescribeRoute[DataType](http.MethodPost, path, summary, parameters...)
+	route.BodyType = getReflectType[BodyType]()
+	return route
+}
+
+// Describe a query parameter that's parsed into ArgType, such as common.Address or uint64. Use []ArgType for
+// comma-separated batches.
+func DescribeParameter[ArgType any](name string, description string, required bool) ParameterDescription {
+	return ParameterDescription{
+		Name:        name,
+		Description: description,
+		Required:    required,
+		Type:        getReflectType[ArgType](),
+	}
+}
+
+// ========================
+// === OpenAPI Document ===
+// ========================
+
+// An OpenAPI 3 document describing the API
+type OpenApiDocument struct {
+	OpenApi    string                     `json:"openapi"`
+	Info       OpenApiInfo                `json:"info"`
+	Servers    []OpenApiServer            `json:"servers,omitempty"`
+	Paths      map[string]OpenApiPathItem `json:"paths"`
+	Components OpenApiComponents          `json:"components"`
+}
+
+// The API's name and version
+type OpenApiInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// The base route the API's paths are relative to
+type OpenApiServer struct {
+	Url string `json:"url"`
+}
+
+// The operations on a path, keyed by lowercase HTTP method
+type OpenApiPathItem map[string]*OpenApiOperation
+
+// A single route
+type OpenApiOperation struct {
+	OperationId string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []OpenApiParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenApiRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenApiResponse `json:"responses"`
+}
+
+// A query parameter of an operation
+type OpenApiParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Explode     *bool          `json:"explode,omitempty"`
+	Schema      *OpenApiSchema `json:"schema"`
+}
+
+// The body of an operation's request
+type OpenApiRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenApiMediaType `json:"content"`
+}
+
+// A possible response from an operation
+type OpenApiResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenApiMediaType `json:"content,omitempty"`
+}
+
+// The schema of a request or response body
+type OpenApiMediaType struct {
+	Schema *OpenApiSchema `json:"schema"`
+}
+
+// The reusable schemas referenced by the document
+type OpenApiComponents struct {
+	Schemas map[string]*OpenApiSchema `json:"schemas"`
+}
+
+// A JSON schema, in the subset OpenAPI 3.0 supports
+type OpenApiSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *OpenApiSchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenApiSchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenApiSchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+}
+
+// Generate an OpenAPI document for the routes of the provided handlers. Handlers that don't implement
+// IDescribedHandler are left out. Paths are relative to the base path, which is the API's versioned base route.
+func GenerateOpenApiSpec(title string, version string, basePath string, handlers []IHandler) (*OpenApiDocument, error) {
+	schemas := newOpenApiSchemaBuilder()
+	doc := &OpenApiDocument{
+		OpenApi: openApiVersion,
+		Info: OpenApiInfo{
+			Title:   title,
+			Version: version,
+		},
+		Servers: []OpenApiServer{
+			{Url: basePath},
+		},
+		Paths: map[string]OpenApiPathItem{},
+	}
+
+	// Failed requests all have the same body
+	errorCodes := []string{}
+	for _, info := range types.GetErrorCodes() {
+		errorCodes = append(errorCodes, string(info.Code))
+	}
+	schemas.schemas[errorResponseSchemaName] = &OpenApiSchema{
+		Type: "object",
+		Properties: map[string]*OpenApiSchema{
+			"success": {Type: "boolean"},
+			"error":   {Type: "string"},
+			"code":    {Type: "string", Enum: errorCodes},
+		},
+		Required: []string{"success", "error"},
+	}
+	errorResponse := OpenApiResponse{
+		Description: "The request failed",
+		Content: map[string]OpenApiMediaType{
+			"application/json": {Schema: &OpenApiSchema{Ref: getOpenApiSchemaRef(errorResponseSchemaName)}},
+		},
+	}
+
+	for _, handler := range handlers {
+		describedHandler, ok := handler.(IDescribedHandler)
+		if !ok {
+			continue
+		}
+		for _, route := range describedHandler.DescribeRoutes() {
+			operation, err := createOpenApiOperation(route, schemas, errorResponse)
+			if err != nil {
+				return nil, fmt.Errorf("error describing route [%s %s]: %w", route.Method, route.Path, err)
+			}
+			pathItem, exists := doc.Paths[route.Path]
+			if !exists {
+				pathItem = OpenApiPathItem{}
+				doc.Paths[route.Path] = pathItem
+			}
+			method := strings.ToLower(route.Method)
+			if _, exists := pathItem[method]; exists {
+				return nil, fmt.Errorf("route [%s %s] is described more than once", route.Method, route.Path)
+			}
+			pathItem[method] = operation
+		}
+	}
+	doc.Components.Schemas = schemas.schemas
+	return doc, nil
+}
+
+// Create the OpenAPI operation for a route
+func createOpenApiOperation(route RouteDescription, schemas *openApiSchemaBuilder, errorResponse OpenApiResponse) (*OpenApiOperation, error) {
+	switch route.Method {
+	case http.MethodGet, http.MethodPost:
+	default:
+		return nil, fmt.Errorf("unsupported method")
+	}
+	if !strings.HasPrefix(route.Path, "/") {
+		return nil, fmt.Errorf("path must start with a slash")
+	}
+	if route.DataType == nil {
+		return nil, fmt.Errorf("data type is missing")
+	}
+
+	// The first path segment groups the operations
+	segments := strings.Split(strings.Trim(route.Path, "/"), "/")
+	operation := &OpenApiOperation{
+		OperationId: getOpenApiOperationId(route.Method, segments),
+		Summary:     route.Summary,
+		Tags:        []string{segments[0]},
+		Responses: map[string]OpenApiResponse{
+			"default": errorResponse,
+		},
+	}
+
+	// Add the parameters
+	for _, parameter := range route.Parameters {
+		if parameter.Type == nil {
+			return nil, fmt.Errorf("parameter [%s] is missing its type", parameter.Name)
+		}
+		openApiParameter := OpenApiParameter{
+			Name:        parameter.Name,
+			In:          "query",
+			Description: parameter.Description,
+			Required:    parameter.Required,
+			Schema:      schemas.getSchema(parameter.Type),
+		}
+
+		// Batches are comma-separated instead of repeating the parameter
+		if openApiParameter.Schema.Type == "array" {
+			explode := false
+			openApiParameter.Explode = &explode
+		}
+		operation.Parameters = append(operation.Parameters, openApiParameter)
+	}
+
+	// Add the body
+	if route.BodyType != nil {
+		operation.RequestBody = &OpenApiRequestBody{
+			Required: true,
+			Content: map[string]OpenApiMediaType{
+				"application/json": {Schema: schemas.getSchema(route.BodyType)},
+			},
+		}
+	}
+
+	// Successful responses wrap the data in an ApiResponse
+	dataSchema := schemas.getSchema(route.DataType)
+	operation.Responses["200"] = OpenApiResponse{
+		Description: "The request succeeded",
+		Content: map[string]OpenApiMediaType{
+			"application/json": {
+				Schema: &OpenApiSchema{
+					Type: "object",
+					Properties: map[string]*OpenApiSchema{
+						"success": {Type: "boolean"},
+						"data":    dataSchema,
+					},
+					Required: []string{"success"},
+				},
+			},
+		},
+	}
+	return operation, nil
+}
+
+// Get the ID of an operation, such as "getWalletAccountsList", for generated clients to name their functions with
+func getOpenApiOperationId(method string, segments []string) string {
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(method))
+	for _, segment := range segments {
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		}) {
+			builder.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return builder.String()
+}
+
+// ==================
+// === Spec Route ===
+// ==================
+
+// Create the handler for the OpenAPI spec route. The spec is generated on the first request, since the handlers
+// can't change once the server is created.
+func newOpenApiSpecHandler(logger *slog.Logger, handlers []IHandler, title string, version string, basePath string) http.HandlerFunc {
+	getSpec := sync.OnceValues(func() ([]byte, error) {
+		doc, err := GenerateOpenApiSpec(title, version, basePath, handlers)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(doc)
+	})
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Info("New request", slog.String(log.MethodKey, r.Method), slog.String(log.PathKey, r.URL.Path))
+		var err error
+		if r.Method != http.MethodGet {
+			err = HandleInvalidMethod(logger, w)
+		} else if spec, specErr := getSpec(); specErr != nil {
+			err = HandleServerError(logger, w, fmt.Errorf("error generating OpenAPI spec: %w", specErr))
+		} else {
+			err = writeResponse(w, logger, http.StatusOK, "", nil, spec)
+		}
+		if err != nil {
+			logger.Error("Error handling response", log.Err(err))
+		}
+	}
+}
+
+// Get the reflection type of a type parameter, including interface types
+func getReflectType[Type any]() reflect.Type {
+	return reflect.TypeOf((*Type)(nil)).Elem()
+}
+
+// Get a reference to a schema in the document's components
+func getOpenApiSchemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// The credentials of the process that opened a Unix socket connection
+type PeerCredentials struct {
+	Pid int32
+	Uid uint32
+	Gid uint32
+}
+
+// The context key for a connection's peer credentials
+type peerCredentialsContextKey struct{}
+
+// Get the credentials of the process that sent a request over the Unix socket. Returns false if they aren't
+// available, such as for network requests or on platforms without SO_PEERCRED support.
+func GetPeerCredentials(ctx context.Context) (PeerCredentials, bool) {
+	creds, exists := ctx.Value(peerCredentialsContextKey{}).(PeerCredentials)
+	return creds, exists
+}
+
+// Attach the peer credentials of a new connection to its context, if they can be read
+func attachPeerCredentials(logger *slog.Logger) func(ctx context.Context, conn net.Conn) context.Context {
+	return func(ctx context.Context, conn net.Conn) context.Context {
+		creds, err := getPeerCredentials(conn)
+		if err != nil {
+			logger.Debug("Couldn't get peer credentials for connection", log.Err(err))
+			return ctx
+		}
+		return context.WithValue(ctx, peerCredentialsContextKey{}, creds)
+	}
+}
+
+// A set of routes and the users and groups allowed to use them
+type peerRouteGroup struct {
+	prefix      string
+	allowedUids []uint32
+	allowedGids []uint32
+}
+
+// Restricts groups of routes on the Unix socket to specific users and groups, based on the credentials of the
+// process that opened the connection. Routes that aren't in a group are open to anyone who can access the socket.
+type PeerCredentialPolicy struct {
+	logger *slog.Logger
+	groups []peerRouteGroup
+}
+
+// Creates a new peer credential policy with no restrictions
+func NewPeerCredentialPolicy(logger *slog.Logger) *PeerCredentialPolicy {
+	return &PeerCredentialPolicy{
+		logger: logger,
+		groups: []peerRouteGroup{},
+	}
+}
+
+// Restrict the routes starting with the provided path prefix to callers whose UID or primary GID is in the provided
+// lists. If a route matches more than one group, the group with the longest prefix is used.
+func (p *PeerCredentialPolicy) AddRouteGroup(prefix string, allowedUids []uint32, allowedGids []uint32) {
+	p.groups = append(p.groups, peerRouteGroup{
+		prefix:      prefix,
+		allowedUids: allowedUids,
+		allowedGids: allowedGids,
+	})
+}
+
+// Check if the caller with the provided credentials is allowed to use a route
+func (p *PeerCredentialPolicy) IsAllowed(creds PeerCredentials, hasCreds bool, path string) bool {
+	var group *peerRouteGroup
+	for i, candidate := range p.groups {
+		if strings.HasPrefix(path, candidate.prefix) && (group == nil || len(candidate.prefix) > len(group.prefix)) {
+			group = &p.groups[i]
+		}
+	}
+	if group == nil {
+		return true
+	}
+	if !hasCreds {
+		return false
+	}
+	return slices.Contains(group.allowedUids, creds.Uid) || slices.Contains(group.allowedGids, creds.Gid)
+}
+
+// Reject requests from callers that aren't allowed to use the route
+func (p *PeerCredentialPolicy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creds, hasCreds := GetPeerCredentials(r.Context())
+		if !p.IsAllowed(creds, hasCreds, r.URL.Path) {
+			var err error
+			if hasCreds {
+				err = fmt.Errorf("user %d (group %d) can't use %s", creds.Uid, creds.Gid, r.URL.Path)
+			} else {
+				err = fmt.Errorf("the caller's credentials couldn't be verified for %s", r.URL.Path)
+			}
+			err = HandleForbidden(p.logger, w, err)
+			if err != nil {
+				p.logger.Error("Error handling response", log.Err(err))
+			}
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
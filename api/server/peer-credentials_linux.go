@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// Get the credentials of the process on the other end of a Unix socket connection
+func getPeerCredentials(conn net.Conn) (PeerCredentials, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return PeerCredentials{}, fmt.Errorf("connection is not a Unix socket")
+	}
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return PeerCredentials{}, fmt.Errorf("error getting raw connection: %w", err)
+	}
+
+	var ucred *syscall.Ucred
+	var credErr error
+	err = rawConn.Control(func(fd uintptr) {
+		ucred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return PeerCredentials{}, fmt.Errorf("error accessing socket: %w", err)
+	}
+	if credErr != nil {
+		return PeerCredentials{}, fmt.Errorf("error getting peer credentials: %w", credErr)
+	}
+	return PeerCredentials{
+		Pid: ucred.Pid,
+		Uid: ucred.Uid,
+		Gid: ucred.Gid,
+	}, nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+)
+
+// Get the credentials of the process on the other end of a Unix socket connection; this is only supported on Linux
+func getPeerCredentials(conn net.Conn) (PeerCredentials, error) {
+	return PeerCredentials{}, fmt.Errorf("peer credentials aren't supported on %s", runtime.GOOS)
+}
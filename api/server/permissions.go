@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// The prefix of bearer tokens in the Authorization header
+	bearerPrefix string = "Bearer "
+)
+
+// The permission tier of an API caller
+type ApiRole string
+
+const (
+	// The caller can only query the node's state
+	ApiRole_ReadOnly ApiRole = "read-only"
+
+	// The caller can use every route, including ones that submit transactions or modify the wallet
+	ApiRole_Admin ApiRole = "admin"
+)
+
+// The context key for the caller's role
+type apiRoleContextKey struct{}
+
+// The context key for the ID of the credential the caller authenticated with
+type apiCallerIdContextKey struct{}
+
+// Get the role assigned to a request by the authentication middleware. Returns false if it doesn't have one.
+func GetApiRole(ctx context.Context) (ApiRole, bool) {
+	role, exists := ctx.Value(apiRoleContextKey{}).(ApiRole)
+	return role, exists
+}
+
+// Get the ID of the credential a request was authenticated with, such as the ID of the key that signed it. Returns
+// false if the request wasn't authenticated with a credential.
+func GetApiCallerId(ctx context.Context) (string, bool) {
+	callerId, exists := ctx.Value(apiCallerIdContextKey{}).(string)
+	return callerId, exists
+}
+
+// Attach the role and credential ID of an authenticated caller to its request
+func withApiCaller(r *http.Request, role ApiRole, callerId string) *http.Request {
+	ctx := context.WithValue(r.Context(), apiRoleContextKey{}, role)
+	ctx = context.WithValue(ctx, apiCallerIdContextKey{}, callerId)
+	return r.WithContext(ctx)
+}
+
+// Create middleware that assigns the same role to every request, for servers whose access is already restricted
+// (such as a separate socket for monitoring integrations)
+func AssignRole(role ApiRole) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiRoleContextKey{}, role)))
+		})
+	}
+}
+
+// ==========================
+// === TokenAuthenticator ===
+// ==========================
+
+// Assigns roles to requests based on the bearer token in their Authorization header
+type TokenAuthenticator struct {
+	logger *slog.Logger
+	tokens map[string]ApiRole
+}
+
+// Creates a new token authenticator with the provided role for each token
+func NewTokenAuthenticator(logger *slog.Logger, tokens map[string]ApiRole) (*TokenAuthenticator, error) {
+	for token := range tokens {
+		if token == "" {
+			return nil, fmt.Errorf("API tokens can't be empty")
+		}
+	}
+	return &TokenAuthenticator{
+		logger: logger,
+		tokens: tokens,
+	}, nil
+}
+
+// Reject requests without a valid token, and assign the token's role to the others
+func (a *TokenAuthenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			a.handleError(HandleUnauthorized(a.logger, w, fmt.Errorf("missing bearer token")))
+			return
+		}
+		token := strings.TrimPrefix(header, bearerPrefix)
+		role, exists := a.getRole(token)
+		if !exists {
+			a.handleError(HandleUnauthorized(a.logger, w, fmt.Errorf("invalid bearer token")))
+			return
+		}
+		next.ServeHTTP(w, withApiCaller(r, role, getTokenId(token)))
+	})
+}
+
+// Get the role for a token, comparing it against every known token in constant time
+func (a *TokenAuthenticator) getRole(token string) (ApiRole, bool) {
+	var role ApiRole
+	found := false
+	for candidate, candidateRole := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			role = candidateRole
+			found = true
+		}
+	}
+	return role, found
+}
+
+// Get an ID for a token that can be logged or used as a cache key without revealing it
+func getTokenId(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "token-" + hex.EncodeToString(hash[:8])
+}
+
+// Log an error from writing a response
+func (a *TokenAuthenticator) handleError(err error) {
+	if err != nil {
+		a.logger.Error("Error handling response", log.Err(err))
+	}
+}
+
+// ========================
+// === PermissionPolicy ===
+// ========================
+
+// Decides which routes each role can use. Read-only callers can only make GET requests, and not to any of the
+// admin-only routes; admins can use every route. Requests without a role get the default role.
+type PermissionPolicy struct {
+	logger          *slog.Logger
+	defaultRole     ApiRole
+	adminOnlyRoutes []string
+}
+
+// Creates a new permission policy. Admin-only routes are path prefixes (such as "/myapp/api/v1/wallet/") that
+// read-only callers can't use even with GET, for queries that expose sensitive data.
+func NewPermissionPolicy(logger *slog.Logger, defaultRole ApiRole, adminOnlyRoutes []string) *PermissionPolicy {
+	return &PermissionPolicy{
+		logger:          logger,
+		defaultRole:     defaultRole,
+		adminOnlyRoutes: adminOnlyRoutes,
+	}
+}
+
+// Check if a role is allowed to make a request
+func (p *PermissionPolicy) IsAllowed(role ApiRole, r *http.Request) bool {
+	switch role {
+	case ApiRole_Admin:
+		return true
+	case ApiRole_ReadOnly:
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			return false
+		}
+		for _, route := range p.adminOnlyRoutes {
+			if strings.HasPrefix(r.URL.Path, route) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Reject requests that the caller's role isn't allowed to make
+func (p *PermissionPolicy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, exists := GetApiRole(r.Context())
+		if !exists {
+			role = p.defaultRole
+		}
+		if !p.IsAllowed(role, r) {
+			err := HandleForbidden(p.logger, w, fmt.Errorf("the %s role can't use %s %s", role, r.Method, r.URL.Path))
+			if err != nil {
+				p.logger.Error("Error handling response", log.Err(err))
+			}
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
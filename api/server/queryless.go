@@ -173,7 +173,8 @@ func runQuerylessRoute[DataType any](ctx IQuerylessCallContext[DataType], servic
 	// Create the response and data
 	data := new(DataType)
 	response := &types.ApiResponse[DataType]{
-		Data: data,
+		Success: true,
+		Data:    data,
 	}
 
 	// Prep the data with the context-specific behavior
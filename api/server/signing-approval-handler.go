@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	nodewallet "github.com/rocket-pool/node-manager-core/node/wallet"
+)
+
+// Serves routes for reviewing and deciding the transactions waiting for the operator's approval
+type SigningApprovalHandler struct {
+	logger *slog.Logger
+	wallet *nodewallet.Wallet
+}
+
+// Creates a new signing approval handler
+func NewSigningApprovalHandler(logger *slog.Logger, wallet *nodewallet.Wallet) *SigningApprovalHandler {
+	return &SigningApprovalHandler{
+		logger: logger,
+		wallet: wallet,
+	}
+}
+
+// Register the signing approval routes with the router
+func (h *SigningApprovalHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/signing").Subrouter()
+	RegisterGet(subrouter, "requests", h.logger, h.getRequests)
+	RegisterPost(subrouter, "approve", h.logger, h.approve)
+	RegisterPost(subrouter, "reject", h.logger, h.reject)
+}
+
+// Describe the signing approval routes for the API's OpenAPI spec
+func (h *SigningApprovalHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribeRoute[types.SigningRequestsData](http.MethodGet, "/signing/requests", "Get the signing requests waiting for approval"),
+		DescribePostRoute[types.SigningApproveBody, types.SuccessData]("/signing/approve", "Approve a signing request"),
+		DescribePostRoute[types.SigningRejectBody, types.SuccessData]("/signing/reject", "Reject a signing request"),
+	}
+}
+
+// Get the requests waiting for approval
+func (h *SigningApprovalHandler) getRequests(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	queue, err := h.getQueue()
+	if err != nil {
+		return types.ResponseStatus_ResourceNotFound, nil, err
+	}
+	return types.ResponseStatus_Success, types.SigningRequestsData{
+		Requests: queue.GetRequests(),
+	}, nil
+}
+
+// Approve a request
+func (h *SigningApprovalHandler) approve(ctx context.Context, body types.SigningApproveBody) (types.ResponseStatus, any, error) {
+	queue, err := h.getQueue()
+	if err != nil {
+		return types.ResponseStatus_ResourceNotFound, nil, err
+	}
+	if body.ID == "" {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'id'")
+	}
+	err = queue.Approve(body.ID)
+	if err != nil {
+		return types.ResponseStatus_ResourceNotFound, nil, err
+	}
+	return types.ResponseStatus_Success, nil, nil
+}
+
+// Reject a request, optionally with a reason
+func (h *SigningApprovalHandler) reject(ctx context.Context, body types.SigningRejectBody) (types.ResponseStatus, any, error) {
+	queue, err := h.getQueue()
+	if err != nil {
+		return types.ResponseStatus_ResourceNotFound, nil, err
+	}
+	if body.ID == "" {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'id'")
+	}
+	err = queue.Reject(body.ID, body.Reason)
+	if err != nil {
+		return types.ResponseStatus_ResourceNotFound, nil, err
+	}
+	return types.ResponseStatus_Success, nil, nil
+}
+
+// Get the wallet's approval queue, or an error if signing approval isn't enabled
+func (h *SigningApprovalHandler) getQueue() (*nodewallet.SigningApprovalQueue, error) {
+	queue := h.wallet.GetSigningApprovalQueue()
+	if queue == nil {
+		return nil, fmt.Errorf("signing approval is not enabled")
+	}
+	return queue, nil
+}
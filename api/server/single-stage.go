@@ -193,7 +193,8 @@ func runSingleStageRoute[DataType any](ctx ISingleStageCallContext[DataType], se
 	// Create the response and data
 	data := new(DataType)
 	response := &types.ApiResponse[DataType]{
-		Data: data,
+		Success: true,
+		Data:    data,
 	}
 
 	// Prep the data with the context-specific behavior
@@ -13,13 +13,16 @@ import (
 	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/events"
 	"github.com/rocket-pool/node-manager-core/log"
 )
 
 type UnixSocketApiServer struct {
 	logger     *slog.Logger
 	handlers   []IHandler
+	baseRoute  string
 	socketPath string
+	socketMode fs.FileMode
 	socket     net.Listener
 	server     http.Server
 	router     *mux.Router
@@ -33,10 +36,13 @@ func NewUnixSocketApiServer(logger *slog.Logger, socketPath string, handlers []I
 	server := &UnixSocketApiServer{
 		logger:     logger,
 		handlers:   handlers,
+		baseRoute:  baseRoute,
 		socketPath: socketPath,
+		socketMode: 0600,
 		router:     router,
 		server: http.Server{
-			Handler: router,
+			Handler:     router,
+			ConnContext: attachPeerCredentials(logger),
 		},
 	}
 
@@ -46,6 +52,9 @@ func NewUnixSocketApiServer(logger *slog.Logger, socketPath string, handlers []I
 		handler.RegisterRoutes(nmcRouter)
 	}
 
+	// Serve the OpenAPI spec for the routes
+	router.Host(baseRoute).Path(OpenApiSpecRoute).HandlerFunc(newOpenApiSpecHandler(logger, handlers, baseRoute, apiVersion, "/api/v"+apiVersion))
+
 	// Create the socket directory
 	socketDir := filepath.Dir(socketPath)
 	err := os.MkdirAll(socketDir, 0700)
@@ -56,6 +65,24 @@ func NewUnixSocketApiServer(logger *slog.Logger, socketPath string, handlers []I
 	return server, nil
 }
 
+// Adds middleware that runs before every route, such as an IdempotencyCache. This must be called before the
+// server is started.
+func (s *UnixSocketApiServer) Use(middleware ...mux.MiddlewareFunc) {
+	s.router.Use(middleware...)
+}
+
+// Stream the events published to the bus to WebSocket clients on the event stream route. This must be called before
+// the server is started.
+func (s *UnixSocketApiServer) EnableEventStream(bus *events.EventBus) {
+	s.router.Host(s.baseRoute).Path(EventStreamRoute).HandlerFunc(newEventStreamHandler(s.logger, bus))
+}
+
+// Set the permissions of the socket file, which are 0600 by default. Use broader permissions with a
+// PeerCredentialPolicy to let other users access some of the routes. This must be called before the server is started.
+func (s *UnixSocketApiServer) SetSocketMode(mode fs.FileMode) {
+	s.socketMode = mode
+}
+
 // Starts listening for incoming HTTP requests
 func (s *UnixSocketApiServer) Start(wg *sync.WaitGroup, socketOwnerUid uint32, socketOwnerGid uint32) error {
 	// Remove the socket if it's already there
@@ -74,8 +101,8 @@ func (s *UnixSocketApiServer) Start(wg *sync.WaitGroup, socketOwnerUid uint32, s
 	}
 	s.socket = socket
 
-	// Make it so only the user can write to the socket
-	err = os.Chmod(s.socketPath, 0600)
+	// Make it so only the user can write to the socket, unless broader permissions were requested
+	err = os.Chmod(s.socketPath, s.socketMode)
 	if err != nil {
 		return fmt.Errorf("error setting permissions on socket: %w", err)
 	}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/node/updates"
+)
+
+// Serves routes for checking whether a newer version of the daemon is available
+type UpdateHandler struct {
+	logger  *slog.Logger
+	checker *updates.UpdateChecker
+}
+
+// Creates a new update handler
+func NewUpdateHandler(logger *slog.Logger, checker *updates.UpdateChecker) *UpdateHandler {
+	return &UpdateHandler{
+		logger:  logger,
+		checker: checker,
+	}
+}
+
+// Register the update routes with the router
+func (h *UpdateHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/updates").Subrouter()
+	RegisterGet(subrouter, "status", h.logger, h.getStatus)
+	RegisterPost(subrouter, "check", h.logger, h.check)
+}
+
+// Describe the update routes for the API's OpenAPI spec
+func (h *UpdateHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribeRoute[types.UpdateStatus](http.MethodGet, "/updates/status", "Get the results of the latest update check"),
+		DescribeRoute[types.UpdateStatus](http.MethodPost, "/updates/check", "Check for updates now and get the results"),
+	}
+}
+
+// Get the results of the latest update check
+func (h *UpdateHandler) getStatus(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	status, exists := h.checker.GetStatus()
+	if !exists {
+		return types.ResponseStatus_ResourceNotFound, nil, fmt.Errorf("updates haven't been checked yet")
+	}
+	return types.ResponseStatus_Success, status, nil
+}
+
+// Check for updates now and get the results
+func (h *UpdateHandler) check(ctx context.Context, body struct{}) (types.ResponseStatus, any, error) {
+	err := h.checker.Check(ctx)
+	if err != nil {
+		// The status records why the check failed, so it's returned instead of the error
+		h.logger.Warn("Update check failed", log.Err(err))
+	}
+	status, _ := h.checker.GetStatus()
+	return types.ResponseStatus_Success, status, nil
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	nodewallet "github.com/rocket-pool/node-manager-core/node/wallet"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+)
+
+const (
+	// The number of accounts to list if the request doesn't specify a count
+	defaultWalletAccountCount uint64 = 10
+
+	// The most accounts that can be listed in one request, since each one has to be derived
+	maxWalletAccountCount uint64 = 100
+)
+
+// Serves routes for listing the accounts derived from the local wallet and switching the node account between them
+type WalletAccountHandler struct {
+	logger *slog.Logger
+	wallet *nodewallet.Wallet
+}
+
+// Creates a new wallet account handler
+func NewWalletAccountHandler(logger *slog.Logger, wallet *nodewallet.Wallet) *WalletAccountHandler {
+	return &WalletAccountHandler{
+		logger: logger,
+		wallet: wallet,
+	}
+}
+
+// Register the wallet account routes with the router
+func (h *WalletAccountHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/wallet/accounts").Subrouter()
+	RegisterGet(subrouter, "list", h.logger, h.list)
+	RegisterPost(subrouter, "select", h.logger, h.selectAccount)
+}
+
+// Describe the wallet account routes for the API's OpenAPI spec
+func (h *WalletAccountHandler) DescribeRoutes() []RouteDescription {
+	return []RouteDescription{
+		DescribeRoute[types.WalletAccountsData](http.MethodGet, "/wallet/accounts/list", "List the accounts derived from the local wallet's seed at a range of indices",
+			DescribeParameter[uint64]("start", "The first index to list", false),
+			DescribeParameter[uint64]("count", "How many accounts to list", false),
+		),
+		DescribePostRoute[types.WalletAccountSelectBody, types.SuccessData]("/wallet/accounts/select", "Switch the node account to the account at an index"),
+	}
+}
+
+// List the accounts derived at a range of indices
+func (h *WalletAccountHandler) list(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	var start uint64
+	var exists bool
+	err := ValidateOptionalArg("start", args, input.ValidateUint, &start, &exists)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+	count := defaultWalletAccountCount
+	err = ValidateOptionalArg("count", args, input.ValidatePositiveUint, &count, &exists)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+	count = min(count, maxWalletAccountCount)
+
+	activeIndex, err := h.wallet.GetWalletIndex()
+	if err != nil {
+		return getWalletErrorStatus(err), nil, err
+	}
+	accounts, err := h.wallet.GetDerivedAccounts(uint(start), uint(count))
+	if err != nil {
+		return getWalletErrorStatus(err), nil, err
+	}
+	return types.ResponseStatus_Success, types.WalletAccountsData{
+		ActiveIndex: activeIndex,
+		Accounts:    accounts,
+	}, nil
+}
+
+// Switch the node account to the account at an index
+func (h *WalletAccountHandler) selectAccount(ctx context.Context, body types.WalletAccountSelectBody) (types.ResponseStatus, any, error) {
+	if body.Index == nil {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'index'")
+	}
+	index := *body.Index
+	err := h.wallet.SetWalletIndex(uint(index))
+	if err != nil {
+		return getWalletErrorStatus(err), nil, err
+	}
+	address, _ := h.wallet.GetAddress()
+	h.logger.Warn("Switched node account", slog.Uint64(log.WalletIndexKey, index), slog.String(log.AddressKey, address.Hex()))
+	return types.ResponseStatus_Success, nil, nil
+}
+
+// Get the response status for a wallet error
+func getWalletErrorStatus(err error) types.ResponseStatus {
+	switch {
+	case errors.Is(err, nodewallet.ErrWalletNotLoaded):
+		return types.ResponseStatus_WalletNotReady
+	case errors.Is(err, nodewallet.ErrNotSupported):
+		return types.ResponseStatus_InvalidArguments
+	default:
+		return types.ResponseStatus_Error
+	}
+}
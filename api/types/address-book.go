@@ -0,0 +1,16 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// The entries in the address book
+type AddressBookData struct {
+	Entries []eth.AddressBookEntry `json:"entries"`
+}
+
+// A request to remove an address's label
+type AddressBookDeleteBody struct {
+	Address common.Address `json:"address"`
+}
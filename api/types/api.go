@@ -4,9 +4,19 @@ import (
 	"github.com/rocket-pool/node-manager-core/eth"
 )
 
+// The envelope every API response is wrapped in
 type ApiResponse[Data any] struct {
-	Data  *Data  `json:"data,omitempty"`
+	// True if the request succeeded
+	Success bool `json:"success"`
+
+	// The error message, if the request failed
 	Error string `json:"error,omitempty"`
+
+	// The code for why the request failed, if it did
+	Code ErrorCode `json:"code,omitempty"`
+
+	// The route's data, if the request succeeded
+	Data *Data `json:"data,omitempty"`
 }
 
 type SuccessData struct {
@@ -58,4 +68,8 @@ const (
 	// The request failed because the chain's state won't allow it to proceed. This is usually used for methods that
 	// build transactions, but the preconditions for it aren't correct (and executing it will revert)
 	ResponseStatus_InvalidChainState
+
+	// The request couldn't be accepted because the daemon is at capacity, such as when its job queue is full.
+	// It can be retried later.
+	ResponseStatus_Busy
 )
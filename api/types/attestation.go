@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// A node's signed attestation in response to a service's challenge
+type NodeAttestationData struct {
+	wallet.SignedNodeAttestation
+}
+
+// A request to sign an attestation for a service's challenge
+type NodeAttestationBody struct {
+	// The name of the service that issued the challenge
+	Service string `json:"service"`
+
+	// The service's challenge nonce
+	Nonce utils.ByteArray `json:"nonce"`
+}
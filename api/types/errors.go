@@ -0,0 +1,193 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// A machine-readable code for why an API request failed, so clients can handle specific failures without parsing
+// error messages
+type ErrorCode string
+
+const (
+	// The daemon hit an unexpected error
+	ErrorCode_InternalError ErrorCode = "internal-error"
+
+	// There was a problem with the request's arguments
+	ErrorCode_InvalidArguments ErrorCode = "invalid-arguments"
+
+	// The request requires a node address but one isn't set
+	ErrorCode_AddressNotPresent ErrorCode = "address-not-present"
+
+	// The request requires a node wallet but it isn't ready, such as when its address doesn't match the node address
+	ErrorCode_WalletNotReady ErrorCode = "wallet-not-ready"
+
+	// The request requires a node wallet but one isn't loaded
+	ErrorCode_WalletNotLoaded ErrorCode = "wallet-not-loaded"
+
+	// The request conflicts with a resource that already exists
+	ErrorCode_ResourceConflict ErrorCode = "resource-conflict"
+
+	// The request refers to a resource that doesn't exist
+	ErrorCode_ResourceNotFound ErrorCode = "resource-not-found"
+
+	// The request requires synced clients but they're still syncing
+	ErrorCode_ClientsNotSynced ErrorCode = "clients-not-synced"
+
+	// None of the Execution Clients or Beacon Nodes could be reached
+	ErrorCode_ClientsOffline ErrorCode = "clients-offline"
+
+	// The chain's state won't allow the request, such as a transaction that would revert
+	ErrorCode_InvalidChainState ErrorCode = "invalid-chain-state"
+
+	// The daemon is at capacity; the request can be retried later
+	ErrorCode_Busy ErrorCode = "busy"
+
+	// The request didn't have valid credentials
+	ErrorCode_Unauthorized ErrorCode = "unauthorized"
+
+	// The caller's role isn't allowed to use the route
+	ErrorCode_Forbidden ErrorCode = "forbidden"
+)
+
+// A registered error code and how the API server responds with it
+type ErrorCodeInfo struct {
+	// The code
+	Code ErrorCode `json:"code"`
+
+	// The HTTP status the server responds with
+	HttpStatus int `json:"httpStatus"`
+
+	// What the code means
+	Description string `json:"description"`
+}
+
+var (
+	// The registered error codes
+	errorCodes = map[ErrorCode]ErrorCodeInfo{
+		ErrorCode_InternalError:     {ErrorCode_InternalError, http.StatusInternalServerError, "The daemon hit an unexpected error"},
+		ErrorCode_InvalidArguments:  {ErrorCode_InvalidArguments, http.StatusBadRequest, "There was a problem with the request's arguments"},
+		ErrorCode_AddressNotPresent: {ErrorCode_AddressNotPresent, http.StatusUnprocessableEntity, "The request requires a node address but one isn't set"},
+		ErrorCode_WalletNotReady:    {ErrorCode_WalletNotReady, http.StatusUnprocessableEntity, "The request requires a node wallet but it isn't ready"},
+		ErrorCode_WalletNotLoaded:   {ErrorCode_WalletNotLoaded, http.StatusUnprocessableEntity, "The request requires a node wallet but one isn't loaded"},
+		ErrorCode_ResourceConflict:  {ErrorCode_ResourceConflict, http.StatusConflict, "The request conflicts with a resource that already exists"},
+		ErrorCode_ResourceNotFound:  {ErrorCode_ResourceNotFound, http.StatusNotFound, "The request refers to a resource that doesn't exist"},
+		ErrorCode_ClientsNotSynced:  {ErrorCode_ClientsNotSynced, http.StatusUnprocessableEntity, "The request requires synced clients but they're still syncing"},
+		ErrorCode_ClientsOffline:    {ErrorCode_ClientsOffline, http.StatusServiceUnavailable, "None of the Execution Clients or Beacon Nodes could be reached"},
+		ErrorCode_InvalidChainState: {ErrorCode_InvalidChainState, http.StatusUnprocessableEntity, "The chain's state won't allow the request"},
+		ErrorCode_Busy:              {ErrorCode_Busy, http.StatusServiceUnavailable, "The daemon is at capacity; try again later"},
+		ErrorCode_Unauthorized:      {ErrorCode_Unauthorized, http.StatusUnauthorized, "The request didn't have valid credentials"},
+		ErrorCode_Forbidden:         {ErrorCode_Forbidden, http.StatusForbidden, "The caller isn't allowed to use the route"},
+	}
+	errorCodeLock sync.RWMutex
+)
+
+// Register a project-specific error code, so the API server responds to ApiErrors with it using the provided HTTP
+// status. Codes can't be registered more than once.
+func RegisterErrorCode(code ErrorCode, httpStatus int, description string) error {
+	if code == "" {
+		return fmt.Errorf("error code can't be empty")
+	}
+	if httpStatus < 400 || httpStatus > 599 {
+		return fmt.Errorf("error code [%s] has HTTP status %d, but it must be a 4xx or 5xx status", code, httpStatus)
+	}
+
+	errorCodeLock.Lock()
+	defer errorCodeLock.Unlock()
+	if _, exists := errorCodes[code]; exists {
+		return fmt.Errorf("error code [%s] is already registered", code)
+	}
+	errorCodes[code] = ErrorCodeInfo{
+		Code:        code,
+		HttpStatus:  httpStatus,
+		Description: description,
+	}
+	return nil
+}
+
+// Get the details of a registered error code. Returns false if it isn't registered.
+func GetErrorCodeInfo(code ErrorCode) (ErrorCodeInfo, bool) {
+	errorCodeLock.RLock()
+	defer errorCodeLock.RUnlock()
+	info, exists := errorCodes[code]
+	return info, exists
+}
+
+// Get all of the registered error codes, sorted by code
+func GetErrorCodes() []ErrorCodeInfo {
+	errorCodeLock.RLock()
+	defer errorCodeLock.RUnlock()
+	infos := make([]ErrorCodeInfo, 0, len(errorCodes))
+	for _, info := range errorCodes {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+	return infos
+}
+
+// An error that has an error code, which the API server includes in its response
+type ICodedError interface {
+	error
+
+	// Get the error's code
+	GetErrorCode() ErrorCode
+}
+
+// An error with an error code. Handlers can return these to respond with a specific code, and the API client returns
+// them for failed requests so callers can check the code with IsErrorCode.
+type ApiError struct {
+	// The error's code
+	Code ErrorCode
+
+	// The error message
+	Message string
+
+	// The underlying error, if there is one
+	Err error
+}
+
+// Creates a new API error with the provided code, using the underlying error's message
+func NewApiError(code ErrorCode, err error) *ApiError {
+	apiErr := &ApiError{
+		Code: code,
+		Err:  err,
+	}
+	if err != nil {
+		apiErr.Message = err.Error()
+	}
+	return apiErr
+}
+
+func (e *ApiError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return string(e.Code)
+}
+
+func (e *ApiError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ApiError) GetErrorCode() ErrorCode {
+	return e.Code
+}
+
+// Get the code of the first error in the chain that has one, or an empty code if none of them do
+func GetErrorCode(err error) ErrorCode {
+	var codedErr ICodedError
+	if errors.As(err, &codedErr) {
+		return codedErr.GetErrorCode()
+	}
+	return ""
+}
+
+// Check if an error, or any error it wraps, has the provided code
+func IsErrorCode(err error, code ErrorCode) bool {
+	return GetErrorCode(err) == code
+}
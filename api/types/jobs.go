@@ -0,0 +1,74 @@
+package types
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// The state of a background job
+type JobState string
+
+const (
+	// The job is waiting for a worker
+	JobState_Queued JobState = "queued"
+
+	// The job is running
+	JobState_Running JobState = "running"
+
+	// The job finished successfully
+	JobState_Succeeded JobState = "succeeded"
+
+	// The job finished with an error
+	JobState_Failed JobState = "failed"
+
+	// The job was cancelled before it finished
+	JobState_Cancelled JobState = "cancelled"
+
+	// The job was running when the daemon stopped, and couldn't be resumed
+	JobState_Interrupted JobState = "interrupted"
+)
+
+// Check if a job in this state is done and won't change anymore
+func (s JobState) IsFinished() bool {
+	switch s {
+	case JobState_Succeeded, JobState_Failed, JobState_Cancelled, JobState_Interrupted:
+		return true
+	default:
+		return false
+	}
+}
+
+// The status of a background job
+type JobInfo struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+
+	// The state of the job, its progress from 0 to 1, and a description of what it's currently doing
+	State    JobState `json:"state"`
+	Progress float64  `json:"progress"`
+	Message  string   `json:"message,omitempty"`
+
+	// The job's result if it succeeded, or the error if it failed
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+
+	// Incremented on every change to the job, so clients can wait for updates
+	Version uint64 `json:"version"`
+
+	CreatedTime  time.Time  `json:"createdTime"`
+	StartedTime  *time.Time `json:"startedTime,omitempty"`
+	FinishedTime *time.Time `json:"finishedTime,omitempty"`
+}
+
+type JobData struct {
+	Job JobInfo `json:"job"`
+}
+
+type JobListData struct {
+	Jobs []JobInfo `json:"jobs"`
+}
+
+type JobCancelBody struct {
+	ID string `json:"id"`
+}
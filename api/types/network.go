@@ -0,0 +1,82 @@
+package types
+
+import (
+	"time"
+)
+
+// The transport protocol a port is used with
+type PortProtocol string
+
+const (
+	// The port accepts TCP connections
+	PortProtocol_Tcp PortProtocol = "tcp"
+
+	// The port receives UDP packets
+	PortProtocol_Udp PortProtocol = "udp"
+)
+
+// Whether a port can be reached from outside the node's network
+type PortReachability string
+
+const (
+	// A connection to the port through the external IP address succeeded
+	PortReachability_Reachable PortReachability = "reachable"
+
+	// A connection to the port through the external IP address failed
+	PortReachability_Unreachable PortReachability = "unreachable"
+
+	// The port couldn't be tested, either because the external IP address isn't known or because the protocol can't
+	// be probed without a response from the other side (as with UDP)
+	PortReachability_Unknown PortReachability = "unknown"
+)
+
+// The result of testing a single port
+type PortStatus struct {
+	// A short name for the port, such as which client uses it
+	Name string `json:"name"`
+
+	// The port number
+	Port uint16 `json:"port"`
+
+	// The protocol the port is used with
+	Protocol PortProtocol `json:"protocol"`
+
+	// True if something on this machine is listening on the port; this is only known for TCP ports
+	IsListening bool `json:"isListening"`
+
+	// Whether the port can be reached through the external IP address
+	Reachability PortReachability `json:"reachability"`
+
+	// The error from the test, if it failed
+	Error string `json:"error"`
+}
+
+// The address one external IP provider reported for the node
+type ExternalIpProviderResult struct {
+	// The provider that was queried
+	Provider string `json:"provider"`
+
+	// The address it reported, or an empty string if the query failed
+	Ip string `json:"ip"`
+
+	// The error from the query, if it failed
+	Error string `json:"error"`
+}
+
+// The results of the latest external IP and port reachability check
+type NetworkReachabilityStatus struct {
+	// The time of the check
+	CheckTime time.Time `json:"checkTime"`
+
+	// The external IP address enough of the providers agreed on, or an empty string if they didn't
+	ExternalIp string `json:"externalIp"`
+
+	// The error from determining the external IP address, if it failed
+	ExternalIpError string `json:"externalIpError"`
+
+	// What each provider reported
+	Providers []ExternalIpProviderResult `json:"providers"`
+
+	// The results of testing each port
+	Ports []PortStatus `json:"ports"`
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// A transaction waiting for the operator to approve it before the wallet signs it
+type SigningRequest struct {
+	// The request's unique ID
+	ID string `json:"id"`
+
+	// The time the request was made
+	CreatedAt time.Time `json:"createdAt"`
+
+	// The time the request will be rejected automatically if it hasn't been approved
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// The account that will sign the transaction
+	From common.Address `json:"from"`
+
+	// The recipient of the transaction, or nil if it deploys a contract
+	To *common.Address `json:"to"`
+
+	// The ETH value, in wei, sent with the transaction
+	Value *big.Int `json:"value"`
+
+	// The transaction's nonce
+	Nonce uint64 `json:"nonce"`
+
+	// The transaction's gas limit
+	GasLimit uint64 `json:"gasLimit"`
+
+	// The max fee and priority fee, in wei
+	GasFeeCap *big.Int `json:"gasFeeCap"`
+	GasTipCap *big.Int `json:"gasTipCap"`
+
+	// The transaction's calldata
+	Data hexutil.Bytes `json:"data"`
+
+	// A human-readable description of the call, decoded from the calldata if the contract is known
+	Description string `json:"description"`
+}
+
+// The signing requests waiting for approval
+type SigningRequestsData struct {
+	Requests []SigningRequest `json:"requests"`
+}
+
+// A request to approve a signing request
+type SigningApproveBody struct {
+	ID string `json:"id"`
+}
+
+// A request to reject a signing request
+type SigningRejectBody struct {
+	ID string `json:"id"`
+
+	// Why the request was rejected
+	Reason string `json:"reason,omitempty"`
+}
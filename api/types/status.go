@@ -1,5 +1,11 @@
 package types
 
+import (
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
 // This is a wrapper for the EC / BN status report
 type ClientStatus struct {
 	IsWorking    bool    `json:"isWorking"`
@@ -7,6 +13,12 @@ type ClientStatus struct {
 	SyncProgress float64 `json:"syncProgress"`
 	ChainId      uint    `json:"networkId"`
 	Error        string  `json:"error"`
+
+	// Latency and availability statistics for the client's endpoint, if the client tracks them
+	Stats *utils.EndpointStats `json:"stats,omitempty"`
+
+	// The client's score from the manager's latest health check, if health checks are running
+	HealthScore *float64 `json:"healthScore,omitempty"`
 }
 
 // This is a wrapper for the manager's overall status report
@@ -14,4 +26,70 @@ type ClientManagerStatus struct {
 	PrimaryClientStatus  ClientStatus `json:"primaryEcStatus"`
 	FallbackEnabled      bool         `json:"fallbackEnabled"`
 	FallbackClientStatus ClientStatus `json:"fallbackEcStatus"`
+
+	// The statuses of all of the fallback clients, in the order they're used; the first one is also in
+	// FallbackClientStatus
+	FallbackClientStatuses []ClientStatus `json:"fallbackStatuses,omitempty"`
+
+	// The client the manager is pinned to, if it's pinned
+	Pin *ClientPin `json:"pin,omitempty"`
+}
+
+// The client a manager is pinned to
+type ClientPinTarget string
+
+const (
+	// The manager isn't pinned, so it uses the primary and falls back as normal
+	ClientPinTarget_None ClientPinTarget = ""
+
+	// The manager only uses the primary client
+	ClientPinTarget_Primary ClientPinTarget = "primary"
+
+	// The manager only uses its fallback clients
+	ClientPinTarget_Fallback ClientPinTarget = "fallback"
+)
+
+// The type of client manager a request applies to
+type ClientManagerKind string
+
+const (
+	// Both the Execution Client and Beacon Node managers
+	ClientManagerKind_All ClientManagerKind = "all"
+
+	// The Execution Client manager
+	ClientManagerKind_Execution ClientManagerKind = "execution"
+
+	// The Beacon Node manager
+	ClientManagerKind_Beacon ClientManagerKind = "beacon"
+)
+
+// A manager's pin to its primary client or its fallbacks. While it's pinned, the clients it's pinned away from aren't
+// used or checked.
+type ClientPin struct {
+	Target     ClientPinTarget `json:"target"`
+	Expiration time.Time       `json:"expiration"`
+}
+
+// The pins of the Execution Client and Beacon Node managers
+type ClientPinData struct {
+	ExecutionClient ClientPin `json:"executionClient"`
+	BeaconNode      ClientPin `json:"beaconNode"`
+}
+
+// A request to pin client managers to one of their clients
+type ClientPinBody struct {
+	// The managers to pin; both are pinned if this is empty
+	Client ClientManagerKind `json:"client,omitempty"`
+
+	// The client to pin the managers to
+	Target ClientPinTarget `json:"target"`
+
+	// How long to pin the managers for, such as "2h"
+	Duration string `json:"duration"`
+}
+
+// A request to remove the pin from client managers
+type ClientUnpinBody struct {
+	// The managers to unpin; both are unpinned if this is empty
+	Client ClientManagerKind `json:"client,omitempty"`
 }
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"time"
+)
+
+// A release of a daemon
+type ReleaseInfo struct {
+	// The release's semantic version, with a v prefix
+	Version string `json:"version"`
+
+	// A link to the release's page or download
+	Url string `json:"url"`
+
+	// The release notes
+	Notes string `json:"notes"`
+
+	// The time the release was published, if the source provides it
+	PublishedAt time.Time `json:"publishedAt"`
+
+	// True if this is a pre-release
+	IsPrerelease bool `json:"isPrerelease"`
+
+	// True if the release fixes a critical issue and operators should update as soon as possible
+	IsCritical bool `json:"isCritical"`
+}
+
+// The results of the latest update check
+type UpdateStatus struct {
+	// The time of the check
+	CheckTime time.Time `json:"checkTime"`
+
+	// The version of the running daemon
+	CurrentVersion string `json:"currentVersion"`
+
+	// The latest release the source reported, or nil if the check failed
+	LatestRelease *ReleaseInfo `json:"latestRelease,omitempty"`
+
+	// True if the latest release is newer than the running version
+	IsUpdateAvailable bool `json:"isUpdateAvailable"`
+
+	// The error from the check, if it failed
+	Error string `json:"error"`
+}
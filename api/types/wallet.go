@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// The accounts derived from the local wallet's seed
+type WalletAccountsData struct {
+	// The index of the node account
+	ActiveIndex uint `json:"activeIndex"`
+
+	// The derived accounts in the requested range
+	Accounts []wallet.DerivedAccount `json:"accounts"`
+}
+
+// A request to switch the node account to the account at an index
+type WalletAccountSelectBody struct {
+	// The index of the account to use; this is required since 0 is a valid index
+	Index *uint64 `json:"index"`
+}
@@ -18,6 +18,7 @@ type IBeaconClient interface {
 	GetValidatorStatusByIndex(ctx context.Context, index string, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatus(ctx context.Context, pubkey ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatuses(ctx context.Context, pubkeys []ValidatorPubkey, opts *ValidatorStatusOptions) (map[ValidatorPubkey]ValidatorStatus, error)
+	GetValidatorStatusesByIndex(ctx context.Context, indices []string, opts *ValidatorStatusOptions) (map[string]ValidatorStatus, error)
 	GetValidatorIndex(ctx context.Context, pubkey ValidatorPubkey) (string, error)
 	GetValidatorSyncDuties(ctx context.Context, indices []string, epoch uint64) (map[string]bool, error)
 	GetValidatorProposerDuties(ctx context.Context, indices []string, epoch uint64) (map[string]uint64, error)
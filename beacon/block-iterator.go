@@ -0,0 +1,204 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rocket-pool/node-manager-core/store"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// The default number of blocks to request from the Beacon Node at the same time
+	DefaultBlockIteratorConcurrency int = 8
+
+	// The store bucket that holds block iterator checkpoints, keyed by the iterator's checkpoint key
+	blockIteratorBucket string = "block-iterator-checkpoints"
+)
+
+// Settings for walking a range of Beacon blocks
+type BlockIteratorSettings struct {
+	// The number of blocks to request from the Beacon Node at the same time. Blocks are still passed to the handler
+	// one at a time, in order.
+	Concurrency int
+
+	// The policy for retrying block requests that fail
+	RetryPolicy utils.RetryPolicy
+}
+
+// The block iterator settings to use if none are configured
+var DefaultBlockIteratorSettings = BlockIteratorSettings{
+	Concurrency: DefaultBlockIteratorConcurrency,
+	RetryPolicy: utils.DefaultRetryPolicy,
+}
+
+// The block for a slot, as provided to a block iterator's handler
+type SlotBlock struct {
+	// The slot being visited
+	Slot uint64
+
+	// True if the slot doesn't have a block because its proposer missed it
+	Missed bool
+
+	// The slot's block; only set if the slot wasn't missed
+	Block BeaconBlock
+}
+
+// Walks a range of slots on the Beacon chain in either direction, handing each slot's block to a handler in order.
+// Blocks are requested concurrently ahead of the handler, and missed slots are reported instead of being treated as
+// errors. Progress can be saved in a key-value store so an interrupted walk picks up where it left off.
+type BlockIterator struct {
+	client        IBeaconClient
+	settings      BlockIteratorSettings
+	kvStore       store.IKeyValueStore
+	checkpointKey string
+}
+
+// Creates a new block iterator
+func NewBlockIterator(client IBeaconClient, settings BlockIteratorSettings) (*BlockIterator, error) {
+	if settings.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+	return &BlockIterator{
+		client:   client,
+		settings: settings,
+	}, nil
+}
+
+// Save the iterator's progress in the provided store under the provided key, so walks started with
+// IterateFromCheckpoint resume after the last slot that was fully processed
+func (i *BlockIterator) SetCheckpointStore(kvStore store.IKeyValueStore, key string) {
+	i.kvStore = kvStore
+	i.checkpointKey = key
+}
+
+// Get the last slot that was fully processed, or false if the iterator doesn't have a checkpoint yet
+func (i *BlockIterator) GetCheckpoint() (uint64, bool, error) {
+	if i.kvStore == nil {
+		return 0, false, fmt.Errorf("block iterator doesn't have a checkpoint store")
+	}
+	value, err := i.kvStore.Get(blockIteratorBucket, []byte(i.checkpointKey))
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting checkpoint [%s]: %w", i.checkpointKey, err)
+	}
+	if value == nil {
+		return 0, false, nil
+	}
+	slot, err := store.ParseUint64Key(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("error parsing checkpoint [%s]: %w", i.checkpointKey, err)
+	}
+	return slot, true, nil
+}
+
+// Walk the slots from the slot after the checkpoint (or the first slot, if there's no checkpoint yet or it's outside
+// of the range) through the last slot, saving a new checkpoint as slots are handled. See Iterate for details.
+func (i *BlockIterator) IterateFromCheckpoint(ctx context.Context, firstSlot uint64, lastSlot uint64, handler func(block SlotBlock) error) error {
+	checkpoint, exists, err := i.GetCheckpoint()
+	if err != nil {
+		return err
+	}
+	if exists {
+		if firstSlot <= lastSlot && checkpoint >= firstSlot && checkpoint <= lastSlot {
+			if checkpoint == lastSlot {
+				return nil
+			}
+			firstSlot = checkpoint + 1
+		} else if firstSlot > lastSlot && checkpoint <= firstSlot && checkpoint >= lastSlot {
+			if checkpoint == lastSlot {
+				return nil
+			}
+			firstSlot = checkpoint - 1
+		}
+	}
+	return i.Iterate(ctx, firstSlot, lastSlot, handler)
+}
+
+// Walk the slots from the first slot through the last slot (inclusive), handing each one to the handler in order. If
+// the first slot is after the last slot, the walk goes backwards. Missed slots are passed to the handler with Missed
+// set. If the handler returns an error, the walk stops. If the iterator has a checkpoint store, the last slot the
+// handler finished with is saved as the checkpoint after each batch of concurrent requests.
+func (i *BlockIterator) Iterate(ctx context.Context, firstSlot uint64, lastSlot uint64, handler func(block SlotBlock) error) error {
+	forward := firstSlot <= lastSlot
+	var remaining uint64
+	if forward {
+		remaining = lastSlot - firstSlot + 1
+	} else {
+		remaining = firstSlot - lastSlot + 1
+	}
+
+	slot := firstSlot
+	for remaining > 0 {
+		// Get the next batch of slots
+		count := min(remaining, uint64(i.settings.Concurrency))
+		slots := make([]uint64, count)
+		for j := range slots {
+			slots[j] = slot
+			if forward {
+				slot++
+			} else {
+				slot--
+			}
+		}
+		remaining -= count
+
+		blocks, err := i.getBlocks(ctx, slots)
+		if err != nil {
+			return err
+		}
+		for _, block := range blocks {
+			err = handler(block)
+			if err != nil {
+				return fmt.Errorf("error handling slot %d: %w", block.Slot, err)
+			}
+		}
+		err = i.saveCheckpoint(slots[len(slots)-1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the blocks for the provided slots concurrently, in the same order as the slots
+func (i *BlockIterator) getBlocks(ctx context.Context, slots []uint64) ([]SlotBlock, error) {
+	blocks := make([]SlotBlock, len(slots))
+	var wg errgroup.Group
+	for j, slot := range slots {
+		j := j
+		slot := slot
+		wg.Go(func() error {
+			block, err := utils.RetryWithResult(ctx, i.settings.RetryPolicy, func() (SlotBlock, error) {
+				block, exists, err := i.client.GetBeaconBlock(ctx, fmt.Sprint(slot))
+				return SlotBlock{
+					Slot:   slot,
+					Missed: !exists,
+					Block:  block,
+				}, err
+			})
+			if err != nil {
+				return fmt.Errorf("error getting block for slot %d: %w", slot, err)
+			}
+			blocks[j] = block
+			return nil
+		})
+	}
+	err := wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// Save the last slot that was fully processed, if the iterator has a checkpoint store
+func (i *BlockIterator) saveCheckpoint(slot uint64) error {
+	if i.kvStore == nil {
+		return nil
+	}
+	err := i.kvStore.Put(blockIteratorBucket, []byte(i.checkpointKey), store.Uint64Key(slot))
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint [%s]: %w", i.checkpointKey, err)
+	}
+	return nil
+}
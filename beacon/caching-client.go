@@ -0,0 +1,227 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The duties and committees cached for a single epoch
+type epochCache struct {
+	// The epoch the chain was on when the entries were fetched
+	fetchEpoch uint64
+
+	// Whether each validator has a sync committee duty, by index
+	syncDuties map[string]bool
+
+	// The number of block proposals for each validator, by index
+	proposerDuties map[string]uint64
+
+	// The attestation committees, if they've been fetched
+	committees *cachedCommittees
+}
+
+// A Beacon client that caches validator duties and attestation committees, so several task loops asking for the same
+// epoch's data only cost the Beacon Node one request. Entries are only kept for the epoch they were fetched in; once
+// the chain moves to the next epoch (according to the chain's slot clock), they're dropped and fetched again when
+// needed, so duties that shift with reorgs at the epoch boundary aren't served stale. All other calls are passed
+// through to the underlying client.
+type CachingBeaconClient struct {
+	IBeaconClient
+
+	config Eth2Config
+	epochs map[uint64]*epochCache
+	now    func() time.Time
+	lock   sync.Mutex
+}
+
+// Creates a new caching Beacon client around the provided one. The config is used to determine the current epoch.
+func NewCachingBeaconClient(bc IBeaconClient, config Eth2Config) (*CachingBeaconClient, error) {
+	if config.SecondsPerSlot == 0 || config.SlotsPerEpoch == 0 {
+		return nil, fmt.Errorf("Beacon config has an invalid epoch length")
+	}
+	return &CachingBeaconClient{
+		IBeaconClient: bc,
+		config:        config,
+		epochs:        map[uint64]*epochCache{},
+		now:           time.Now,
+	}, nil
+}
+
+// Get the sync committee duties of the provided validators for an epoch, only requesting the ones that aren't cached
+func (c *CachingBeaconClient) GetValidatorSyncDuties(ctx context.Context, indices []string, epoch uint64) (map[string]bool, error) {
+	c.lock.Lock()
+	cache := c.getEpochCache(epoch)
+	duties := make(map[string]bool, len(indices))
+	missing := []string{}
+	for _, index := range indices {
+		duty, exists := cache.syncDuties[index]
+		if exists {
+			duties[index] = duty
+		} else {
+			missing = append(missing, index)
+		}
+	}
+	c.lock.Unlock()
+	if len(missing) == 0 {
+		return duties, nil
+	}
+
+	fetched, err := c.IBeaconClient.GetValidatorSyncDuties(ctx, missing, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cache = c.getEpochCache(epoch)
+	for _, index := range missing {
+		duties[index] = fetched[index]
+		cache.syncDuties[index] = fetched[index]
+	}
+	return duties, nil
+}
+
+// Get the number of blocks each of the provided validators is scheduled to propose in an epoch, only requesting the
+// ones that aren't cached
+func (c *CachingBeaconClient) GetValidatorProposerDuties(ctx context.Context, indices []string, epoch uint64) (map[string]uint64, error) {
+	c.lock.Lock()
+	cache := c.getEpochCache(epoch)
+	duties := make(map[string]uint64, len(indices))
+	missing := []string{}
+	for _, index := range indices {
+		duty, exists := cache.proposerDuties[index]
+		if exists {
+			duties[index] = duty
+		} else {
+			missing = append(missing, index)
+		}
+	}
+	c.lock.Unlock()
+	if len(missing) == 0 {
+		return duties, nil
+	}
+
+	fetched, err := c.IBeaconClient.GetValidatorProposerDuties(ctx, missing, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cache = c.getEpochCache(epoch)
+	for _, index := range missing {
+		duties[index] = fetched[index]
+		cache.proposerDuties[index] = fetched[index]
+	}
+	return duties, nil
+}
+
+// Get the attestation committees for the provided epoch, or the current epoch if nil. The returned committees are
+// shared with other callers; calling Release on them does nothing.
+func (c *CachingBeaconClient) GetCommitteesForEpoch(ctx context.Context, epoch *uint64) (Committees, error) {
+	targetEpoch := c.config.EpochAt(c.now())
+	if epoch != nil {
+		targetEpoch = *epoch
+	}
+
+	c.lock.Lock()
+	committees := c.getEpochCache(targetEpoch).committees
+	c.lock.Unlock()
+	if committees != nil {
+		return committees, nil
+	}
+
+	response, err := c.IBeaconClient.GetCommitteesForEpoch(ctx, &targetEpoch)
+	if err != nil {
+		return nil, err
+	}
+	committees = copyCommittees(response)
+	response.Release()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.getEpochCache(targetEpoch).committees = committees
+	return committees, nil
+}
+
+// Drop all of the cached entries
+func (c *CachingBeaconClient) ClearCache() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.epochs = map[uint64]*epochCache{}
+}
+
+// Get the cache for an epoch, dropping every epoch's entries that were fetched before the current epoch started. The
+// lock must be held.
+func (c *CachingBeaconClient) getEpochCache(epoch uint64) *epochCache {
+	currentEpoch := c.config.EpochAt(c.now())
+	for cachedEpoch, cache := range c.epochs {
+		if cache.fetchEpoch != currentEpoch {
+			delete(c.epochs, cachedEpoch)
+		}
+	}
+
+	cache, exists := c.epochs[epoch]
+	if !exists {
+		cache = &epochCache{
+			fetchEpoch:     currentEpoch,
+			syncDuties:     map[string]bool{},
+			proposerDuties: map[string]uint64{},
+		}
+		c.epochs[epoch] = cache
+	}
+	return cache
+}
+
+// ========================
+// === Committees Cache ===
+// ========================
+
+// A copy of a set of committees that doesn't depend on the client's pooled buffers
+type cachedCommittees struct {
+	indices    []uint64
+	slots      []uint64
+	validators [][]string
+}
+
+// Copy a set of committees so they can outlive the original's buffers
+func copyCommittees(committees Committees) *cachedCommittees {
+	count := committees.Count()
+	copied := &cachedCommittees{
+		indices:    make([]uint64, count),
+		slots:      make([]uint64, count),
+		validators: make([][]string, count),
+	}
+	for i := 0; i < count; i++ {
+		copied.indices[i] = committees.Index(i)
+		copied.slots[i] = committees.Slot(i)
+		copied.validators[i] = append([]string{}, committees.Validators(i)...)
+	}
+	return copied
+}
+
+// Get the index of the committee at the provided offset
+func (c *cachedCommittees) Index(idx int) uint64 {
+	return c.indices[idx]
+}
+
+// Get the slot of the committee at the provided offset
+func (c *cachedCommittees) Slot(idx int) uint64 {
+	return c.slots[idx]
+}
+
+// Get the validators of the committee at the provided offset
+func (c *cachedCommittees) Validators(idx int) []string {
+	return c.validators[idx]
+}
+
+// Get the number of committees
+func (c *cachedCommittees) Count() int {
+	return len(c.indices)
+}
+
+// Does nothing, since the committees are shared between callers
+func (c *cachedCommittees) Release() {
+}
@@ -0,0 +1,37 @@
+package beacon
+
+import (
+	"time"
+)
+
+// Get the time the chain started
+func (c Eth2Config) GenesisTimestamp() time.Time {
+	return time.Unix(int64(c.GenesisTime), 0)
+}
+
+// Get the slot the chain is on at the provided time. Times before genesis are in slot 0.
+func (c Eth2Config) SlotAt(t time.Time) uint64 {
+	genesis := c.GenesisTimestamp()
+	if c.SecondsPerSlot == 0 || t.Before(genesis) {
+		return 0
+	}
+	return uint64(t.Sub(genesis) / (time.Duration(c.SecondsPerSlot) * time.Second))
+}
+
+// Get the epoch the chain is on at the provided time. Times before genesis are in epoch 0.
+func (c Eth2Config) EpochAt(t time.Time) uint64 {
+	if c.SlotsPerEpoch == 0 {
+		return 0
+	}
+	return c.SlotAt(t) / c.SlotsPerEpoch
+}
+
+// Get the time the provided slot starts
+func (c Eth2Config) SlotStartTime(slot uint64) time.Time {
+	return c.GenesisTimestamp().Add(time.Duration(slot*c.SecondsPerSlot) * time.Second)
+}
+
+// Get the time the provided epoch starts
+func (c Eth2Config) EpochStartTime(epoch uint64) time.Time {
+	return c.SlotStartTime(epoch * c.SlotsPerEpoch)
+}
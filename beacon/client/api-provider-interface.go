@@ -3,18 +3,24 @@ package client
 import "context"
 
 type IBeaconApiProvider interface {
+	Beacon_AttestationRewards_Post(ctx context.Context, epoch uint64, indices []string) (AttestationRewardsResponse, error)
 	Beacon_Attestations(ctx context.Context, blockId string) (AttestationsResponse, bool, error)
+	Beacon_BlobSidecars(ctx context.Context, blockId string) (BlobSidecarsResponse, bool, error)
 	Beacon_Block(ctx context.Context, blockId string) (BeaconBlockResponse, bool, error)
+	Beacon_BlockRewards(ctx context.Context, blockId string) (BlockRewardsResponse, bool, error)
 	Beacon_BlsToExecutionChanges_Post(ctx context.Context, request BLSToExecutionChangeRequest) error
 	Beacon_Committees(ctx context.Context, stateId string, epoch *uint64) (CommitteesResponse, error)
 	Beacon_FinalityCheckpoints(ctx context.Context, stateId string) (FinalityCheckpointsResponse, error)
 	Beacon_Genesis(ctx context.Context) (GenesisResponse, error)
 	Beacon_Header(ctx context.Context, blockId string) (BeaconBlockHeaderResponse, bool, error)
+	Beacon_SyncCommitteeRewards_Post(ctx context.Context, blockId string, indices []string) (SyncCommitteeRewardsResponse, error)
+	Beacon_ValidatorBalances(ctx context.Context, stateId string, ids []string) (ValidatorBalancesResponse, error)
 	Beacon_Validators(ctx context.Context, stateId string, ids []string) (ValidatorsResponse, error)
 	Beacon_VoluntaryExits_Post(ctx context.Context, request VoluntaryExitRequest) error
 	Config_DepositContract(ctx context.Context) (Eth2DepositContractResponse, error)
 	Config_Spec(ctx context.Context) (Eth2ConfigResponse, error)
 	Node_Syncing(ctx context.Context) (SyncStatusResponse, error)
+	Validator_DutiesAttester_Post(ctx context.Context, indices []string, epoch uint64) (AttesterDutiesResponse, error)
 	Validator_DutiesProposer(ctx context.Context, indices []string, epoch uint64) (ProposerDutiesResponse, error)
 	Validator_DutiesSync_Post(ctx context.Context, indices []string, epoch uint64) (SyncDutiesResponse, error)
 }
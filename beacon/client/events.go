@@ -0,0 +1,320 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	RequestEventsPath        = "/eth/v1/events"
+	RequestEventsContentType = "text/event-stream"
+
+	// The number of events that can be buffered on each channel before the stream blocks
+	eventChannelBufferSize int = 16
+
+	// The largest line the stream will accept; block events for large blocks can be long
+	maxEventLineSize int = 1024 * 1024
+)
+
+// A topic that can be subscribed to on the Beacon Node's event stream
+type EventTopic string
+
+const (
+	EventTopic_Head                EventTopic = "head"
+	EventTopic_FinalizedCheckpoint EventTopic = "finalized_checkpoint"
+	EventTopic_ChainReorg          EventTopic = "chain_reorg"
+	EventTopic_Block               EventTopic = "block"
+)
+
+// The policy used to reconnect to the event stream after it drops. There's no time limit, so the stream keeps trying
+// until its context is cancelled.
+var EventStreamRetryPolicy = utils.RetryPolicy{
+	InitialInterval: time.Second,
+	MaxInterval:     30 * time.Second,
+	Multiplier:      2,
+	JitterFactor:    0.2,
+}
+
+// ===================
+// === Event Types ===
+// ===================
+
+// Sent when the Beacon Node's head has changed
+type HeadEvent struct {
+	Slot                      utils.Uinteger `json:"slot"`
+	Block                     utils.Bytes32  `json:"block"`
+	State                     utils.Bytes32  `json:"state"`
+	EpochTransition           bool           `json:"epoch_transition"`
+	PreviousDutyDependentRoot utils.Bytes32  `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  utils.Bytes32  `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool           `json:"execution_optimistic"`
+}
+
+// Sent when a new checkpoint has been finalized
+type FinalizedCheckpointEvent struct {
+	Block               utils.Bytes32  `json:"block"`
+	State               utils.Bytes32  `json:"state"`
+	Epoch               utils.Uinteger `json:"epoch"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
+
+// Sent when the chain has been reorganized
+type ChainReorgEvent struct {
+	Slot                utils.Uinteger `json:"slot"`
+	Depth               utils.Uinteger `json:"depth"`
+	OldHeadBlock        utils.Bytes32  `json:"old_head_block"`
+	NewHeadBlock        utils.Bytes32  `json:"new_head_block"`
+	OldHeadState        utils.Bytes32  `json:"old_head_state"`
+	NewHeadState        utils.Bytes32  `json:"new_head_state"`
+	Epoch               utils.Uinteger `json:"epoch"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
+
+// Sent when a block has been imported, whether or not it became the head
+type BlockEvent struct {
+	Slot                utils.Uinteger `json:"slot"`
+	Block               utils.Bytes32  `json:"block"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
+
+// =========================
+// === BeaconEventStream ===
+// =========================
+
+// A subscription to the Beacon Node's event stream. Each subscribed topic's events are delivered on its own channel;
+// channels for topics that weren't subscribed to never receive anything. The stream reconnects automatically if the
+// connection drops, and all of the channels are closed once its context is cancelled.
+type BeaconEventStream struct {
+	provider *BeaconHttpProvider
+	logger   *slog.Logger
+	topics   []EventTopic
+
+	head                chan HeadEvent
+	finalizedCheckpoint chan FinalizedCheckpointEvent
+	chainReorg          chan ChainReorgEvent
+	block               chan BlockEvent
+	done                chan struct{}
+}
+
+// Subscribe to the provided topics on the Beacon Node's event stream. The stream runs until the context is cancelled.
+func (p *BeaconHttpProvider) SubscribeEvents(ctx context.Context, logger *slog.Logger, topics ...EventTopic) (*BeaconEventStream, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("at least one topic is required")
+	}
+	for _, topic := range topics {
+		switch topic {
+		case EventTopic_Head, EventTopic_FinalizedCheckpoint, EventTopic_ChainReorg, EventTopic_Block:
+		default:
+			return nil, fmt.Errorf("unsupported event topic [%s]", topic)
+		}
+	}
+
+	stream := &BeaconEventStream{
+		provider:            p,
+		logger:              logger,
+		topics:              topics,
+		head:                make(chan HeadEvent, eventChannelBufferSize),
+		finalizedCheckpoint: make(chan FinalizedCheckpointEvent, eventChannelBufferSize),
+		chainReorg:          make(chan ChainReorgEvent, eventChannelBufferSize),
+		block:               make(chan BlockEvent, eventChannelBufferSize),
+		done:                make(chan struct{}),
+	}
+	go stream.run(ctx)
+	return stream, nil
+}
+
+// Get the channel for head events
+func (s *BeaconEventStream) Head() <-chan HeadEvent {
+	return s.head
+}
+
+// Get the channel for finalized checkpoint events
+func (s *BeaconEventStream) FinalizedCheckpoint() <-chan FinalizedCheckpointEvent {
+	return s.finalizedCheckpoint
+}
+
+// Get the channel for chain reorg events
+func (s *BeaconEventStream) ChainReorg() <-chan ChainReorgEvent {
+	return s.chainReorg
+}
+
+// Get the channel for block events
+func (s *BeaconEventStream) Block() <-chan BlockEvent {
+	return s.block
+}
+
+// Get a channel that's closed once the stream has stopped and all of the event channels are closed
+func (s *BeaconEventStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Keep the stream connected until the context is cancelled
+func (s *BeaconEventStream) run(ctx context.Context) {
+	defer func() {
+		close(s.head)
+		close(s.finalizedCheckpoint)
+		close(s.chainReorg)
+		close(s.block)
+		close(s.done)
+	}()
+
+	for {
+		// Connect, retrying until it works or the context is cancelled
+		body, err := utils.RetryWithResult(ctx, EventStreamRetryPolicy, func() (io.ReadCloser, error) {
+			body, err := s.connect(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.logger.Warn("Error connecting to the Beacon Node's event stream", log.Err(err))
+			}
+			return body, err
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("Stopped connecting to the Beacon Node's event stream", log.Err(err))
+			}
+			return
+		}
+		s.logger.Debug("Connected to the Beacon Node's event stream")
+
+		// Read events until the connection drops
+		err = s.readEvents(ctx, body)
+		_ = body.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			s.logger.Warn("Beacon Node event stream disconnected", log.Err(err))
+		} else {
+			s.logger.Warn("Beacon Node event stream closed by the client")
+		}
+		if utils.SleepWithCancel(ctx, EventStreamRetryPolicy.InitialInterval) {
+			return
+		}
+	}
+}
+
+// Open a connection to the event stream
+func (s *BeaconEventStream) connect(ctx context.Context) (io.ReadCloser, error) {
+	topics := make([]string, len(s.topics))
+	for i, topic := range s.topics {
+		topics[i] = string(topic)
+	}
+	path := utils.JoinUrlPath(s.provider.providerAddress, RequestEventsPath) + "?topics=" + strings.Join(topics, ",")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, utils.Permanent(fmt.Errorf("error creating event stream request to [%s]: %w", RequestEventsPath, err))
+	}
+	req.Header.Set("Accept", RequestEventsContentType)
+	req.Header.Set("Cache-Control", "no-cache")
+
+	// The stream stays open indefinitely, so the usual request timeout can't apply
+	client := s.provider.getClientWithoutTimeout()
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error running event stream request to [%s]: %w", RequestEventsPath, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		_ = response.Body.Close()
+		err = fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+		if response.StatusCode == http.StatusBadRequest {
+			// The client doesn't support one of the topics, so reconnecting won't help
+			return nil, utils.Permanent(err)
+		}
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// Parse the events from an open stream and send them to their channels. Returns when the stream ends or the context
+// is cancelled.
+func (s *BeaconEventStream) readEvents(ctx context.Context, body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLineSize)
+
+	var eventName string
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the event that's been read so far
+		if line == "" {
+			if data.Len() > 0 {
+				s.dispatch(ctx, eventName, data.Bytes())
+			}
+			eventName = ""
+			data.Reset()
+			continue
+		}
+
+		// Lines starting with a colon are comments, which some clients send as keep-alives
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventName = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+
+	err := scanner.Err()
+	if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// Decode an event and send it to the channel for its topic, dropping it if the context is cancelled first
+func (s *BeaconEventStream) dispatch(ctx context.Context, eventName string, data []byte) {
+	var err error
+	switch EventTopic(eventName) {
+	case EventTopic_Head:
+		err = decodeAndSend(ctx, data, s.head)
+	case EventTopic_FinalizedCheckpoint:
+		err = decodeAndSend(ctx, data, s.finalizedCheckpoint)
+	case EventTopic_ChainReorg:
+		err = decodeAndSend(ctx, data, s.chainReorg)
+	case EventTopic_Block:
+		err = decodeAndSend(ctx, data, s.block)
+	default:
+		s.logger.Debug("Ignoring unknown event", slog.String(log.EventKey, eventName))
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Error decoding event", slog.String(log.EventKey, eventName), log.Err(err))
+	}
+}
+
+// Decode an event's data and send it to the provided channel
+func decodeAndSend[EventType any](ctx context.Context, data []byte, channel chan EventType) error {
+	var event EventType
+	err := json.Unmarshal(data, &event)
+	if err != nil {
+		return err
+	}
+	select {
+	case channel <- event:
+	case <-ctx.Done():
+	}
+	return nil
+}
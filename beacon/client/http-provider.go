@@ -13,6 +13,7 @@ import (
 
 	"github.com/goccy/go-json"
 	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
 )
 
 const (
@@ -27,10 +28,16 @@ const (
 	RequestFinalityCheckpointsPath         = "/eth/v1/beacon/states/%s/finality_checkpoints"
 	RequestForkPath                        = "/eth/v1/beacon/states/%s/fork"
 	RequestValidatorsPath                  = "/eth/v1/beacon/states/%s/validators"
+	RequestValidatorBalancesPath           = "/eth/v1/beacon/states/%s/validator_balances"
 	RequestVoluntaryExitPath               = "/eth/v1/beacon/pool/voluntary_exits"
 	RequestAttestationsPath                = "/eth/v1/beacon/blocks/%s/attestations"
 	RequestBeaconBlockPath                 = "/eth/v2/beacon/blocks/%s"
 	RequestBeaconBlockHeaderPath           = "/eth/v1/beacon/headers/%s"
+	RequestBlobSidecarsPath                = "/eth/v1/beacon/blob_sidecars/%s"
+	RequestBlockRewardsPath                = "/eth/v1/beacon/rewards/blocks/%s"
+	RequestAttestationRewardsPath          = "/eth/v1/beacon/rewards/attestations/%s"
+	RequestSyncCommitteeRewardsPath        = "/eth/v1/beacon/rewards/sync_committee/%s"
+	RequestValidatorAttesterDuties         = "/eth/v1/validator/duties/attester/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
@@ -41,150 +48,174 @@ const (
 type BeaconHttpProvider struct {
 	providerAddress string
 	client          http.Client
+	stats           *utils.EndpointStatsTracker
+	retryTransport  *utils.RetryTransport
+	limitTransport  *utils.ResponseLimitTransport
+	sszEnabled      bool
 }
 
 func NewBeaconHttpProvider(providerAddress string, timeout time.Duration) *BeaconHttpProvider {
+	return NewBeaconHttpProviderWithOptions(providerAddress, timeout, utils.ProxyOptions{})
+}
+
+// Creates a new provider for a Beacon Node that sits behind a reverse proxy.
+// Any path prefix in the provider address is preserved when building request URLs.
+func NewBeaconHttpProviderWithOptions(providerAddress string, timeout time.Duration, proxyOpts utils.ProxyOptions) *BeaconHttpProvider {
+	stats := utils.NewEndpointStatsTracker(utils.DefaultEndpointStatsWindowSize)
+	limitTransport := &utils.ResponseLimitTransport{
+		Settings: utils.DefaultResponseLimitSettings,
+	}
+	retryTransport := &utils.RetryTransport{
+		Base: &utils.StatsTrackingTransport{
+			Base: &utils.ProxyTransport{
+				Base:    limitTransport,
+				Options: proxyOpts,
+			},
+			Tracker: stats,
+		},
+		Settings: utils.DefaultHttpRetrySettings,
+	}
 	return &BeaconHttpProvider{
 		providerAddress: providerAddress,
 		client: http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: retryTransport,
 		},
+		stats:          stats,
+		retryTransport: retryTransport,
+		limitTransport: limitTransport,
 	}
 }
 
+// Set how the provider retries requests that fail with transient errors, such as a 502 from a load balancer in front
+// of the Beacon Node. This should be called before the provider is used.
+func (p *BeaconHttpProvider) SetRetrySettings(settings utils.HttpRetrySettings) {
+	p.retryTransport.Settings = settings
+}
+
+// Set the largest response the provider accepts from the Beacon Node, and whether it asks for compressed responses.
+// This should be called before the provider is used.
+func (p *BeaconHttpProvider) SetResponseLimits(settings utils.ResponseLimitSettings) {
+	p.limitTransport.Settings = settings
+}
+
+// Get the latency and availability statistics for the Beacon Node's endpoint
+func (p *BeaconHttpProvider) GetEndpointStats() utils.EndpointStats {
+	return p.stats.GetStats()
+}
+
 func (p *BeaconHttpProvider) Beacon_Attestations(ctx context.Context, blockId string) (AttestationsResponse, bool, error) {
-	responseBody, status, err := p.getRequest(ctx, fmt.Sprintf(RequestAttestationsPath, blockId))
+	attestations, exists, err := getJSON[AttestationsResponse](ctx, p, p.client, fmt.Sprintf(RequestAttestationsPath, blockId), true)
 	if err != nil {
 		return AttestationsResponse{}, false, fmt.Errorf("error getting attestations data for slot %s: %w", blockId, err)
 	}
-	if status == http.StatusNotFound {
-		return AttestationsResponse{}, false, nil
+	return attestations, exists, nil
+}
+
+func (p *BeaconHttpProvider) Beacon_AttestationRewards_Post(ctx context.Context, epoch uint64, indices []string) (AttestationRewardsResponse, error) {
+	if indices == nil {
+		// An empty list gets the rewards for every validator
+		indices = []string{}
 	}
-	if status != http.StatusOK {
-		return AttestationsResponse{}, false, fmt.Errorf("error getting attestations data for slot %s: HTTP status %d; response body: '%s'", blockId, status, string(responseBody))
+	rewards, err := postJSON[AttestationRewardsResponse](ctx, p, fmt.Sprintf(RequestAttestationRewardsPath, strconv.FormatUint(epoch, 10)), indices)
+	if err != nil {
+		return AttestationRewardsResponse{}, fmt.Errorf("error getting attestation rewards for epoch %d: %w", epoch, err)
 	}
-	var attestations AttestationsResponse
-	if err := json.Unmarshal(responseBody, &attestations); err != nil {
-		return AttestationsResponse{}, false, fmt.Errorf("error decoding attestations data for slot %s: %w", blockId, err)
+	return rewards, nil
+}
+
+func (p *BeaconHttpProvider) Beacon_BlobSidecars(ctx context.Context, blockId string) (BlobSidecarsResponse, bool, error) {
+	blobSidecars, exists, err := getJSON[BlobSidecarsResponse](ctx, p, p.client, fmt.Sprintf(RequestBlobSidecarsPath, blockId), true)
+	if err != nil {
+		return BlobSidecarsResponse{}, false, fmt.Errorf("error getting blob sidecars: %w", err)
 	}
-	return attestations, true, nil
+	return blobSidecars, exists, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_Block(ctx context.Context, blockId string) (BeaconBlockResponse, bool, error) {
-	responseBody, status, err := p.getRequest(ctx, fmt.Sprintf(RequestBeaconBlockPath, blockId))
+	beaconBlock, exists, err := p.getBlockNegotiated(ctx, fmt.Sprintf(RequestBeaconBlockPath, blockId))
 	if err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("error getting beacon block data: %w", err)
 	}
-	if status == http.StatusNotFound {
-		return BeaconBlockResponse{}, false, nil
-	}
-	if status != http.StatusOK {
-		return BeaconBlockResponse{}, false, fmt.Errorf("error getting beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var beaconBlock BeaconBlockResponse
-	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
-		return BeaconBlockResponse{}, false, fmt.Errorf("error decoding beacon block data: %w", err)
+	return beaconBlock, exists, nil
+}
+
+func (p *BeaconHttpProvider) Beacon_BlockRewards(ctx context.Context, blockId string) (BlockRewardsResponse, bool, error) {
+	rewards, exists, err := getJSON[BlockRewardsResponse](ctx, p, p.client, fmt.Sprintf(RequestBlockRewardsPath, blockId), true)
+	if err != nil {
+		return BlockRewardsResponse{}, false, fmt.Errorf("error getting block rewards for block %s: %w", blockId, err)
 	}
-	return beaconBlock, true, nil
+	return rewards, exists, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_BlsToExecutionChanges_Post(ctx context.Context, request BLSToExecutionChangeRequest) error {
 	requestArray := []BLSToExecutionChangeRequest{request} // This route must be wrapped in an array
-	responseBody, status, err := p.postRequest(ctx, RequestWithdrawalCredentialsChangePath, requestArray)
+	_, err := postJSON[struct{}](ctx, p, RequestWithdrawalCredentialsChangePath, requestArray)
 	if err != nil {
 		return fmt.Errorf("error broadcasting withdrawal credentials change for validator %s: %w", request.Message.ValidatorIndex, err)
 	}
-	if status != http.StatusOK {
-		return fmt.Errorf("error broadcasting withdrawal credentials change for validator %s: HTTP status %d; response body: '%s'", request.Message.ValidatorIndex, status, string(responseBody))
-	}
 	return nil
 }
 
 func (p *BeaconHttpProvider) Beacon_Committees(ctx context.Context, stateId string, epoch *uint64) (CommitteesResponse, error) {
-	var committees CommitteesResponse
-
 	query := ""
 	if epoch != nil {
 		query = fmt.Sprintf("?epoch=%d", *epoch)
 	}
 
-	// Committees responses are large, so let the json decoder read it in a buffered fashion
-	clientWithoutTimeout := http.Client{}
-	reader, status, err := getRequestReader(ctx, fmt.Sprintf(RequestCommitteePath, stateId)+query, p.providerAddress, clientWithoutTimeout)
+	committees, err := p.getCommitteesNegotiated(ctx, fmt.Sprintf(RequestCommitteePath, stateId)+query)
 	if err != nil {
 		return CommitteesResponse{}, fmt.Errorf("error getting committees: %w", err)
 	}
-	defer func() {
-		_ = reader.Close()
-	}()
-
-	if status != http.StatusOK {
-		body, _ := io.ReadAll(reader)
-		return CommitteesResponse{}, fmt.Errorf("error getting committees: HTTP status %d; response body: '%s'", status, string(body))
-	}
-
-	d := committeesDecoderPool.Get().(*committeesDecoder)
-	defer func() {
-		d.currentReader = nil
-		committeesDecoderPool.Put(d)
-	}()
-
-	d.currentReader = &reader
-
-	// Begin decoding
-	if err := d.decoder.Decode(&committees); err != nil {
-		return CommitteesResponse{}, fmt.Errorf("error decoding committees: %w", err)
-	}
-
 	return committees, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_FinalityCheckpoints(ctx context.Context, stateId string) (FinalityCheckpointsResponse, error) {
-	responseBody, status, err := p.getRequest(ctx, fmt.Sprintf(RequestFinalityCheckpointsPath, stateId))
+	finalityCheckpoints, _, err := getJSON[FinalityCheckpointsResponse](ctx, p, p.client, fmt.Sprintf(RequestFinalityCheckpointsPath, stateId), false)
 	if err != nil {
 		return FinalityCheckpointsResponse{}, fmt.Errorf("error getting finality checkpoints: %w", err)
 	}
-	if status != http.StatusOK {
-		return FinalityCheckpointsResponse{}, fmt.Errorf("error getting finality checkpoints: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var finalityCheckpoints FinalityCheckpointsResponse
-	if err := json.Unmarshal(responseBody, &finalityCheckpoints); err != nil {
-		return FinalityCheckpointsResponse{}, fmt.Errorf("error decoding finality checkpoints: %w", err)
-	}
 	return finalityCheckpoints, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_Genesis(ctx context.Context) (GenesisResponse, error) {
-	responseBody, status, err := p.getRequest(ctx, RequestGenesisPath)
+	genesis, _, err := getJSON[GenesisResponse](ctx, p, p.client, RequestGenesisPath, false)
 	if err != nil {
 		return GenesisResponse{}, fmt.Errorf("error getting genesis data: %w", err)
 	}
-	if status != http.StatusOK {
-		return GenesisResponse{}, fmt.Errorf("error getting genesis data: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var genesis GenesisResponse
-	if err := json.Unmarshal(responseBody, &genesis); err != nil {
-		return GenesisResponse{}, fmt.Errorf("error decoding genesis: %w", err)
-	}
 	return genesis, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_Header(ctx context.Context, blockId string) (BeaconBlockHeaderResponse, bool, error) {
-	responseBody, status, err := p.getRequest(ctx, fmt.Sprintf(RequestBeaconBlockHeaderPath, blockId))
+	beaconBlock, exists, err := getJSON[BeaconBlockHeaderResponse](ctx, p, p.client, fmt.Sprintf(RequestBeaconBlockHeaderPath, blockId), true)
 	if err != nil {
 		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("error getting beacon block header data: %w", err)
 	}
-	if status == http.StatusNotFound {
-		return BeaconBlockHeaderResponse{}, false, nil
+	return beaconBlock, exists, nil
+}
+
+func (p *BeaconHttpProvider) Beacon_SyncCommitteeRewards_Post(ctx context.Context, blockId string, indices []string) (SyncCommitteeRewardsResponse, error) {
+	if indices == nil {
+		// An empty list gets the rewards for every member of the sync committee
+		indices = []string{}
 	}
-	if status != http.StatusOK {
-		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("error getting beacon block header data: HTTP status %d; response body: '%s'", status, string(responseBody))
+	rewards, err := postJSON[SyncCommitteeRewardsResponse](ctx, p, fmt.Sprintf(RequestSyncCommitteeRewardsPath, blockId), indices)
+	if err != nil {
+		return SyncCommitteeRewardsResponse{}, fmt.Errorf("error getting sync committee rewards for block %s: %w", blockId, err)
 	}
-	var beaconBlock BeaconBlockHeaderResponse
-	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
-		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("error getting beacon block header data: %w", err)
+	return rewards, nil
+}
+
+func (p *BeaconHttpProvider) Beacon_ValidatorBalances(ctx context.Context, stateId string, ids []string) (ValidatorBalancesResponse, error) {
+	var query string
+	if len(ids) > 0 {
+		query = fmt.Sprintf("?id=%s", strings.Join(ids, ","))
 	}
-	return beaconBlock, true, nil
+	balances, _, err := getJSON[ValidatorBalancesResponse](ctx, p, p.getClientWithoutTimeout(), fmt.Sprintf(RequestValidatorBalancesPath, stateId)+query, false)
+	if err != nil {
+		return ValidatorBalancesResponse{}, fmt.Errorf("error getting validator balances: %w", err)
+	}
+	return balances, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_Validators(ctx context.Context, stateId string, ids []string) (ValidatorsResponse, error) {
@@ -192,123 +223,134 @@ func (p *BeaconHttpProvider) Beacon_Validators(ctx context.Context, stateId stri
 	if len(ids) > 0 {
 		query = fmt.Sprintf("?id=%s", strings.Join(ids, ","))
 	}
-	responseBody, status, err := p.getRequestWithoutTimeout(ctx, fmt.Sprintf(RequestValidatorsPath, stateId)+query)
+	validators, err := p.getValidatorsNegotiated(ctx, fmt.Sprintf(RequestValidatorsPath, stateId)+query)
 	if err != nil {
 		return ValidatorsResponse{}, fmt.Errorf("error getting validators: %w", err)
 	}
-	if status != http.StatusOK {
-		return ValidatorsResponse{}, fmt.Errorf("error getting validators: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var validators ValidatorsResponse
-	if err := json.Unmarshal(responseBody, &validators); err != nil {
-		return ValidatorsResponse{}, fmt.Errorf("error decoding validators: %w", err)
-	}
 	return validators, nil
 }
 
 func (p *BeaconHttpProvider) Beacon_VoluntaryExits_Post(ctx context.Context, request VoluntaryExitRequest) error {
-	responseBody, status, err := p.postRequest(ctx, RequestVoluntaryExitPath, request)
+	_, err := postJSON[struct{}](ctx, p, RequestVoluntaryExitPath, request)
 	if err != nil {
 		return fmt.Errorf("error broadcasting exit for validator at index %s: %w", request.Message.ValidatorIndex, err)
 	}
-	if status != http.StatusOK {
-		return fmt.Errorf("error broadcasting exit for validator at index %s: HTTP status %d; response body: '%s'", request.Message.ValidatorIndex, status, string(responseBody))
-	}
 	return nil
 }
 
 func (p *BeaconHttpProvider) Config_DepositContract(ctx context.Context) (Eth2DepositContractResponse, error) {
-	responseBody, status, err := p.getRequest(ctx, RequestEth2DepositContractMethod)
+	eth2DepositContract, _, err := getJSON[Eth2DepositContractResponse](ctx, p, p.client, RequestEth2DepositContractMethod, false)
 	if err != nil {
 		return Eth2DepositContractResponse{}, fmt.Errorf("error getting eth2 deposit contract: %w", err)
 	}
-	if status != http.StatusOK {
-		return Eth2DepositContractResponse{}, fmt.Errorf("error gettingeth2 deposit contract: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var eth2DepositContract Eth2DepositContractResponse
-	if err := json.Unmarshal(responseBody, &eth2DepositContract); err != nil {
-		return Eth2DepositContractResponse{}, fmt.Errorf("error decoding eth2 deposit contract: %w", err)
-	}
 	return eth2DepositContract, nil
 }
 
 func (p *BeaconHttpProvider) Config_Spec(ctx context.Context) (Eth2ConfigResponse, error) {
-	responseBody, status, err := p.getRequest(ctx, RequestEth2ConfigPath)
+	eth2Config, _, err := getJSON[Eth2ConfigResponse](ctx, p, p.client, RequestEth2ConfigPath, false)
 	if err != nil {
 		return Eth2ConfigResponse{}, fmt.Errorf("error getting eth2 config: %w", err)
 	}
-	if status != http.StatusOK {
-		return Eth2ConfigResponse{}, fmt.Errorf("error getting eth2 config: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var eth2Config Eth2ConfigResponse
-	if err := json.Unmarshal(responseBody, &eth2Config); err != nil {
-		return Eth2ConfigResponse{}, fmt.Errorf("error decoding eth2 config: %w", err)
-	}
 	return eth2Config, nil
 }
 
 func (p *BeaconHttpProvider) Node_Syncing(ctx context.Context) (SyncStatusResponse, error) {
-	responseBody, status, err := p.getRequest(ctx, RequestSyncStatusPath)
+	syncStatus, _, err := getJSON[SyncStatusResponse](ctx, p, p.client, RequestSyncStatusPath, false)
 	if err != nil {
 		return SyncStatusResponse{}, fmt.Errorf("error getting node sync status: %w", err)
 	}
-	if status != http.StatusOK {
-		return SyncStatusResponse{}, fmt.Errorf("error getting node sync status: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
-	var syncStatus SyncStatusResponse
-	if err := json.Unmarshal(responseBody, &syncStatus); err != nil {
-		return SyncStatusResponse{}, fmt.Errorf("error decoding node sync status: %w", err)
-	}
 	return syncStatus, nil
 }
 
+func (p *BeaconHttpProvider) Validator_DutiesAttester_Post(ctx context.Context, indices []string, epoch uint64) (AttesterDutiesResponse, error) {
+	attesterDuties, err := postJSON[AttesterDutiesResponse](ctx, p, fmt.Sprintf(RequestValidatorAttesterDuties, strconv.FormatUint(epoch, 10)), indices)
+	if err != nil {
+		return AttesterDutiesResponse{}, fmt.Errorf("error getting validator attester duties: %w", err)
+	}
+	return attesterDuties, nil
+}
+
 func (p *BeaconHttpProvider) Validator_DutiesProposer(ctx context.Context, indices []string, epoch uint64) (ProposerDutiesResponse, error) {
-	responseBody, status, err := p.getRequest(ctx, fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
+	proposerDuties, _, err := getJSON[ProposerDutiesResponse](ctx, p, p.client, fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)), false)
 	if err != nil {
 		return ProposerDutiesResponse{}, fmt.Errorf("error getting validator proposer duties: %w", err)
 	}
-	if status != http.StatusOK {
-		return ProposerDutiesResponse{}, fmt.Errorf("error getting validator proposer duties: HTTP status %d; response body: '%s'", status, string(responseBody))
-	}
+	return proposerDuties, nil
+}
 
-	var syncDuties ProposerDutiesResponse
-	if err := json.Unmarshal(responseBody, &syncDuties); err != nil {
-		return ProposerDutiesResponse{}, fmt.Errorf("error decoding validator proposer duties data: %w", err)
+func (p *BeaconHttpProvider) Validator_DutiesSync_Post(ctx context.Context, indices []string, epoch uint64) (SyncDutiesResponse, error) {
+	syncDuties, err := postJSON[SyncDutiesResponse](ctx, p, fmt.Sprintf(RequestValidatorSyncDuties, strconv.FormatUint(epoch, 10)), indices)
+	if err != nil {
+		return SyncDutiesResponse{}, fmt.Errorf("error getting validator sync duties: %w", err)
 	}
 	return syncDuties, nil
 }
 
-func (p *BeaconHttpProvider) Validator_DutiesSync_Post(ctx context.Context, indices []string, epoch uint64) (SyncDutiesResponse, error) {
-	// Perform the post request
-	responseBody, status, err := p.postRequest(ctx, fmt.Sprintf(RequestValidatorSyncDuties, strconv.FormatUint(epoch, 10)), indices)
+// ===============================
+// === Generic Request Helpers ===
+// ===============================
+
+// Make a GET request to an arbitrary Beacon API route and decode the JSON response into the provided type.
+// This can be used to call routes that the provider doesn't wrap directly.
+// If allowNotFound is true, a 404 response will return the zero value and false instead of an error.
+func GetJSON[ResponseType any](ctx context.Context, p *BeaconHttpProvider, requestPath string, allowNotFound bool) (ResponseType, bool, error) {
+	return getJSON[ResponseType](ctx, p, p.client, requestPath, allowNotFound)
+}
+
+// Make a POST request to an arbitrary Beacon API route with the provided body serialized as JSON, and decode
+// the JSON response into the provided type. Empty response bodies will return the zero value of the type.
+// This can be used to call routes that the provider doesn't wrap directly.
+func PostJSON[ResponseType any](ctx context.Context, p *BeaconHttpProvider, requestPath string, requestBody any) (ResponseType, error) {
+	return postJSON[ResponseType](ctx, p, requestPath, requestBody)
+}
 
+// Make a GET request with the provided HTTP client, check the status code, and decode the response body
+func getJSON[ResponseType any](ctx context.Context, p *BeaconHttpProvider, client http.Client, requestPath string, allowNotFound bool) (ResponseType, bool, error) {
+	var response ResponseType
+	responseBody, status, err := getRequestImpl(ctx, requestPath, p.providerAddress, client)
 	if err != nil {
-		return SyncDutiesResponse{}, fmt.Errorf("error getting validator sync duties: %w", err)
+		return response, false, err
+	}
+	if allowNotFound && status == http.StatusNotFound {
+		return response, false, nil
 	}
 	if status != http.StatusOK {
-		return SyncDutiesResponse{}, fmt.Errorf("error getting validator sync duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+		return response, false, fmt.Errorf("HTTP status %d; response body: '%s'", status, string(responseBody))
 	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return response, false, fmt.Errorf("error decoding response body: %w", err)
+	}
+	return response, true, nil
+}
 
-	var syncDuties SyncDutiesResponse
-	if err := json.Unmarshal(responseBody, &syncDuties); err != nil {
-		return SyncDutiesResponse{}, fmt.Errorf("error decoding validator sync duties data: %w", err)
+// Make a POST request, check the status code, and decode the response body if there is one
+func postJSON[ResponseType any](ctx context.Context, p *BeaconHttpProvider, requestPath string, requestBody any) (ResponseType, error) {
+	var response ResponseType
+	responseBody, status, err := p.postRequest(ctx, requestPath, requestBody)
+	if err != nil {
+		return response, err
 	}
-	return syncDuties, nil
+	if status != http.StatusOK {
+		return response, fmt.Errorf("HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	if len(responseBody) == 0 {
+		return response, nil
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return response, fmt.Errorf("error decoding response body: %w", err)
+	}
+	return response, nil
 }
 
 // ==========================
 // === Internal Functions ===
 // ==========================
 
-// Make a GET request to the beacon node and read the body of the response
-func (p *BeaconHttpProvider) getRequest(ctx context.Context, requestPath string) ([]byte, int, error) {
-	return getRequestImpl(ctx, requestPath, p.providerAddress, p.client)
-}
-
-// Make a GET request to the beacon node and read the body of the response
-func (p *BeaconHttpProvider) getRequestWithoutTimeout(ctx context.Context, requestPath string) ([]byte, int, error) {
-	clientWithoutTimeout := http.Client{}
-	return getRequestImpl(ctx, requestPath, p.providerAddress, clientWithoutTimeout)
+// Get a copy of the HTTP client without a timeout, for requests that can take a long time to complete
+func (p *BeaconHttpProvider) getClientWithoutTimeout() http.Client {
+	return http.Client{
+		Transport: p.client.Transport,
+	}
 }
 
 // Make a GET request to the beacon node and read the body of the response
@@ -342,7 +384,7 @@ func (p *BeaconHttpProvider) postRequest(ctx context.Context, requestPath string
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Create the request
-	path := fmt.Sprintf(RequestUrlFormat, p.providerAddress, requestPath)
+	path := utils.JoinUrlPath(p.providerAddress, requestPath)
 	request, err := http.NewRequestWithContext(ctx, http.MethodPost, path, requestBodyReader)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error creating POST request to [%s]: %w", path, err)
@@ -368,6 +410,24 @@ func (p *BeaconHttpProvider) postRequest(ctx context.Context, requestPath string
 	return body, response.StatusCode, nil
 }
 
+// Decode a JSON committees response. Committees responses are large, so the json decoder reads it in a buffered fashion.
+func decodeCommittees(reader io.ReadCloser) (CommitteesResponse, error) {
+	var committees CommitteesResponse
+	d := committeesDecoderPool.Get().(*committeesDecoder)
+	defer func() {
+		d.currentReader = nil
+		committeesDecoderPool.Put(d)
+	}()
+
+	d.currentReader = &reader
+
+	// Begin decoding
+	if err := d.decoder.Decode(&committees); err != nil {
+		return CommitteesResponse{}, fmt.Errorf("error decoding committees: %w", err)
+	}
+	return committees, nil
+}
+
 // Get an eth2 epoch number by time
 func epochAt(config beacon.Eth2Config, time uint64) uint64 {
 	return config.GenesisEpoch + (time-config.GenesisTime)/config.SecondsPerEpoch
@@ -376,7 +436,7 @@ func epochAt(config beacon.Eth2Config, time uint64) uint64 {
 // Make a GET request but do not read its body yet (allows buffered decoding)
 func getRequestReader(ctx context.Context, requestPath string, providerAddress string, client http.Client) (io.ReadCloser, int, error) {
 	// Make the request
-	path := fmt.Sprintf(RequestUrlFormat, providerAddress, requestPath)
+	path := utils.JoinUrlPath(providerAddress, requestPath)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error creating GET request to [%s]: %w", path, err)
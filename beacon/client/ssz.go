@@ -0,0 +1,316 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/ssz_types"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	RequestSszContentType = "application/octet-stream"
+
+	// Prefer SSZ, but let the Beacon Node respond with JSON if it doesn't support SSZ for the route
+	requestSszAcceptHeader = RequestSszContentType + ";q=1.0," + RequestContentType + ";q=0.9"
+
+	// The header that holds the fork of a block returned by the Beacon Node
+	consensusVersionHeader = "Eth-Consensus-Version"
+)
+
+// The validator statuses in the order they're encoded in SSZ validators responses
+var sszValidatorStates = []beacon.ValidatorState{
+	beacon.ValidatorState_PendingInitialized,
+	beacon.ValidatorState_PendingQueued,
+	beacon.ValidatorState_ActiveOngoing,
+	beacon.ValidatorState_ActiveExiting,
+	beacon.ValidatorState_ActiveSlashed,
+	beacon.ValidatorState_ExitedUnslashed,
+	beacon.ValidatorState_ExitedSlashed,
+	beacon.ValidatorState_WithdrawalPossible,
+	beacon.ValidatorState_WithdrawalDone,
+}
+
+// Set whether the provider asks for SSZ responses from the validators, committees, and block routes instead of JSON.
+// SSZ responses are much smaller and faster to decode; if the Beacon Node doesn't support SSZ for a route, the
+// provider falls back to JSON.
+func (p *BeaconHttpProvider) SetSszEnabled(enabled bool) {
+	p.sszEnabled = enabled
+}
+
+// Make a GET request, asking for an SSZ response if SSZ is enabled. If the Beacon Node rejects the request because it
+// can't respond with SSZ, the request is sent again asking for JSON.
+func (p *BeaconHttpProvider) getNegotiatedResponse(ctx context.Context, requestPath string, client http.Client) (*http.Response, error) {
+	if !p.sszEnabled {
+		return getResponse(ctx, requestPath, p.providerAddress, client, RequestContentType)
+	}
+	response, err := getResponse(ctx, requestPath, p.providerAddress, client, requestSszAcceptHeader)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotAcceptable || response.StatusCode == http.StatusUnsupportedMediaType {
+		_ = response.Body.Close()
+		return getResponse(ctx, requestPath, p.providerAddress, client, RequestContentType)
+	}
+	return response, nil
+}
+
+// Make a GET request with the provided Accept header
+func getResponse(ctx context.Context, requestPath string, providerAddress string, client http.Client, accept string) (*http.Response, error) {
+	path := utils.JoinUrlPath(providerAddress, requestPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GET request to [%s]: %w", path, err)
+	}
+	req.Header.Set("Content-Type", RequestContentType)
+	req.Header.Set("Accept", accept)
+
+	response, err := client.Do(req)
+	if err != nil {
+		// Remove the query for readability
+		trimmedPath, _, _ := strings.Cut(path, "?")
+		return nil, fmt.Errorf("error running GET request to [%s]: %w", trimmedPath, err)
+	}
+	return response, nil
+}
+
+// Check if a response is SSZ-encoded
+func isSszResponse(response *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	return err == nil && mediaType == RequestSszContentType
+}
+
+// Read the body of an unsuccessful response into an error
+func getStatusError(response *http.Response) error {
+	body, _ := io.ReadAll(response.Body)
+	return fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+}
+
+// ==================
+// === Validators ===
+// ==================
+
+// Get validators, decoding the response as SSZ or JSON depending on what the Beacon Node sent
+func (p *BeaconHttpProvider) getValidatorsNegotiated(ctx context.Context, requestPath string) (ValidatorsResponse, error) {
+	response, err := p.getNegotiatedResponse(ctx, requestPath, p.getClientWithoutTimeout())
+	if err != nil {
+		return ValidatorsResponse{}, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return ValidatorsResponse{}, getStatusError(response)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if !isSszResponse(response) {
+		var validators ValidatorsResponse
+		if err := json.Unmarshal(body, &validators); err != nil {
+			return ValidatorsResponse{}, fmt.Errorf("error decoding response body: %w", err)
+		}
+		return validators, nil
+	}
+
+	var sszValidators ssz_types.ValidatorsResponse
+	if err := sszValidators.UnmarshalSSZ(body); err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("error decoding SSZ response body: %w", err)
+	}
+	validators := ValidatorsResponse{
+		Data: make([]Validator, len(sszValidators.Data)),
+	}
+	for i, sszValidator := range sszValidators.Data {
+		if int(sszValidator.Status) >= len(sszValidatorStates) {
+			return ValidatorsResponse{}, fmt.Errorf("validator %d has unknown status %d", sszValidator.Index, sszValidator.Status)
+		}
+		validator := &validators.Data[i]
+		validator.Index = strconv.FormatUint(sszValidator.Index, 10)
+		validator.Balance = utils.Uinteger(sszValidator.Balance)
+		validator.Status = string(sszValidatorStates[sszValidator.Status])
+		validator.Validator.Pubkey = utils.Bytes48(sszValidator.Validator.PublicKey)
+		validator.Validator.WithdrawalCredentials = utils.Bytes32(sszValidator.Validator.WithdrawalCredentials)
+		validator.Validator.EffectiveBalance = utils.Uinteger(sszValidator.Validator.EffectiveBalance)
+		validator.Validator.Slashed = sszValidator.Validator.Slashed
+		validator.Validator.ActivationEligibilityEpoch = utils.Uinteger(sszValidator.Validator.ActivationEligibilityEpoch)
+		validator.Validator.ActivationEpoch = utils.Uinteger(sszValidator.Validator.ActivationEpoch)
+		validator.Validator.ExitEpoch = utils.Uinteger(sszValidator.Validator.ExitEpoch)
+		validator.Validator.WithdrawableEpoch = utils.Uinteger(sszValidator.Validator.WithdrawableEpoch)
+	}
+	return validators, nil
+}
+
+// ==================
+// === Committees ===
+// ==================
+
+// Get committees, decoding the response as SSZ or JSON depending on what the Beacon Node sent
+func (p *BeaconHttpProvider) getCommitteesNegotiated(ctx context.Context, requestPath string) (CommitteesResponse, error) {
+	response, err := p.getNegotiatedResponse(ctx, requestPath, p.getClientWithoutTimeout())
+	if err != nil {
+		return CommitteesResponse{}, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return CommitteesResponse{}, getStatusError(response)
+	}
+
+	if !isSszResponse(response) {
+		return decodeCommittees(response.Body)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return CommitteesResponse{}, fmt.Errorf("error reading response body: %w", err)
+	}
+	var sszCommittees ssz_types.CommitteesResponse
+	if err := sszCommittees.UnmarshalSSZ(body); err != nil {
+		return CommitteesResponse{}, fmt.Errorf("error decoding SSZ response body: %w", err)
+	}
+	committees := CommitteesResponse{
+		Data: make([]Committee, len(sszCommittees.Data)),
+	}
+	for i, sszCommittee := range sszCommittees.Data {
+		// Use the pooled validator slices so Release() works the same way as it does for JSON responses
+		validatorSlice := *validatorSlicePool.Get().(*[]string)
+		for _, index := range sszCommittee.Validators {
+			validatorSlice = append(validatorSlice, strconv.FormatUint(index, 10))
+		}
+		committees.Data[i] = Committee{
+			Index:      utils.Uinteger(sszCommittee.Index),
+			Slot:       utils.Uinteger(sszCommittee.Slot),
+			Validators: validatorSlice,
+		}
+	}
+	return committees, nil
+}
+
+// ==============
+// === Blocks ===
+// ==============
+
+// Get a block, decoding the response as SSZ or JSON depending on what the Beacon Node sent. SSZ can only be decoded
+// for blocks from Deneb onward; older blocks are requested again as JSON.
+func (p *BeaconHttpProvider) getBlockNegotiated(ctx context.Context, requestPath string) (BeaconBlockResponse, bool, error) {
+	response, err := p.getNegotiatedResponse(ctx, requestPath, p.client)
+	if err != nil {
+		return BeaconBlockResponse{}, false, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode == http.StatusNotFound {
+		return BeaconBlockResponse{}, false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return BeaconBlockResponse{}, false, getStatusError(response)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return BeaconBlockResponse{}, false, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if !isSszResponse(response) {
+		var block BeaconBlockResponse
+		if err := json.Unmarshal(body, &block); err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("error decoding response body: %w", err)
+		}
+		return block, true, nil
+	}
+
+	fork := strings.ToLower(response.Header.Get(consensusVersionHeader))
+	switch fork {
+	case "deneb":
+		var sszBlock ssz_types.SignedBeaconBlockDeneb
+		if err := sszBlock.UnmarshalSSZ(body); err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("error decoding SSZ response body: %w", err)
+		}
+		block := BeaconBlockResponse{}
+		message := sszBlock.Message
+		setBlockHeaderFields(&block, message.Slot, message.ProposerIndex, message.Body.Eth1Data, message.Body.ExecutionPayload)
+		block.Data.Message.Body.Attestations = make([]Attestation, len(message.Body.Attestations))
+		for i, attestation := range message.Body.Attestations {
+			block.Data.Message.Body.Attestations[i] = convertSszAttestation(attestation.AggregationBits, nil, attestation.Data)
+		}
+		return block, true, nil
+
+	case "electra", "fulu":
+		var sszBlock ssz_types.SignedBeaconBlockElectra
+		if err := sszBlock.UnmarshalSSZ(body); err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("error decoding SSZ response body: %w", err)
+		}
+		block := BeaconBlockResponse{}
+		message := sszBlock.Message
+		setBlockHeaderFields(&block, message.Slot, message.ProposerIndex, message.Body.Eth1Data, message.Body.ExecutionPayload)
+		block.Data.Message.Body.Attestations = make([]Attestation, len(message.Body.Attestations))
+		for i, attestation := range message.Body.Attestations {
+			block.Data.Message.Body.Attestations[i] = convertSszAttestation(attestation.AggregationBits, attestation.CommitteeBits, attestation.Data)
+		}
+		return block, true, nil
+
+	default:
+		// The block is from a fork that doesn't have an SSZ layout here, so get it as JSON instead
+		block, exists, err := getJSON[BeaconBlockResponse](ctx, p, p.client, requestPath, true)
+		if err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("error getting %s block as JSON: %w", fork, err)
+		}
+		return block, exists, nil
+	}
+}
+
+// Set the fields of a block response that are the same in every supported fork
+func setBlockHeaderFields(block *BeaconBlockResponse, slot uint64, proposerIndex uint64, eth1Data *ssz_types.Eth1Data, payload *ssz_types.ExecutionPayloadDeneb) {
+	message := &block.Data.Message
+	message.Slot = utils.Uinteger(slot)
+	message.ProposerIndex = strconv.FormatUint(proposerIndex, 10)
+	message.Body.Eth1Data.DepositRoot = utils.Bytes32(eth1Data.DepositRoot)
+	message.Body.Eth1Data.DepositCount = utils.Uinteger(eth1Data.DepositCount)
+	message.Body.Eth1Data.BlockHash = utils.Bytes32(eth1Data.BlockHash)
+
+	withdrawals := make([]Withdrawal, len(payload.Withdrawals))
+	for i, withdrawal := range payload.Withdrawals {
+		withdrawals[i] = Withdrawal{
+			Index:          utils.Uinteger(withdrawal.Index),
+			ValidatorIndex: strconv.FormatUint(withdrawal.ValidatorIndex, 10),
+			Address:        utils.ByteArray(withdrawal.Address[:]),
+			Amount:         utils.Uinteger(withdrawal.Amount),
+		}
+	}
+	message.Body.ExecutionPayload = &ExecutionPayload{
+		FeeRecipient: utils.ByteArray(payload.FeeRecipient[:]),
+		BlockNumber:  utils.Uinteger(payload.BlockNumber),
+		Withdrawals:  withdrawals,
+	}
+}
+
+// Convert an SSZ attestation into the JSON response format. The committee bits are nil before Electra.
+func convertSszAttestation(aggregationBits []byte, committeeBits []byte, data *ssz_types.AttestationData) Attestation {
+	var attestation Attestation
+	attestation.AggregationBits = utils.EncodeHexWithPrefix(aggregationBits)
+	if committeeBits != nil {
+		attestation.CommitteeBits = utils.EncodeHexWithPrefix(committeeBits)
+	}
+	attestation.Data.Slot = utils.Uinteger(data.Slot)
+	attestation.Data.Index = utils.Uinteger(data.Index)
+	attestation.Data.BeaconBlockRoot = utils.Bytes32(data.BeaconBlockRoot)
+	attestation.Data.Source = Checkpoint{
+		Epoch: utils.Uinteger(data.Source.Epoch),
+		Root:  utils.Bytes32(data.Source.Root),
+	}
+	attestation.Data.Target = Checkpoint{
+		Epoch: utils.Uinteger(data.Target.Epoch),
+		Root:  utils.Bytes32(data.Target.Root),
+	}
+	return attestation
+}
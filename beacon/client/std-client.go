@@ -3,7 +3,6 @@ package client
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
 	"fmt"
 	"runtime"
 	"strconv"
@@ -29,6 +28,11 @@ func NewStandardClient(provider IBeaconApiProvider) *StandardClient {
 	}
 }
 
+// Get the provider the client uses to talk to the Beacon Node
+func (c *StandardClient) GetApiProvider() IBeaconApiProvider {
+	return c.provider
+}
+
 // Close the client connection
 func (c *StandardClient) Close(ctx context.Context) error {
 	return nil
@@ -47,8 +51,10 @@ func (c *StandardClient) GetSyncStatus(ctx context.Context) (beacon.SyncStatus,
 
 	// Return response
 	return beacon.SyncStatus{
-		Syncing:  syncStatus.Data.IsSyncing,
-		Progress: progress,
+		Syncing:      syncStatus.Data.IsSyncing,
+		Progress:     progress,
+		HeadSlot:     uint64(syncStatus.Data.HeadSlot),
+		SyncDistance: uint64(syncStatus.Data.SyncDistance),
 	}, nil
 }
 
@@ -81,7 +87,7 @@ func (c *StandardClient) GetEth2Config(ctx context.Context) (beacon.Eth2Config,
 	// Return response
 	return beacon.Eth2Config{
 		GenesisForkVersion:           genesis.Data.GenesisForkVersion,
-		GenesisValidatorsRoot:        genesis.Data.GenesisValidatorsRoot,
+		GenesisValidatorsRoot:        genesis.Data.GenesisValidatorsRoot[:],
 		GenesisEpoch:                 0,
 		GenesisTime:                  uint64(genesis.Data.GenesisTime),
 		SecondsPerSlot:               uint64(eth2Config.Data.SecondsPerSlot),
@@ -170,7 +176,7 @@ func (c *StandardClient) getValidatorStatus(ctx context.Context, pubkeyOrIndex s
 	return beacon.ValidatorStatus{
 		Pubkey:                     beacon.ValidatorPubkey(validator.Validator.Pubkey),
 		Index:                      validator.Index,
-		WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+		WithdrawalCredentials:      common.Hash(validator.Validator.WithdrawalCredentials),
 		Balance:                    uint64(validator.Balance),
 		EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
 		Status:                     beacon.ValidatorState(validator.Status),
@@ -229,7 +235,7 @@ func (c *StandardClient) GetValidatorStatuses(ctx context.Context, pubkeys []bea
 	for _, validator := range validators.Data {
 
 		// Ignore empty pubkeys
-		if bytes.Equal(validator.Validator.Pubkey, nullPubkey[:]) {
+		if bytes.Equal(validator.Validator.Pubkey[:], nullPubkey[:]) {
 			continue
 		}
 
@@ -240,7 +246,7 @@ func (c *StandardClient) GetValidatorStatuses(ctx context.Context, pubkeys []bea
 		statuses[pubkey] = beacon.ValidatorStatus{
 			Pubkey:                     beacon.ValidatorPubkey(validator.Validator.Pubkey),
 			Index:                      validator.Index,
-			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			WithdrawalCredentials:      common.Hash(validator.Validator.WithdrawalCredentials),
 			Balance:                    uint64(validator.Balance),
 			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
 			Status:                     beacon.ValidatorState(validator.Status),
@@ -262,6 +268,47 @@ func (c *StandardClient) GetValidatorStatuses(ctx context.Context, pubkeys []bea
 
 }
 
+// Get multiple validators' statuses by their indices. Looking validators up by index is much cheaper for the Beacon
+// Node than looking them up by pubkey. Indices that don't belong to a validator are left out of the results.
+func (c *StandardClient) GetValidatorStatusesByIndex(ctx context.Context, indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+	// Filter out blank and duplicate indices
+	realIndices := []string{}
+	seen := map[string]bool{}
+	for _, index := range indices {
+		if index == "" || seen[index] {
+			continue
+		}
+		seen[index] = true
+		realIndices = append(realIndices, index)
+	}
+
+	// Get validators
+	validators, err := c.getValidatorsByOpts(ctx, realIndices, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build validator status map
+	statuses := make(map[string]beacon.ValidatorStatus, len(validators.Data))
+	for _, validator := range validators.Data {
+		statuses[validator.Index] = beacon.ValidatorStatus{
+			Pubkey:                     beacon.ValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      validator.Index,
+			WithdrawalCredentials:      common.Hash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Status:                     beacon.ValidatorState(validator.Status),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		}
+	}
+	return statuses, nil
+}
+
 // Get whether validators have sync duties to perform at given epoch
 func (c *StandardClient) GetValidatorSyncDuties(ctx context.Context, indices []string, epoch uint64) (map[string]bool, error) {
 	// Perform the post request
@@ -366,7 +413,7 @@ func (c *StandardClient) GetDomainData(ctx context.Context, domainType []byte, e
 	// Compute & return domain
 	var dt [4]byte
 	copy(dt[:], domainType[:])
-	return eth2types.ComputeDomain(dt, forkVersion, genesis.Data.GenesisValidatorsRoot)
+	return eth2types.ComputeDomain(dt, forkVersion, genesis.Data.GenesisValidatorsRoot[:])
 }
 
 // Perform a voluntary exit on a validator
@@ -376,7 +423,7 @@ func (c *StandardClient) ExitValidator(ctx context.Context, validatorIndex strin
 			Epoch:          utils.Uinteger(epoch),
 			ValidatorIndex: validatorIndex,
 		},
-		Signature: signature[:],
+		Signature: utils.Bytes96(signature),
 	})
 }
 
@@ -393,9 +440,9 @@ func (c *StandardClient) GetEth1DataForEth2Block(ctx context.Context, blockId st
 
 	// Convert the response to the eth1 data struct
 	return beacon.Eth1Data{
-		DepositRoot:  common.BytesToHash(block.Data.Message.Body.Eth1Data.DepositRoot),
+		DepositRoot:  common.Hash(block.Data.Message.Body.Eth1Data.DepositRoot),
 		DepositCount: uint64(block.Data.Message.Body.Eth1Data.DepositCount),
-		BlockHash:    common.BytesToHash(block.Data.Message.Body.Eth1Data.BlockHash),
+		BlockHash:    common.Hash(block.Data.Message.Body.Eth1Data.BlockHash),
 	}, true, nil
 }
 
@@ -411,13 +458,21 @@ func (c *StandardClient) GetAttestations(ctx context.Context, blockId string) ([
 	// Add attestation info
 	attestationInfo := make([]beacon.AttestationInfo, len(attestations.Data))
 	for i, attestation := range attestations.Data {
-		bitString := utils.RemovePrefix(attestation.AggregationBits)
 		attestationInfo[i].SlotIndex = uint64(attestation.Data.Slot)
 		attestationInfo[i].CommitteeIndex = uint64(attestation.Data.Index)
-		attestationInfo[i].AggregationBits, err = hex.DecodeString(bitString)
+		attestationInfo[i].BeaconBlockRoot = common.Hash(attestation.Data.BeaconBlockRoot)
+		attestationInfo[i].SourceEpoch = uint64(attestation.Data.Source.Epoch)
+		attestationInfo[i].TargetEpoch = uint64(attestation.Data.Target.Epoch)
+		attestationInfo[i].AggregationBits, err = utils.DecodeHex(attestation.AggregationBits)
 		if err != nil {
 			return nil, false, fmt.Errorf("error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
 		}
+		if attestation.CommitteeBits != "" {
+			attestationInfo[i].CommitteeBits, err = utils.DecodeHex(attestation.CommitteeBits)
+			if err != nil {
+				return nil, false, fmt.Errorf("error decoding committee bits for attestation %d of block %s: %w", i, blockId, err)
+			}
+		}
 	}
 
 	return attestationInfo, true, nil
@@ -446,19 +501,35 @@ func (c *StandardClient) GetBeaconBlock(ctx context.Context, blockId string) (be
 		beaconBlock.HasExecutionPayload = true
 		beaconBlock.FeeRecipient = common.BytesToAddress(block.Data.Message.Body.ExecutionPayload.FeeRecipient)
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
+		for _, withdrawal := range block.Data.Message.Body.ExecutionPayload.Withdrawals {
+			beaconBlock.Withdrawals = append(beaconBlock.Withdrawals, beacon.WithdrawalInfo{
+				Index:          uint64(withdrawal.Index),
+				ValidatorIndex: withdrawal.ValidatorIndex,
+				Address:        common.BytesToAddress(withdrawal.Address),
+				Amount:         uint64(withdrawal.Amount),
+			})
+		}
 	}
 
 	// Add attestation info
 	for i, attestation := range block.Data.Message.Body.Attestations {
-		bitString := utils.RemovePrefix(attestation.AggregationBits)
 		info := beacon.AttestationInfo{
-			SlotIndex:      uint64(attestation.Data.Slot),
-			CommitteeIndex: uint64(attestation.Data.Index),
+			SlotIndex:       uint64(attestation.Data.Slot),
+			CommitteeIndex:  uint64(attestation.Data.Index),
+			BeaconBlockRoot: common.Hash(attestation.Data.BeaconBlockRoot),
+			SourceEpoch:     uint64(attestation.Data.Source.Epoch),
+			TargetEpoch:     uint64(attestation.Data.Target.Epoch),
 		}
-		info.AggregationBits, err = hex.DecodeString(bitString)
+		info.AggregationBits, err = utils.DecodeHex(attestation.AggregationBits)
 		if err != nil {
 			return beacon.BeaconBlock{}, false, fmt.Errorf("error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
 		}
+		if attestation.CommitteeBits != "" {
+			info.CommitteeBits, err = utils.DecodeHex(attestation.CommitteeBits)
+			if err != nil {
+				return beacon.BeaconBlock{}, false, fmt.Errorf("error decoding committee bits for attestation %d of block %s: %w", i, blockId, err)
+			}
+		}
 		beaconBlock.Attestations = append(beaconBlock.Attestations, info)
 	}
 
@@ -477,6 +548,7 @@ func (c *StandardClient) GetBeaconBlockHeader(ctx context.Context, blockId strin
 	header := beacon.BeaconBlockHeader{
 		Slot:          uint64(block.Data.Header.Message.Slot),
 		ProposerIndex: block.Data.Header.Message.ProposerIndex,
+		Root:          common.Hash(block.Data.Root),
 	}
 	return header, true, nil
 }
@@ -496,10 +568,10 @@ func (c *StandardClient) ChangeWithdrawalCredentials(ctx context.Context, valida
 	return c.provider.Beacon_BlsToExecutionChanges_Post(ctx, BLSToExecutionChangeRequest{
 		Message: BLSToExecutionChangeMessage{
 			ValidatorIndex:     validatorIndex,
-			FromBLSPubkey:      fromBlsPubkey[:],
+			FromBLSPubkey:      utils.Bytes48(fromBlsPubkey),
 			ToExecutionAddress: toExecutionAddress[:],
 		},
-		Signature: signature[:],
+		Signature: utils.Bytes96(signature),
 	})
 }
 
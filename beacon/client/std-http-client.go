@@ -1,15 +1,49 @@
 package client
 
-import "time"
+import (
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/utils"
+)
 
 type StandardHttpClient struct {
 	*StandardClient
+	httpProvider *BeaconHttpProvider
 }
 
 // Create a new client instance
 func NewStandardHttpClient(providerAddress string, timeout time.Duration) *StandardHttpClient {
-	provider := NewBeaconHttpProvider(providerAddress, timeout)
+	return NewStandardHttpClientWithOptions(providerAddress, timeout, utils.ProxyOptions{})
+}
+
+// Create a new client instance for a Beacon Node that sits behind a reverse proxy
+func NewStandardHttpClientWithOptions(providerAddress string, timeout time.Duration, proxyOpts utils.ProxyOptions) *StandardHttpClient {
+	provider := NewBeaconHttpProviderWithOptions(providerAddress, timeout, proxyOpts)
 	return &StandardHttpClient{
 		StandardClient: NewStandardClient(provider),
+		httpProvider:   provider,
 	}
 }
+
+// Get the latency and availability statistics for the Beacon Node's endpoint
+func (c *StandardHttpClient) GetEndpointStats() utils.EndpointStats {
+	return c.httpProvider.GetEndpointStats()
+}
+
+// Set whether the client asks the Beacon Node for SSZ responses on routes with large responses, falling back to
+// JSON if the Beacon Node doesn't support it
+func (c *StandardHttpClient) SetSszEnabled(enabled bool) {
+	c.httpProvider.SetSszEnabled(enabled)
+}
+
+// Set how the client retries requests that fail with transient errors. This should be called before the client is
+// used.
+func (c *StandardHttpClient) SetRetrySettings(settings utils.HttpRetrySettings) {
+	c.httpProvider.SetRetrySettings(settings)
+}
+
+// Set the largest response the client accepts from the Beacon Node, and whether it asks for compressed responses.
+// This should be called before the client is used.
+func (c *StandardHttpClient) SetResponseLimits(settings utils.ResponseLimitSettings) {
+	c.httpProvider.SetResponseLimits(settings)
+}
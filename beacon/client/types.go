@@ -12,16 +12,16 @@ type VoluntaryExitMessage struct {
 }
 type VoluntaryExitRequest struct {
 	Message   VoluntaryExitMessage `json:"message"`
-	Signature utils.ByteArray      `json:"signature"`
+	Signature utils.Bytes96        `json:"signature"`
 }
 type BLSToExecutionChangeMessage struct {
 	ValidatorIndex     string          `json:"validator_index"`
-	FromBLSPubkey      utils.ByteArray `json:"from_bls_pubkey"`
+	FromBLSPubkey      utils.Bytes48   `json:"from_bls_pubkey"`
 	ToExecutionAddress utils.ByteArray `json:"to_execution_address"`
 }
 type BLSToExecutionChangeRequest struct {
 	Message   BLSToExecutionChangeMessage `json:"message"`
-	Signature utils.ByteArray             `json:"signature"`
+	Signature utils.Bytes96               `json:"signature"`
 }
 
 // Response types
@@ -50,7 +50,7 @@ type GenesisResponse struct {
 	Data struct {
 		GenesisTime           utils.Uinteger  `json:"genesis_time"`
 		GenesisForkVersion    utils.ByteArray `json:"genesis_fork_version"`
-		GenesisValidatorsRoot utils.ByteArray `json:"genesis_validators_root"`
+		GenesisValidatorsRoot utils.Bytes32   `json:"genesis_validators_root"`
 	} `json:"data"`
 }
 type FinalityCheckpointsResponse struct {
@@ -83,24 +83,46 @@ type BeaconBlockResponse struct {
 			ProposerIndex string         `json:"proposer_index"`
 			Body          struct {
 				Eth1Data struct {
-					DepositRoot  utils.ByteArray `json:"deposit_root"`
-					DepositCount utils.Uinteger  `json:"deposit_count"`
-					BlockHash    utils.ByteArray `json:"block_hash"`
+					DepositRoot  utils.Bytes32  `json:"deposit_root"`
+					DepositCount utils.Uinteger `json:"deposit_count"`
+					BlockHash    utils.Bytes32  `json:"block_hash"`
 				} `json:"eth1_data"`
-				Attestations     []Attestation `json:"attestations"`
-				ExecutionPayload *struct {
-					FeeRecipient utils.ByteArray `json:"fee_recipient"`
-					BlockNumber  utils.Uinteger  `json:"block_number"`
-				} `json:"execution_payload"`
+				Attestations     []Attestation     `json:"attestations"`
+				ExecutionPayload *ExecutionPayload `json:"execution_payload"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
 }
+type ExecutionPayload struct {
+	FeeRecipient utils.ByteArray `json:"fee_recipient"`
+	BlockNumber  utils.Uinteger  `json:"block_number"`
+	Withdrawals  []Withdrawal    `json:"withdrawals"`
+}
+type BlobSidecarsResponse struct {
+	Data []BlobSidecar `json:"data"`
+}
+type BlobSidecar struct {
+	Index             utils.Uinteger  `json:"index"`
+	Blob              utils.ByteArray `json:"blob"`
+	KzgCommitment     utils.Bytes48   `json:"kzg_commitment"`
+	KzgProof          utils.Bytes48   `json:"kzg_proof"`
+	SignedBlockHeader struct {
+		Message struct {
+			Slot          utils.Uinteger `json:"slot"`
+			ProposerIndex string         `json:"proposer_index"`
+			ParentRoot    utils.Bytes32  `json:"parent_root"`
+			StateRoot     utils.Bytes32  `json:"state_root"`
+			BodyRoot      utils.Bytes32  `json:"body_root"`
+		} `json:"message"`
+		Signature utils.Bytes96 `json:"signature"`
+	} `json:"signed_block_header"`
+	KzgCommitmentInclusionProof []utils.Bytes32 `json:"kzg_commitment_inclusion_proof"`
+}
 type BeaconBlockHeaderResponse struct {
 	Finalized bool `json:"finalized"`
 	Data      struct {
-		Root      string `json:"root"`
-		Canonical bool   `json:"canonical"`
+		Root      utils.Bytes32 `json:"root"`
+		Canonical bool          `json:"canonical"`
 		Header    struct {
 			Message struct {
 				Slot          utils.Uinteger `json:"slot"`
@@ -117,24 +139,47 @@ type Validator struct {
 	Balance   utils.Uinteger `json:"balance"`
 	Status    string         `json:"status"`
 	Validator struct {
-		Pubkey                     utils.ByteArray `json:"pubkey"`
-		WithdrawalCredentials      utils.ByteArray `json:"withdrawal_credentials"`
-		EffectiveBalance           utils.Uinteger  `json:"effective_balance"`
-		Slashed                    bool            `json:"slashed"`
-		ActivationEligibilityEpoch utils.Uinteger  `json:"activation_eligibility_epoch"`
-		ActivationEpoch            utils.Uinteger  `json:"activation_epoch"`
-		ExitEpoch                  utils.Uinteger  `json:"exit_epoch"`
-		WithdrawableEpoch          utils.Uinteger  `json:"withdrawable_epoch"`
+		Pubkey                     utils.Bytes48  `json:"pubkey"`
+		WithdrawalCredentials      utils.Bytes32  `json:"withdrawal_credentials"`
+		EffectiveBalance           utils.Uinteger `json:"effective_balance"`
+		Slashed                    bool           `json:"slashed"`
+		ActivationEligibilityEpoch utils.Uinteger `json:"activation_eligibility_epoch"`
+		ActivationEpoch            utils.Uinteger `json:"activation_epoch"`
+		ExitEpoch                  utils.Uinteger `json:"exit_epoch"`
+		WithdrawableEpoch          utils.Uinteger `json:"withdrawable_epoch"`
 	} `json:"validator"`
 }
+type ValidatorBalancesResponse struct {
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Finalized           bool               `json:"finalized"`
+	Data                []ValidatorBalance `json:"data"`
+}
+type ValidatorBalance struct {
+	Index   string         `json:"index"`
+	Balance utils.Uinteger `json:"balance"`
+}
 type SyncDutiesResponse struct {
 	Data []SyncDuty `json:"data"`
 }
 type SyncDuty struct {
-	Pubkey               utils.ByteArray  `json:"pubkey"`
+	Pubkey               utils.Bytes48    `json:"pubkey"`
 	ValidatorIndex       string           `json:"validator_index"`
 	SyncCommitteeIndices []utils.Uinteger `json:"validator_sync_committee_indices"`
 }
+type AttesterDutiesResponse struct {
+	DependentRoot       utils.Bytes32  `json:"dependent_root"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	Data                []AttesterDuty `json:"data"`
+}
+type AttesterDuty struct {
+	Pubkey                  utils.Bytes48  `json:"pubkey"`
+	ValidatorIndex          string         `json:"validator_index"`
+	CommitteeIndex          utils.Uinteger `json:"committee_index"`
+	CommitteeLength         utils.Uinteger `json:"committee_length"`
+	CommitteesAtSlot        utils.Uinteger `json:"committees_at_slot"`
+	ValidatorCommitteeIndex utils.Uinteger `json:"validator_committee_index"`
+	Slot                    utils.Uinteger `json:"slot"`
+}
 type ProposerDutiesResponse struct {
 	Data []ProposerDuty `json:"data"`
 }
@@ -142,6 +187,53 @@ type ProposerDuty struct {
 	ValidatorIndex string `json:"validator_index"`
 }
 
+type BlockRewardsResponse struct {
+	ExecutionOptimistic bool         `json:"execution_optimistic"`
+	Finalized           bool         `json:"finalized"`
+	Data                BlockRewards `json:"data"`
+}
+type BlockRewards struct {
+	ProposerIndex     string         `json:"proposer_index"`
+	Total             utils.Uinteger `json:"total"`
+	Attestations      utils.Uinteger `json:"attestations"`
+	SyncAggregate     utils.Uinteger `json:"sync_aggregate"`
+	ProposerSlashings utils.Uinteger `json:"proposer_slashings"`
+	AttesterSlashings utils.Uinteger `json:"attester_slashings"`
+}
+type AttestationRewardsResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                struct {
+		IdealRewards []IdealAttestationRewards `json:"ideal_rewards"`
+		TotalRewards []TotalAttestationRewards `json:"total_rewards"`
+	} `json:"data"`
+}
+type IdealAttestationRewards struct {
+	EffectiveBalance utils.Uinteger  `json:"effective_balance"`
+	Head             utils.Uinteger  `json:"head"`
+	Target           utils.Uinteger  `json:"target"`
+	Source           utils.Uinteger  `json:"source"`
+	InclusionDelay   *utils.Uinteger `json:"inclusion_delay,omitempty"`
+	Inactivity       utils.Uinteger  `json:"inactivity"`
+}
+type TotalAttestationRewards struct {
+	ValidatorIndex string              `json:"validator_index"`
+	Head           utils.SignedInteger `json:"head"`
+	Target         utils.SignedInteger `json:"target"`
+	Source         utils.SignedInteger `json:"source"`
+	InclusionDelay *utils.Uinteger     `json:"inclusion_delay,omitempty"`
+	Inactivity     utils.SignedInteger `json:"inactivity"`
+}
+type SyncCommitteeRewardsResponse struct {
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+	Finalized           bool                  `json:"finalized"`
+	Data                []SyncCommitteeReward `json:"data"`
+}
+type SyncCommitteeReward struct {
+	ValidatorIndex string              `json:"validator_index"`
+	Reward         utils.SignedInteger `json:"reward"`
+}
+
 type CommitteesResponse struct {
 	Data []Committee `json:"data"`
 }
@@ -149,7 +241,21 @@ type CommitteesResponse struct {
 type Attestation struct {
 	AggregationBits string `json:"aggregation_bits"`
 	Data            struct {
-		Slot  utils.Uinteger `json:"slot"`
-		Index utils.Uinteger `json:"index"`
+		Slot            utils.Uinteger `json:"slot"`
+		Index           utils.Uinteger `json:"index"`
+		BeaconBlockRoot utils.Bytes32  `json:"beacon_block_root"`
+		Source          Checkpoint     `json:"source"`
+		Target          Checkpoint     `json:"target"`
 	} `json:"data"`
+	CommitteeBits string `json:"committee_bits,omitempty"`
+}
+type Withdrawal struct {
+	Index          utils.Uinteger  `json:"index"`
+	ValidatorIndex string          `json:"validator_index"`
+	Address        utils.ByteArray `json:"address"`
+	Amount         utils.Uinteger  `json:"amount"`
+}
+type Checkpoint struct {
+	Epoch utils.Uinteger `json:"epoch"`
+	Root  utils.Bytes32  `json:"root"`
 }
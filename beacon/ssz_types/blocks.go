@@ -0,0 +1,214 @@
+package ssz_types
+
+// ==============
+// === Common ===
+// ==============
+
+// Eth1 data voted on by a block's proposer
+type Eth1Data struct {
+	DepositRoot  [32]byte `json:"deposit_root" ssz-size:"32"`
+	DepositCount uint64   `json:"deposit_count"`
+	BlockHash    [32]byte `json:"block_hash" ssz-size:"32"`
+}
+
+// A checkpoint for Casper FFG
+type Checkpoint struct {
+	Epoch uint64   `json:"epoch"`
+	Root  [32]byte `json:"root" ssz-size:"32"`
+}
+
+// The data an attestation votes for
+type AttestationData struct {
+	Slot            uint64      `json:"slot"`
+	Index           uint64      `json:"index"`
+	BeaconBlockRoot [32]byte    `json:"beacon_block_root" ssz-size:"32"`
+	Source          *Checkpoint `json:"source"`
+	Target          *Checkpoint `json:"target"`
+}
+
+// Proof that a proposer signed two different blocks for the same slot
+type ProposerSlashing struct {
+	SignedHeader1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+	SignedHeader2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+}
+
+// A deposit included in a block, along with its proof against the deposit root
+type Deposit struct {
+	Proof [][]byte     `json:"proof" ssz-size:"33,32"`
+	Data  *DepositData `json:"data"`
+}
+
+// Signed voluntary exit message
+type SignedVoluntaryExit struct {
+	Message   *VoluntaryExit `json:"message"`
+	Signature [96]byte       `json:"signature" ssz-size:"96"`
+}
+
+// The sync committee's aggregate signature for the previous block
+type SyncAggregate struct {
+	SyncCommitteeBits      []byte   `json:"sync_committee_bits" ssz-size:"64"`
+	SyncCommitteeSignature [96]byte `json:"sync_committee_signature" ssz-size:"96"`
+}
+
+// The execution payload of a block, as of Deneb
+type ExecutionPayloadDeneb struct {
+	ParentHash    [32]byte      `json:"parent_hash" ssz-size:"32"`
+	FeeRecipient  [20]byte      `json:"fee_recipient" ssz-size:"20"`
+	StateRoot     [32]byte      `json:"state_root" ssz-size:"32"`
+	ReceiptsRoot  [32]byte      `json:"receipts_root" ssz-size:"32"`
+	LogsBloom     []byte        `json:"logs_bloom" ssz-size:"256"`
+	PrevRandao    [32]byte      `json:"prev_randao" ssz-size:"32"`
+	BlockNumber   uint64        `json:"block_number"`
+	GasLimit      uint64        `json:"gas_limit"`
+	GasUsed       uint64        `json:"gas_used"`
+	Timestamp     uint64        `json:"timestamp"`
+	ExtraData     []byte        `json:"extra_data" ssz-max:"32"`
+	BaseFeePerGas [32]byte      `json:"base_fee_per_gas" ssz-size:"32"`
+	BlockHash     [32]byte      `json:"block_hash" ssz-size:"32"`
+	Transactions  [][]byte      `json:"transactions" ssz-max:"1048576,1073741824" ssz-size:"?,?"`
+	Withdrawals   []*Withdrawal `json:"withdrawals" ssz-max:"16"`
+	BlobGasUsed   uint64        `json:"blob_gas_used"`
+	ExcessBlobGas uint64        `json:"excess_blob_gas"`
+}
+
+// =============
+// === Deneb ===
+// =============
+
+// An attestation, before Electra
+type Attestation struct {
+	AggregationBits []byte           `json:"aggregation_bits" ssz:"bitlist" ssz-max:"2048"`
+	Data            *AttestationData `json:"data"`
+	Signature       [96]byte         `json:"signature" ssz-size:"96"`
+}
+
+// An attestation with the indices of the validators that signed it, before Electra
+type IndexedAttestation struct {
+	AttestingIndices []uint64         `json:"attesting_indices" ssz-max:"2048"`
+	Data             *AttestationData `json:"data"`
+	Signature        [96]byte         `json:"signature" ssz-size:"96"`
+}
+
+// Proof that validators made conflicting attestations, before Electra
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation `json:"attestation_1"`
+	Attestation2 *IndexedAttestation `json:"attestation_2"`
+}
+
+// The body of a Deneb block
+type BeaconBlockBodyDeneb struct {
+	RandaoReveal          [96]byte                             `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data              *Eth1Data                            `json:"eth1_data"`
+	Graffiti              [32]byte                             `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings     []*ProposerSlashing                  `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing                  `json:"attester_slashings" ssz-max:"2"`
+	Attestations          []*Attestation                       `json:"attestations" ssz-max:"128"`
+	Deposits              []*Deposit                           `json:"deposits" ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit               `json:"voluntary_exits" ssz-max:"16"`
+	SyncAggregate         *SyncAggregate                       `json:"sync_aggregate"`
+	ExecutionPayload      *ExecutionPayloadDeneb               `json:"execution_payload"`
+	BlsToExecutionChanges []*SignedWithdrawalCredentialsChange `json:"bls_to_execution_changes" ssz-max:"16"`
+	BlobKzgCommitments    [][]byte                             `json:"blob_kzg_commitments" ssz-max:"4096" ssz-size:"?,48"`
+}
+
+// A Deneb block
+type BeaconBlockDeneb struct {
+	Slot          uint64                `json:"slot"`
+	ProposerIndex uint64                `json:"proposer_index"`
+	ParentRoot    [32]byte              `json:"parent_root" ssz-size:"32"`
+	StateRoot     [32]byte              `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyDeneb `json:"body"`
+}
+
+// A signed Deneb block
+type SignedBeaconBlockDeneb struct {
+	Message   *BeaconBlockDeneb `json:"message"`
+	Signature [96]byte          `json:"signature" ssz-size:"96"`
+}
+
+// ===============
+// === Electra ===
+// ===============
+
+// An attestation, as of Electra
+type AttestationElectra struct {
+	AggregationBits []byte           `json:"aggregation_bits" ssz:"bitlist" ssz-max:"131072"`
+	Data            *AttestationData `json:"data"`
+	Signature       [96]byte         `json:"signature" ssz-size:"96"`
+	CommitteeBits   []byte           `json:"committee_bits" ssz-size:"8"`
+}
+
+// An attestation with the indices of the validators that signed it, as of Electra
+type IndexedAttestationElectra struct {
+	AttestingIndices []uint64         `json:"attesting_indices" ssz-max:"131072"`
+	Data             *AttestationData `json:"data"`
+	Signature        [96]byte         `json:"signature" ssz-size:"96"`
+}
+
+// Proof that validators made conflicting attestations, as of Electra
+type AttesterSlashingElectra struct {
+	Attestation1 *IndexedAttestationElectra `json:"attestation_1"`
+	Attestation2 *IndexedAttestationElectra `json:"attestation_2"`
+}
+
+// A deposit requested by the execution layer
+type DepositRequest struct {
+	Pubkey                [48]byte `json:"pubkey" ssz-size:"48"`
+	WithdrawalCredentials [32]byte `json:"withdrawal_credentials" ssz-size:"32"`
+	Amount                uint64   `json:"amount"`
+	Signature             [96]byte `json:"signature" ssz-size:"96"`
+	Index                 uint64   `json:"index"`
+}
+
+// A withdrawal or exit requested by the execution layer
+type WithdrawalRequest struct {
+	SourceAddress   [20]byte `json:"source_address" ssz-size:"20"`
+	ValidatorPubkey [48]byte `json:"validator_pubkey" ssz-size:"48"`
+	Amount          uint64   `json:"amount"`
+}
+
+// A consolidation requested by the execution layer
+type ConsolidationRequest struct {
+	SourceAddress [20]byte `json:"source_address" ssz-size:"20"`
+	SourcePubkey  [48]byte `json:"source_pubkey" ssz-size:"48"`
+	TargetPubkey  [48]byte `json:"target_pubkey" ssz-size:"48"`
+}
+
+// The requests from the execution layer included in a block
+type ExecutionRequests struct {
+	Deposits       []*DepositRequest       `json:"deposits" ssz-max:"8192"`
+	Withdrawals    []*WithdrawalRequest    `json:"withdrawals" ssz-max:"16"`
+	Consolidations []*ConsolidationRequest `json:"consolidations" ssz-max:"2"`
+}
+
+// The body of an Electra block; Fulu blocks use the same layout
+type BeaconBlockBodyElectra struct {
+	RandaoReveal          [96]byte                             `json:"randao_reveal" ssz-size:"96"`
+	Eth1Data              *Eth1Data                            `json:"eth1_data"`
+	Graffiti              [32]byte                             `json:"graffiti" ssz-size:"32"`
+	ProposerSlashings     []*ProposerSlashing                  `json:"proposer_slashings" ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashingElectra           `json:"attester_slashings" ssz-max:"1"`
+	Attestations          []*AttestationElectra                `json:"attestations" ssz-max:"8"`
+	Deposits              []*Deposit                           `json:"deposits" ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit               `json:"voluntary_exits" ssz-max:"16"`
+	SyncAggregate         *SyncAggregate                       `json:"sync_aggregate"`
+	ExecutionPayload      *ExecutionPayloadDeneb               `json:"execution_payload"`
+	BlsToExecutionChanges []*SignedWithdrawalCredentialsChange `json:"bls_to_execution_changes" ssz-max:"16"`
+	BlobKzgCommitments    [][]byte                             `json:"blob_kzg_commitments" ssz-max:"4096" ssz-size:"?,48"`
+	ExecutionRequests     *ExecutionRequests                   `json:"execution_requests"`
+}
+
+// An Electra block
+type BeaconBlockElectra struct {
+	Slot          uint64                  `json:"slot"`
+	ProposerIndex uint64                  `json:"proposer_index"`
+	ParentRoot    [32]byte                `json:"parent_root" ssz-size:"32"`
+	StateRoot     [32]byte                `json:"state_root" ssz-size:"32"`
+	Body          *BeaconBlockBodyElectra `json:"body"`
+}
+
+// A signed Electra block
+type SignedBeaconBlockElectra struct {
+	Message   *BeaconBlockElectra `json:"message"`
+	Signature [96]byte            `json:"signature" ssz-size:"96"`
+}
@@ -0,0 +1,487 @@
+package ssz_types
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+	"sort"
+
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// Indices of the fields in a Beacon block header
+const (
+	BeaconBlockHeaderSlotFieldIndex uint64 = iota
+	BeaconBlockHeaderProposerIndexFieldIndex
+	BeaconBlockHeaderParentRootFieldIndex
+	BeaconBlockHeaderStateRootFieldIndex
+	BeaconBlockHeaderBodyRootFieldIndex
+
+	beaconBlockHeaderFieldCount
+)
+
+// Indices of the fields in a validator record
+const (
+	ValidatorPublicKeyFieldIndex uint64 = iota
+	ValidatorWithdrawalCredentialsFieldIndex
+	ValidatorEffectiveBalanceFieldIndex
+	ValidatorSlashedFieldIndex
+	ValidatorActivationEligibilityEpochFieldIndex
+	ValidatorActivationEpochFieldIndex
+	ValidatorExitEpochFieldIndex
+	ValidatorWithdrawableEpochFieldIndex
+
+	validatorFieldCount
+)
+
+// Indices of the fields in a withdrawal
+const (
+	WithdrawalIndexFieldIndex uint64 = iota
+	WithdrawalValidatorIndexFieldIndex
+	WithdrawalAddressFieldIndex
+	WithdrawalAmountFieldIndex
+
+	withdrawalFieldCount
+)
+
+const (
+	// The index of the validator registry in the Beacon state; it hasn't changed since genesis
+	BeaconStateValidatorsFieldIndex uint64 = 11
+
+	// The index of the validator balances in the Beacon state; it hasn't changed since genesis
+	BeaconStateBalancesFieldIndex uint64 = 12
+
+	// The number of fields in the Beacon state as of the Deneb fork
+	DenebBeaconStateFieldCount uint64 = 28
+
+	// The number of fields in the Beacon state as of the Electra fork
+	ElectraBeaconStateFieldCount uint64 = 37
+
+	// The size of a uint64 in a packed list, in bytes
+	uint64Size uint64 = 8
+
+	// The size of a Merkle tree chunk, in bytes
+	chunkSize uint64 = 32
+)
+
+// A Merkle proof of a single node in an SSZ tree
+type Proof struct {
+	// The generalized index of the proven node
+	Index uint64
+
+	// The value of the proven node
+	Leaf [32]byte
+
+	// The sibling hashes along the path from the node to the root, starting with the node's own sibling
+	Branch [][32]byte
+}
+
+// A Merkle proof of several nodes in the same SSZ tree
+type Multiproof struct {
+	// The generalized indices of the proven nodes
+	Indices []uint64
+
+	// The values of the proven nodes, in the same order as the indices
+	Leaves [][32]byte
+
+	// The additional hashes needed to compute the root, in decreasing order of generalized index
+	Hashes [][32]byte
+}
+
+// An SSZ object that can be represented as a Merkle tree
+type TreeProvider interface {
+	GetTree() (*ssz.Node, error)
+}
+
+// =========================
+// === Generalized Index ===
+// =========================
+
+// Get the depth of a generalized index in its tree; the root (index 1) has a depth of 0
+func GetGeneralizedIndexDepth(gindex uint64) int {
+	return bits.Len64(gindex) - 1
+}
+
+// Get the generalized index of a field in a container with the provided number of fields
+func GetContainerFieldGeneralizedIndex(fieldCount uint64, fieldIndex uint64) uint64 {
+	return nextPowerOfTwo(fieldCount) + fieldIndex
+}
+
+// Get the generalized index of an element in a list of containers (or other composite types) with the provided limit.
+// This accounts for the length mixed into the list's root.
+func GetListElementGeneralizedIndex(limit uint64, elementIndex uint64) uint64 {
+	return 2*nextPowerOfTwo(limit) + elementIndex
+}
+
+// Get the generalized index of the chunk holding an element in a list of packed uint64s (such as the validator
+// balances) with the provided limit. Each chunk holds 4 elements, so the element is at (index % 4) * 8 in the chunk.
+// This accounts for the length mixed into the list's root.
+func GetUint64ListChunkGeneralizedIndex(limit uint64, elementIndex uint64) uint64 {
+	chunkLimit := (limit*uint64Size + chunkSize - 1) / chunkSize
+	return 2*nextPowerOfTwo(chunkLimit) + elementIndex*uint64Size/chunkSize
+}
+
+// Combine generalized indices for nested objects into a single one, starting with the outermost object.
+// For example, combining the index of a validator in the state with the index of one of its fields gives the index
+// of that field in the state.
+func ConcatGeneralizedIndices(gindices ...uint64) uint64 {
+	result := uint64(1)
+	for _, gindex := range gindices {
+		depth := GetGeneralizedIndexDepth(gindex)
+		result = result<<depth | (gindex ^ (1 << depth))
+	}
+	return result
+}
+
+// Get the generalized index of a validator field in a Beacon state with the provided number of fields
+func GetStateValidatorFieldGeneralizedIndex(stateFieldCount uint64, validatorIndex uint64, fieldIndex uint64) uint64 {
+	return ConcatGeneralizedIndices(
+		GetContainerFieldGeneralizedIndex(stateFieldCount, BeaconStateValidatorsFieldIndex),
+		GetListElementGeneralizedIndex(ValidatorRegistryLimit, validatorIndex),
+		GetContainerFieldGeneralizedIndex(validatorFieldCount, fieldIndex),
+	)
+}
+
+// Get the generalized index of the chunk holding a validator's balance in a Beacon state with the provided number of fields
+func GetStateBalanceGeneralizedIndex(stateFieldCount uint64, validatorIndex uint64) uint64 {
+	return ConcatGeneralizedIndices(
+		GetContainerFieldGeneralizedIndex(stateFieldCount, BeaconStateBalancesFieldIndex),
+		GetUint64ListChunkGeneralizedIndex(ValidatorRegistryLimit, validatorIndex),
+	)
+}
+
+// Get the generalized index of the state root in a Beacon block header
+func GetBlockHeaderStateRootGeneralizedIndex() uint64 {
+	return GetContainerFieldGeneralizedIndex(beaconBlockHeaderFieldCount, BeaconBlockHeaderStateRootFieldIndex)
+}
+
+// Get the generalized index of a field in a validator record
+func GetValidatorFieldGeneralizedIndex(fieldIndex uint64) uint64 {
+	return GetContainerFieldGeneralizedIndex(validatorFieldCount, fieldIndex)
+}
+
+// Get the generalized index of a field in a withdrawal
+func GetWithdrawalFieldGeneralizedIndex(fieldIndex uint64) uint64 {
+	return GetContainerFieldGeneralizedIndex(withdrawalFieldCount, fieldIndex)
+}
+
+// =============
+// === Trees ===
+// =============
+
+// Get the Merkle tree of the validator registry, as stored in the Beacon state's validators field
+func GetValidatorListTree(validators []*Validator) (*ssz.Node, error) {
+	leaves := make([]*ssz.Node, len(validators))
+	for i, validator := range validators {
+		tree, err := validator.GetTree()
+		if err != nil {
+			return nil, fmt.Errorf("error getting tree for validator %d: %w", i, err)
+		}
+		leaves[i] = tree
+	}
+	return ssz.TreeFromNodesWithMixin(leaves, len(validators), int(nextPowerOfTwo(ValidatorRegistryLimit)))
+}
+
+// Get the Merkle tree of the validator balances, as stored in the Beacon state's balances field
+func GetBalanceListTree(balances []uint64) (*ssz.Node, error) {
+	chunkLimit := (ValidatorRegistryLimit*uint64Size + chunkSize - 1) / chunkSize
+	return ssz.TreeFromNodesWithMixin(ssz.LeavesFromUint64(balances), len(balances), int(nextPowerOfTwo(chunkLimit)))
+}
+
+// Get the Merkle tree of an execution payload's withdrawals
+func GetWithdrawalListTree(withdrawals []*Withdrawal) (*ssz.Node, error) {
+	leaves := make([]*ssz.Node, len(withdrawals))
+	for i, withdrawal := range withdrawals {
+		tree, err := withdrawal.GetTree()
+		if err != nil {
+			return nil, fmt.Errorf("error getting tree for withdrawal %d: %w", i, err)
+		}
+		leaves[i] = tree
+	}
+	return ssz.TreeFromNodesWithMixin(leaves, len(withdrawals), int(nextPowerOfTwo(MaxWithdrawalsPerPayload)))
+}
+
+// ==============
+// === Proofs ===
+// ==============
+
+// Generate a proof of the node at the provided generalized index in an SSZ object
+func GenerateProof(object TreeProvider, gindex uint64) (*Proof, error) {
+	tree, err := object.GetTree()
+	if err != nil {
+		return nil, fmt.Errorf("error getting tree: %w", err)
+	}
+	return GenerateProofFromTree(tree, gindex)
+}
+
+// Generate a proof of the node at the provided generalized index in a Merkle tree
+func GenerateProofFromTree(tree *ssz.Node, gindex uint64) (*Proof, error) {
+	if gindex == 0 {
+		return nil, fmt.Errorf("generalized index must be at least 1")
+	}
+	depth := GetGeneralizedIndexDepth(gindex)
+	branch := make([][32]byte, depth)
+	current := tree
+	for level := depth - 1; level >= 0; level-- {
+		isRight := gindex&(1<<level) != 0
+		next, err := getChild(current, isRight)
+		if err != nil {
+			return nil, fmt.Errorf("error getting node on the path to index %d: %w", gindex, err)
+		}
+		sibling, err := getChild(current, !isRight)
+		if err != nil {
+			return nil, fmt.Errorf("error getting sibling on the path to index %d: %w", gindex, err)
+		}
+		branch[level] = toChunk(sibling.Hash())
+		current = next
+	}
+
+	return &Proof{
+		Index:  gindex,
+		Leaf:   toChunk(current.Hash()),
+		Branch: branch,
+	}, nil
+}
+
+// Generate a proof of the nodes at the provided generalized indices in an SSZ object
+func GenerateMultiproof(object TreeProvider, gindices []uint64) (*Multiproof, error) {
+	tree, err := object.GetTree()
+	if err != nil {
+		return nil, fmt.Errorf("error getting tree: %w", err)
+	}
+	return GenerateMultiproofFromTree(tree, gindices)
+}
+
+// Generate a proof of the nodes at the provided generalized indices in a Merkle tree
+func GenerateMultiproofFromTree(tree *ssz.Node, gindices []uint64) (*Multiproof, error) {
+	if len(gindices) == 0 {
+		return nil, fmt.Errorf("at least one generalized index is required")
+	}
+	leaves := make([][32]byte, len(gindices))
+	for i, gindex := range gindices {
+		node, err := getNode(tree, gindex)
+		if err != nil {
+			return nil, fmt.Errorf("error getting node at index %d: %w", gindex, err)
+		}
+		leaves[i] = toChunk(node.Hash())
+	}
+
+	helperIndices := getHelperIndices(gindices)
+	hashes := make([][32]byte, len(helperIndices))
+	for i, gindex := range helperIndices {
+		node, err := getNode(tree, gindex)
+		if err != nil {
+			return nil, fmt.Errorf("error getting node at index %d: %w", gindex, err)
+		}
+		hashes[i] = toChunk(node.Hash())
+	}
+
+	return &Multiproof{
+		Indices: gindices,
+		Leaves:  leaves,
+		Hashes:  hashes,
+	}, nil
+}
+
+// Combine proofs of nested objects into a single proof, starting with the outermost one. Each proof's leaf must be
+// the root of the next one; for example, a proof of a validator's root against the state root can be combined with a
+// proof of the validator's withdrawal credentials against its root.
+func CombineProofs(proofs ...*Proof) (*Proof, error) {
+	if len(proofs) == 0 {
+		return nil, fmt.Errorf("at least one proof is required")
+	}
+
+	gindices := make([]uint64, len(proofs))
+	branch := [][32]byte{}
+	for i := len(proofs) - 1; i >= 0; i-- {
+		proof := proofs[i]
+		if i > 0 {
+			outer := proofs[i-1]
+			root, err := proof.ComputeRoot()
+			if err != nil {
+				return nil, fmt.Errorf("error computing root of proof %d: %w", i, err)
+			}
+			if root != outer.Leaf {
+				return nil, fmt.Errorf("root of proof %d does not match the leaf of proof %d", i, i-1)
+			}
+		}
+		gindices[i] = proof.Index
+		branch = append(branch, proof.Branch...)
+	}
+
+	combinedIndex := ConcatGeneralizedIndices(gindices...)
+	if GetGeneralizedIndexDepth(combinedIndex) != len(branch) {
+		return nil, fmt.Errorf("combined proof is too deep for a 64-bit generalized index")
+	}
+	return &Proof{
+		Index:  combinedIndex,
+		Leaf:   proofs[len(proofs)-1].Leaf,
+		Branch: branch,
+	}, nil
+}
+
+// Compute the root of the tree the proof was generated from
+func (p *Proof) ComputeRoot() ([32]byte, error) {
+	if p.Index == 0 {
+		return [32]byte{}, fmt.Errorf("generalized index must be at least 1")
+	}
+	if len(p.Branch) != GetGeneralizedIndexDepth(p.Index) {
+		return [32]byte{}, fmt.Errorf("branch length %d does not match the depth of index %d", len(p.Branch), p.Index)
+	}
+	node := p.Leaf
+	for level, sibling := range p.Branch {
+		if p.Index&(1<<level) != 0 {
+			node = hashPair(sibling, node)
+		} else {
+			node = hashPair(node, sibling)
+		}
+	}
+	return node, nil
+}
+
+// Check if the proof is valid for the provided root
+func VerifyProof(root [32]byte, proof *Proof) (bool, error) {
+	computed, err := proof.ComputeRoot()
+	if err != nil {
+		return false, err
+	}
+	return computed == root, nil
+}
+
+// Check if the multiproof is valid for the provided root
+func VerifyMultiproof(root [32]byte, proof *Multiproof) (bool, error) {
+	if len(proof.Indices) == 0 {
+		return false, fmt.Errorf("at least one generalized index is required")
+	}
+	if len(proof.Indices) != len(proof.Leaves) {
+		return false, fmt.Errorf("proof has %d indices but %d leaves", len(proof.Indices), len(proof.Leaves))
+	}
+	helperIndices := getHelperIndices(proof.Indices)
+	if len(helperIndices) != len(proof.Hashes) {
+		return false, fmt.Errorf("proof has %d hashes but %d are required", len(proof.Hashes), len(helperIndices))
+	}
+
+	// Build the known nodes, then hash them up to the root from the deepest one
+	nodes := map[uint64][32]byte{}
+	for i, gindex := range proof.Indices {
+		if gindex == 0 {
+			return false, fmt.Errorf("generalized index must be at least 1")
+		}
+		nodes[gindex] = proof.Leaves[i]
+	}
+	for i, gindex := range helperIndices {
+		nodes[gindex] = proof.Hashes[i]
+	}
+	pending := make([]uint64, 0, len(nodes))
+	for gindex := range nodes {
+		pending = append(pending, gindex)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i] > pending[j]
+	})
+
+	for i := 0; i < len(pending); i++ {
+		gindex := pending[i]
+		if gindex == 1 {
+			continue
+		}
+		parent := gindex / 2
+		if _, exists := nodes[parent]; exists {
+			continue
+		}
+		left, leftExists := nodes[gindex&^1]
+		right, rightExists := nodes[gindex|1]
+		if !leftExists || !rightExists {
+			continue
+		}
+		nodes[parent] = hashPair(left, right)
+		pending = insertDescending(pending, i+1, parent)
+	}
+
+	computed, exists := nodes[1]
+	if !exists {
+		return false, fmt.Errorf("proof does not contain enough nodes to compute the root")
+	}
+	return computed == root, nil
+}
+
+// Get the generalized indices of the sibling nodes needed to prove the provided nodes, in decreasing order
+func getHelperIndices(gindices []uint64) []uint64 {
+	required := map[uint64]bool{}
+	computed := map[uint64]bool{}
+	for _, gindex := range gindices {
+		computed[gindex] = true
+		for current := gindex; current > 1; current /= 2 {
+			required[current^1] = true
+			computed[current/2] = true
+		}
+	}
+
+	helpers := []uint64{}
+	for gindex := range required {
+		if !computed[gindex] {
+			helpers = append(helpers, gindex)
+		}
+	}
+	sort.Slice(helpers, func(i, j int) bool {
+		return helpers[i] > helpers[j]
+	})
+	return helpers
+}
+
+// Get the node at the provided generalized index in a Merkle tree
+func getNode(tree *ssz.Node, gindex uint64) (*ssz.Node, error) {
+	if gindex == 0 {
+		return nil, fmt.Errorf("generalized index must be at least 1")
+	}
+	current := tree
+	for level := GetGeneralizedIndexDepth(gindex) - 1; level >= 0; level-- {
+		var err error
+		current, err = getChild(current, gindex&(1<<level) != 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return current, nil
+}
+
+// Get the left or right child of a node
+func getChild(node *ssz.Node, isRight bool) (*ssz.Node, error) {
+	// Generalized index 2 is the left child of the root and 3 is the right child
+	if isRight {
+		return node.Get(3)
+	}
+	return node.Get(2)
+}
+
+// Insert a generalized index into a list sorted in decreasing order, starting the search at the provided position
+func insertDescending(list []uint64, start int, gindex uint64) []uint64 {
+	position := start
+	for position < len(list) && list[position] > gindex {
+		position++
+	}
+	list = append(list, 0)
+	copy(list[position+1:], list[position:])
+	list[position] = gindex
+	return list
+}
+
+// Hash two sibling nodes into their parent
+func hashPair(left [32]byte, right [32]byte) [32]byte {
+	return sha256.Sum256(append(left[:], right[:]...))
+}
+
+// Convert a node's hash into a fixed-size chunk
+func toChunk(hash []byte) [32]byte {
+	var chunk [32]byte
+	copy(chunk[:], hash)
+	return chunk
+}
+
+// Get the smallest power of two that's at least the provided value
+func nextPowerOfTwo(value uint64) uint64 {
+	if value <= 1 {
+		return 1
+	}
+	return 1 << bits.Len64(value-1)
+}
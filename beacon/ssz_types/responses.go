@@ -0,0 +1,27 @@
+package ssz_types
+
+// A validator in the validators response, with its status encoded as its position in the Beacon API's list of
+// validator statuses (pending_initialized is 0, withdrawal_done is 8)
+type ValidatorResponse struct {
+	Index     uint64     `json:"index"`
+	Balance   uint64     `json:"balance"`
+	Status    uint8      `json:"status"`
+	Validator *Validator `json:"validator"`
+}
+
+// The validators response
+type ValidatorsResponse struct {
+	Data []*ValidatorResponse `json:"data" ssz-max:"1099511627776"`
+}
+
+// A committee in the committees response
+type Committee struct {
+	Index      uint64   `json:"index"`
+	Slot       uint64   `json:"slot"`
+	Validators []uint64 `json:"validators" ssz-max:"2048"`
+}
+
+// The committees response
+type CommitteesResponse struct {
+	Data []*Committee `json:"data" ssz-max:"2048"`
+}
@@ -0,0 +1,469 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: e8a3fcd2b9ce7355f7b924f8ee98cc0f406421fcc1b0e52794b8cee62d2e4ba7
+// Version: 0.1.3
+package ssz_types
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// MarshalSSZ ssz marshals the ValidatorResponse object
+func (v *ValidatorResponse) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(v)
+}
+
+// MarshalSSZTo ssz marshals the ValidatorResponse object to a target array
+func (v *ValidatorResponse) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Index'
+	dst = ssz.MarshalUint64(dst, v.Index)
+
+	// Field (1) 'Balance'
+	dst = ssz.MarshalUint64(dst, v.Balance)
+
+	// Field (2) 'Status'
+	dst = ssz.MarshalUint8(dst, v.Status)
+
+	// Field (3) 'Validator'
+	if v.Validator == nil {
+		v.Validator = new(Validator)
+	}
+	if dst, err = v.Validator.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ValidatorResponse object
+func (v *ValidatorResponse) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 138 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Index'
+	v.Index = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Balance'
+	v.Balance = ssz.UnmarshallUint64(buf[8:16])
+
+	// Field (2) 'Status'
+	v.Status = ssz.UnmarshallUint8(buf[16:17])
+
+	// Field (3) 'Validator'
+	if v.Validator == nil {
+		v.Validator = new(Validator)
+	}
+	if err = v.Validator.UnmarshalSSZ(buf[17:138]); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ValidatorResponse object
+func (v *ValidatorResponse) SizeSSZ() (size int) {
+	size = 138
+	return
+}
+
+// HashTreeRoot ssz hashes the ValidatorResponse object
+func (v *ValidatorResponse) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(v)
+}
+
+// HashTreeRootWith ssz hashes the ValidatorResponse object with a hasher
+func (v *ValidatorResponse) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Index'
+	hh.PutUint64(v.Index)
+
+	// Field (1) 'Balance'
+	hh.PutUint64(v.Balance)
+
+	// Field (2) 'Status'
+	hh.PutUint8(v.Status)
+
+	// Field (3) 'Validator'
+	if v.Validator == nil {
+		v.Validator = new(Validator)
+	}
+	if err = v.Validator.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the ValidatorResponse object
+func (v *ValidatorResponse) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(v)
+}
+
+// MarshalSSZ ssz marshals the ValidatorsResponse object
+func (v *ValidatorsResponse) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(v)
+}
+
+// MarshalSSZTo ssz marshals the ValidatorsResponse object to a target array
+func (v *ValidatorsResponse) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(v.Data) * 138
+
+	// Field (0) 'Data'
+	if size := len(v.Data); size > 1099511627776 {
+		err = ssz.ErrListTooBigFn("ValidatorsResponse.Data", size, 1099511627776)
+		return
+	}
+	for ii := 0; ii < len(v.Data); ii++ {
+		if dst, err = v.Data[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ValidatorsResponse object
+func (v *ValidatorsResponse) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Data'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Data'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DivideInt2(len(buf), 138, 1099511627776)
+		if err != nil {
+			return err
+		}
+		v.Data = make([]*ValidatorResponse, num)
+		for ii := 0; ii < num; ii++ {
+			if v.Data[ii] == nil {
+				v.Data[ii] = new(ValidatorResponse)
+			}
+			if err = v.Data[ii].UnmarshalSSZ(buf[ii*138 : (ii+1)*138]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ValidatorsResponse object
+func (v *ValidatorsResponse) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Data'
+	size += len(v.Data) * 138
+
+	return
+}
+
+// HashTreeRoot ssz hashes the ValidatorsResponse object
+func (v *ValidatorsResponse) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(v)
+}
+
+// HashTreeRootWith ssz hashes the ValidatorsResponse object with a hasher
+func (v *ValidatorsResponse) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Data'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(v.Data))
+		if num > 1099511627776 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range v.Data {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 1099511627776)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the ValidatorsResponse object
+func (v *ValidatorsResponse) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(v)
+}
+
+// MarshalSSZ ssz marshals the Committee object
+func (c *Committee) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(c)
+}
+
+// MarshalSSZTo ssz marshals the Committee object to a target array
+func (c *Committee) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(20)
+
+	// Field (0) 'Index'
+	dst = ssz.MarshalUint64(dst, c.Index)
+
+	// Field (1) 'Slot'
+	dst = ssz.MarshalUint64(dst, c.Slot)
+
+	// Offset (2) 'Validators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(c.Validators) * 8
+
+	// Field (2) 'Validators'
+	if size := len(c.Validators); size > 2048 {
+		err = ssz.ErrListTooBigFn("Committee.Validators", size, 2048)
+		return
+	}
+	for ii := 0; ii < len(c.Validators); ii++ {
+		dst = ssz.MarshalUint64(dst, c.Validators[ii])
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Committee object
+func (c *Committee) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 20 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o2 uint64
+
+	// Field (0) 'Index'
+	c.Index = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Slot'
+	c.Slot = ssz.UnmarshallUint64(buf[8:16])
+
+	// Offset (2) 'Validators'
+	if o2 = ssz.ReadOffset(buf[16:20]); o2 > size {
+		return ssz.ErrOffset
+	}
+
+	if o2 < 20 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'Validators'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 8, 2048)
+		if err != nil {
+			return err
+		}
+		c.Validators = ssz.ExtendUint64(c.Validators, num)
+		for ii := 0; ii < num; ii++ {
+			c.Validators[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the Committee object
+func (c *Committee) SizeSSZ() (size int) {
+	size = 20
+
+	// Field (2) 'Validators'
+	size += len(c.Validators) * 8
+
+	return
+}
+
+// HashTreeRoot ssz hashes the Committee object
+func (c *Committee) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(c)
+}
+
+// HashTreeRootWith ssz hashes the Committee object with a hasher
+func (c *Committee) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Index'
+	hh.PutUint64(c.Index)
+
+	// Field (1) 'Slot'
+	hh.PutUint64(c.Slot)
+
+	// Field (2) 'Validators'
+	{
+		if size := len(c.Validators); size > 2048 {
+			err = ssz.ErrListTooBigFn("Committee.Validators", size, 2048)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range c.Validators {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+		numItems := uint64(len(c.Validators))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(2048, numItems, 8))
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the Committee object
+func (c *Committee) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(c)
+}
+
+// MarshalSSZ ssz marshals the CommitteesResponse object
+func (c *CommitteesResponse) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(c)
+}
+
+// MarshalSSZTo ssz marshals the CommitteesResponse object to a target array
+func (c *CommitteesResponse) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(c.Data); ii++ {
+		offset += 4
+		offset += c.Data[ii].SizeSSZ()
+	}
+
+	// Field (0) 'Data'
+	if size := len(c.Data); size > 2048 {
+		err = ssz.ErrListTooBigFn("CommitteesResponse.Data", size, 2048)
+		return
+	}
+	{
+		offset = 4 * len(c.Data)
+		for ii := 0; ii < len(c.Data); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += c.Data[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(c.Data); ii++ {
+		if dst, err = c.Data[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the CommitteesResponse object
+func (c *CommitteesResponse) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Data'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Data'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DecodeDynamicLength(buf, 2048)
+		if err != nil {
+			return err
+		}
+		c.Data = make([]*Committee, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if c.Data[indx] == nil {
+				c.Data[indx] = new(Committee)
+			}
+			if err = c.Data[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the CommitteesResponse object
+func (c *CommitteesResponse) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Data'
+	for ii := 0; ii < len(c.Data); ii++ {
+		size += 4
+		size += c.Data[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the CommitteesResponse object
+func (c *CommitteesResponse) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(c)
+}
+
+// HashTreeRootWith ssz hashes the CommitteesResponse object with a hasher
+func (c *CommitteesResponse) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Data'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(c.Data))
+		if num > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range c.Data {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 2048)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the CommitteesResponse object
+func (c *CommitteesResponse) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(c)
+}
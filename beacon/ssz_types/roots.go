@@ -0,0 +1,76 @@
+package ssz_types
+
+import (
+	"fmt"
+
+	ssz "github.com/ferranbt/fastssz"
+)
+
+const (
+	// The maximum number of validators in the Beacon state's registry (VALIDATOR_REGISTRY_LIMIT)
+	ValidatorRegistryLimit uint64 = 1 << 40
+
+	// The maximum number of withdrawals in an execution payload (MAX_WITHDRAWALS_PER_PAYLOAD)
+	MaxWithdrawalsPerPayload uint64 = 16
+)
+
+// Get the hash tree root of a Beacon block header, which is the root of the block it belongs to
+func GetBlockRoot(header *BeaconBlockHeader) ([32]byte, error) {
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("error hashing block header: %w", err)
+	}
+	return root, nil
+}
+
+// Get the hash tree root of the validator registry, as stored in the Beacon state's validators field
+func GetValidatorListRoot(validators []*Validator) ([32]byte, error) {
+	if uint64(len(validators)) > ValidatorRegistryLimit {
+		return [32]byte{}, fmt.Errorf("validator list has %d entries but the limit is %d", len(validators), ValidatorRegistryLimit)
+	}
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	indx := hh.Index()
+	for i, validator := range validators {
+		err := validator.HashTreeRootWith(hh)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("error hashing validator %d: %w", i, err)
+		}
+	}
+	num := uint64(len(validators))
+	hh.MerkleizeWithMixin(indx, num, ssz.CalculateLimit(ValidatorRegistryLimit, num, 32))
+	return hh.HashRoot()
+}
+
+// Get the hash tree root of the validator balances, as stored in the Beacon state's balances field
+func GetBalanceListRoot(balances []uint64) ([32]byte, error) {
+	if uint64(len(balances)) > ValidatorRegistryLimit {
+		return [32]byte{}, fmt.Errorf("balance list has %d entries but the limit is %d", len(balances), ValidatorRegistryLimit)
+	}
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	hh.PutUint64Array(balances, ValidatorRegistryLimit)
+	return hh.HashRoot()
+}
+
+// Get the hash tree root of an execution payload's withdrawals
+func GetWithdrawalListRoot(withdrawals []*Withdrawal) ([32]byte, error) {
+	if uint64(len(withdrawals)) > MaxWithdrawalsPerPayload {
+		return [32]byte{}, fmt.Errorf("withdrawal list has %d entries but the limit is %d", len(withdrawals), MaxWithdrawalsPerPayload)
+	}
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	indx := hh.Index()
+	for i, withdrawal := range withdrawals {
+		err := withdrawal.HashTreeRootWith(hh)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("error hashing withdrawal %d: %w", i, err)
+		}
+	}
+	num := uint64(len(withdrawals))
+	hh.MerkleizeWithMixin(indx, num, ssz.CalculateLimit(MaxWithdrawalsPerPayload, num, 32))
+	return hh.HashRoot()
+}
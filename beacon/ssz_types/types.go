@@ -33,3 +33,44 @@ type WithdrawalCredentialsChange struct {
 	FromBLSPubkey      [48]byte `json:"from_bls_pubkey" ssz-size:"48"`
 	ToExecutionAddress [20]byte `json:"to_execution_address" ssz-size:"20"`
 }
+
+// Signed withdrawal creds change message
+type SignedWithdrawalCredentialsChange struct {
+	Message   *WithdrawalCredentialsChange `json:"message"`
+	Signature [96]byte                     `json:"signature" ssz-size:"96"`
+}
+
+// Beacon block header
+type BeaconBlockHeader struct {
+	Slot          uint64   `json:"slot"`
+	ProposerIndex uint64   `json:"proposer_index"`
+	ParentRoot    [32]byte `json:"parent_root" ssz-size:"32"`
+	StateRoot     [32]byte `json:"state_root" ssz-size:"32"`
+	BodyRoot      [32]byte `json:"body_root" ssz-size:"32"`
+}
+
+// Signed beacon block header
+type SignedBeaconBlockHeader struct {
+	Message   *BeaconBlockHeader `json:"message"`
+	Signature [96]byte           `json:"signature" ssz-size:"96"`
+}
+
+// Validator record in the Beacon state's registry
+type Validator struct {
+	PublicKey                  [48]byte `json:"pubkey" ssz-size:"48"`
+	WithdrawalCredentials      [32]byte `json:"withdrawal_credentials" ssz-size:"32"`
+	EffectiveBalance           uint64   `json:"effective_balance"`
+	Slashed                    bool     `json:"slashed"`
+	ActivationEligibilityEpoch uint64   `json:"activation_eligibility_epoch"`
+	ActivationEpoch            uint64   `json:"activation_epoch"`
+	ExitEpoch                  uint64   `json:"exit_epoch"`
+	WithdrawableEpoch          uint64   `json:"withdrawable_epoch"`
+}
+
+// Withdrawal from the Beacon chain to the execution layer, included in an execution payload
+type Withdrawal struct {
+	Index          uint64   `json:"index"`
+	ValidatorIndex uint64   `json:"validator_index"`
+	Address        [20]byte `json:"address" ssz-size:"20"`
+	Amount         uint64   `json:"amount"`
+}
@@ -13,8 +13,10 @@ type ValidatorStatusOptions struct {
 
 // API response types
 type SyncStatus struct {
-	Syncing  bool
-	Progress float64
+	Syncing      bool
+	Progress     float64
+	HeadSlot     uint64
+	SyncDistance uint64
 }
 type Eth2Config struct {
 	GenesisForkVersion           []byte
@@ -61,10 +63,12 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	Withdrawals          []WithdrawalInfo
 }
 type BeaconBlockHeader struct {
 	Slot          uint64
 	ProposerIndex string
+	Root          common.Hash // Only provided by GetBeaconBlockHeader
 }
 
 // Committees is an interface as an optimization- since committees responses
@@ -97,6 +101,20 @@ type AttestationInfo struct {
 	AggregationBits bitfield.Bitlist
 	SlotIndex       uint64
 	CommitteeIndex  uint64
+	BeaconBlockRoot common.Hash
+	SourceEpoch     uint64
+	TargetEpoch     uint64
+
+	// The committees the aggregation bits cover, in order, as of Electra; nil for earlier forks, where the attestation
+	// covers only the committee at CommitteeIndex
+	CommitteeBits bitfield.Bitvector64
+}
+
+type WithdrawalInfo struct {
+	Index          uint64
+	ValidatorIndex string
+	Address        common.Address
+	Amount         uint64 // In gwei
 }
 
 type ValidatorState string
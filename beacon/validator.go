@@ -5,6 +5,7 @@ import (
 
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
+	"github.com/rocket-pool/node-manager-core/utils"
 )
 
 // Encrypted validator keystore following the EIP-2335 standard
@@ -45,7 +46,7 @@ func (b *ByteArray) UnmarshalJSON(data []byte) error {
 	}
 
 	// Decode hex
-	value, err := hex.DecodeString(dataStr)
+	value, err := utils.DecodeHex(dataStr)
 	if err != nil {
 		return err
 	}
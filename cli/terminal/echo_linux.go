@@ -0,0 +1,9 @@
+package terminal
+
+import "golang.org/x/sys/unix"
+
+const (
+	// The ioctl requests for getting and setting a terminal's attributes
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// Turn off echoing for a terminal so typed input isn't shown; this is only supported on Linux and BSD-based systems
+func disableEcho(file *os.File) (func() error, error) {
+	return nil, fmt.Errorf("hiding terminal input isn't supported on %s", runtime.GOOS)
+}
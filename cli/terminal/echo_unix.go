@@ -0,0 +1,35 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package terminal
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Turn off echoing for a terminal so typed input isn't shown, returning a function that turns it back on
+func disableEcho(file *os.File) (func() error, error) {
+	fd := int(file.Fd())
+	original, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		if errors.Is(err, unix.ENOTTY) || errors.Is(err, unix.EINVAL) {
+			return nil, errNotTerminal
+		}
+		return nil, err
+	}
+
+	// Keep echoing newlines so the cursor still moves to the next line when the user presses enter
+	hidden := *original
+	hidden.Lflag &^= unix.ECHO
+	hidden.Lflag |= unix.ICANON | unix.ISIG
+	hidden.Iflag |= unix.ICRNL
+	err = unix.IoctlSetTermios(fd, ioctlSetTermios, &hidden)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		return unix.IoctlSetTermios(fd, ioctlSetTermios, original)
+	}, nil
+}
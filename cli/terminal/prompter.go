@@ -0,0 +1,191 @@
+package terminal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	// The prompt's input ended before the user answered
+	ErrNoInput = errors.New("no input was provided")
+
+	// A prompt needed the user but the prompter is non-interactive
+	ErrNonInteractive = errors.New("input is required but the CLI is running non-interactively")
+
+	// The input isn't a terminal, so it can't be hidden
+	errNotTerminal = errors.New("input is not a terminal")
+)
+
+// Asks the user questions in the terminal. CLIs built on NMC use this for their prompts so confirmations, passwords,
+// and gas selection look and behave the same everywhere.
+type Prompter struct {
+	in          *bufio.Reader
+	inFile      *os.File
+	out         io.Writer
+	autoConfirm bool
+}
+
+// Creates a new prompter that reads from the input and writes to the output. Hidden input only works if the input is a
+// terminal file such as os.Stdin.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	inFile, _ := in.(*os.File)
+	return &Prompter{
+		in:     bufio.NewReader(in),
+		inFile: inFile,
+		out:    out,
+	}
+}
+
+// Creates a new prompter that uses the process's standard input and output
+func NewStdPrompter() *Prompter {
+	return NewPrompter(os.Stdin, os.Stdout)
+}
+
+// Answer yes to every confirmation without asking, such as when the CLI is run with a --yes flag. Prompts that need a
+// value from the user fail with ErrNonInteractive instead of waiting for input.
+func (p *Prompter) SetAutoConfirm(autoConfirm bool) {
+	p.autoConfirm = autoConfirm
+}
+
+// Print a line to the prompter's output
+func (p *Prompter) Println(message string) {
+	fmt.Fprintln(p.out, message)
+}
+
+// Ask the user for a value, asking again until the validator accepts it. The validator can be nil.
+func (p *Prompter) Prompt(message string, validate func(string) error) (string, error) {
+	if p.autoConfirm {
+		return "", ErrNonInteractive
+	}
+	for {
+		fmt.Fprint(p.out, message+" ")
+		value, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimSpace(value)
+		if validate != nil {
+			err = validate(value)
+			if err != nil {
+				fmt.Fprintf(p.out, "Invalid value: %s\n", err.Error())
+				continue
+			}
+		}
+		return value, nil
+	}
+}
+
+// Ask the user a yes or no question, asking again until they answer with one
+func (p *Prompter) Confirm(message string) (bool, error) {
+	if p.autoConfirm {
+		return true, nil
+	}
+	for {
+		fmt.Fprint(p.out, message+" [y/n] ")
+		value, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "Please answer 'y' or 'n'.")
+	}
+}
+
+// Ask the user for a password without showing what they type. If the input isn't a terminal, such as when a password
+// is piped in, it's read as a normal line.
+func (p *Prompter) PromptPassword(message string) (string, error) {
+	if p.autoConfirm {
+		return "", ErrNonInteractive
+	}
+	fmt.Fprint(p.out, message+" ")
+	if p.inFile == nil {
+		return p.readLine()
+	}
+
+	restore, err := disableEcho(p.inFile)
+	if err != nil {
+		if errors.Is(err, errNotTerminal) {
+			return p.readLine()
+		}
+		return "", fmt.Errorf("error hiding password input: %w", err)
+	}
+	password, err := p.readLine()
+	restoreErr := restore()
+	fmt.Fprintln(p.out)
+	if err != nil {
+		return "", err
+	}
+	if restoreErr != nil {
+		return "", fmt.Errorf("error restoring terminal after password input: %w", restoreErr)
+	}
+	return password, nil
+}
+
+// Ask the user for a new password twice, asking again until it's long enough and both entries match
+func (p *Prompter) PromptNewPassword(message string, minLength int) (string, error) {
+	for {
+		password, err := p.PromptPassword(message)
+		if err != nil {
+			return "", err
+		}
+		if len(password) < minLength {
+			fmt.Fprintf(p.out, "The password must be at least %d characters long.\n", minLength)
+			continue
+		}
+		confirmation, err := p.PromptPassword("Please enter it again to confirm:")
+		if err != nil {
+			return "", err
+		}
+		if password != confirmation {
+			fmt.Fprintln(p.out, "The passwords don't match, please try again.")
+			continue
+		}
+		return password, nil
+	}
+}
+
+// Ask the user to pick one of the options, returning its index. The options are listed starting from 1.
+func (p *Prompter) Select(message string, options []string) (int, error) {
+	if len(options) == 0 {
+		return 0, fmt.Errorf("there are no options to select from")
+	}
+	fmt.Fprintln(p.out, message)
+	for i, option := range options {
+		fmt.Fprintf(p.out, "%d: %s\n", i+1, option)
+	}
+	var selection int
+	_, err := p.Prompt(fmt.Sprintf("Enter a number from 1 to %d:", len(options)), func(value string) error {
+		var err error
+		selection, err = strconv.Atoi(value)
+		if err != nil || selection < 1 || selection > len(options) {
+			return fmt.Errorf("'%s' is not a number from 1 to %d", value, len(options))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return selection - 1, nil
+}
+
+// Read a line of input without its line ending. Other whitespace is kept so passwords can include it.
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		if errors.Is(err, io.EOF) {
+			return "", ErrNoInput
+		}
+		return "", fmt.Errorf("error reading input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,215 @@
+package terminal
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/gas"
+)
+
+const (
+	// How many bytes of undecoded calldata to show before truncating it
+	maxDisplayedCalldataBytes int = 64
+)
+
+// ===================
+// === Transaction ===
+// ===================
+
+// Print a transaction's details, including its decoded call if it has one, and ask the user to confirm submitting it.
+// Transactions that failed simulation get a warning before the question.
+func (p *Prompter) ConfirmTransaction(txInfo *eth.TransactionInfo, gasLimit uint64, maxFee *big.Int, maxPriorityFee *big.Int) (bool, error) {
+	p.Println(FormatTransactionDetails(txInfo, gasLimit, maxFee, maxPriorityFee))
+	if txInfo.SimulationResult.SimulationError != "" {
+		p.Println(fmt.Sprintf("WARNING: this transaction failed simulation and will likely revert: %s", txInfo.SimulationResult.SimulationError))
+	}
+	return p.Confirm("Are you sure you want to submit this transaction?")
+}
+
+// Print a signing request waiting for approval and ask the user to approve it
+func (p *Prompter) ConfirmSigningRequest(request types.SigningRequest) (bool, error) {
+	p.Println(FormatSigningRequest(request))
+	return p.Confirm("Do you want to approve this signing request?")
+}
+
+// Render a transaction's details for the user to review. The gas fields are left out if the gas limit is 0 or the fees
+// are nil.
+func FormatTransactionDetails(txInfo *eth.TransactionInfo, gasLimit uint64, maxFee *big.Int, maxPriorityFee *big.Int) string {
+	to := txInfo.To
+	details := newDetailsBuilder()
+	details.add("To", to.Hex())
+	details.add("Value", formatEth(txInfo.Value))
+	details.addCall(txInfo.CallDescription, txInfo.Data)
+	details.addGas(gasLimit, maxFee, maxPriorityFee)
+	return details.String()
+}
+
+// Render a signing request for the user to review
+func FormatSigningRequest(request types.SigningRequest) string {
+	details := newDetailsBuilder()
+	details.add("Request", request.ID)
+	details.add("From", request.From.Hex())
+	if request.To == nil {
+		details.add("To", "(contract deployment)")
+	} else {
+		details.add("To", request.To.Hex())
+	}
+	details.add("Value", formatEth(request.Value))
+	if request.Description != "" {
+		details.add("Call", request.Description)
+	} else {
+		details.addCall(nil, request.Data)
+	}
+	details.add("Nonce", fmt.Sprint(request.Nonce))
+	details.addGas(request.GasLimit, request.GasFeeCap, request.GasTipCap)
+	details.add("Expires", request.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	return details.String()
+}
+
+// Builds aligned "label: value" lines
+type detailsBuilder struct {
+	labels []string
+	values []string
+}
+
+// Creates a new details builder
+func newDetailsBuilder() *detailsBuilder {
+	return &detailsBuilder{}
+}
+
+// Add a line
+func (b *detailsBuilder) add(label string, value string) {
+	b.labels = append(b.labels, label)
+	b.values = append(b.values, value)
+}
+
+// Add the call a transaction makes, showing the raw calldata if it couldn't be decoded
+func (b *detailsBuilder) addCall(description *eth.CallDescription, data []byte) {
+	switch {
+	case description != nil:
+		b.add("Call", description.String())
+	case len(data) == 0:
+		b.add("Call", "(none, plain ETH transfer)")
+	case len(data) > maxDisplayedCalldataBytes:
+		b.add("Data", fmt.Sprintf("0x%x... (%d bytes)", data[:maxDisplayedCalldataBytes], len(data)))
+	default:
+		b.add("Data", fmt.Sprintf("0x%x", data))
+	}
+}
+
+// Add the gas limit and fees, along with the most the transaction can cost
+func (b *detailsBuilder) addGas(gasLimit uint64, maxFee *big.Int, maxPriorityFee *big.Int) {
+	if gasLimit == 0 {
+		return
+	}
+	b.add("Gas limit", fmt.Sprint(gasLimit))
+	if maxFee != nil {
+		b.add("Max fee", fmt.Sprintf("%s gwei (up to %s for the transaction)", eth.WeiToGweiDecimal(maxFee), formatEth(getMaxCost(maxFee, gasLimit))))
+	}
+	if maxPriorityFee != nil {
+		b.add("Priority fee", eth.WeiToGweiDecimal(maxPriorityFee)+" gwei")
+	}
+}
+
+// Render the lines with their values lined up
+func (b *detailsBuilder) String() string {
+	width := 0
+	for _, label := range b.labels {
+		width = max(width, len(label))
+	}
+	var builder strings.Builder
+	for i, label := range b.labels {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("%-*s  %s", width+1, label+":", b.values[i]))
+	}
+	return builder.String()
+}
+
+// ===========
+// === Gas ===
+// ===========
+
+// Ask the user to pick one of a recommendation's max fees or enter their own. The recent base fees are shown first if
+// the recommendation has them, and each option shows the most it would cost along with how many recent blocks it would
+// have covered. Returns the max fee per gas in wei.
+func (p *Prompter) SelectMaxFee(recommendation *gas.GasRecommendation) (*big.Int, error) {
+	if p.autoConfirm {
+		return nil, ErrNonInteractive
+	}
+	history := recommendation.BaseFeeHistory
+	if history != nil {
+		p.Println(fmt.Sprintf("Base fees over the last %d blocks: %s gwei min, %s gwei median, %s gwei max. The next block's base fee is %s gwei.",
+			len(history.BaseFees),
+			eth.WeiToGweiDecimal(history.MinBaseFee),
+			eth.WeiToGweiDecimal(history.MedianBaseFee),
+			eth.WeiToGweiDecimal(history.MaxBaseFee),
+			eth.WeiToGweiDecimal(history.NextBaseFee),
+		))
+	}
+
+	labels := make([]string, 0, len(recommendation.Options)+1)
+	for i, option := range recommendation.Options {
+		label := fmt.Sprintf("%s: %s gwei", option.Label, eth.WeiToGweiDecimal(option.MaxFeeWei))
+		if option.WaitTime != "" {
+			label += fmt.Sprintf(" (%s)", option.WaitTime)
+		}
+		if recommendation.GasLimit > 0 {
+			label += fmt.Sprintf(", up to %s", formatEth(option.MaxCostWei))
+			if recommendation.FiatCurrency != "" {
+				label += fmt.Sprintf(" (%.2f %s)", option.MaxCostFiat, recommendation.FiatCurrency)
+			}
+		}
+		if history != nil {
+			label += fmt.Sprintf(", covers %.0f%% of recent blocks", option.HistoricalCoverage)
+		}
+		if i == recommendation.RecommendedIndex {
+			label += " [recommended]"
+		}
+		labels = append(labels, label)
+	}
+	labels = append(labels, "Enter a custom max fee")
+
+	selection, err := p.Select(fmt.Sprintf("Please select a max fee for the transaction (suggested by %s):", recommendation.Source), labels)
+	if err != nil {
+		return nil, err
+	}
+	if selection < len(recommendation.Options) {
+		return new(big.Int).Set(recommendation.Options[selection].MaxFeeWei), nil
+	}
+
+	// Get a custom fee
+	var maxFee *big.Int
+	_, err = p.Prompt("Enter the max fee in gwei:", func(value string) error {
+		var err error
+		maxFee, err = eth.ParseGweiAmount(value)
+		if err != nil {
+			return err
+		}
+		if maxFee.Sign() <= 0 {
+			return fmt.Errorf("the max fee must be greater than 0")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return maxFee, nil
+}
+
+// Get the most a transaction can cost in wei
+func getMaxCost(maxFee *big.Int, gasLimit uint64) *big.Int {
+	return new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gasLimit))
+}
+
+// Render a wei amount in ETH
+func formatEth(wei *big.Int) string {
+	if wei == nil {
+		return "0 ETH"
+	}
+	return eth.WeiToEthDecimal(wei) + " ETH"
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/config/ids"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Configuration for retrying Beacon Node requests that fail with transient errors
+type BeaconRetryConfig struct {
+	// The most times a request will be sent, including the first attempt
+	MaxAttempts Parameter[uint64]
+
+	// The number of milliseconds to wait before the first retry
+	InitialBackoff Parameter[uint64]
+
+	// The most milliseconds to wait between retries
+	MaxBackoff Parameter[uint64]
+
+	// A comma-separated list of the HTTP status codes that will be retried
+	RetryableStatusCodes Parameter[string]
+}
+
+// Generates a new BeaconRetryConfig configuration
+func NewBeaconRetryConfig() *BeaconRetryConfig {
+	defaultPolicy := utils.DefaultHttpRetrySettings.Policy
+	defaultStatusCodes := make([]string, len(utils.DefaultHttpRetrySettings.RetryableStatusCodes))
+	for i, code := range utils.DefaultHttpRetrySettings.RetryableStatusCodes {
+		defaultStatusCodes[i] = strconv.Itoa(code)
+	}
+
+	return &BeaconRetryConfig{
+		MaxAttempts: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.BnRetryMaxAttemptsID,
+				Name:               "Max Attempts",
+				Description:        "The most times your node will send a request to your Beacon Node if it fails with a temporary error, such as a dropped connection. Set this to 1 to disable retries.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: uint64(defaultPolicy.MaxAttempts),
+			},
+		},
+
+		InitialBackoff: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.BnRetryInitialBackoffID,
+				Name:               "Initial Backoff",
+				Description:        "The number of milliseconds your node waits before sending a failed request to your Beacon Node again. This doubles after each retry, up to the Max Backoff.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: uint64(defaultPolicy.InitialInterval / time.Millisecond),
+			},
+		},
+
+		MaxBackoff: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.BnRetryMaxBackoffID,
+				Name:               "Max Backoff",
+				Description:        "The most milliseconds your node will wait between retries of a failed request to your Beacon Node.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: uint64(defaultPolicy.MaxInterval / time.Millisecond),
+			},
+		},
+
+		RetryableStatusCodes: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.BnRetryRetryableStatusCodesID,
+				Name:               "Retryable Status Codes",
+				Description:        "A comma-separated list of the HTTP status codes from your Beacon Node that your node will retry, such as the 502 errors a load balancer sends when it can't reach the Beacon Node behind it.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: strings.Join(defaultStatusCodes, ","),
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *BeaconRetryConfig) GetTitle() string {
+	return "Beacon Node Retries"
+}
+
+// Get the Parameters for this config
+func (cfg *BeaconRetryConfig) GetParameters() []IParameter {
+	return []IParameter{
+		&cfg.MaxAttempts,
+		&cfg.InitialBackoff,
+		&cfg.MaxBackoff,
+		&cfg.RetryableStatusCodes,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *BeaconRetryConfig) GetSubconfigs() map[string]IConfigSection {
+	return map[string]IConfigSection{}
+}
+
+// Get the retry settings for Beacon Node requests. Entries in the status code list that aren't valid status codes
+// are ignored.
+func (cfg *BeaconRetryConfig) GetRetrySettings() utils.HttpRetrySettings {
+	settings := utils.DefaultHttpRetrySettings
+	settings.Policy.MaxAttempts = int(cfg.MaxAttempts.Value)
+	settings.Policy.InitialInterval = time.Duration(cfg.InitialBackoff.Value) * time.Millisecond
+	settings.Policy.MaxInterval = time.Duration(cfg.MaxBackoff.Value) * time.Millisecond
+
+	settings.RetryableStatusCodes = []int{}
+	for _, entry := range strings.Split(cfg.RetryableStatusCodes.Value, ",") {
+		code, err := strconv.Atoi(strings.TrimSpace(entry))
+		if err != nil || code < 100 || code > 599 {
+			continue
+		}
+		settings.RetryableStatusCodes = append(settings.RetryableStatusCodes, code)
+	}
+	return settings
+}
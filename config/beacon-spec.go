@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Beacon chain spec values for custom networks, such as local or Kurtosis devnets.
+// These are used in place of the values reported by the Beacon Node when it can't be reached.
+type BeaconSpec struct {
+	// The genesis time of the chain, in seconds since the Unix epoch
+	GenesisTime uint64 `yaml:"genesisTime" json:"genesisTime"`
+
+	// The genesis validators root of the chain
+	GenesisValidatorsRoot utils.ByteArray `yaml:"genesisValidatorsRoot" json:"genesisValidatorsRoot"`
+
+	// The duration of each slot, in seconds
+	SecondsPerSlot uint64 `yaml:"secondsPerSlot" json:"secondsPerSlot"`
+
+	// The number of slots in each epoch
+	SlotsPerEpoch uint64 `yaml:"slotsPerEpoch" json:"slotsPerEpoch"`
+
+	// The number of epochs in each sync committee period
+	EpochsPerSyncCommitteePeriod uint64 `yaml:"epochsPerSyncCommitteePeriod" json:"epochsPerSyncCommitteePeriod"`
+
+	// The address of the Beacon deposit contract
+	DepositContractAddress common.Address `yaml:"depositContractAddress" json:"depositContractAddress"`
+}
+
+// Check that the spec values are usable
+func (s *BeaconSpec) Validate() error {
+	if s.SecondsPerSlot == 0 {
+		return fmt.Errorf("seconds per slot cannot be 0")
+	}
+	if s.SlotsPerEpoch == 0 {
+		return fmt.Errorf("slots per epoch cannot be 0")
+	}
+	if s.EpochsPerSyncCommitteePeriod == 0 {
+		return fmt.Errorf("epochs per sync committee period cannot be 0")
+	}
+	if len(s.GenesisValidatorsRoot) != common.HashLength {
+		return fmt.Errorf("genesis validators root must be %d bytes but was %d", common.HashLength, len(s.GenesisValidatorsRoot))
+	}
+	if s.DepositContractAddress == (common.Address{}) {
+		return fmt.Errorf("deposit contract address cannot be empty")
+	}
+	return nil
+}
+
+// Get the Beacon chain configuration described by the network resources' spec.
+// Returns false if the resources don't have a custom spec.
+func (r *NetworkResources) GetEth2Config() (beacon.Eth2Config, bool) {
+	if r.BeaconSpec == nil {
+		return beacon.Eth2Config{}, false
+	}
+	spec := r.BeaconSpec
+	return beacon.Eth2Config{
+		GenesisForkVersion:           r.GenesisForkVersion,
+		GenesisValidatorsRoot:        spec.GenesisValidatorsRoot,
+		GenesisEpoch:                 0,
+		GenesisTime:                  spec.GenesisTime,
+		SecondsPerSlot:               spec.SecondsPerSlot,
+		SlotsPerEpoch:                spec.SlotsPerEpoch,
+		SecondsPerEpoch:              spec.SecondsPerSlot * spec.SlotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: spec.EpochsPerSyncCommitteePeriod,
+	}, true
+}
+
+// Get the Beacon deposit contract described by the network resources' spec.
+// Returns false if the resources don't have a custom spec.
+func (r *NetworkResources) GetEth2DepositContract() (beacon.Eth2DepositContract, bool) {
+	if r.BeaconSpec == nil {
+		return beacon.Eth2DepositContract{}, false
+	}
+	return beacon.Eth2DepositContract{
+		ChainID: uint64(r.ChainID),
+		Address: r.BeaconSpec.DepositContractAddress,
+	}, true
+}
@@ -24,6 +24,7 @@ func NewBitflyNodeMetricsConfig() *BitflyNodeMetricsConfig {
 				AffectsContainers: []ContainerID{ContainerID_BeaconNode, ContainerID_ValidatorClient},
 				// ensures the string is 28 characters of Base64
 				Regex:              "^[A-Za-z0-9+/=]{28}$",
+				Secret:             true,
 				CanBeBlank:         true,
 				OverwriteOnUpgrade: false,
 			},
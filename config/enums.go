@@ -8,6 +8,12 @@ const (
 
 	// The standard name of the Ethereum holesky network, used for EL / CL flags
 	EthNetwork_Holesky string = "holesky"
+
+	// The standard name of the Ethereum sepolia network, used for EL / CL flags
+	EthNetwork_Sepolia string = "sepolia"
+
+	// The standard name of the Ethereum hoodi network, used for EL / CL flags
+	EthNetwork_Hoodi string = "hoodi"
 )
 
 // Type for network keys, used in NetworkSettings to uniquely define the network
@@ -26,6 +32,12 @@ const (
 
 	// The standard key for the Holesky test network
 	Network_Holesky Network = Network(EthNetwork_Holesky)
+
+	// The standard key for the Sepolia test network
+	Network_Sepolia Network = Network(EthNetwork_Sepolia)
+
+	// The standard key for the Hoodi test network
+	Network_Hoodi Network = Network(EthNetwork_Hoodi)
 )
 
 // A Docker container name
@@ -154,3 +166,16 @@ func (m RpcPortMode) DockerPortMapping(port uint16) string {
 		return ""
 	}
 }
+
+// Creates the Docker config strings for a P2P port, which is always open to external hosts over both TCP and UDP
+func DockerP2pPortMappings(port uint16) []string {
+	return []string{
+		fmt.Sprintf("%d:%d/tcp", port, port),
+		fmt.Sprintf("%d:%d/udp", port, port),
+	}
+}
+
+// Creates the Docker config string for a UDP-only P2P port, such as the ones used for QUIC traffic
+func DockerP2pUdpPortMapping(port uint16) string {
+	return fmt.Sprintf("%d:%d/udp", port, port)
+}
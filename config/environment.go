@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// The value that replaces secret parameters in masked environments
+	SecretMask string = "********"
+)
+
+// Matches the environment variable names that Docker accepts in env files
+var environmentVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// The environment variables for each container, keyed by container and then by variable name
+type ContainerEnvironments map[ContainerID]map[string]string
+
+// An environment variable that's set by a parameter
+type environmentVariableSource struct {
+	paramID string
+	value   string
+}
+
+// Build the environment variables for each container from the parameters in the section and all of its subsections.
+// Each parameter's value is assigned to its EnvironmentVariables in every container it affects. If maskSecrets is
+// true, the values of secret parameters are replaced with SecretMask so the environments can be displayed safely.
+// Returns an error if two parameters set the same variable in the same container to different values.
+func GetContainerEnvironments(cfg IConfigSection, maskSecrets bool) (ContainerEnvironments, error) {
+	sources := map[ContainerID]map[string]environmentVariableSource{}
+	err := addEnvironmentVariables(cfg, maskSecrets, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	environments := ContainerEnvironments{}
+	for container, variables := range sources {
+		environment := make(map[string]string, len(variables))
+		for name, source := range variables {
+			environment[name] = source.value
+		}
+		environments[container] = environment
+	}
+	return environments, nil
+}
+
+// Write a container's environment to an env file that Docker can read, with one variable per line sorted by name.
+// The file is only readable by its owner, since it can hold secrets.
+func WriteEnvironmentFile(path string, environment map[string]string) error {
+	names := make([]string, 0, len(environment))
+	for name := range environment {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var builder strings.Builder
+	for _, name := range names {
+		value := environment[name]
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("environment variable [%s] can't be written to an env file because its value has a line break", name)
+		}
+		builder.WriteString(name + "=" + value + "\n")
+	}
+
+	err := os.WriteFile(path, []byte(builder.String()), 0600)
+	if err != nil {
+		return fmt.Errorf("error writing env file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Add the environment variables for the parameters in a section and its subsections
+func addEnvironmentVariables(cfg IConfigSection, maskSecrets bool, sources map[ContainerID]map[string]environmentVariableSource) error {
+	for _, param := range cfg.GetParameters() {
+		common := param.GetCommon()
+		if len(common.EnvironmentVariables) == 0 {
+			continue
+		}
+		value := param.String()
+		if maskSecrets && common.Secret {
+			value = SecretMask
+		}
+
+		for _, name := range common.EnvironmentVariables {
+			if !environmentVariableNameRegex.MatchString(name) {
+				return fmt.Errorf("parameter [%s] has an invalid environment variable name [%s]", common.ID, name)
+			}
+			for _, container := range common.AffectsContainers {
+				variables, exists := sources[container]
+				if !exists {
+					variables = map[string]environmentVariableSource{}
+					sources[container] = variables
+				}
+				existing, exists := variables[name]
+				if exists && existing.value != value {
+					return fmt.Errorf("parameters [%s] and [%s] both set environment variable [%s] for container [%s]", existing.paramID, common.ID, name, container)
+				}
+				variables[name] = environmentVariableSource{
+					paramID: common.ID,
+					value:   value,
+				}
+			}
+		}
+	}
+
+	for name, subconfig := range cfg.GetSubconfigs() {
+		err := addEnvironmentVariables(subconfig, maskSecrets, sources)
+		if err != nil {
+			return fmt.Errorf("error getting environment variables for section [%s]: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,35 @@
 package config
 
-import "github.com/rocket-pool/node-manager-core/config/ids"
+import (
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/config/ids"
+)
+
+// Settings for the circuit breaker that guards each client endpoint
+type CircuitBreakerSettings struct {
+	// The number of consecutive connection failures that takes an endpoint out of service
+	FailureThreshold uint64
+
+	// How long an endpoint stays out of service the first time it fails; this doubles each time it fails again
+	// while it's being probed
+	Cooldown time.Duration
+
+	// The longest an endpoint can stay out of service before it's probed again
+	MaxCooldown time.Duration
+
+	// The number of consecutive successful requests an endpoint needs while it's being probed to go back into service
+	ProbeCount uint64
+}
+
+// The circuit breaker settings to use if none are configured
+var DefaultCircuitBreakerSettings = CircuitBreakerSettings{
+	FailureThreshold: 3,
+	Cooldown:         30 * time.Second,
+	MaxCooldown:      10 * time.Minute,
+	ProbeCount:       2,
+}
 
 // Fallback configuration
 type FallbackConfig struct {
@@ -15,6 +44,24 @@ type FallbackConfig struct {
 
 	// The URL of the Prysm gRPC endpoint (only needed if using Prysm VCs)
 	PrysmRpcUrl Parameter[string]
+
+	// A comma-separated list of the URLs of more Execution Client HTTP endpoints to fall back to, in order
+	AdditionalEcHttpUrls Parameter[string]
+
+	// A comma-separated list of the URLs of more Beacon Node HTTP endpoints to fall back to, in order
+	AdditionalBnHttpUrls Parameter[string]
+
+	// The number of consecutive connection failures before a client is taken out of service
+	CircuitBreakerThreshold Parameter[uint64]
+
+	// The number of seconds a client stays out of service after it fails
+	CircuitBreakerCooldown Parameter[uint64]
+
+	// The most seconds a client can stay out of service before it's probed again
+	CircuitBreakerMaxCooldown Parameter[uint64]
+
+	// The number of successful requests a client needs while it's being probed to go back into service
+	CircuitBreakerProbeCount Parameter[uint64]
 }
 
 // Generates a new FallbackConfig configuration
@@ -75,6 +122,92 @@ func NewFallbackConfig() *FallbackConfig {
 				Network_All: "",
 			},
 		},
+
+		AdditionalEcHttpUrls: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.FallbackAdditionalEcHttpUrlsID,
+				Name:               "Additional Execution Client URLs",
+				Description:        "A comma-separated list of the URLs of more fallback Execution clients. If your primary and fallback Execution clients both go offline, your node will try each of these in order.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				Advanced:           true,
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		AdditionalBnHttpUrls: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.FallbackAdditionalBnHttpUrlsID,
+				Name:               "Additional Beacon Node URLs",
+				Description:        "A comma-separated list of the URLs of more fallback Beacon Nodes. If your primary and fallback Beacon Nodes both go offline, your node will try each of these in order.\n\nNOTE: These are only used by your node, not by your Validator Client(s).",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				Advanced:           true,
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		CircuitBreakerThreshold: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.FallbackCircuitBreakerThresholdID,
+				Name:               "Failure Threshold",
+				Description:        "The number of connection failures in a row before your node stops using a client and switches to the other one.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: DefaultCircuitBreakerSettings.FailureThreshold,
+			},
+		},
+
+		CircuitBreakerCooldown: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.FallbackCircuitBreakerCooldownID,
+				Name:               "Failover Cooldown",
+				Description:        "The number of seconds your node waits before trying a client again after it stopped using it. Each time the client fails again while it's being retried, this doubles, up to the Max Failover Cooldown.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: uint64(DefaultCircuitBreakerSettings.Cooldown / time.Second),
+			},
+		},
+
+		CircuitBreakerMaxCooldown: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.FallbackCircuitBreakerMaxCooldownID,
+				Name:               "Max Failover Cooldown",
+				Description:        "The most seconds your node will wait before trying a client again after it stopped using it.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: uint64(DefaultCircuitBreakerSettings.MaxCooldown / time.Second),
+			},
+		},
+
+		CircuitBreakerProbeCount: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.FallbackCircuitBreakerProbeCountID,
+				Name:               "Recovery Probes",
+				Description:        "The number of successful requests in a row a client needs, when your node tries it again, before your node goes back to using it normally. Raise this if your primary client is unreliable and your node keeps switching back and forth.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: DefaultCircuitBreakerSettings.ProbeCount,
+			},
+		},
 	}
 }
 
@@ -90,6 +223,12 @@ func (cfg *FallbackConfig) GetParameters() []IParameter {
 		&cfg.EcHttpUrl,
 		&cfg.BnHttpUrl,
 		&cfg.PrysmRpcUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalBnHttpUrls,
+		&cfg.CircuitBreakerThreshold,
+		&cfg.CircuitBreakerCooldown,
+		&cfg.CircuitBreakerMaxCooldown,
+		&cfg.CircuitBreakerProbeCount,
 	}
 }
 
@@ -97,3 +236,38 @@ func (cfg *FallbackConfig) GetParameters() []IParameter {
 func (cfg *FallbackConfig) GetSubconfigs() map[string]IConfigSection {
 	return map[string]IConfigSection{}
 }
+
+// Get the URLs of the fallback Execution clients in the order they should be used, or nil if fallbacks are disabled
+func (cfg *FallbackConfig) GetEcHttpUrls() []string {
+	return cfg.getFallbackUrls(cfg.EcHttpUrl.Value, cfg.AdditionalEcHttpUrls.Value)
+}
+
+// Get the URLs of the fallback Beacon Nodes in the order they should be used, or nil if fallbacks are disabled
+func (cfg *FallbackConfig) GetBnHttpUrls() []string {
+	return cfg.getFallbackUrls(cfg.BnHttpUrl.Value, cfg.AdditionalBnHttpUrls.Value)
+}
+
+// Get the circuit breaker settings for the client endpoints
+func (cfg *FallbackConfig) GetCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		FailureThreshold: cfg.CircuitBreakerThreshold.Value,
+		Cooldown:         time.Duration(cfg.CircuitBreakerCooldown.Value) * time.Second,
+		MaxCooldown:      time.Duration(cfg.CircuitBreakerMaxCooldown.Value) * time.Second,
+		ProbeCount:       cfg.CircuitBreakerProbeCount.Value,
+	}
+}
+
+// Combine the main fallback URL with the additional ones, skipping any that are blank
+func (cfg *FallbackConfig) getFallbackUrls(url string, additionalUrls string) []string {
+	if !cfg.UseFallbackClients.Value {
+		return nil
+	}
+	urls := []string{}
+	for _, candidate := range append([]string{url}, strings.Split(additionalUrls, ",")...) {
+		candidate = strings.TrimSpace(candidate)
+		if candidate != "" {
+			urls = append(urls, candidate)
+		}
+	}
+	return urls
+}
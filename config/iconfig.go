@@ -1,6 +1,11 @@
 package config
 
-import "github.com/rocket-pool/node-manager-core/log"
+import (
+	"github.com/rocket-pool/node-manager-core/features"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
 
 // NMC servers typically provide some kind of persistent configuration; it must implement this interface.
 type IConfig interface {
@@ -21,11 +26,40 @@ type IConfig interface {
 	// The path to use for the wallet keystore's password file
 	GetPasswordFilePath() string
 
-	// The URLs for the Execution clients to use
-	GetExecutionClientUrls() (string, string)
+	// Where to get the wallet password from. File sources without a path use the password file path.
+	GetPasswordSourceSettings() wallet.PasswordSourceSettings
 
-	// The URLs for the Beacon nodes to use
-	GetBeaconNodeUrls() (string, string)
+	// The path to use for the daemon's persistent key-value store; if it's empty, the state is kept in memory instead
+	GetStoreFilePath() string
+
+	// The URLs for the Execution clients to use: the primary, then the fallbacks in the order they should be used
+	GetExecutionClientUrls() (string, []string)
+
+	// The URLs for the Beacon nodes to use: the primary, then the fallbacks in the order they should be used
+	GetBeaconNodeUrls() (string, []string)
+
+	// The reverse proxy and authentication options for the Execution clients: the primary's, then the fallbacks' in the
+	// same order as GetExecutionClientUrls. Fallbacks without an entry don't use any options.
+	GetExecutionClientProxyOptions() (utils.ProxyOptions, []utils.ProxyOptions)
+
+	// The reverse proxy and authentication options for the Beacon nodes: the primary's, then the fallbacks' in the same
+	// order as GetBeaconNodeUrls. Fallbacks without an entry don't use any options.
+	GetBeaconNodeProxyOptions() (utils.ProxyOptions, []utils.ProxyOptions)
+
+	// The settings for the circuit breaker that guards each client endpoint
+	GetCircuitBreakerSettings() CircuitBreakerSettings
+
+	// The settings for retrying Beacon node requests that fail with transient errors
+	GetBeaconRetrySettings() utils.HttpRetrySettings
+
+	// The limits on the size of Beacon node and Execution client responses, and whether they're compressed
+	GetResponseLimitSettings() utils.ResponseLimitSettings
+
+	// How the daemon identifies itself (with a User-Agent and custom headers) in outbound HTTP requests
+	GetHttpIdentity() utils.HttpIdentity
+
+	// The values of the daemon's feature flags, and the prefix of the environment variables that override them
+	GetFeatureFlagConfig() features.FeatureFlagConfig
 
 	// The configuration for the daemon loggers
 	GetLoggerOptions() log.LoggerOptions
@@ -18,6 +18,9 @@ const (
 	MetricsPortID           string = "metricsPort"
 	CacheSizeID             string = "cacheSize"
 
+	// Shared Beacon Node storage
+	BnReconstructHistoricStatesID string = "reconstructHistoricStates"
+
 	// Logger
 	LoggerLevelID      string = "level"
 	LoggerFormatID     string = "format"
@@ -28,6 +31,12 @@ const (
 	LoggerLocalTimeID  string = "localTime"
 	LoggerCompressID   string = "compress"
 
+	// Beacon Node Retries
+	BnRetryMaxAttemptsID          string = "bnRetryMaxAttempts"
+	BnRetryInitialBackoffID       string = "bnRetryInitialBackoff"
+	BnRetryMaxBackoffID           string = "bnRetryMaxBackoff"
+	BnRetryRetryableStatusCodesID string = "bnRetryRetryableStatusCodes"
+
 	// Besu
 	BesuJvmHeapSizeID   string = "jvmHeapSize"
 	BesuMaxBackLayersID string = "maxBackLayers"
@@ -45,19 +54,39 @@ const (
 	ExternalEcWebsocketUrlID string = "wsUrl"
 
 	// Fallback
-	FallbackUseFallbackClientsID string = "useFallbackClients"
-	FallbackEcHttpUrlID          string = "ecHttpUrl"
-	FallbackBnHttpUrlID          string = "bnHttpUrl"
+	FallbackUseFallbackClientsID        string = "useFallbackClients"
+	FallbackEcHttpUrlID                 string = "ecHttpUrl"
+	FallbackBnHttpUrlID                 string = "bnHttpUrl"
+	FallbackAdditionalEcHttpUrlsID      string = "additionalEcHttpUrls"
+	FallbackAdditionalBnHttpUrlsID      string = "additionalBnHttpUrls"
+	FallbackCircuitBreakerThresholdID   string = "circuitBreakerThreshold"
+	FallbackCircuitBreakerCooldownID    string = "circuitBreakerCooldown"
+	FallbackCircuitBreakerMaxCooldownID string = "circuitBreakerMaxCooldown"
+	FallbackCircuitBreakerProbeCountID  string = "circuitBreakerProbeCount"
 
 	// Geth
 	GethEvmTimeoutID  string = "evmTimeout"
 	GethArchiveModeID string = "archiveMode"
 
+	// KMS Wallet
+	KmsProviderID        string = "kmsProvider"
+	KmsKeyID             string = "kmsKeyId"
+	KmsRegionID          string = "kmsRegion"
+	KmsCredentialsPathID string = "kmsCredentialsPath"
+	KmsProfileID         string = "kmsProfile"
+
 	// Lighthouse
-	LighthouseQuicPortID string = "p2pQuicPort"
+	LighthouseQuicPortID             string = "p2pQuicPort"
+	LighthousePruneBlobsID           string = "pruneBlobs"
+	LighthouseSlotsPerRestorePointID string = "slotsPerRestorePoint"
+
+	// Lodestar
+	LodestarPruneHistoryID               string = "pruneHistory"
+	LodestarArchiveStateEpochFrequencyID string = "archiveStateEpochFrequency"
 
 	// Local Beacon Node
 	LocalBnCheckpointSyncUrlID string = "checkpointSyncUrl"
+	LocalBnOpenMetricsPortID   string = "openMetricsPort"
 	LocalBnLighthouseID        string = "lighthouse"
 	LocalBnLodestarID          string = "lodestar"
 	LocalBnNimbusID            string = "nimbus"
@@ -93,7 +122,8 @@ const (
 	NethermindFullPruningThresholdMbID string = "fullPruningThresholdMb"
 
 	// Nimbus
-	NimbusPruningModeID string = "pruningMode"
+	NimbusPruningModeID  string = "pruningMode"
+	NimbusCombinedModeID string = "combinedMode"
 
 	// Prysm
 	PrysmRpcPortID     string = "rpcPort"
@@ -104,6 +134,17 @@ const (
 	RethMaxInboundPeersID  string = "maxInboundPeers"
 	RethMaxOutboundPeersID string = "maxOutboundPeers"
 
+	// Resource Limits
+	ResourceLimitsCpuSharesID   string = "cpuShares"
+	ResourceLimitsMemoryLimitID string = "memoryLimit"
+
+	// Storage
+	StorageEcDataDirectoryID    string = "ecDataDirectory"
+	StorageEcVolumeNameID       string = "ecVolumeName"
+	StorageBnDataDirectoryID    string = "bnDataDirectory"
+	StorageBnVolumeNameID       string = "bnVolumeName"
+	StorageUseExternalVolumesID string = "useExternalVolumes"
+
 	// Teku
 	TekuJvmHeapSizeID           string = "jvmHeapSize"
 	TekuArchiveModeID           string = "archiveMode"
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"github.com/rocket-pool/node-manager-core/config/ids"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// Configuration for a node wallet whose key is held by a cloud key management service
+type KmsConfig struct {
+	// The service that holds the key
+	Provider Parameter[wallet.KmsProvider]
+
+	// The ID or ARN of the key for AWS, or the resource name of the key version for GCP
+	KeyId Parameter[string]
+
+	// The region the key is in (AWS only)
+	Region Parameter[string]
+
+	// The path of the shared credentials file for AWS, or the service account key file for GCP
+	CredentialsPath Parameter[string]
+
+	// The profile to use from the shared credentials file, or from the default shared config files if there isn't one
+	// (AWS only)
+	Profile Parameter[string]
+}
+
+// Generates a new KMS wallet configuration
+func NewKmsConfig() *KmsConfig {
+	return &KmsConfig{
+		Provider: Parameter[wallet.KmsProvider]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.KmsProviderID,
+				Name:               "Key Management Service",
+				Description:        "The cloud key management service that holds your node wallet's key.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Options: []*ParameterOption[wallet.KmsProvider]{
+				{
+					ParameterOptionCommon: &ParameterOptionCommon{
+						Name:        "AWS KMS",
+						Description: "Use a key in AWS Key Management Service.",
+					},
+					Value: wallet.KmsProvider_Aws,
+				}, {
+					ParameterOptionCommon: &ParameterOptionCommon{
+						Name:        "Google Cloud KMS",
+						Description: "Use a key in Google Cloud Key Management Service.",
+					},
+					Value: wallet.KmsProvider_Gcp,
+				},
+			},
+			Default: map[Network]wallet.KmsProvider{
+				Network_All: wallet.KmsProvider_Aws,
+			},
+		},
+
+		KeyId: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.KmsKeyID,
+				Name:               "Key ID",
+				Description:        "The ID or ARN of the key for AWS KMS, or the full resource name of the key version for Google Cloud KMS (projects/.../cryptoKeyVersions/...). The key must be a secp256k1 signing key.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		Region: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.KmsRegionID,
+				Name:               "Region",
+				Description:        "The AWS region the key is in, such as us-east-1. Only used for AWS KMS.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				Regex:              wallet.AwsRegionRegex,
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		CredentialsPath: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.KmsCredentialsPathID,
+				Name:               "Credentials Path",
+				Description:        "The path of the AWS shared credentials file, or the Google Cloud service account key file, to access the key with.\n\nLeave this blank to use the credentials from the environment instead: the AWS SDK's default credential chain (environment variables, the shared config files in ~/.aws, a web identity token, or the instance's role) for AWS, or the instance's service account for Google Cloud.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		Profile: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.KmsProfileID,
+				Name:               "Profile",
+				Description:        "The profile to use from the AWS shared credentials file, or from the default shared config files if no credentials file is set. Leave this blank to use the default profile. Only used for AWS KMS.",
+				AffectsContainers:  []ContainerID{ContainerID_Daemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *KmsConfig) GetTitle() string {
+	return "KMS Wallet"
+}
+
+// Get the parameters for this config
+func (cfg *KmsConfig) GetParameters() []IParameter {
+	return []IParameter{
+		&cfg.Provider,
+		&cfg.KeyId,
+		&cfg.Region,
+		&cfg.CredentialsPath,
+		&cfg.Profile,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *KmsConfig) GetSubconfigs() map[string]IConfigSection {
+	return map[string]IConfigSection{}
+}
+
+// Get the wallet data for the configured key, which can be used to initialize a KMS wallet
+func (cfg *KmsConfig) GetKmsWalletData() wallet.KmsWalletData {
+	return wallet.KmsWalletData{
+		Provider:        cfg.Provider.Value,
+		KeyId:           cfg.KeyId.Value,
+		Region:          cfg.Region.Value,
+		CredentialsPath: cfg.CredentialsPath.Value,
+		Profile:         cfg.Profile.Value,
+	}
+}
@@ -17,6 +17,15 @@ type LighthouseBnConfig struct {
 	// The max number of P2P peers to connect to
 	MaxPeers Parameter[uint16]
 
+	// Toggle for deleting blobs once they're past the retention period
+	PruneBlobs Parameter[bool]
+
+	// Toggle for rebuilding the historic states from before the checkpoint sync point
+	ReconstructHistoricStates Parameter[bool]
+
+	// The number of slots between stored historic states
+	SlotsPerRestorePoint Parameter[uint64]
+
 	// The Docker Hub tag for Lighthouse BN
 	ContainerTag Parameter[string]
 
@@ -55,6 +64,49 @@ func NewLighthouseBnConfig() *LighthouseBnConfig {
 			},
 		},
 
+		PruneBlobs: Parameter[bool]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.LighthousePruneBlobsID,
+				Name:               "Prune Blobs",
+				Description:        "When enabled, Lighthouse will delete blobs once they're older than the minimum retention period (about 18 days). Disable this if you need to keep every blob, at the cost of much more disk space.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]bool{
+				Network_All: true,
+			},
+		},
+
+		ReconstructHistoricStates: Parameter[bool]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.BnReconstructHistoricStatesID,
+				Name:               "Reconstruct Historic States",
+				Description:        "When enabled, Lighthouse will rebuild the states of the Beacon chain before its checkpoint sync point in the background, so it can serve the entire history like an archive node. Use it with a low Slots Per Restore Point to make historic state lookups fast.\n\nThis takes a long time and a lot of extra disk space, so only enable it if you need it.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]bool{
+				Network_All: false,
+			},
+		},
+
+		SlotsPerRestorePoint: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.LighthouseSlotsPerRestorePointID,
+				Name:               "Slots Per Restore Point",
+				Description:        "The number of slots between the historic states Lighthouse stores. Lower values make looking up old states faster but use more disk space. It must be a power of 2 that divides 8192.\n\nChanging this requires resyncing the Beacon Node.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_Mainnet: 8192,
+				Network_All:     2048,
+			},
+		},
+
 		ContainerTag: Parameter[string]{
 			ParameterCommon: &ParameterCommon{
 				ID:                 ids.ContainerTagID,
@@ -95,6 +147,9 @@ func (cfg *LighthouseBnConfig) GetParameters() []IParameter {
 	return []IParameter{
 		&cfg.MaxPeers,
 		&cfg.P2pQuicPort,
+		&cfg.PruneBlobs,
+		&cfg.ReconstructHistoricStates,
+		&cfg.SlotsPerRestorePoint,
 		&cfg.ContainerTag,
 		&cfg.AdditionalFlags,
 	}
@@ -23,6 +23,9 @@ type LocalBeaconConfig struct {
 	// Toggle for forwarding the HTTP API port outside of Docker
 	OpenHttpPort Parameter[RpcPortMode]
 
+	// Toggle for forwarding the metrics port outside of Docker
+	OpenMetricsPort Parameter[RpcPortMode]
+
 	// Subconfigs
 	Lighthouse *LighthouseBnConfig
 	Lodestar   *LodestarBnConfig
@@ -138,6 +141,21 @@ func NewLocalBeaconConfig() *LocalBeaconConfig {
 				Network_All: RpcPortMode_Closed,
 			},
 		},
+
+		OpenMetricsPort: Parameter[RpcPortMode]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.LocalBnOpenMetricsPortID,
+				Name:               "Expose Metrics Port",
+				Description:        "Select an option to expose your Beacon Node's metrics port to your localhost or external hosts on the network, so an external monitoring system can scrape it. This has no effect if metrics are disabled.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Options: GetPortModes(""),
+			Default: map[Network]RpcPortMode{
+				Network_All: RpcPortMode_Closed,
+			},
+		},
 	}
 
 	cfg.Lighthouse = NewLighthouseBnConfig()
@@ -149,6 +167,21 @@ func NewLocalBeaconConfig() *LocalBeaconConfig {
 	return cfg
 }
 
+// Rough sizes of a Beacon Node's data directory, in GB
+type bnDataSizeHint struct {
+	pruned  uint64
+	archive uint64
+}
+
+// The data directory size hints for each network; Network_All is used for networks that aren't listed
+var bnDataSizeHints = map[Network]bnDataSizeHint{
+	Network_Mainnet: {pruned: 200, archive: 2500},
+	Network_Holesky: {pruned: 100, archive: 1000},
+	Network_Sepolia: {pruned: 50, archive: 300},
+	Network_Hoodi:   {pruned: 50, archive: 300},
+	Network_All:     {pruned: 50, archive: 300},
+}
+
 // The title for the config
 func (cfg *LocalBeaconConfig) GetTitle() string {
 	return "Local Beacon Node"
@@ -162,6 +195,7 @@ func (cfg *LocalBeaconConfig) GetParameters() []IParameter {
 		&cfg.P2pPort,
 		&cfg.HttpPort,
 		&cfg.OpenHttpPort,
+		&cfg.OpenMetricsPort,
 	}
 }
 
@@ -202,6 +236,87 @@ func (cfg *LocalBeaconConfig) GetOpenApiPortMapping() []string {
 	return bnOpenPorts
 }
 
+// Get the Docker mapping for the HTTP API port based on the selected API port mode, or an empty string if it's closed
+func (cfg *LocalBeaconConfig) GetHttpPortMapping() string {
+	return cfg.OpenHttpPort.Value.DockerPortMapping(cfg.HttpPort.Value)
+}
+
+// Get the Docker mapping for the provided metrics port based on the selected metrics port mode, or an empty string if
+// it's closed. The port itself lives in the metrics config, so it has to be provided here.
+func (cfg *LocalBeaconConfig) GetMetricsPortMapping(metricsPort uint16) string {
+	return cfg.OpenMetricsPort.Value.DockerPortMapping(metricsPort)
+}
+
+// Get the QUIC port of the selected BN, or 0 if it doesn't use a separate port for QUIC traffic
+func (cfg *LocalBeaconConfig) GetP2pQuicPort() uint16 {
+	switch cfg.BeaconNode.Value {
+	case BeaconNode_Lighthouse:
+		return cfg.Lighthouse.P2pQuicPort.Value
+	default:
+		return 0
+	}
+}
+
+// Get the Docker mappings for the selected BN's P2P ports, including its QUIC port if it has one
+func (cfg *LocalBeaconConfig) GetP2pPortMappings() []string {
+	mappings := DockerP2pPortMappings(cfg.P2pPort.Value)
+	quicPort := cfg.GetP2pQuicPort()
+	if quicPort != 0 {
+		mappings = append(mappings, DockerP2pUdpPortMapping(quicPort))
+	}
+	return mappings
+}
+
+// Check if the selected BN runs the validators itself, so there's no separate VC container
+func (cfg *LocalBeaconConfig) IsCombinedMode() bool {
+	return cfg.BeaconNode.Value == BeaconNode_Nimbus && cfg.Nimbus.CombinedMode.Value
+}
+
+// Get the containers that make up the local Beacon Node deployment, which includes the VC unless the BN runs the
+// validators itself
+func (cfg *LocalBeaconConfig) GetContainers() []ContainerID {
+	if cfg.IsCombinedMode() {
+		return []ContainerID{ContainerID_BeaconNode}
+	}
+	return []ContainerID{ContainerID_BeaconNode, ContainerID_ValidatorClient}
+}
+
+// Get the container that runs the validators, which is the BN in combined mode and the VC otherwise
+func (cfg *LocalBeaconConfig) GetValidatorContainer() ContainerID {
+	if cfg.IsCombinedMode() {
+		return ContainerID_BeaconNode
+	}
+	return ContainerID_ValidatorClient
+}
+
+// Check if the selected BN keeps the full history of the Beacon chain's states, either by running in archive mode or
+// by reconstructing the states from before its checkpoint sync point
+func (cfg *LocalBeaconConfig) IsArchiveMode() bool {
+	switch cfg.BeaconNode.Value {
+	case BeaconNode_Lighthouse:
+		return cfg.Lighthouse.ReconstructHistoricStates.Value
+	case BeaconNode_Nimbus:
+		return cfg.Nimbus.PruningMode.Value == Nimbus_PruningMode_Archive
+	case BeaconNode_Teku:
+		return cfg.Teku.ArchiveMode.Value
+	default:
+		return false
+	}
+}
+
+// Get a rough estimate of the disk space the selected BN's data directory needs on the provided network, in GB,
+// based on whether it keeps the full history. This is meant as a hint for users, not as a hard limit.
+func (cfg *LocalBeaconConfig) GetDataSizeHint(network Network) uint64 {
+	hint, exists := bnDataSizeHints[network]
+	if !exists {
+		hint = bnDataSizeHints[Network_All]
+	}
+	if cfg.IsArchiveMode() {
+		return hint.archive
+	}
+	return hint.pruned
+}
+
 // Gets the max peers of the selected EC
 func (cfg *LocalBeaconConfig) GetMaxPeers() uint16 {
 	switch cfg.BeaconNode.Value {
@@ -13,6 +13,12 @@ type LodestarBnConfig struct {
 	// The max number of P2P peers to connect to
 	MaxPeers Parameter[uint16]
 
+	// Toggle for deleting blocks and blobs once they're past the retention period
+	PruneHistory Parameter[bool]
+
+	// The number of epochs between stored historic states
+	ArchiveStateEpochFrequency Parameter[uint64]
+
 	// The Docker Hub tag for Lodestar BN
 	ContainerTag Parameter[string]
 
@@ -37,6 +43,34 @@ func NewLodestarBnConfig() *LodestarBnConfig {
 			},
 		},
 
+		PruneHistory: Parameter[bool]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.LodestarPruneHistoryID,
+				Name:               "Prune History",
+				Description:        "When enabled, Lodestar will delete blocks and blobs once they're older than the minimum retention period (about 5 months for blocks and 18 days for blobs). This saves a lot of disk space, but your node won't be able to serve old blocks to its peers.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]bool{
+				Network_All: false,
+			},
+		},
+
+		ArchiveStateEpochFrequency: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.LodestarArchiveStateEpochFrequencyID,
+				Name:               "Archive State Epoch Frequency",
+				Description:        "The number of epochs between the historic states Lodestar stores. Lower values make looking up old states faster but use more disk space.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: 1024,
+			},
+		},
+
 		ContainerTag: Parameter[string]{
 			ParameterCommon: &ParameterCommon{
 				ID:                 ids.ContainerTagID,
@@ -76,6 +110,8 @@ func (cfg *LodestarBnConfig) GetTitle() string {
 func (cfg *LodestarBnConfig) GetParameters() []IParameter {
 	return []IParameter{
 		&cfg.MaxPeers,
+		&cfg.PruneHistory,
+		&cfg.ArchiveStateEpochFrequency,
 		&cfg.ContainerTag,
 		&cfg.AdditionalFlags,
 	}
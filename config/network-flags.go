@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// The path a custom network's files are mounted to inside the client containers
+	CustomNetworkMountPath string = "/network"
+
+	// The names of the files in a custom network's directory that the clients load, using the layout of the
+	// eth-clients and ethPandaOps network config repositories
+	CustomNetworkEcGenesisFile       string = "genesis.json"
+	CustomNetworkBesuGenesisFile     string = "besu.json"
+	CustomNetworkNethermindChainspec string = "chainspec.json"
+	CustomNetworkBeaconConfigFile    string = "config.yaml"
+	CustomNetworkBeaconGenesisFile   string = "genesis.ssz"
+)
+
+// Settings for a network the clients don't know about natively, such as a devnet, which they have to load from genesis
+// files instead
+type CustomNetworkConfig struct {
+	// The directory on the host with the network's genesis files. It should have the standard layout (genesis.json,
+	// besu.json, chainspec.json, config.yaml, genesis.ssz, and deploy_block.txt) and is mounted into the client
+	// containers at CustomNetworkMountPath.
+	Directory string `yaml:"directory" json:"directory"`
+
+	// The enodes of the Execution layer bootnodes
+	EcBootnodes []string `yaml:"ecBootnodes,omitempty" json:"ecBootnodes,omitempty"`
+
+	// The ENRs of the Beacon chain bootnodes
+	BnBootnodes []string `yaml:"bnBootnodes,omitempty" json:"bnBootnodes,omitempty"`
+}
+
+// Check that all of the required fields are present
+func (c *CustomNetworkConfig) Validate() error {
+	if strings.TrimSpace(c.Directory) == "" {
+		return fmt.Errorf("custom network directory is missing")
+	}
+	return nil
+}
+
+// Get the volume mapping that mounts a custom network's files into a client container, or an empty string if the
+// network is one the clients know natively
+func GetCustomNetworkVolume(resources *NetworkResources) string {
+	if resources.CustomNetwork == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s:ro", resources.CustomNetwork.Directory, CustomNetworkMountPath)
+}
+
+// Get the command line flags that select the network for an Execution Client. For custom networks, the network's
+// files must be mounted with the volume from GetCustomNetworkVolume. Geth also has to have its data directory
+// initialized from the genesis file with `geth init` before it can join a custom network.
+func GetExecutionClientNetworkFlags(client ExecutionClient, resources *NetworkResources) ([]string, error) {
+	custom := resources.CustomNetwork
+	if custom == nil {
+		network := resources.EthNetworkName
+		switch client {
+		case ExecutionClient_Geth:
+			return []string{"--" + network}, nil
+		case ExecutionClient_Nethermind:
+			return []string{"--config=" + network}, nil
+		case ExecutionClient_Besu:
+			return []string{"--network=" + network}, nil
+		case ExecutionClient_Reth:
+			return []string{"--chain=" + network}, nil
+		default:
+			return nil, fmt.Errorf("unknown Execution Client [%s]", client)
+		}
+	}
+
+	var flags []string
+	bootnodes := strings.Join(custom.EcBootnodes, ",")
+	switch client {
+	case ExecutionClient_Geth:
+		flags = []string{
+			fmt.Sprintf("--networkid=%d", resources.ChainID),
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--bootnodes="+bootnodes)
+		}
+	case ExecutionClient_Nethermind:
+		flags = []string{
+			"--config=none",
+			"--Init.ChainSpecPath=" + getCustomNetworkFile(CustomNetworkNethermindChainspec),
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--Network.Bootnodes="+bootnodes)
+		}
+	case ExecutionClient_Besu:
+		flags = []string{
+			"--genesis-file=" + getCustomNetworkFile(CustomNetworkBesuGenesisFile),
+			fmt.Sprintf("--network-id=%d", resources.ChainID),
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--bootnodes="+bootnodes)
+		}
+	case ExecutionClient_Reth:
+		flags = []string{
+			"--chain=" + getCustomNetworkFile(CustomNetworkEcGenesisFile),
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--bootnodes="+bootnodes)
+		}
+	default:
+		return nil, fmt.Errorf("unknown Execution Client [%s]", client)
+	}
+	return flags, nil
+}
+
+// Get the command line flags that select the network for a Beacon Node. For custom networks, the network's files must
+// be mounted with the volume from GetCustomNetworkVolume.
+func GetBeaconNodeNetworkFlags(client BeaconNode, resources *NetworkResources) ([]string, error) {
+	custom := resources.CustomNetwork
+	if custom == nil {
+		network := resources.EthNetworkName
+		switch client {
+		case BeaconNode_Lighthouse, BeaconNode_Lodestar, BeaconNode_Nimbus, BeaconNode_Teku:
+			return []string{"--network=" + network}, nil
+		case BeaconNode_Prysm:
+			return []string{"--" + network}, nil
+		default:
+			return nil, fmt.Errorf("unknown Beacon Node [%s]", client)
+		}
+	}
+
+	var flags []string
+	bootnodes := strings.Join(custom.BnBootnodes, ",")
+	switch client {
+	case BeaconNode_Lighthouse:
+		flags = []string{
+			"--testnet-dir=" + CustomNetworkMountPath,
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--boot-nodes="+bootnodes)
+		}
+	case BeaconNode_Lodestar:
+		flags = []string{
+			"--paramsFile=" + getCustomNetworkFile(CustomNetworkBeaconConfigFile),
+			"--genesisStateFile=" + getCustomNetworkFile(CustomNetworkBeaconGenesisFile),
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--bootnodes="+bootnodes)
+		}
+	case BeaconNode_Nimbus:
+		flags = []string{
+			"--network=" + CustomNetworkMountPath,
+		}
+		for _, bootnode := range custom.BnBootnodes {
+			flags = append(flags, "--bootstrap-node="+bootnode)
+		}
+	case BeaconNode_Prysm:
+		flags = []string{
+			"--chain-config-file=" + getCustomNetworkFile(CustomNetworkBeaconConfigFile),
+			"--genesis-state=" + getCustomNetworkFile(CustomNetworkBeaconGenesisFile),
+		}
+		for _, bootnode := range custom.BnBootnodes {
+			flags = append(flags, "--bootstrap-node="+bootnode)
+		}
+	case BeaconNode_Teku:
+		flags = []string{
+			"--network=" + getCustomNetworkFile(CustomNetworkBeaconConfigFile),
+			"--genesis-state=" + getCustomNetworkFile(CustomNetworkBeaconGenesisFile),
+		}
+		if bootnodes != "" {
+			flags = append(flags, "--p2p-discovery-bootnodes="+bootnodes)
+		}
+	default:
+		return nil, fmt.Errorf("unknown Beacon Node [%s]", client)
+	}
+	return flags, nil
+}
+
+// Get the path of one of a custom network's files inside the client containers
+func getCustomNetworkFile(name string) string {
+	return path.Join(CustomNetworkMountPath, name)
+}
@@ -5,12 +5,20 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/features"
+	"github.com/rocket-pool/node-manager-core/gas"
 	"github.com/rocket-pool/node-manager-core/utils"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// The length of a genesis fork version, in bytes
+	genesisForkVersionLength int = 4
+)
+
 var (
 	// Reference for Mainnet network resources, not used directly but helpful for testing
 	MainnetResourcesReference *NetworkResources = &NetworkResources{
@@ -21,6 +29,13 @@ var (
 		BalanceBatcherAddress: common.HexToAddress("0xb1f8e55c7f64d203c1400b9d8555d050f94adf39"),
 		TxWatchUrl:            "https://etherscan.io/tx",
 		FlashbotsProtectUrl:   "https://rpc.flashbots.net/",
+		GasOracles: &gas.GasOracleConfig{
+			Oracles: []gas.GasOracleSettings{
+				{Type: gas.GasOracleType_Beaconchain},
+				{Type: gas.GasOracleType_Etherscan},
+				{Type: gas.GasOracleType_FeeHistory},
+			},
+		},
 	}
 
 	// Reference for Holesky network resources, not used directly but helpful for testing
@@ -33,6 +48,42 @@ var (
 		TxWatchUrl:            "https://holesky.etherscan.io/tx",
 		FlashbotsProtectUrl:   "https://rpc-holesky.flashbots.net",
 	}
+
+	// Reference for Sepolia network resources, not used directly but helpful for testing
+	SepoliaResourcesReference *NetworkResources = &NetworkResources{
+		EthNetworkName:      "sepolia",
+		ChainID:             11155111,
+		GenesisForkVersion:  common.FromHex("0x90000069"), // https://github.com/eth-clients/sepolia
+		MulticallAddress:    common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"),
+		TxWatchUrl:          "https://sepolia.etherscan.io/tx",
+		FlashbotsProtectUrl: "https://rpc-sepolia.flashbots.net",
+	}
+
+	// Reference for Hoodi network resources, not used directly but helpful for testing
+	HoodiResourcesReference *NetworkResources = &NetworkResources{
+		EthNetworkName:      "hoodi",
+		ChainID:             560048,
+		GenesisForkVersion:  common.FromHex("0x10000910"), // https://github.com/eth-clients/hoodi
+		MulticallAddress:    common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"),
+		TxWatchUrl:          "https://hoodi.etherscan.io/tx",
+		FlashbotsProtectUrl: "https://rpc-hoodi.flashbots.net/",
+	}
+
+	// Default settings for the Sepolia test network
+	DefaultSepoliaSettings *NetworkSettings = &NetworkSettings{
+		Key:              Network_Sepolia,
+		Name:             "Sepolia Testnet",
+		Description:      "The Sepolia test network, which uses free fake ETH.",
+		NetworkResources: SepoliaResourcesReference,
+	}
+
+	// Default settings for the Hoodi test network
+	DefaultHoodiSettings *NetworkSettings = &NetworkSettings{
+		Key:              Network_Hoodi,
+		Name:             "Hoodi Testnet",
+		Description:      "The Hoodi test network, which uses free fake ETH and is the successor to Holesky.",
+		NetworkResources: HoodiResourcesReference,
+	}
 )
 
 // A collection of network-specific resources and getters for them
@@ -49,7 +100,7 @@ type NetworkResources struct {
 	// The address of the multicall contract
 	MulticallAddress common.Address `yaml:"multicallAddress" json:"multicallAddress"`
 
-	// The BalanceChecker contract address
+	// The BalanceChecker contract address (optional, since not every network has a deployment)
 	BalanceBatcherAddress common.Address `yaml:"balanceBatcherAddress" json:"balanceBatcherAddress"`
 
 	// The URL for transaction monitoring on the network's chain explorer
@@ -57,6 +108,16 @@ type NetworkResources struct {
 
 	// The FlashBots Protect RPC endpoint
 	FlashbotsProtectUrl string `yaml:"flashbotsProtectUrl" json:"flashbotsProtectUrl"`
+
+	// The gas oracles used to suggest max fees, in the order they're tried (optional). If this isn't set, the
+	// Execution Client's fee history is used.
+	GasOracles *gas.GasOracleConfig `yaml:"gasOracles,omitempty" json:"gasOracles,omitempty"`
+
+	// Custom Beacon chain spec values, for networks such as local devnets (optional)
+	BeaconSpec *BeaconSpec `yaml:"beaconSpec,omitempty" json:"beaconSpec,omitempty"`
+
+	// Genesis files and bootnodes for networks the clients don't know natively, such as local devnets (optional)
+	CustomNetwork *CustomNetworkConfig `yaml:"customNetwork,omitempty" json:"customNetwork,omitempty"`
 }
 
 // NetworkSettings contains all of the settings for a given Ethereum network
@@ -70,16 +131,253 @@ type NetworkSettings struct {
 	// A brief description of the network
 	Description string `yaml:"description" json:"description"`
 
+	// The revision of the settings, which should be increased whenever a published settings file changes. Remote
+	// settings sources won't replace a cached copy with an older version.
+	Version uint64 `yaml:"version,omitempty" json:"version,omitempty"`
+
 	// The list of resources for the network
 	NetworkResources *NetworkResources `yaml:"networkResources" json:"networkResources"`
 
 	// A collection of default configuration settings to use for the network, which will override
 	// the standard "general-purpose" default value for the setting
 	DefaultConfigSettings map[string]any `yaml:"defaultConfigSettings,omitempty" json:"defaultConfigSettings,omitempty"`
+
+	// Project-specific resources that NMC doesn't use directly, keyed by extension name.
+	// Use GetExtension and SetExtension to work with these as typed structs.
+	Extensions map[string]any `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+
+	// Feature flag values for every node on the network, keyed by flag name. Updating these in a remote settings
+	// file toggles features across the fleet; nodes can still override them in their own config.
+	FeatureFlags map[string]features.FeatureFlagSetting `yaml:"featureFlags,omitempty" json:"featureFlags,omitempty"`
 }
 
-// Load network settings from a file
+// Load network settings from a file and validate them
 func LoadSettingsFile(path string) (*NetworkSettings, error) {
+	bytes, err := readSettingsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the settings
+	settings, err := ParseSettings(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error loading network settings file [%s]: %w", path, err)
+	}
+	return settings, nil
+}
+
+// Load a partial network settings file and layer it on top of the provided defaults.
+// Any fields missing from the file will use the values from the defaults; default config settings are merged, with
+// the file's values taking precedence. The defaults are not modified. The merged settings are validated before being returned.
+func LoadSettingsFileWithDefaults(path string, defaults *NetworkSettings) (*NetworkSettings, error) {
+	bytes, err := readSettingsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start with a copy of the defaults
+	settings, err := defaults.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("error copying default network settings: %w", err)
+	}
+	baseConfigSettings := settings.DefaultConfigSettings
+	settings.DefaultConfigSettings = nil
+
+	// Unmarshal the file on top of them
+	err = yaml.Unmarshal(bytes, settings)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling network settings file [%s]: %w", path, err)
+	}
+
+	// Merge the default config settings
+	if baseConfigSettings != nil || settings.DefaultConfigSettings != nil {
+		if baseConfigSettings == nil {
+			baseConfigSettings = map[string]any{}
+		}
+		merged, _, err := MergeSettingsMaps(baseConfigSettings, settings.DefaultConfigSettings, true)
+		if err != nil {
+			return nil, fmt.Errorf("error merging default config settings from network settings file [%s]: %w", path, err)
+		}
+		settings.DefaultConfigSettings = merged
+	}
+
+	err = settings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid network settings file [%s]: %w", path, err)
+	}
+	return settings, nil
+}
+
+// Create a deep copy of the settings
+func (s *NetworkSettings) Clone() (*NetworkSettings, error) {
+	clone := *s
+	if s.NetworkResources != nil {
+		resources := *s.NetworkResources
+		resources.GenesisForkVersion = append(utils.ByteArray{}, s.NetworkResources.GenesisForkVersion...)
+		if s.NetworkResources.BeaconSpec != nil {
+			spec := *s.NetworkResources.BeaconSpec
+			spec.GenesisValidatorsRoot = append(utils.ByteArray{}, s.NetworkResources.BeaconSpec.GenesisValidatorsRoot...)
+			resources.BeaconSpec = &spec
+		}
+		if s.NetworkResources.CustomNetwork != nil {
+			custom := *s.NetworkResources.CustomNetwork
+			custom.EcBootnodes = slices.Clone(custom.EcBootnodes)
+			custom.BnBootnodes = slices.Clone(custom.BnBootnodes)
+			resources.CustomNetwork = &custom
+		}
+		if s.NetworkResources.GasOracles != nil {
+			resources.GasOracles = s.NetworkResources.GasOracles.Clone()
+		}
+		clone.NetworkResources = &resources
+	}
+	if s.DefaultConfigSettings != nil {
+		configSettings, err := CopySettingsMap(s.DefaultConfigSettings)
+		if err != nil {
+			return nil, err
+		}
+		clone.DefaultConfigSettings = configSettings
+	}
+	if s.Extensions != nil {
+		clone.Extensions = make(map[string]any, len(s.Extensions))
+		for name, extension := range s.Extensions {
+			var extensionCopy any
+			err := reencodeYaml(extension, &extensionCopy)
+			if err != nil {
+				return nil, fmt.Errorf("error copying extension [%s]: %w", name, err)
+			}
+			clone.Extensions[name] = extensionCopy
+		}
+	}
+	if s.FeatureFlags != nil {
+		clone.FeatureFlags = make(map[string]features.FeatureFlagSetting, len(s.FeatureFlags))
+		for name, setting := range s.FeatureFlags {
+			if setting.Enabled != nil {
+				enabled := *setting.Enabled
+				setting.Enabled = &enabled
+			}
+			if setting.RolloutPercent != nil {
+				percent := *setting.RolloutPercent
+				setting.RolloutPercent = &percent
+			}
+			clone.FeatureFlags[name] = setting
+		}
+	}
+	return &clone, nil
+}
+
+// Decode the extension with the provided name into a new instance of the provided type, using its YAML tags.
+// Returns false if the settings don't have an extension with that name.
+func GetExtension[ExtensionType any](settings *NetworkSettings, name string) (*ExtensionType, bool, error) {
+	raw, exists := settings.Extensions[name]
+	if !exists {
+		return nil, false, nil
+	}
+
+	extension := new(ExtensionType)
+	err := reencodeYaml(raw, extension)
+	if err != nil {
+		return nil, false, fmt.Errorf("error decoding extension [%s] of network [%s]: %w", name, settings.Key, err)
+	}
+	return extension, true, nil
+}
+
+// Store a project-specific extension in the settings, replacing any existing extension with the same name.
+// The value is converted to its generic YAML form so the settings can be serialized normally.
+func (s *NetworkSettings) SetExtension(name string, extension any) error {
+	var raw any
+	err := reencodeYaml(extension, &raw)
+	if err != nil {
+		return fmt.Errorf("error encoding extension [%s] of network [%s]: %w", name, s.Key, err)
+	}
+	if s.Extensions == nil {
+		s.Extensions = map[string]any{}
+	}
+	s.Extensions[name] = raw
+	return nil
+}
+
+// Convert a value into another type by serializing it to YAML and deserializing the result
+func reencodeYaml(source any, target any) error {
+	bytes, err := yaml.Marshal(source)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(bytes, target)
+}
+
+// Check that all of the required fields are present and well-formed
+func (s *NetworkSettings) Validate() error {
+	if s.Key == Network_Unknown {
+		return fmt.Errorf("network key is missing")
+	}
+	if s.Key == Network_All {
+		return fmt.Errorf("network key [%s] is reserved", s.Key)
+	}
+	if s.Name == "" {
+		return fmt.Errorf("network [%s] is missing a name", s.Key)
+	}
+
+	resources := s.NetworkResources
+	if resources == nil {
+		return fmt.Errorf("network [%s] is missing its network resources", s.Key)
+	}
+	if resources.EthNetworkName == "" {
+		return fmt.Errorf("network [%s] is missing its Ethereum network name", s.Key)
+	}
+	if resources.ChainID == 0 {
+		return fmt.Errorf("network [%s] has a chain ID of 0", s.Key)
+	}
+	if len(resources.GenesisForkVersion) != genesisForkVersionLength {
+		return fmt.Errorf("network [%s] has a genesis fork version of %d bytes, but it must be %d bytes", s.Key, len(resources.GenesisForkVersion), genesisForkVersionLength)
+	}
+	if resources.MulticallAddress == (common.Address{}) {
+		return fmt.Errorf("network [%s] is missing its multicall address", s.Key)
+	}
+	if resources.BeaconSpec != nil {
+		err := resources.BeaconSpec.Validate()
+		if err != nil {
+			return fmt.Errorf("network [%s] has an invalid Beacon spec: %w", s.Key, err)
+		}
+	}
+	if resources.GasOracles != nil {
+		err := resources.GasOracles.Validate()
+		if err != nil {
+			return fmt.Errorf("network [%s] has an invalid gas oracle config: %w", s.Key, err)
+		}
+	}
+	if resources.CustomNetwork != nil {
+		err := resources.CustomNetwork.Validate()
+		if err != nil {
+			return fmt.Errorf("network [%s] has an invalid custom network config: %w", s.Key, err)
+		}
+	}
+	for name, setting := range s.FeatureFlags {
+		err := setting.Validate()
+		if err != nil {
+			return fmt.Errorf("network [%s] has an invalid setting for feature flag [%s]: %w", s.Key, name, err)
+		}
+	}
+	return nil
+}
+
+// Validate a collection of network settings, ensuring each one is valid and that no two networks share the same key
+func ValidateSettingsCollection(settingsList []*NetworkSettings) error {
+	keys := map[Network]bool{}
+	for _, settings := range settingsList {
+		err := settings.Validate()
+		if err != nil {
+			return err
+		}
+		if keys[settings.Key] {
+			return fmt.Errorf("network key [%s] is used by more than one network", settings.Key)
+		}
+		keys[settings.Key] = true
+	}
+	return nil
+}
+
+// Read the contents of a network settings file
+func readSettingsFile(path string) ([]byte, error) {
 	// Make sure the file exists
 	_, err := os.Stat(path)
 	if errors.Is(err, fs.ErrNotExist) {
@@ -91,13 +389,19 @@ func LoadSettingsFile(path string) (*NetworkSettings, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error reading network settings file [%s]: %w", path, err)
 	}
+	return bytes, nil
+}
 
-	// Unmarshal the settings
+// Parse network settings from the contents of a settings file and validate them
+func ParseSettings(data []byte) (*NetworkSettings, error) {
 	settings := new(NetworkSettings)
-	err = yaml.Unmarshal(bytes, settings)
+	err := yaml.Unmarshal(data, settings)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling network settings file [%s]: %w", path, err)
+		return nil, fmt.Errorf("error unmarshalling network settings: %w", err)
+	}
+	err = settings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid network settings: %w", err)
 	}
-
 	return settings, nil
 }
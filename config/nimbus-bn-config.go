@@ -31,6 +31,9 @@ type NimbusBnConfig struct {
 	// The pruning mode to use in the BN
 	PruningMode Parameter[Nimbus_PruningMode]
 
+	// Toggle for running the validators in the BN instead of a separate VC container
+	CombinedMode Parameter[bool]
+
 	// Custom command line flags for the BN
 	AdditionalFlags Parameter[string]
 }
@@ -81,6 +84,20 @@ func NewNimbusBnConfig() *NimbusBnConfig {
 			},
 		},
 
+		CombinedMode: Parameter[bool]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.NimbusCombinedModeID,
+				Name:               "Run Validators in the Beacon Node",
+				Description:        "Enable this to have Nimbus run your validators inside the Beacon Node, instead of in a separate Validator Client container. This uses less memory and CPU, which can help on resource-constrained systems, but the Beacon Node will have to restart whenever your validators change.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode, ContainerID_ValidatorClient},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]bool{
+				Network_All: false,
+			},
+		},
+
 		ContainerTag: Parameter[string]{
 			ParameterCommon: &ParameterCommon{
 				ID:                 ids.ContainerTagID,
@@ -122,6 +139,7 @@ func (cfg *NimbusBnConfig) GetParameters() []IParameter {
 		&cfg.MaxPeers,
 		&cfg.ContainerTag,
 		&cfg.PruningMode,
+		&cfg.CombinedMode,
 		&cfg.AdditionalFlags,
 	}
 }
@@ -131,6 +149,21 @@ func (cfg *NimbusBnConfig) GetSubconfigs() map[string]IConfigSection {
 	return map[string]IConfigSection{}
 }
 
+// Get the flags that have the BN run the validators itself in combined mode. The validator keystores and their
+// secrets are loaded from the provided directories inside the BN container, using the layout of the Nimbus keystore
+// manager. The Nimbus VC's additional flags aren't included, since they're meant for the VC binary.
+func (cfg *NimbusBnConfig) GetCombinedModeFlags(vcCommon *ValidatorClientCommonConfig, validatorsDir string, secretsDir string) []string {
+	flags := []string{
+		"--validators-dir=" + validatorsDir,
+		"--secrets-dir=" + secretsDir,
+		fmt.Sprintf("--doppelganger-detection=%t", vcCommon.DoppelgangerDetection.Value),
+	}
+	if vcCommon.Graffiti.Value != "" {
+		flags = append(flags, "--graffiti="+vcCommon.Graffiti.Value)
+	}
+	return flags
+}
+
 // Get the default number of peers
 func getNimbusDefaultPeers() uint16 {
 	switch runtime.GOARCH {
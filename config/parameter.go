@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -33,6 +34,9 @@ type ParameterCommon struct {
 	// A list of Docker container environment variables that should be set to this parameter's value
 	EnvironmentVariables []string
 
+	// True if the parameter holds sensitive data such as an API key, so its value should be masked when displayed
+	Secret bool
+
 	// Whether or not the parameter is allowed to be blank
 	CanBeBlank bool
 
@@ -248,14 +252,14 @@ func (p *Parameter[Type]) deserializeToType(serializedDefault string, network Ne
 			*value = ""
 		} else if p.MaxLength > 0 && len(serializedDefault) > p.MaxLength {
 			err = fmt.Errorf("value [%s] is longer than the max length of [%d]", serializedDefault, p.MaxLength)
-		} else if p.Regex != "" {
-			regex := regexp.MustCompile(p.Regex)
-			if !regex.MatchString(serializedDefault) {
-				err = fmt.Errorf("value [%s] did not match the expected format", serializedDefault)
-			}
+		} else if p.Regex != "" && !regexp.MustCompile(p.Regex).MatchString(serializedDefault) {
+			err = fmt.Errorf("value [%s] did not match the expected format", serializedDefault)
 		} else {
 			*value = serializedDefault
 		}
+	case encoding.TextUnmarshaler:
+		// Custom types such as utils.Duration can provide their own parsing
+		err = value.UnmarshalText([]byte(serializedDefault))
 	}
 	return defaultValue, err
 }
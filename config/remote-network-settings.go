@@ -0,0 +1,336 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/rocket-pool/node-manager-core/utils/artifacts"
+)
+
+const (
+	// The default timeout for each remote network settings request
+	DefaultRemoteSettingsTimeout time.Duration = 30 * time.Second
+
+	// The default limit on the size of a remote settings file
+	DefaultMaxRemoteSettingsSize int64 = 10 * 1024 * 1024
+
+	// The suffix appended to a settings URL to get its minisign signature
+	MinisignSignatureSuffix string = ".minisig"
+
+	remoteSettingsFileExtension string = ".yml"
+	remoteEtagFileExtension     string = ".etag"
+
+	// The most of an error response body to include in the error message
+	maxRemoteErrorBodySize int64 = 1024
+
+	// The limit on the size of a minisign signature
+	maxRemoteSignatureSize int64 = 4096
+)
+
+// A source of network settings hosted on remote HTTPS servers, with a local disk cache
+type RemoteSettingsSource struct {
+	// The URLs to fetch the settings file from, in order of preference. If one fails, the next one is tried.
+	Urls []string
+
+	// The directory to cache downloaded settings in. Cached settings are used if the remote copy hasn't changed
+	// or if none of the URLs can be reached.
+	CacheDir string
+
+	// An optional minisign public key; if set, each settings file must have a valid signature at its URL with
+	// MinisignSignatureSuffix appended.
+	MinisignPublicKey string
+
+	// The timeout for each request; if 0, DefaultRemoteSettingsTimeout is used
+	Timeout time.Duration
+
+	// The largest settings file to accept, in bytes; if 0, DefaultMaxRemoteSettingsSize is used
+	MaxSize int64
+}
+
+// Fetch network settings from the source's URLs, using the cached copy when the remote one hasn't changed.
+// If none of the URLs succeed, the newest cached copy of any of them is used instead. Settings with an older version
+// than the newest cached copy of any URL are rejected, so a compromised or stale mirror can't roll them back. Failing
+// to update the cache isn't an error; it's logged to the context's logger if it has one.
+func (s *RemoteSettingsSource) Fetch(ctx context.Context) (*NetworkSettings, error) {
+	if len(s.Urls) == 0 {
+		return nil, fmt.Errorf("no remote network settings URLs were provided")
+	}
+	for _, url := range s.Urls {
+		err := checkRemoteSettingsUrl(url)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.CacheDir != "" {
+		err := os.MkdirAll(s.CacheDir, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("error creating network settings cache directory [%s]: %w", s.CacheDir, err)
+		}
+	}
+
+	// Get the newest cached copy, which the remote copies can't be older than
+	cachedSettings := s.loadNewestFromCache()
+	var minVersion uint64
+	if cachedSettings != nil {
+		minVersion = cachedSettings.Version
+	}
+
+	// Try each URL in order
+	errs := []error{}
+	for _, url := range s.Urls {
+		settings, err := s.fetchFromUrl(ctx, url, minVersion)
+		if err == nil {
+			return settings, nil
+		}
+		errs = append(errs, fmt.Errorf("error fetching network settings from [%s]: %w", url, err))
+	}
+
+	// Fall back to the cache
+	if cachedSettings != nil {
+		return cachedSettings, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Fetch the settings from a single URL, respecting the cache. Settings older than the min version are rejected.
+func (s *RemoteSettingsSource) fetchFromUrl(ctx context.Context, url string, minVersion uint64) (*NetworkSettings, error) {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultRemoteSettingsTimeout
+	}
+	client := utils.NewIdentityHttpClient(timeout)
+
+	// Build the request, using the cached ETag if there is one
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	etagPath, _ := s.getCachePaths(url)
+	if s.CacheDir != "" {
+		etag, err := os.ReadFile(etagPath)
+		if err == nil && len(etag) > 0 {
+			request.Header.Set("If-None-Match", strings.TrimSpace(string(etag)))
+		}
+	}
+
+	// Send it
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+	defer response.Body.Close()
+
+	// Use the cached copy if the remote hasn't changed
+	if response.StatusCode == http.StatusNotModified {
+		data, err := s.loadFromCache(url)
+		if err != nil {
+			return nil, fmt.Errorf("server reported the settings were unchanged but the cached copy could not be used: %w", err)
+		}
+		settings, err := ParseSettings(data)
+		if err != nil {
+			return nil, err
+		}
+		if settings.Version < minVersion {
+			return nil, fmt.Errorf("cached network settings for this URL have version %d, which is older than the newest cached version %d", settings.Version, minVersion)
+		}
+		return settings, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxRemoteErrorBodySize))
+		return nil, fmt.Errorf("HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	// Read and verify the new copy
+	maxSize := s.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxRemoteSettingsSize
+	}
+	data, err := readLimited(response.Body, maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	signature, err := s.verifySignature(ctx, client, url, data)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := ParseSettings(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't let the remote copy roll back a newer cached one
+	if settings.Version < minVersion {
+		return nil, fmt.Errorf("remote network settings have version %d, which is older than the newest cached version %d", settings.Version, minVersion)
+	}
+
+	// Update the cache
+	if s.CacheDir != "" {
+		err = s.saveToCache(url, data, signature, response.Header.Get("ETag"))
+		if err != nil {
+			if logger, exists := log.FromContext(ctx); exists {
+				logger.Warn("Couldn't cache the remote network settings.", slog.String("url", url), log.Err(err))
+			}
+		}
+	}
+	return settings, nil
+}
+
+// Save a settings file, its signature, and its ETag to the cache
+func (s *RemoteSettingsSource) saveToCache(url string, data []byte, signature []byte, etag string) error {
+	etagPath, settingsPath := s.getCachePaths(url)
+	err := os.WriteFile(settingsPath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error caching network settings to [%s]: %w", settingsPath, err)
+	}
+	if signature != nil {
+		signaturePath := settingsPath + MinisignSignatureSuffix
+		err = os.WriteFile(signaturePath, signature, 0644)
+		if err != nil {
+			return fmt.Errorf("error caching network settings signature to [%s]: %w", signaturePath, err)
+		}
+	}
+	if etag != "" {
+		err = os.WriteFile(etagPath, []byte(etag), 0644)
+	} else {
+		err = os.Remove(etagPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error caching network settings ETag to [%s]: %w", etagPath, err)
+	}
+	return nil
+}
+
+// Download the signature for a settings file and verify it. Returns the signature if a public key was provided, or nil if not.
+func (s *RemoteSettingsSource) verifySignature(ctx context.Context, client *http.Client, url string, data []byte) ([]byte, error) {
+	if s.MinisignPublicKey == "" {
+		return nil, nil
+	}
+
+	signatureUrl := url + MinisignSignatureSuffix
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, signatureUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating signature request: %w", err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading signature from [%s]: %w", signatureUrl, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading signature from [%s]: HTTP status %d", signatureUrl, response.StatusCode)
+	}
+	signature, err := readLimited(response.Body, maxRemoteSignatureSize)
+	if err != nil {
+		return nil, fmt.Errorf("error reading signature from [%s]: %w", signatureUrl, err)
+	}
+
+	err = s.checkSignature(data, signature)
+	if err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// Verify a settings file against its minisign signature
+func (s *RemoteSettingsSource) checkSignature(data []byte, signature []byte) error {
+	publicKey, err := artifacts.ParseMinisignPublicKey(s.MinisignPublicKey)
+	if err != nil {
+		return fmt.Errorf("error parsing network settings public key: %w", err)
+	}
+	_, err = artifacts.VerifyMinisignSignature(data, signature, publicKey)
+	if err != nil {
+		return fmt.Errorf("error verifying network settings signature: %w", err)
+	}
+	return nil
+}
+
+// Load the cached copy of a settings file, verifying its signature if a public key was provided
+func (s *RemoteSettingsSource) loadFromCache(url string) ([]byte, error) {
+	if s.CacheDir == "" {
+		return nil, fmt.Errorf("caching is disabled")
+	}
+	_, settingsPath := s.getCachePaths(url)
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cached network settings [%s]: %w", settingsPath, err)
+	}
+	if s.MinisignPublicKey != "" {
+		signature, err := os.ReadFile(settingsPath + MinisignSignatureSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cached network settings signature: %w", err)
+		}
+		err = s.checkSignature(data, signature)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// Load the cached copy with the newest version across all of the source's URLs, or nil if none of them have a usable
+// cached copy
+func (s *RemoteSettingsSource) loadNewestFromCache() *NetworkSettings {
+	var newest *NetworkSettings
+	for _, url := range s.Urls {
+		data, err := s.loadFromCache(url)
+		if err != nil {
+			continue
+		}
+		settings, err := ParseSettings(data)
+		if err != nil {
+			continue
+		}
+		if newest == nil || settings.Version > newest.Version {
+			newest = settings
+		}
+	}
+	return newest
+}
+
+// Get the paths of the cached ETag and settings file for a URL
+func (s *RemoteSettingsSource) getCachePaths(url string) (string, string) {
+	hash := sha256.Sum256([]byte(url))
+	name := hex.EncodeToString(hash[:])
+	return filepath.Join(s.CacheDir, name+remoteEtagFileExtension), filepath.Join(s.CacheDir, name+remoteSettingsFileExtension)
+}
+
+// Make sure a settings URL uses HTTPS, since the settings control which contracts and endpoints the node trusts
+func checkRemoteSettingsUrl(rawUrl string) error {
+	parsedUrl, err := neturl.Parse(rawUrl)
+	if err != nil {
+		return fmt.Errorf("invalid network settings URL [%s]: %w", rawUrl, err)
+	}
+	if parsedUrl.Scheme != "https" {
+		return fmt.Errorf("network settings URL [%s] must use HTTPS", rawUrl)
+	}
+	return nil
+}
+
+// Read a body, failing if it's larger than the limit
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("body is larger than the limit of %d bytes", limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/node-manager-core/config/ids"
+)
+
+// The containers that get resource limits if none are specified
+var defaultResourceLimitContainers = []ContainerID{
+	ContainerID_Daemon,
+	ContainerID_ExecutionClient,
+	ContainerID_BeaconNode,
+	ContainerID_ValidatorClient,
+	ContainerID_MevBoost,
+	ContainerID_Exporter,
+	ContainerID_Prometheus,
+	ContainerID_Grafana,
+}
+
+// Configuration for the resource limits of each container
+type ResourceLimitsConfig struct {
+	// The limits for each container
+	Containers map[ContainerID]*ContainerResourceLimitsConfig
+}
+
+// Generates a new resource limits configuration with a section for each of the provided containers. If no containers
+// are provided, every container NMC knows about gets a section.
+func NewResourceLimitsConfig(containers ...ContainerID) *ResourceLimitsConfig {
+	if len(containers) == 0 {
+		containers = defaultResourceLimitContainers
+	}
+	cfg := &ResourceLimitsConfig{
+		Containers: make(map[ContainerID]*ContainerResourceLimitsConfig, len(containers)),
+	}
+	for _, container := range containers {
+		cfg.Containers[container] = NewContainerResourceLimitsConfig(container)
+	}
+	return cfg
+}
+
+// The title for the config
+func (cfg *ResourceLimitsConfig) GetTitle() string {
+	return "Resource Limits"
+}
+
+// Get the parameters for this config
+func (cfg *ResourceLimitsConfig) GetParameters() []IParameter {
+	return []IParameter{}
+}
+
+// Get the sections underneath this one
+func (cfg *ResourceLimitsConfig) GetSubconfigs() map[string]IConfigSection {
+	subconfigs := make(map[string]IConfigSection, len(cfg.Containers))
+	for container, limits := range cfg.Containers {
+		subconfigs[string(container)] = limits
+	}
+	return subconfigs
+}
+
+// ==================
+// === Templating ===
+// ==================
+
+// Get the CPU shares for the container in a format compose files accept, or an empty string if it doesn't have a limit
+func (cfg *ResourceLimitsConfig) GetCpuShares(container ContainerID) string {
+	limits, exists := cfg.Containers[container]
+	if !exists {
+		return ""
+	}
+	return limits.GetCpuShares()
+}
+
+// Get the memory limit for the container in a format compose files accept, or an empty string if it doesn't have a
+// limit
+func (cfg *ResourceLimitsConfig) GetMemoryLimit(container ContainerID) string {
+	limits, exists := cfg.Containers[container]
+	if !exists {
+		return ""
+	}
+	return limits.GetMemoryLimit()
+}
+
+// Configuration for the resource limits of a single container
+type ContainerResourceLimitsConfig struct {
+	// The container these limits apply to
+	container ContainerID
+
+	// The relative CPU weight of the container when the host's CPUs are under contention; 0 uses Docker's default
+	CpuShares Parameter[uint64]
+
+	// The maximum amount of memory the container can use, in MB; 0 means no limit
+	MemoryLimit Parameter[uint64]
+}
+
+// Generates a new resource limits configuration for a single container
+func NewContainerResourceLimitsConfig(container ContainerID) *ContainerResourceLimitsConfig {
+	return &ContainerResourceLimitsConfig{
+		container: container,
+
+		CpuShares: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.ResourceLimitsCpuSharesID,
+				Name:               "CPU Shares",
+				Description:        "The relative weight this container gets when the CPUs on your machine are busy. Docker's default is 1024, so a container with 512 gets half as much CPU time as one with the default when they compete for it. This has no effect when the CPUs aren't fully used.\n\nUse 0 for Docker's default.",
+				AffectsContainers:  []ContainerID{container},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: 0,
+			},
+		},
+
+		MemoryLimit: Parameter[uint64]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.ResourceLimitsMemoryLimitID,
+				Name:               "Memory Limit",
+				Description:        "The maximum amount of memory this container can use, in MB. Docker will stop the container if it goes over this limit, so make sure it leaves enough room for the client to run normally.\n\nUse 0 for no limit.",
+				AffectsContainers:  []ContainerID{container},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]uint64{
+				Network_All: 0,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *ContainerResourceLimitsConfig) GetTitle() string {
+	return fmt.Sprintf("Resource Limits (%s)", cfg.container)
+}
+
+// Get the parameters for this config
+func (cfg *ContainerResourceLimitsConfig) GetParameters() []IParameter {
+	return []IParameter{
+		&cfg.CpuShares,
+		&cfg.MemoryLimit,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *ContainerResourceLimitsConfig) GetSubconfigs() map[string]IConfigSection {
+	return map[string]IConfigSection{}
+}
+
+// Get the CPU shares in a format compose files accept, or an empty string if the container uses Docker's default
+func (cfg *ContainerResourceLimitsConfig) GetCpuShares() string {
+	if cfg.CpuShares.Value == 0 {
+		return ""
+	}
+	return strconv.FormatUint(cfg.CpuShares.Value, 10)
+}
+
+// Get the memory limit in a format compose files accept (e.g. "2048m"), or an empty string if the container doesn't
+// have a limit
+func (cfg *ContainerResourceLimitsConfig) GetMemoryLimit() string {
+	if cfg.MemoryLimit.Value == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dm", cfg.MemoryLimit.Value)
+}
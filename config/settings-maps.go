@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A setting that had different values in the two maps being merged
+type MergeConflict struct {
+	// The path to the setting, with section names separated by periods
+	Path string
+
+	// The value in the base map
+	BaseValue string
+
+	// The value in the overlay map
+	OverlayValue string
+}
+
+// Create a deep copy of a serialized config section, as produced by Serialize().
+// Nested map[string]string sections are converted to map[string]any so the result can be used with Deserialize().
+func CopySettingsMap(source map[string]any) (map[string]any, error) {
+	return copySettingsMapImpl(source, "")
+}
+
+// Merge the settings in an overlay map on top of a base map, returning a new map and leaving both inputs unmodified.
+// Settings that exist in both maps with different values are reported as conflicts; if overwrite is true the overlay's
+// value is used for them, otherwise the base's value is kept.
+// An error is returned if a key is a section in one map but a setting in the other.
+func MergeSettingsMaps(base map[string]any, overlay map[string]any, overwrite bool) (map[string]any, []MergeConflict, error) {
+	merged, err := CopySettingsMap(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error copying base settings: %w", err)
+	}
+	conflicts := []MergeConflict{}
+	err = mergeSettingsMapsImpl(merged, overlay, overwrite, "", &conflicts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return merged, conflicts, nil
+}
+
+// Recursive implementation of CopySettingsMap
+func copySettingsMapImpl(source map[string]any, path string) (map[string]any, error) {
+	result := make(map[string]any, len(source))
+	for key, value := range source {
+		keyPath := joinSettingsPath(path, key)
+		switch typedValue := value.(type) {
+		case string:
+			result[key] = typedValue
+		case map[string]any:
+			subcopy, err := copySettingsMapImpl(typedValue, keyPath)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = subcopy
+		case map[string]string:
+			subcopy := make(map[string]any, len(typedValue))
+			for subkey, subvalue := range typedValue {
+				subcopy[subkey] = subvalue
+			}
+			result[key] = subcopy
+		default:
+			return nil, fmt.Errorf("setting [%s] has unsupported type %s", keyPath, reflect.TypeOf(value))
+		}
+	}
+	return result, nil
+}
+
+// Recursive implementation of MergeSettingsMaps; target is modified in place
+func mergeSettingsMapsImpl(target map[string]any, overlay map[string]any, overwrite bool, path string, conflicts *[]MergeConflict) error {
+	for key, overlayValue := range overlay {
+		keyPath := joinSettingsPath(path, key)
+
+		// Normalize the overlay value so nested sections are always map[string]any
+		if overlayStrings, isStringMap := overlayValue.(map[string]string); isStringMap {
+			converted := make(map[string]any, len(overlayStrings))
+			for subkey, subvalue := range overlayStrings {
+				converted[subkey] = subvalue
+			}
+			overlayValue = converted
+		}
+
+		targetValue, exists := target[key]
+		if !exists {
+			switch typedValue := overlayValue.(type) {
+			case string:
+				target[key] = typedValue
+			case map[string]any:
+				subcopy, err := copySettingsMapImpl(typedValue, keyPath)
+				if err != nil {
+					return err
+				}
+				target[key] = subcopy
+			default:
+				return fmt.Errorf("setting [%s] has unsupported type %s", keyPath, reflect.TypeOf(overlayValue))
+			}
+			continue
+		}
+
+		switch typedTarget := targetValue.(type) {
+		case string:
+			overlayString, isString := overlayValue.(string)
+			if !isString {
+				return fmt.Errorf("setting [%s] is a value in the base settings but a section in the overlay", keyPath)
+			}
+			if overlayString != typedTarget {
+				*conflicts = append(*conflicts, MergeConflict{
+					Path:         keyPath,
+					BaseValue:    typedTarget,
+					OverlayValue: overlayString,
+				})
+				if overwrite {
+					target[key] = overlayString
+				}
+			}
+		case map[string]any:
+			overlayMap, isMap := overlayValue.(map[string]any)
+			if !isMap {
+				return fmt.Errorf("setting [%s] is a section in the base settings but a value in the overlay", keyPath)
+			}
+			err := mergeSettingsMapsImpl(typedTarget, overlayMap, overwrite, keyPath, conflicts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Append a key to a settings path
+func joinSettingsPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return strings.Join([]string{path, key}, ".")
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-pool/node-manager-core/config/ids"
+	"github.com/rocket-pool/node-manager-core/utils/sys"
+)
+
+const (
+	// The default name of the Docker volume for the Execution Client's data
+	DefaultEcVolumeName string = "ecdata"
+
+	// The default name of the Docker volume for the Beacon Node's data
+	DefaultBnVolumeName string = "bndata"
+
+	// Matches the names Docker accepts for volumes
+	dockerVolumeNameRegex string = "^[a-zA-Z0-9][a-zA-Z0-9_.-]*$"
+
+	// The number of bytes in a GB, for disk space checks
+	bytesPerGb uint64 = 1e9
+)
+
+// Configuration for where the clients store their chain data
+type StorageConfig struct {
+	// A directory on the host to store the Execution Client's data in, instead of a Docker volume
+	EcDataDirectory Parameter[string]
+
+	// The name of the Docker volume for the Execution Client's data
+	EcVolumeName Parameter[string]
+
+	// A directory on the host to store the Beacon Node's data in, instead of a Docker volume
+	BnDataDirectory Parameter[string]
+
+	// The name of the Docker volume for the Beacon Node's data
+	BnVolumeName Parameter[string]
+
+	// Toggle for using volumes that were created outside of Docker Compose
+	UseExternalVolumes Parameter[bool]
+}
+
+// Generates a new storage configuration
+func NewStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		EcDataDirectory: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.StorageEcDataDirectoryID,
+				Name:               "Execution Client Data Directory",
+				Description:        "The full path of a directory on your machine to store the Execution Client's chain data in, such as one on a separate SSD. The directory must already exist.\n\nLeave this blank to store the data in a Docker volume instead.",
+				AffectsContainers:  []ContainerID{ContainerID_ExecutionClient},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		EcVolumeName: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.StorageEcVolumeNameID,
+				Name:               "Execution Client Volume Name",
+				Description:        "The name of the Docker volume to store the Execution Client's chain data in. This isn't used if you set a data directory for the Execution Client.",
+				AffectsContainers:  []ContainerID{ContainerID_ExecutionClient},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Regex:              dockerVolumeNameRegex,
+			},
+			Default: map[Network]string{
+				Network_All: DefaultEcVolumeName,
+			},
+		},
+
+		BnDataDirectory: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.StorageBnDataDirectoryID,
+				Name:               "Beacon Node Data Directory",
+				Description:        "The full path of a directory on your machine to store the Beacon Node's chain data in, such as one on a separate SSD. The directory must already exist.\n\nLeave this blank to store the data in a Docker volume instead.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]string{
+				Network_All: "",
+			},
+		},
+
+		BnVolumeName: Parameter[string]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.StorageBnVolumeNameID,
+				Name:               "Beacon Node Volume Name",
+				Description:        "The name of the Docker volume to store the Beacon Node's chain data in. This isn't used if you set a data directory for the Beacon Node.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Regex:              dockerVolumeNameRegex,
+			},
+			Default: map[Network]string{
+				Network_All: DefaultBnVolumeName,
+			},
+		},
+
+		UseExternalVolumes: Parameter[bool]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.StorageUseExternalVolumesID,
+				Name:               "Use External Volumes",
+				Description:        "Enable this if the Docker volumes for the client data were created outside of Docker Compose (for example with `docker volume create`), so they aren't created or removed along with the containers. The volumes must already exist.",
+				AffectsContainers:  []ContainerID{ContainerID_ExecutionClient, ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]bool{
+				Network_All: false,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *StorageConfig) GetTitle() string {
+	return "Storage"
+}
+
+// Get the parameters for this config
+func (cfg *StorageConfig) GetParameters() []IParameter {
+	return []IParameter{
+		&cfg.EcDataDirectory,
+		&cfg.EcVolumeName,
+		&cfg.BnDataDirectory,
+		&cfg.BnVolumeName,
+		&cfg.UseExternalVolumes,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *StorageConfig) GetSubconfigs() map[string]IConfigSection {
+	return map[string]IConfigSection{}
+}
+
+// Check that the configured data directories exist and that the filesystems they're on have at least the provided
+// amount of free space, in GB. Use 0 to skip the free space check for a client. Clients that store their data in a
+// Docker volume aren't checked.
+func (cfg *StorageConfig) Validate(ecMinFreeGb uint64, bnMinFreeGb uint64) error {
+	errs := []error{}
+	if cfg.EcDataDirectory.Value != "" {
+		err := validateDataDirectory(cfg.EcDataDirectory.Value, ecMinFreeGb)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid Execution Client data directory: %w", err))
+		}
+	}
+	if cfg.BnDataDirectory.Value != "" {
+		err := validateDataDirectory(cfg.BnDataDirectory.Value, bnMinFreeGb)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid Beacon Node data directory: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check that a data directory is an existing absolute path with enough free space on its filesystem
+func validateDataDirectory(path string, minFreeGb uint64) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("[%s] is not an absolute path", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("[%s] does not exist", path)
+		}
+		return fmt.Errorf("error checking [%s]: %w", path, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("[%s] is not a directory", path)
+	}
+	if minFreeGb == 0 {
+		return nil
+	}
+
+	space, err := sys.GetDiskSpace(path)
+	if err != nil {
+		return err
+	}
+	freeGb := space.Available / bytesPerGb
+	if freeGb < minFreeGb {
+		return fmt.Errorf("[%s] only has %d GB free but needs at least %d GB", path, freeGb, minFreeGb)
+	}
+	return nil
+}
+
+// ==================
+// === Templating ===
+// ==================
+
+// Get the source of the Execution Client's data mount: its data directory if one is set, or its volume name otherwise
+func (cfg *StorageConfig) GetEcDataMount() string {
+	if cfg.EcDataDirectory.Value != "" {
+		return cfg.EcDataDirectory.Value
+	}
+	return cfg.EcVolumeName.Value
+}
+
+// Get the source of the Beacon Node's data mount: its data directory if one is set, or its volume name otherwise
+func (cfg *StorageConfig) GetBnDataMount() string {
+	if cfg.BnDataDirectory.Value != "" {
+		return cfg.BnDataDirectory.Value
+	}
+	return cfg.BnVolumeName.Value
+}
+
+// Get the names of the Docker volumes the clients use, which need to be declared in the compose file. Clients that
+// store their data in a directory don't have a volume.
+func (cfg *StorageConfig) GetDataVolumes() []string {
+	volumes := []string{}
+	if cfg.EcDataDirectory.Value == "" {
+		volumes = append(volumes, cfg.EcVolumeName.Value)
+	}
+	if cfg.BnDataDirectory.Value == "" {
+		volumes = append(volumes, cfg.BnVolumeName.Value)
+	}
+	return volumes
+}
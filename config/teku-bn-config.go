@@ -21,6 +21,9 @@ type TekuBnConfig struct {
 	// The archive mode flag
 	ArchiveMode Parameter[bool]
 
+	// Toggle for rebuilding the historic states from before the checkpoint sync point
+	ReconstructHistoricStates Parameter[bool]
+
 	// The Docker Hub tag for the Teku BN
 	ContainerTag Parameter[string]
 
@@ -73,6 +76,20 @@ func NewTekuBnConfig() *TekuBnConfig {
 			},
 		},
 
+		ReconstructHistoricStates: Parameter[bool]{
+			ParameterCommon: &ParameterCommon{
+				ID:                 ids.BnReconstructHistoricStatesID,
+				Name:               "Reconstruct Historic States",
+				Description:        "When enabled, Teku will rebuild the states of the Beacon chain before its checkpoint sync point in the background, so it can serve the entire history like an archive node. It only has an effect in archive mode.\n\nThis takes a long time and a lot of extra disk space, so only enable it if you need it.",
+				AffectsContainers:  []ContainerID{ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[Network]bool{
+				Network_All: false,
+			},
+		},
+
 		ContainerTag: Parameter[string]{
 			ParameterCommon: &ParameterCommon{
 				ID:                 ids.ContainerTagID,
@@ -114,6 +131,7 @@ func (cfg *TekuBnConfig) GetParameters() []IParameter {
 		&cfg.JvmHeapSize,
 		&cfg.MaxPeers,
 		&cfg.ArchiveMode,
+		&cfg.ReconstructHistoricStates,
 		&cfg.ContainerTag,
 		&cfg.AdditionalFlags,
 	}
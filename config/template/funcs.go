@@ -0,0 +1,167 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+// A config section that runs a client in a container with a selectable tag
+type containerTagProvider interface {
+	GetContainerTag() string
+}
+
+// A config section for a client with a peer limit
+type maxPeersProvider interface {
+	GetMaxPeers() uint16
+}
+
+// A config section for a client that accepts extra command line flags
+type additionalFlagsProvider interface {
+	GetAdditionalFlags() string
+}
+
+// Get the standard helper functions for rendering compose templates from the config. These are available in every
+// template rendered by a Renderer.
+func GetFuncMap() template.FuncMap {
+	return template.FuncMap{
+		// Ports
+		"portMapping": func(mode config.RpcPortMode, port uint16) string {
+			return mode.DockerPortMapping(port)
+		},
+		"ecOpenPorts": func(cfg *config.LocalExecutionConfig) string {
+			return cfg.GetOpenApiPortMapping()
+		},
+		"bnOpenPorts": func(cfg *config.LocalBeaconConfig) []string {
+			return cfg.GetOpenApiPortMapping()
+		},
+		"bnP2pPorts": func(cfg *config.LocalBeaconConfig) []string {
+			return cfg.GetP2pPortMappings()
+		},
+		"bnMetricsPort": func(cfg *config.LocalBeaconConfig, port uint16) string {
+			return cfg.GetMetricsPortMapping(port)
+		},
+
+		// Client settings
+		"containerTag": func(cfg containerTagProvider) string {
+			return cfg.GetContainerTag()
+		},
+		"maxPeers": func(cfg maxPeersProvider) uint16 {
+			return cfg.GetMaxPeers()
+		},
+		"additionalFlags": func(cfg additionalFlagsProvider) string {
+			return cfg.GetAdditionalFlags()
+		},
+
+		// Networks
+		"ecNetworkFlag":       GetEcNetworkFlag,
+		"bnNetworkFlag":       GetBnNetworkFlag,
+		"ecNetworkFlags":      config.GetExecutionClientNetworkFlags,
+		"bnNetworkFlags":      config.GetBeaconNodeNetworkFlags,
+		"networkVolume":       config.GetCustomNetworkVolume,
+		"checkpointSyncUrl":   GetCheckpointSyncUrl,
+		"checkpointSyncFlags": GetCheckpointSyncFlags,
+
+		// Deployment
+		"combinedMode": func(cfg *config.LocalBeaconConfig) bool {
+			return cfg.IsCombinedMode()
+		},
+		"validatorContainer": func(cfg *config.LocalBeaconConfig) config.ContainerID {
+			return cfg.GetValidatorContainer()
+		},
+		"bnArchiveMode": func(cfg *config.LocalBeaconConfig) bool {
+			return cfg.IsArchiveMode()
+		},
+		"bnDataSizeHint": func(cfg *config.LocalBeaconConfig, network config.Network) uint64 {
+			return cfg.GetDataSizeHint(network)
+		},
+
+		// Resources
+		"cpuShares": func(cfg *config.ResourceLimitsConfig, container config.ContainerID) string {
+			return cfg.GetCpuShares(container)
+		},
+		"memLimit": func(cfg *config.ResourceLimitsConfig, container config.ContainerID) string {
+			return cfg.GetMemoryLimit(container)
+		},
+
+		// Storage
+		"ecDataMount": func(cfg *config.StorageConfig) string {
+			return cfg.GetEcDataMount()
+		},
+		"bnDataMount": func(cfg *config.StorageConfig) string {
+			return cfg.GetBnDataMount()
+		},
+		"dataVolumes": func(cfg *config.StorageConfig) []string {
+			return cfg.GetDataVolumes()
+		},
+		"externalVolumes": func(cfg *config.StorageConfig) bool {
+			return cfg.UseExternalVolumes.Value
+		},
+
+		// Formatting
+		"indent": Indent,
+	}
+}
+
+// Get the flag that selects the provided network for an Execution Client. This only supports networks the client
+// knows natively; use config.GetExecutionClientNetworkFlags for custom networks.
+func GetEcNetworkFlag(client config.ExecutionClient, network string) (string, error) {
+	flags, err := config.GetExecutionClientNetworkFlags(client, &config.NetworkResources{EthNetworkName: network})
+	if err != nil {
+		return "", err
+	}
+	return flags[0], nil
+}
+
+// Get the flag that selects the provided network for a Beacon Node. This only supports networks the client knows
+// natively; use config.GetBeaconNodeNetworkFlags for custom networks.
+func GetBnNetworkFlag(client config.BeaconNode, network string) (string, error) {
+	flags, err := config.GetBeaconNodeNetworkFlags(client, &config.NetworkResources{EthNetworkName: network})
+	if err != nil {
+		return "", err
+	}
+	return flags[0], nil
+}
+
+// Get the checkpoint sync URL for the local Beacon Node without a trailing slash, or an empty string if checkpoint
+// sync is disabled
+func GetCheckpointSyncUrl(cfg *config.LocalBeaconConfig) string {
+	return strings.TrimSuffix(strings.TrimSpace(cfg.CheckpointSyncProvider.Value), "/")
+}
+
+// Get the flags that enable checkpoint sync on the selected Beacon Node, or an empty string if checkpoint sync is
+// disabled. Nimbus syncs from a checkpoint with a separate command, so its flag is meant for that command rather than
+// the Beacon Node itself.
+func GetCheckpointSyncFlags(cfg *config.LocalBeaconConfig) (string, error) {
+	url := GetCheckpointSyncUrl(cfg)
+	if url == "" {
+		return "", nil
+	}
+	switch cfg.BeaconNode.Value {
+	case config.BeaconNode_Lighthouse, config.BeaconNode_Teku:
+		return "--checkpoint-sync-url=" + url, nil
+	case config.BeaconNode_Lodestar:
+		return "--checkpointSyncUrl=" + url, nil
+	case config.BeaconNode_Nimbus:
+		return "--trusted-node-url=" + url, nil
+	case config.BeaconNode_Prysm:
+		return fmt.Sprintf("--checkpoint-sync-url=%s --genesis-beacon-api-url=%s", url, url), nil
+	default:
+		return "", fmt.Errorf("unknown Beacon Node [%s]", cfg.BeaconNode.Value)
+	}
+}
+
+// Indent every non-empty line of the text by the provided number of spaces, for placing included templates inside
+// YAML blocks
+func Indent(spaces int, text string) string {
+	padding := strings.Repeat(" ", spaces)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = padding + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
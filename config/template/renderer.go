@@ -0,0 +1,109 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+const (
+	// The deepest templates can include each other before rendering fails, to catch include loops
+	maxIncludeDepth int = 32
+)
+
+// Renders compose files and other config-derived templates. Templates can render each other with
+// `{{ include "name" . }}`, which returns the output as a string so it can be piped into other functions like indent.
+type Renderer struct {
+	templates *template.Template
+}
+
+// Creates a new renderer with all of the templates that match the provided glob patterns, named by their file names.
+// The extra functions are added to the standard ones from GetFuncMap, replacing any with the same names.
+func NewRenderer(extraFuncs template.FuncMap, patterns ...string) (*Renderer, error) {
+	funcs := GetFuncMap()
+	for name, function := range extraFuncs {
+		funcs[name] = function
+	}
+
+	// Include is bound to each render separately, so this is just a placeholder for parsing
+	funcs["include"] = func(name string, data any) (string, error) {
+		return "", fmt.Errorf("include can only be used while rendering")
+	}
+
+	templates := template.New("").Funcs(funcs).Option("missingkey=error")
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error matching templates with [%s]: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no templates match [%s]", pattern)
+		}
+		templates, err = templates.ParseFiles(matches...)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing templates matching [%s]: %w", pattern, err)
+		}
+	}
+	return &Renderer{
+		templates: templates,
+	}, nil
+}
+
+// Render the template with the provided name, writing the output to the writer
+func (r *Renderer) Render(writer io.Writer, name string, data any) error {
+	templates, err := r.templates.Clone()
+	if err != nil {
+		return fmt.Errorf("error preparing templates: %w", err)
+	}
+
+	// Bind include to this render so its depth is tracked separately from other renders
+	depth := 0
+	templates.Funcs(template.FuncMap{
+		"include": func(includedName string, includedData any) (string, error) {
+			if depth >= maxIncludeDepth {
+				return "", fmt.Errorf("templates included each other more than %d times, there may be an include loop", maxIncludeDepth)
+			}
+			depth++
+			defer func() {
+				depth--
+			}()
+
+			var buffer bytes.Buffer
+			err := templates.ExecuteTemplate(&buffer, includedName, includedData)
+			if err != nil {
+				return "", err
+			}
+			return buffer.String(), nil
+		},
+	})
+
+	err = templates.ExecuteTemplate(writer, name, data)
+	if err != nil {
+		return fmt.Errorf("error rendering template [%s]: %w", name, err)
+	}
+	return nil
+}
+
+// Render the template with the provided name to a file. The file is only replaced once the template has rendered
+// successfully, so a failed render won't leave a partial file behind.
+func (r *Renderer) RenderToFile(path string, name string, data any, mode os.FileMode) error {
+	var buffer bytes.Buffer
+	err := r.Render(&buffer, name, data)
+	if err != nil {
+		return err
+	}
+
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, buffer.Bytes(), mode)
+	if err != nil {
+		return fmt.Errorf("error writing [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving [%s] to [%s]: %w", tempPath, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package eth
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/store"
+)
+
+const (
+	// The store bucket that holds address book entries, keyed by address
+	addressBookBucket string = "address-book"
+)
+
+// A labeled address in the address book
+type AddressBookEntry struct {
+	// The address being labeled
+	Address common.Address `json:"address"`
+
+	// The human-readable name of the address, such as "Rocket Pool Deposit Pool"
+	Label string `json:"label"`
+
+	// Tags for grouping addresses, such as "contract" or "token"
+	Tags []string `json:"tags,omitempty"`
+}
+
+// A persistent registry of human-readable labels for addresses, so user-facing output can show names instead of raw
+// addresses. Labels are unique (ignoring case) so they can also be used to look addresses up.
+type AddressBook struct {
+	kvStore store.IKeyValueStore
+	entries map[common.Address]AddressBookEntry
+	labels  map[string]common.Address
+	lock    sync.RWMutex
+}
+
+// Creates a new address book, loading its entries from the store
+func NewAddressBook(kvStore store.IKeyValueStore) (*AddressBook, error) {
+	book := &AddressBook{
+		kvStore: kvStore,
+		entries: map[common.Address]AddressBookEntry{},
+		labels:  map[string]common.Address{},
+	}
+	err := kvStore.ForEach(addressBookBucket, nil, nil, func(key []byte, value []byte) error {
+		var entry AddressBookEntry
+		err := json.Unmarshal(value, &entry)
+		if err != nil {
+			return fmt.Errorf("error deserializing entry for %x: %w", key, err)
+		}
+		book.entries[entry.Address] = entry
+		book.labels[normalizeLabel(entry.Label)] = entry.Address
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading address book: %w", err)
+	}
+	return book, nil
+}
+
+// Add an entry to the address book, replacing the address's existing entry if it has one
+func (b *AddressBook) SetEntry(entry AddressBookEntry) error {
+	entry.Label = strings.TrimSpace(entry.Label)
+	if entry.Label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	label := normalizeLabel(entry.Label)
+	if existing, exists := b.labels[label]; exists && existing != entry.Address {
+		return fmt.Errorf("label [%s] is already used by %s", entry.Label, existing.Hex())
+	}
+	err := b.kvStore.Update(func(tx store.IStoreTransaction) error {
+		return store.PutJson(tx, addressBookBucket, entry.Address[:], entry)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving address book entry for %s: %w", entry.Address.Hex(), err)
+	}
+
+	if previous, exists := b.entries[entry.Address]; exists {
+		delete(b.labels, normalizeLabel(previous.Label))
+	}
+	b.entries[entry.Address] = entry
+	b.labels[label] = entry.Address
+	return nil
+}
+
+// Remove an address's entry from the address book. Returns false if it didn't have one.
+func (b *AddressBook) DeleteEntry(address common.Address) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	entry, exists := b.entries[address]
+	if !exists {
+		return false, nil
+	}
+	err := b.kvStore.Delete(addressBookBucket, address[:])
+	if err != nil {
+		return false, fmt.Errorf("error deleting address book entry for %s: %w", address.Hex(), err)
+	}
+	delete(b.entries, address)
+	delete(b.labels, normalizeLabel(entry.Label))
+	return true, nil
+}
+
+// Get an address's entry, or false if it doesn't have one
+func (b *AddressBook) GetEntry(address common.Address) (AddressBookEntry, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	entry, exists := b.entries[address]
+	return entry, exists
+}
+
+// Get the entry with the provided label (ignoring case), or false if there isn't one
+func (b *AddressBook) FindByLabel(label string) (AddressBookEntry, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	address, exists := b.labels[normalizeLabel(label)]
+	if !exists {
+		return AddressBookEntry{}, false
+	}
+	return b.entries[address], true
+}
+
+// Get all of the entries, optionally only the ones with the provided tag, sorted by label
+func (b *AddressBook) GetEntries(tag string) []AddressBookEntry {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	entries := make([]AddressBookEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		if tag == "" || slices.Contains(entry.Tags, tag) {
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(first AddressBookEntry, second AddressBookEntry) int {
+		if order := strings.Compare(normalizeLabel(first.Label), normalizeLabel(second.Label)); order != 0 {
+			return order
+		}
+		return bytes.Compare(first.Address[:], second.Address[:])
+	})
+	return entries
+}
+
+// Format an address for display as "Label (0x...)", or just the checksummed address if it doesn't have a label
+func (b *AddressBook) FormatAddress(address common.Address) string {
+	entry, exists := b.GetEntry(address)
+	if !exists {
+		return address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", entry.Label, address.Hex())
+}
+
+// Normalize a label for case-insensitive lookups
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
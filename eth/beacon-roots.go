@@ -0,0 +1,50 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// The number of timestamps the EIP-4788 beacon roots contract keeps roots for before overwriting them
+	BeaconRootsHistoryBufferLength uint64 = 8191
+
+	// The error message Execution clients return when a call reverts without a reason
+	executionRevertedMessage string = "execution reverted"
+)
+
+// The address of the EIP-4788 beacon roots contract, which is the same on every network
+var BeaconRootsAddress = common.HexToAddress("0x000F3df6D732807Ef1319fB7B8bB8522d0Beac02")
+
+// Get the parent Beacon block root recorded by the EIP-4788 beacon roots contract for the Execution block with the
+// provided timestamp, as of the provided block (or the latest block if it's nil). This is the root of the Beacon
+// block that preceded the one containing the Execution block, which is what on-chain verifiers check Beacon proofs
+// against. Returns false if the contract doesn't have a root for the timestamp, either because there was no block
+// with that timestamp or because it's older than the contract's history buffer.
+func GetParentBeaconBlockRoot(ctx context.Context, client IExecutionClient, timestamp uint64, blockNumber *big.Int) (common.Hash, bool, error) {
+	if timestamp == 0 {
+		return common.Hash{}, false, fmt.Errorf("timestamp must be greater than 0")
+	}
+
+	// The contract takes the timestamp as raw calldata instead of an ABI-encoded function call
+	input := common.BigToHash(new(big.Int).SetUint64(timestamp))
+	output, err := client.CallContract(ctx, ethereum.CallMsg{
+		To:   &BeaconRootsAddress,
+		Data: input[:],
+	}, blockNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), executionRevertedMessage) {
+			return common.Hash{}, false, nil
+		}
+		return common.Hash{}, false, fmt.Errorf("error calling beacon roots contract: %w", err)
+	}
+	if len(output) != common.HashLength {
+		return common.Hash{}, false, fmt.Errorf("beacon roots contract returned %d bytes instead of %d", len(output), common.HashLength)
+	}
+	return common.BytesToHash(output), true, nil
+}
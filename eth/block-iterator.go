@@ -0,0 +1,247 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/node-manager-core/store"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The default number of blocks to request in each JSON-RPC batch
+	DefaultBlockIteratorBatchSize int = 20
+
+	// The store bucket that holds block iterator checkpoints, keyed by the iterator's checkpoint key
+	blockIteratorBucket string = "execution-block-iterator-checkpoints"
+)
+
+// Settings for walking a range of Execution blocks
+type BlockIteratorSettings struct {
+	// The number of blocks to request in each JSON-RPC batch. Blocks are still passed to the handler one at a time,
+	// in order.
+	BatchSize int
+
+	// Whether to get the receipts of each block's transactions too. This uses eth_getBlockReceipts, which the major
+	// clients all support.
+	IncludeReceipts bool
+
+	// The policy for retrying batches that fail
+	RetryPolicy utils.RetryPolicy
+}
+
+// The block iterator settings to use if none are configured
+var DefaultBlockIteratorSettings = BlockIteratorSettings{
+	BatchSize:       DefaultBlockIteratorBatchSize,
+	IncludeReceipts: true,
+	RetryPolicy:     utils.DefaultRetryPolicy,
+}
+
+// A block provided to a block iterator's handler
+type IteratedBlock struct {
+	// The block with its transactions and withdrawals. Uncle headers aren't included.
+	Block *types.Block
+
+	// The receipts of the block's transactions, in the same order; only set if the iterator includes receipts
+	Receipts []*types.Receipt
+}
+
+// The body fields of an eth_getBlockByNumber response; the header fields are decoded separately
+type rpcBlockBody struct {
+	Transactions []*types.Transaction `json:"transactions"`
+	Withdrawals  []*types.Withdrawal  `json:"withdrawals"`
+}
+
+// Walks a range of blocks on the Execution chain in either direction, handing each block (and optionally its
+// receipts) to a handler in order. Blocks are requested in JSON-RPC batches ahead of the handler. Progress can be
+// saved in a key-value store so an interrupted walk picks up where it left off.
+type BlockIterator struct {
+	client        IExecutionClient
+	settings      BlockIteratorSettings
+	kvStore       store.IKeyValueStore
+	checkpointKey string
+}
+
+// Creates a new block iterator. The client must provide access to its RPC client so it can send batches.
+func NewBlockIterator(client IExecutionClient, settings BlockIteratorSettings) (*BlockIterator, error) {
+	if settings.BatchSize < 1 {
+		return nil, fmt.Errorf("batch size must be at least 1")
+	}
+	return &BlockIterator{
+		client:   client,
+		settings: settings,
+	}, nil
+}
+
+// Save the iterator's progress in the provided store under the provided key, so walks started with
+// IterateFromCheckpoint resume after the last block that was fully processed
+func (i *BlockIterator) SetCheckpointStore(kvStore store.IKeyValueStore, key string) {
+	i.kvStore = kvStore
+	i.checkpointKey = key
+}
+
+// Get the last block that was fully processed, or false if the iterator doesn't have a checkpoint yet
+func (i *BlockIterator) GetCheckpoint() (uint64, bool, error) {
+	if i.kvStore == nil {
+		return 0, false, fmt.Errorf("block iterator doesn't have a checkpoint store")
+	}
+	value, err := i.kvStore.Get(blockIteratorBucket, []byte(i.checkpointKey))
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting checkpoint [%s]: %w", i.checkpointKey, err)
+	}
+	if value == nil {
+		return 0, false, nil
+	}
+	block, err := store.ParseUint64Key(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("error parsing checkpoint [%s]: %w", i.checkpointKey, err)
+	}
+	return block, true, nil
+}
+
+// Walk the blocks from the block after the checkpoint (or the first block, if there's no checkpoint yet or it's
+// outside of the range) through the last block, saving a new checkpoint as blocks are handled. See Iterate for details.
+func (i *BlockIterator) IterateFromCheckpoint(ctx context.Context, firstBlock uint64, lastBlock uint64, handler func(block IteratedBlock) error) error {
+	checkpoint, exists, err := i.GetCheckpoint()
+	if err != nil {
+		return err
+	}
+	if exists {
+		if firstBlock <= lastBlock && checkpoint >= firstBlock && checkpoint <= lastBlock {
+			if checkpoint == lastBlock {
+				return nil
+			}
+			firstBlock = checkpoint + 1
+		} else if firstBlock > lastBlock && checkpoint <= firstBlock && checkpoint >= lastBlock {
+			if checkpoint == lastBlock {
+				return nil
+			}
+			firstBlock = checkpoint - 1
+		}
+	}
+	return i.Iterate(ctx, firstBlock, lastBlock, handler)
+}
+
+// Walk the blocks from the first block through the last block (inclusive), handing each one to the handler in order.
+// If the first block is after the last block, the walk goes backwards. If the handler returns an error, the walk
+// stops. If the iterator has a checkpoint store, the last block the handler finished with is saved as the checkpoint
+// after each batch.
+func (i *BlockIterator) Iterate(ctx context.Context, firstBlock uint64, lastBlock uint64, handler func(block IteratedBlock) error) error {
+	forward := firstBlock <= lastBlock
+	var remaining uint64
+	if forward {
+		remaining = lastBlock - firstBlock + 1
+	} else {
+		remaining = firstBlock - lastBlock + 1
+	}
+
+	number := firstBlock
+	for remaining > 0 {
+		// Get the next batch of blocks
+		count := min(remaining, uint64(i.settings.BatchSize))
+		numbers := make([]uint64, count)
+		for j := range numbers {
+			numbers[j] = number
+			if forward {
+				number++
+			} else {
+				number--
+			}
+		}
+		remaining -= count
+
+		blocks, err := utils.RetryWithResult(ctx, i.settings.RetryPolicy, func() ([]IteratedBlock, error) {
+			return i.getBlocks(ctx, numbers)
+		})
+		if err != nil {
+			return err
+		}
+		for _, block := range blocks {
+			err = handler(block)
+			if err != nil {
+				return fmt.Errorf("error handling block %d: %w", block.Block.NumberU64(), err)
+			}
+		}
+		err = i.saveCheckpoint(numbers[len(numbers)-1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the provided blocks (and their receipts, if enabled) in a single batch, in the same order as the numbers
+func (i *BlockIterator) getBlocks(ctx context.Context, numbers []uint64) ([]IteratedBlock, error) {
+	rawBlocks := make([]json.RawMessage, len(numbers))
+	receipts := make([][]*types.Receipt, len(numbers))
+	requests := make([]rpc.BatchElem, 0, len(numbers)*2)
+	for j, number := range numbers {
+		requests = append(requests, rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []any{hexutil.EncodeUint64(number), true},
+			Result: &rawBlocks[j],
+		})
+		if i.settings.IncludeReceipts {
+			requests = append(requests, rpc.BatchElem{
+				Method: "eth_getBlockReceipts",
+				Args:   []any{hexutil.EncodeUint64(number)},
+				Result: &receipts[j],
+			})
+		}
+	}
+
+	err := BatchCall(ctx, i.client, requests, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		if request.Error != nil {
+			return nil, fmt.Errorf("error calling %s for block %s: %w", request.Method, request.Args[0], request.Error)
+		}
+	}
+
+	// Decode the blocks
+	blocks := make([]IteratedBlock, len(numbers))
+	for j, number := range numbers {
+		if len(rawBlocks[j]) == 0 || string(rawBlocks[j]) == "null" {
+			return nil, fmt.Errorf("block %d doesn't exist yet", number)
+		}
+		header := new(types.Header)
+		err = json.Unmarshal(rawBlocks[j], header)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding header of block %d: %w", number, err)
+		}
+		var body rpcBlockBody
+		err = json.Unmarshal(rawBlocks[j], &body)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding body of block %d: %w", number, err)
+		}
+		if i.settings.IncludeReceipts && len(receipts[j]) != len(body.Transactions) {
+			return nil, fmt.Errorf("block %d has %d transactions but %d receipts", number, len(body.Transactions), len(receipts[j]))
+		}
+		blocks[j] = IteratedBlock{
+			Block: types.NewBlockWithHeader(header).WithBody(types.Body{
+				Transactions: body.Transactions,
+				Withdrawals:  body.Withdrawals,
+			}),
+			Receipts: receipts[j],
+		}
+	}
+	return blocks, nil
+}
+
+// Save the last block that was fully processed, if the iterator has a checkpoint store
+func (i *BlockIterator) saveCheckpoint(block uint64) error {
+	if i.kvStore == nil {
+		return nil
+	}
+	err := i.kvStore.Put(blockIteratorBucket, []byte(i.checkpointKey), store.Uint64Key(block))
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint [%s]: %w", i.checkpointKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// The names of uint256 parameters that are treated as token amounts when calling a registered token.
+// Leading underscores are ignored when matching.
+var tokenAmountParameterNames = map[string]bool{
+	"amount": true,
+	"value":  true,
+	"wad":    true,
+}
+
+// A contract known to the decoder
+type knownContract struct {
+	name string
+	abi  *abi.ABI
+}
+
+// Details of a token used to format amounts
+type knownToken struct {
+	symbol   string
+	decimals uint8
+}
+
+// Decodes transaction calldata into human-readable descriptions using a registry of known contract ABIs,
+// so users can see what they're signing before approving a transaction
+type CalldataDecoder struct {
+	contracts   map[common.Address]knownContract
+	tokens      map[common.Address]knownToken
+	addressBook *AddressBook
+	lock        sync.RWMutex
+}
+
+// Creates a new decoder with an empty registry
+func NewCalldataDecoder() *CalldataDecoder {
+	return &CalldataDecoder{
+		contracts: map[common.Address]knownContract{},
+		tokens:    map[common.Address]knownToken{},
+	}
+}
+
+// Register a contract's ABI so calls to it can be decoded
+func (d *CalldataDecoder) RegisterContract(address common.Address, name string, contractAbi *abi.ABI) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.contracts[address] = knownContract{
+		name: name,
+		abi:  contractAbi,
+	}
+}
+
+// Register the details of a token contract so amounts passed to it are formatted with its decimals and symbol.
+// The contract's ABI must also be registered with RegisterContract for its calls to be decoded.
+func (d *CalldataDecoder) RegisterToken(address common.Address, symbol string, decimals uint8) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.tokens[address] = knownToken{
+		symbol:   symbol,
+		decimals: decimals,
+	}
+}
+
+// Use an address book to label the addresses in decoded calls, and the targets of calls to unregistered contracts
+func (d *CalldataDecoder) SetAddressBook(addressBook *AddressBook) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.addressBook = addressBook
+}
+
+// Decode a call to a registered contract. Token amount arguments are formatted with the token's decimals and
+// symbol if the contract is a registered token.
+func (d *CalldataDecoder) Decode(to common.Address, data []byte) (*CallDescription, error) {
+	d.lock.RLock()
+	contract, exists := d.contracts[to]
+	token, isToken := d.tokens[to]
+	addressBook := d.addressBook
+	d.lock.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("contract %s is not registered", to.Hex())
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata is too short to contain a method selector")
+	}
+	method, err := contract.abi.MethodById(data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("error finding method for selector %x on %s: %w", data[:4], contract.name, err)
+	}
+	parameters, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding arguments for %s.%s: %w", contract.name, method.Name, err)
+	}
+
+	description := describeArguments(contract.name, *method, parameters)
+	if addressBook != nil {
+		for i := range method.Inputs {
+			address, ok := parameters[i].(common.Address)
+			if ok {
+				description.Arguments[i].Value = addressBook.FormatAddress(address)
+			}
+		}
+	}
+	if isToken {
+		for i, input := range method.Inputs {
+			amount, ok := parameters[i].(*big.Int)
+			if !ok || !tokenAmountParameterNames[strings.ToLower(strings.TrimLeft(input.Name, "_"))] {
+				continue
+			}
+			description.Arguments[i].Value = FormatTokenAmount(amount, token.decimals) + " " + token.symbol
+		}
+	}
+	return description, nil
+}
+
+// Render a transaction as "Contract.method(name: value, ...)" for display, followed by the ETH value it sends
+// if there is one. Calls to unregistered contracts fall back to the transaction's own call description if it has
+// one, or its raw calldata if it doesn't. If the decoder has an address book, the target is shown with its label.
+func (d *CalldataDecoder) Render(txInfo *TransactionInfo) string {
+	d.lock.RLock()
+	addressBook := d.addressBook
+	d.lock.RUnlock()
+	target := txInfo.To.Hex()
+	if addressBook != nil {
+		target = addressBook.FormatAddress(txInfo.To)
+	}
+
+	var call string
+	description, err := d.Decode(txInfo.To, txInfo.Data)
+	switch {
+	case err == nil:
+		call = description.String()
+	case txInfo.CallDescription != nil:
+		call = txInfo.CallDescription.String()
+	case len(txInfo.Data) == 0:
+		call = "transfer to " + target
+	default:
+		call = fmt.Sprintf("call to %s with data %s", target, utils.EncodeHexWithPrefix(txInfo.Data))
+	}
+
+	if txInfo.Value != nil && txInfo.Value.Sign() > 0 {
+		call += " with " + WeiToEthDecimal(txInfo.Value) + " ETH"
+	}
+	return call
+}
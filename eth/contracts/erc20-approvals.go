@@ -0,0 +1,145 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+const (
+	// The ABI for the EIP-2612 permit extension to ERC20 tokens
+	Erc20PermitAbiString string = `[{"inputs":[{"name":"owner","type":"address"}],"name":"nonces","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"DOMAIN_SEPARATOR","outputs":[{"name":"","type":"bytes32"}],"stateMutability":"view","type":"function"},{"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"},{"name":"value","type":"uint256"},{"name":"deadline","type":"uint256"},{"name":"v","type":"uint8"},{"name":"r","type":"bytes32"},{"name":"s","type":"bytes32"}],"name":"permit","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+	// The EIP-712 type of the permit message
+	permitTypeString string = "Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"
+
+	// The length of an ECDSA signature in [R || S || V] format
+	permitSignatureLength int = 65
+)
+
+// Global container for the parsed ABI above
+var erc20PermitAbi *abi.ABI
+
+// Signs an EIP-712 digest with the token owner's key, returning the signature in [R || S || V] format
+type PermitSigner func(digest common.Hash) ([]byte, error)
+
+// Get the submissions needed to run a transaction that spends the owner's tokens, in the order they must be executed.
+// If the spender's current allowance is lower than the amount, an approval for the amount is added before the
+// dependent transaction. Note that the dependent transaction's simulation will usually fail when an approval is
+// required, so its gas limit should be set by the caller instead of coming from the simulation.
+func GetApprovalBatch(queryMgr *eth.QueryManager, token IErc20Token, owner common.Address, spender common.Address, amount *big.Int, dependent *eth.TransactionSubmission, opts *bind.TransactOpts) ([]*eth.TransactionSubmission, error) {
+	// Check the current allowance
+	var allowance *big.Int
+	err := queryMgr.Query(func(mc *batch.MultiCaller) error {
+		token.Allowance(mc, &allowance, owner, spender)
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s allowance for %s: %w", token.Symbol(), spender.Hex(), err)
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return []*eth.TransactionSubmission{dependent}, nil
+	}
+
+	// Build the approval
+	approval, err := eth.CreateTxSubmissionFromInfo(token.Approve(spender, amount, opts))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s approval for %s: %w", token.Symbol(), spender.Hex(), err)
+	}
+	if approval.TxInfo.SimulationResult.SimulationError != "" {
+		return nil, fmt.Errorf("simulating %s approval for %s failed: %s", token.Symbol(), spender.Hex(), approval.TxInfo.SimulationResult.SimulationError)
+	}
+	return []*eth.TransactionSubmission{approval, dependent}, nil
+}
+
+// Get the submissions needed to run a transaction that spends the owner's tokens using an EIP-2612 permit instead of
+// an approval, in the order they must be executed. If the spender's current allowance is lower than the amount, the
+// owner signs a permit for the amount (valid until the deadline, in seconds since the Unix epoch) and a transaction
+// submitting it is added before the dependent transaction. The token must support EIP-2612.
+func GetPermitBatch(queryMgr *eth.QueryManager, txMgr *eth.TransactionManager, token IErc20Token, owner common.Address, spender common.Address, amount *big.Int, deadline *big.Int, signer PermitSigner, dependent *eth.TransactionSubmission, opts *bind.TransactOpts) ([]*eth.TransactionSubmission, error) {
+	permitContract, err := getPermitContract(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current allowance and permit details
+	var allowance *big.Int
+	var nonce *big.Int
+	var domainSeparator [common.HashLength]byte
+	err = queryMgr.Query(func(mc *batch.MultiCaller) error {
+		token.Allowance(mc, &allowance, owner, spender)
+		eth.AddCallToMulticaller(mc, permitContract, &nonce, "nonces", owner)
+		eth.AddCallToMulticaller(mc, permitContract, &domainSeparator, "DOMAIN_SEPARATOR")
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s permit details for %s: %w", token.Symbol(), owner.Hex(), err)
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return []*eth.TransactionSubmission{dependent}, nil
+	}
+
+	// Sign the permit
+	digest := GetPermitDigest(domainSeparator, owner, spender, amount, nonce, deadline)
+	signature, err := signer(digest)
+	if err != nil {
+		return nil, fmt.Errorf("error signing %s permit: %w", token.Symbol(), err)
+	}
+	if len(signature) != permitSignatureLength {
+		return nil, fmt.Errorf("permit signature must be %d bytes but was %d", permitSignatureLength, len(signature))
+	}
+	var r, s [common.HashLength]byte
+	copy(r[:], signature[:32])
+	copy(s[:], signature[32:64])
+	v := signature[64]
+	if v < 27 {
+		v += 27
+	}
+
+	// Build the permit submission
+	permit, err := eth.CreateTxSubmissionFromInfo(txMgr.CreateTransactionInfo(permitContract, "permit", opts, owner, spender, amount, deadline, v, r, s))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s permit for %s: %w", token.Symbol(), spender.Hex(), err)
+	}
+	if permit.TxInfo.SimulationResult.SimulationError != "" {
+		return nil, fmt.Errorf("simulating %s permit for %s failed: %s", token.Symbol(), spender.Hex(), permit.TxInfo.SimulationResult.SimulationError)
+	}
+	return []*eth.TransactionSubmission{permit, dependent}, nil
+}
+
+// Get the EIP-712 digest the owner signs to permit a spender to transfer up to the provided value of its tokens
+func GetPermitDigest(domainSeparator common.Hash, owner common.Address, spender common.Address, value *big.Int, nonce *big.Int, deadline *big.Int) common.Hash {
+	typeHash := crypto.Keccak256Hash([]byte(permitTypeString))
+	structHash := crypto.Keccak256Hash(
+		typeHash[:],
+		common.LeftPadBytes(owner[:], common.HashLength),
+		common.LeftPadBytes(spender[:], common.HashLength),
+		common.LeftPadBytes(value.Bytes(), common.HashLength),
+		common.LeftPadBytes(nonce.Bytes(), common.HashLength),
+		common.LeftPadBytes(deadline.Bytes(), common.HashLength),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], structHash[:])
+}
+
+// Create a binding for the permit functions of a token
+func getPermitContract(token IErc20Token) (*eth.Contract, error) {
+	if erc20PermitAbi == nil {
+		abiParsed, err := abi.JSON(strings.NewReader(Erc20PermitAbiString))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ERC20 permit ABI: %w", err)
+		}
+		erc20PermitAbi = &abiParsed
+	}
+	return &eth.Contract{
+		Name:    token.Symbol(),
+		Address: token.Address(),
+		ABI:     erc20PermitAbi,
+	}, nil
+}
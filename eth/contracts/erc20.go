@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	Erc20AbiString string = `[{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"payable":false,"type":"function"},{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"payable":false,"type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"payable":false,"type":"function"},{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"payable":false,"type":"function"},{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"success","type":"bool"}],"payable":false,"type":"function"}]`
+	Erc20AbiString string = `[{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"payable":false,"type":"function"},{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"payable":false,"type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"payable":false,"type":"function"},{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"payable":false,"type":"function"},{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"success","type":"bool"}],"payable":false,"type":"function"},{"constant":true,"inputs":[{"name":"_owner","type":"address"},{"name":"_spender","type":"address"}],"name":"allowance","outputs":[{"name":"remaining","type":"uint256"}],"payable":false,"type":"function"},{"constant":false,"inputs":[{"name":"_spender","type":"address"},{"name":"_value","type":"uint256"}],"name":"approve","outputs":[{"name":"success","type":"bool"}],"payable":false,"type":"function"}]`
 )
 
 // Global container for the parsed ABI above
@@ -42,6 +42,12 @@ type IErc20Token interface {
 
 	// Transfer tokens to a different address
 	Transfer(to common.Address, amount *big.Int, opts *bind.TransactOpts) (*eth.TransactionInfo, error)
+
+	// The amount of the owner's tokens the spender is allowed to transfer
+	Allowance(mc *batch.MultiCaller, allowance_Out **big.Int, owner common.Address, spender common.Address)
+
+	// Allow a spender to transfer up to the given amount of tokens on behalf of the sender
+	Approve(spender common.Address, amount *big.Int, opts *bind.TransactOpts) (*eth.TransactionInfo, error)
 }
 
 // ===============
@@ -128,6 +134,11 @@ func (c *Erc20Contract) BalanceOf(mc *batch.MultiCaller, balance_Out **big.Int,
 	eth.AddCallToMulticaller(mc, c.contract, balance_Out, "balanceOf", address)
 }
 
+// Get the amount of the owner's tokens the spender is allowed to transfer
+func (c *Erc20Contract) Allowance(mc *batch.MultiCaller, allowance_Out **big.Int, owner common.Address, spender common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, allowance_Out, "allowance", owner, spender)
+}
+
 // ====================
 // === Transactions ===
 // ====================
@@ -136,3 +147,8 @@ func (c *Erc20Contract) BalanceOf(mc *batch.MultiCaller, balance_Out **big.Int,
 func (c *Erc20Contract) Transfer(to common.Address, amount *big.Int, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
 	return c.txMgr.CreateTransactionInfo(c.contract, "transfer", opts, to, amount)
 }
+
+// Get info for allowing a spender to transfer up to the given amount of tokens on behalf of the sender
+func (c *Erc20Contract) Approve(spender common.Address, amount *big.Int, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+	return c.txMgr.CreateTransactionInfo(c.contract, "approve", opts, spender, amount)
+}
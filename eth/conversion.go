@@ -1,8 +1,10 @@
 package eth
 
 import (
+	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 )
 
 // Conversion factors
@@ -17,12 +19,22 @@ const (
 	GweiPerEth float64 = WeiPerEth / WeiPerGwei
 )
 
+// Decimal places of each unit, relative to wei
+const (
+	// The number of decimal places in an ETH amount
+	EthDecimals uint8 = 18
+
+	// The number of decimal places in a gwei amount
+	GweiDecimals uint8 = 9
+)
+
 var (
 	weiPerEthFloat  *big.Float = big.NewFloat(WeiPerEth)
 	WeiPerGweiFloat *big.Float = big.NewFloat(WeiPerGwei)
 )
 
-// Convert a wei amount (a native uint256 value on the execution layer) to a floating-point ETH amount
+// Convert a wei amount (a native uint256 value on the execution layer) to a floating-point ETH amount.
+// This loses precision on large values; use WeiToEthDecimal when the exact amount matters.
 func WeiToEth(wei *big.Int) float64 {
 	var weiFloat big.Float
 	var eth big.Float
@@ -32,7 +44,8 @@ func WeiToEth(wei *big.Int) float64 {
 	return eth64
 }
 
-// Convert a floating-point ETH amount to a wei amount (a native uint256 value on the execution layer)
+// Convert a floating-point ETH amount to a wei amount (a native uint256 value on the execution layer).
+// This loses precision on large values; use ParseEthAmount when the exact amount matters.
 func EthToWei(eth float64) *big.Int {
 	var ethFloat big.Float
 	var weiFloat big.Float
@@ -43,7 +56,8 @@ func EthToWei(eth float64) *big.Int {
 	return &wei
 }
 
-// Convert a wei amount (a native uint256 value on the execution layer) to a floating-point gwei amount
+// Convert a wei amount (a native uint256 value on the execution layer) to a floating-point gwei amount.
+// This loses precision on large values; use WeiToGweiDecimal when the exact amount matters.
 func WeiToGwei(wei *big.Int) float64 {
 	var weiFloat big.Float
 	var gwei big.Float
@@ -53,7 +67,8 @@ func WeiToGwei(wei *big.Int) float64 {
 	return gwei64
 }
 
-// Convert a floating-point gwei amount to a wei amount (a native uint256 value on the execution layer)
+// Convert a floating-point gwei amount to a wei amount (a native uint256 value on the execution layer).
+// This loses precision on large values; use ParseGweiAmount when the exact amount matters.
 func GweiToWei(gwei float64) *big.Int {
 	var gweiFloat big.Float
 	var weiFloat big.Float
@@ -73,3 +88,90 @@ func EthToGwei(eth float64) float64 {
 func GweiToEth(gwei float64) float64 {
 	return gwei / GweiPerEth
 }
+
+// Format a token amount in its smallest unit (such as wei) as an exact decimal string with the given number of
+// decimal places, trimming trailing zeros (e.g. 1500000000000000000 with 18 decimals becomes "1.5")
+func FormatTokenAmount(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+	negative := amount.Sign() < 0
+	digits := new(big.Int).Abs(amount).String()
+
+	// Pad so there's at least one digit before the decimal point
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	whole := digits[:len(digits)-int(decimals)]
+	fraction := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+
+	formatted := whole
+	if fraction != "" {
+		formatted += "." + fraction
+	}
+	if negative {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// Parse an exact decimal string (such as "1.5") into a token amount in its smallest unit, using the given number of
+// decimal places. This is the lossless inverse of FormatTokenAmount; amounts with more decimal places than the token
+// supports are rejected rather than rounded.
+func ParseTokenAmount(amount string, decimals uint8) (*big.Int, error) {
+	trimmed := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(trimmed, "-")
+	trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(trimmed, ".")
+	if whole == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid amount [%s]", amount)
+	}
+	if !isDigits(whole) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid amount [%s]", amount)
+	}
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("amount [%s] has more than %d decimal places", amount, decimals)
+	}
+
+	// Shift the decimal point to the end and parse the digits as an integer
+	digits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount [%s]", amount)
+	}
+	if negative {
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+// Format a wei amount (a native uint256 value on the execution layer) as an exact ETH amount string
+func WeiToEthDecimal(wei *big.Int) string {
+	return FormatTokenAmount(wei, EthDecimals)
+}
+
+// Format a wei amount (a native uint256 value on the execution layer) as an exact gwei amount string
+func WeiToGweiDecimal(wei *big.Int) string {
+	return FormatTokenAmount(wei, GweiDecimals)
+}
+
+// Parse an exact ETH amount string (such as "32.05") into a wei amount (a native uint256 value on the execution layer)
+func ParseEthAmount(eth string) (*big.Int, error) {
+	return ParseTokenAmount(eth, EthDecimals)
+}
+
+// Parse an exact gwei amount string (such as "1.5") into a wei amount (a native uint256 value on the execution layer)
+func ParseGweiAmount(gwei string) (*big.Int, error) {
+	return ParseTokenAmount(gwei, GweiDecimals)
+}
+
+// Check if the string only contains the digits 0-9; an empty string is considered valid
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
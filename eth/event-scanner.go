@@ -0,0 +1,286 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/node-manager-core/store"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The default number of blocks to query at a time; most providers allow ranges of at least this size
+	DefaultEventScanChunkSize uint64 = 2000
+
+	// The store bucket that holds event scanner checkpoints, keyed by the scanner's checkpoint key
+	eventScannerBucket string = "event-scanner-checkpoints"
+)
+
+// Substrings of the errors providers return when a query's block range or result count is over their limits.
+// Queries that fail with one of these are split into smaller ranges instead of being retried as-is.
+var rangeLimitErrorMessages = []string{
+	"query returned more than",
+	"block range",
+	"range too large",
+	"range is too large",
+	"exceed maximum block range",
+	"is limited to",
+	"response size exceeded",
+	"too many results",
+	"log response size",
+}
+
+// Settings for scanning a block range for events
+type EventScannerSettings struct {
+	// The number of blocks to query at a time. Chunks that go over the provider's limits are split in half until
+	// they succeed; the scanner then works its way back up to this size.
+	ChunkSize uint64
+
+	// The policy for retrying chunks that fail for other reasons, such as throttling or connection errors
+	RetryPolicy utils.RetryPolicy
+}
+
+// The event scanner settings to use if none are configured
+var DefaultEventScannerSettings = EventScannerSettings{
+	ChunkSize:   DefaultEventScanChunkSize,
+	RetryPolicy: utils.DefaultRetryPolicy,
+}
+
+// An event emitted by a contract, decoded with the contract's ABI
+type DecodedEvent struct {
+	// The name of the event
+	Name string
+
+	// The event's arguments (both indexed and non-indexed), keyed by name
+	Fields map[string]any
+
+	// The raw log the event was decoded from
+	Log types.Log
+}
+
+// Scans block ranges for a contract's events, splitting the range into chunks so each query stays within the
+// provider's limits. Progress can be saved in a key-value store so an interrupted scan picks up where it left off.
+type EventScanner struct {
+	client        IExecutionClient
+	contract      *Contract
+	eventIDs      []common.Hash
+	settings      EventScannerSettings
+	kvStore       store.IKeyValueStore
+	checkpointKey string
+}
+
+// Creates a new event scanner for the provided events of a contract. If no event names are provided, all of the
+// events in the contract's ABI are scanned for.
+func NewEventScanner(client IExecutionClient, contract *Contract, eventNames []string, settings EventScannerSettings) (*EventScanner, error) {
+	if settings.ChunkSize == 0 {
+		return nil, fmt.Errorf("chunk size must be at least 1")
+	}
+
+	eventIDs := []common.Hash{}
+	if len(eventNames) == 0 {
+		for _, event := range contract.ABI.Events {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	} else {
+		for _, name := range eventNames {
+			event, exists := contract.ABI.Events[name]
+			if !exists {
+				return nil, fmt.Errorf("contract %s doesn't have an event named [%s]", contract.Name, name)
+			}
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+
+	return &EventScanner{
+		client:   client,
+		contract: contract,
+		eventIDs: eventIDs,
+		settings: settings,
+	}, nil
+}
+
+// Save the scanner's progress in the provided store under the provided key, so scans started with
+// ScanFromCheckpoint resume after the last block that was fully processed
+func (s *EventScanner) SetCheckpointStore(kvStore store.IKeyValueStore, key string) {
+	s.kvStore = kvStore
+	s.checkpointKey = key
+}
+
+// Get the last block that was fully processed, or false if the scanner doesn't have a checkpoint yet
+func (s *EventScanner) GetCheckpoint() (uint64, bool, error) {
+	if s.kvStore == nil {
+		return 0, false, fmt.Errorf("event scanner doesn't have a checkpoint store")
+	}
+	value, err := s.kvStore.Get(eventScannerBucket, []byte(s.checkpointKey))
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting checkpoint [%s]: %w", s.checkpointKey, err)
+	}
+	if value == nil {
+		return 0, false, nil
+	}
+	block, err := store.ParseUint64Key(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("error parsing checkpoint [%s]: %w", s.checkpointKey, err)
+	}
+	return block, true, nil
+}
+
+// Scan the blocks from the block after the checkpoint (or the start block, if there's no checkpoint yet) through the
+// end block, saving a new checkpoint after each chunk is handled. See Scan for details.
+func (s *EventScanner) ScanFromCheckpoint(ctx context.Context, startBlock uint64, endBlock uint64, handler func(events []DecodedEvent) error) error {
+	checkpoint, exists, err := s.GetCheckpoint()
+	if err != nil {
+		return err
+	}
+	if exists && checkpoint+1 > startBlock {
+		startBlock = checkpoint + 1
+	}
+	if startBlock > endBlock {
+		return nil
+	}
+	return s.Scan(ctx, startBlock, endBlock, handler)
+}
+
+// Scan the blocks from the start block through the end block (inclusive) for events, in chunks. The handler is called
+// with the decoded events of each chunk in block order, including chunks without any events; if it returns an error,
+// the scan stops. If the scanner has a checkpoint store, the end of each chunk is saved as the checkpoint once the
+// handler finishes with it.
+func (s *EventScanner) Scan(ctx context.Context, startBlock uint64, endBlock uint64, handler func(events []DecodedEvent) error) error {
+	if startBlock > endBlock {
+		return fmt.Errorf("start block %d is after end block %d", startBlock, endBlock)
+	}
+
+	chunkSize := s.settings.ChunkSize
+	for from := startBlock; from <= endBlock; {
+		to := min(from+chunkSize-1, endBlock)
+		logs, err := s.getLogs(ctx, from, to)
+		if err != nil {
+			if !isRangeLimitError(err) {
+				return fmt.Errorf("error getting events for blocks %d to %d: %w", from, to, err)
+			}
+			if from == to {
+				return fmt.Errorf("events for block %d are over the provider's limits: %w", from, err)
+			}
+			// Try again with half the range
+			chunkSize = max((to-from+1)/2, 1)
+			continue
+		}
+
+		events := make([]DecodedEvent, 0, len(logs))
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+			event, err := s.DecodeLog(log)
+			if err != nil {
+				return fmt.Errorf("error decoding event in transaction %s: %w", log.TxHash.Hex(), err)
+			}
+			events = append(events, event)
+		}
+		err = handler(events)
+		if err != nil {
+			return fmt.Errorf("error handling events for blocks %d to %d: %w", from, to, err)
+		}
+		err = s.saveCheckpoint(to)
+		if err != nil {
+			return err
+		}
+
+		// Work back up to the configured chunk size after splitting
+		if to == endBlock {
+			break
+		}
+		from = to + 1
+		chunkSize = min(chunkSize*2, s.settings.ChunkSize)
+	}
+	return nil
+}
+
+// Decode a log emitted by the contract into its event
+func (s *EventScanner) DecodeLog(log types.Log) (DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return DecodedEvent{}, fmt.Errorf("log doesn't have any topics")
+	}
+	event, err := s.contract.ABI.EventByID(log.Topics[0])
+	if err != nil {
+		return DecodedEvent{}, fmt.Errorf("error getting event for topic %s: %w", log.Topics[0].Hex(), err)
+	}
+
+	fields := map[string]any{}
+	if len(log.Data) > 0 {
+		err = event.Inputs.UnpackIntoMap(fields, log.Data)
+		if err != nil {
+			return DecodedEvent{}, fmt.Errorf("error unpacking data of event [%s]: %w", event.Name, err)
+		}
+	}
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	err = abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:])
+	if err != nil {
+		return DecodedEvent{}, fmt.Errorf("error parsing topics of event [%s]: %w", event.Name, err)
+	}
+
+	return DecodedEvent{
+		Name:   event.Name,
+		Fields: fields,
+		Log:    log,
+	}, nil
+}
+
+// Get the logs for a block range, retrying failures that aren't caused by the provider's range limits
+func (s *EventScanner) getLogs(ctx context.Context, from uint64, to uint64) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{s.contract.Address},
+		Topics:    [][]common.Hash{s.eventIDs},
+	}
+	return utils.RetryWithResult(ctx, s.settings.RetryPolicy, func() ([]types.Log, error) {
+		logs, err := s.client.FilterLogs(ctx, query)
+		if err != nil && isRangeLimitError(err) {
+			return nil, utils.Permanent(err)
+		}
+		return logs, err
+	})
+}
+
+// Save the last block that was fully processed, if the scanner has a checkpoint store
+func (s *EventScanner) saveCheckpoint(block uint64) error {
+	if s.kvStore == nil {
+		return nil
+	}
+	err := s.kvStore.Put(eventScannerBucket, []byte(s.checkpointKey), store.Uint64Key(block))
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint [%s]: %w", s.checkpointKey, err)
+	}
+	return nil
+}
+
+// Check if an error is from a query that was over the provider's block range or result count limits
+func isRangeLimitError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "rate limit") {
+		// Throttled requests should be retried as-is
+		return false
+	}
+	for _, limitMessage := range rangeLimitErrorMessages {
+		if strings.Contains(message, limitMessage) {
+			return true
+		}
+	}
+	return false
+}
@@ -49,6 +49,10 @@ type IExecutionClient interface {
 	// a timely execution of a transaction.
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 
+	// FeeHistory retrieves the base fees, gas usage ratios, and priority fee percentiles of a range of recent blocks
+	// ending with lastBlock. If lastBlock is nil, the range ends with the latest block.
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+
 	// EstimateGas tries to estimate the gas needed to execute a specific
 	// transaction based on the current pending state of the backend blockchain.
 	// There is no guarantee that this is the true gas limit requirement as other
@@ -0,0 +1,214 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The minimum fee increase, in percent, that most clients require to accept a replacement transaction
+	MinFeeBumpPercent uint64 = 10
+
+	// The default time to wait between checks for a transaction's inclusion
+	DefaultFeeEscalationPollInterval time.Duration = 4 * time.Second
+)
+
+// A policy for automatically resubmitting a transaction with higher fees if it isn't included quickly enough
+type FeeEscalationPolicy struct {
+	// The number of blocks to wait for the transaction to be included before bumping its fees
+	BlocksBeforeEscalation uint64
+
+	// The percentage to increase the max fee and priority fee by on each attempt; must be at least MinFeeBumpPercent
+	BumpPercent uint64
+
+	// The highest max fee (in wei) the transaction can be resubmitted with
+	MaxGasFeeCap *big.Int
+
+	// The highest priority fee (in wei) the transaction can be resubmitted with
+	MaxGasTipCap *big.Int
+
+	// The maximum number of times to resubmit the transaction; 0 means there's no limit other than the fee caps
+	MaxAttempts int
+
+	// The time to wait between checks for the transaction's inclusion; if 0, DefaultFeeEscalationPollInterval is used
+	PollInterval time.Duration
+}
+
+// Check that the policy's settings are usable
+func (p *FeeEscalationPolicy) Validate() error {
+	if p.BlocksBeforeEscalation == 0 {
+		return fmt.Errorf("blocks before escalation must be at least 1")
+	}
+	if p.BumpPercent < MinFeeBumpPercent {
+		return fmt.Errorf("bump percent must be at least %d", MinFeeBumpPercent)
+	}
+	if p.MaxGasFeeCap == nil || p.MaxGasFeeCap.Sign() <= 0 {
+		return fmt.Errorf("max fee cap must be set")
+	}
+	if p.MaxGasTipCap == nil || p.MaxGasTipCap.Sign() <= 0 {
+		return fmt.Errorf("max priority fee cap must be set")
+	}
+	if p.MaxGasTipCap.Cmp(p.MaxGasFeeCap) > 0 {
+		return fmt.Errorf("max priority fee cap cannot be higher than the max fee cap")
+	}
+	return nil
+}
+
+// Wait for a transaction to be included, resubmitting it with bumped fees according to the policy whenever it goes
+// too many blocks without being included. The fees are never raised above the policy's caps; once they reach them,
+// this keeps waiting without resubmitting. Every submission is logged so the escalation can be audited.
+// The opts must have the signer and sender of the original transaction; their fee and nonce settings are ignored.
+// Returns whichever of the submitted transactions was included.
+func (t *TransactionManager) WaitForTransactionWithEscalation(ctx context.Context, logger *slog.Logger, tx *types.Transaction, opts *bind.TransactOpts, policy *FeeEscalationPolicy) (*types.Transaction, error) {
+	err := policy.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee escalation policy: %w", err)
+	}
+	pollInterval := policy.PollInterval
+	if pollInterval == 0 {
+		pollInterval = DefaultFeeEscalationPollInterval
+	}
+
+	lastSubmissionBlock, err := t.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	submitted := []*types.Transaction{tx}
+	current := tx
+	attempts := 0
+	canEscalate := true
+	logger.Info("Waiting for transaction with fee escalation.",
+		slog.String(log.TxHashKey, tx.Hash().Hex()),
+		slog.Uint64(log.NonceKey, tx.Nonce()),
+		slog.String(log.GasFeeCapKey, tx.GasFeeCap().String()),
+		slog.String(log.GasTipCapKey, tx.GasTipCap().String()),
+		slog.Uint64(log.BlockKey, lastSubmissionBlock),
+	)
+
+	for {
+		// Check if any of the submitted transactions were included
+		for _, candidate := range submitted {
+			receipt, err := t.client.TransactionReceipt(ctx, candidate.Hash())
+			if err != nil {
+				if errors.Is(err, ethereum.NotFound) {
+					continue
+				}
+				return nil, fmt.Errorf("error getting receipt for transaction %s: %w", candidate.Hash().Hex(), err)
+			}
+			logger.Info("Transaction included.",
+				slog.String(log.TxHashKey, candidate.Hash().Hex()),
+				slog.Uint64(log.BlockKey, receipt.BlockNumber.Uint64()),
+				slog.Int(log.AttemptKey, attempts),
+			)
+			if receipt.Status == types.ReceiptStatusFailed {
+				return candidate, fmt.Errorf("transaction %s failed with status 0", candidate.Hash().Hex())
+			}
+			return candidate, nil
+		}
+
+		// Make sure the nonce hasn't been used by a different transaction
+		nonce, err := t.client.NonceAt(ctx, opts.From, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest nonce: %w", err)
+		}
+		if nonce > tx.Nonce() {
+			// One of ours might have just been included, so check again before giving up
+			for _, candidate := range submitted {
+				if _, err := t.client.TransactionReceipt(ctx, candidate.Hash()); err == nil {
+					return candidate, nil
+				}
+			}
+			return nil, fmt.Errorf("nonce %d was used by a transaction that wasn't submitted by the fee escalator", tx.Nonce())
+		}
+
+		// Resubmit with higher fees if it's been too long
+		if canEscalate {
+			block, err := t.client.BlockNumber(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error getting latest block number: %w", err)
+			}
+			if block >= lastSubmissionBlock+policy.BlocksBeforeEscalation {
+				replacement, err := t.escalateTransaction(current, opts, policy)
+				if err != nil {
+					logger.Warn("Couldn't resubmit transaction with higher fees.", slog.String(log.TxHashKey, current.Hash().Hex()), log.Err(err))
+				} else if replacement == nil {
+					logger.Warn("Transaction fees have reached the escalation policy's caps, waiting without resubmitting.", slog.String(log.TxHashKey, current.Hash().Hex()))
+					canEscalate = false
+				} else {
+					attempts++
+					submitted = append(submitted, replacement)
+					current = replacement
+					logger.Info("Resubmitted transaction with higher fees.",
+						slog.String(log.TxHashKey, replacement.Hash().Hex()),
+						slog.Uint64(log.NonceKey, replacement.Nonce()),
+						slog.String(log.GasFeeCapKey, replacement.GasFeeCap().String()),
+						slog.String(log.GasTipCapKey, replacement.GasTipCap().String()),
+						slog.Uint64(log.BlockKey, block),
+						slog.Int(log.AttemptKey, attempts),
+					)
+					if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+						logger.Warn("Reached the escalation policy's attempt limit, waiting without resubmitting.", slog.Int(log.AttemptKey, attempts))
+						canEscalate = false
+					}
+				}
+				lastSubmissionBlock = block
+			}
+		}
+
+		if utils.SleepWithCancel(ctx, pollInterval) {
+			return nil, fmt.Errorf("stopped waiting for transaction %s: %w", current.Hash().Hex(), ctx.Err())
+		}
+	}
+}
+
+// Sign and submit a copy of the transaction with its fees bumped according to the policy.
+// Returns nil if the fees are already at the policy's caps.
+func (t *TransactionManager) escalateTransaction(tx *types.Transaction, opts *bind.TransactOpts, policy *FeeEscalationPolicy) (*types.Transaction, error) {
+	if tx.To() == nil {
+		return nil, fmt.Errorf("contract creation transactions can't be escalated")
+	}
+	feeCap := bumpFee(tx.GasFeeCap(), policy.BumpPercent, policy.MaxGasFeeCap)
+	tipCap := bumpFee(tx.GasTipCap(), policy.BumpPercent, policy.MaxGasTipCap)
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = feeCap
+	}
+
+	// Clients reject replacements that don't raise both fees by the minimum amount
+	minFeeCap := bumpFee(tx.GasFeeCap(), MinFeeBumpPercent, nil)
+	minTipCap := bumpFee(tx.GasTipCap(), MinFeeBumpPercent, nil)
+	if feeCap.Cmp(minFeeCap) < 0 || tipCap.Cmp(minTipCap) < 0 {
+		return nil, nil
+	}
+
+	newOpts := &bind.TransactOpts{
+		From:      opts.From,
+		Nonce:     new(big.Int).SetUint64(tx.Nonce()),
+		Signer:    opts.Signer,
+		GasFeeCap: feeCap,
+		GasTipCap: tipCap,
+		GasLimit:  tx.Gas(),
+		Context:   opts.Context,
+	}
+	return t.ExecuteTransactionRaw(*tx.To(), tx.Data(), tx.Value(), newOpts)
+}
+
+// Increase a fee by a percentage, rounding up, without going over the cap (if one is provided)
+func bumpFee(fee *big.Int, percent uint64, limit *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if limit != nil && bumped.Cmp(limit) > 0 {
+		return new(big.Int).Set(limit)
+	}
+	return bumped
+}
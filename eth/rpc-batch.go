@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// The default maximum number of requests to send in a single JSON-RPC batch. Most clients accept batches of at
+	// least this size; Geth rejects batches with more than 1000 requests by default.
+	DefaultMaxBatchSize int = 100
+)
+
+// Send the requests to the Execution client as JSON-RPC batches, so they only take one round trip per batch instead
+// of one per request. The requests are split into batches of at most maxBatchSize requests each; if it's 0,
+// DefaultMaxBatchSize is used.
+// The returned error is only for failures of the batch itself, such as connection errors. Errors for individual
+// requests are stored in their Error fields, so check each one after this returns.
+func BatchCall(ctx context.Context, ec IExecutionClient, requests []rpc.BatchElem, maxBatchSize int) error {
+	provider, ok := ec.(IRpcClientProvider)
+	if !ok {
+		return fmt.Errorf("execution client does not provide access to its RPC client")
+	}
+	client := provider.Client()
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	for start := 0; start < len(requests); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(requests))
+		err := client.BatchCallContext(ctx, requests[start:end])
+		if err != nil {
+			return fmt.Errorf("error sending batch of requests %d to %d: %w", start, end-1, err)
+		}
+	}
+	return nil
+}
+
+// Get the receipts for the transactions with the provided hashes using JSON-RPC batches. The receipt for a
+// transaction that hasn't been included yet will be nil.
+func BatchGetTransactionReceipts(ctx context.Context, ec IExecutionClient, hashes []common.Hash, maxBatchSize int) ([]*types.Receipt, error) {
+	receipts := make([]*types.Receipt, len(hashes))
+	requests := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		requests[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []any{hash},
+			Result: &receipts[i],
+		}
+	}
+
+	err := BatchCall(ctx, ec, requests, maxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for i, request := range requests {
+		if request.Error != nil {
+			return nil, fmt.Errorf("error getting receipt for transaction %s: %w", hashes[i].Hex(), request.Error)
+		}
+	}
+	return receipts, nil
+}
+
+// Get the ETH balances (in wei) of the provided addresses as of the provided block (or the latest block if it's nil)
+// using JSON-RPC batches
+func BatchGetBalances(ctx context.Context, ec IExecutionClient, addresses []common.Address, blockNumber *big.Int, maxBatchSize int) ([]*big.Int, error) {
+	block := "latest"
+	if blockNumber != nil {
+		block = hexutil.EncodeBig(blockNumber)
+	}
+	results := make([]hexutil.Big, len(addresses))
+	requests := make([]rpc.BatchElem, len(addresses))
+	for i, address := range addresses {
+		requests[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []any{address, block},
+			Result: &results[i],
+		}
+	}
+
+	err := BatchCall(ctx, ec, requests, maxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]*big.Int, len(addresses))
+	for i, request := range requests {
+		if request.Error != nil {
+			return nil, fmt.Errorf("error getting balance for %s: %w", addresses[i].Hex(), request.Error)
+		}
+		balances[i] = results[i].ToInt()
+	}
+	return balances, nil
+}
@@ -0,0 +1,58 @@
+package eth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// An execution client that records latency and availability statistics for its endpoint.
+// Statistics are only collected for HTTP endpoints; Websocket and IPC endpoints will report empty statistics.
+type StatsTrackingExecutionClient struct {
+	*ethclient.Client
+	stats *utils.EndpointStatsTracker
+}
+
+// Connects to the execution client at the provided URL and starts tracking statistics about its requests.
+// The proxy options are applied to HTTP endpoints, for execution clients that sit behind a reverse proxy. Their
+// headers and credentials (and the daemon's identity headers) are also sent when connecting to Websocket endpoints.
+func DialWithStats(ctx context.Context, url string, proxyOpts utils.ProxyOptions) (*StatsTrackingExecutionClient, error) {
+	return DialWithStatsAndLimits(ctx, url, proxyOpts, utils.DefaultResponseLimitSettings)
+}
+
+// Connects to the execution client at the provided URL like DialWithStats, with custom limits on the size of its
+// responses. The limits only apply to HTTP endpoints.
+func DialWithStatsAndLimits(ctx context.Context, url string, proxyOpts utils.ProxyOptions, limits utils.ResponseLimitSettings) (*StatsTrackingExecutionClient, error) {
+	stats := utils.NewEndpointStatsTracker(utils.DefaultEndpointStatsWindowSize)
+	httpClient := &http.Client{
+		Transport: &utils.StatsTrackingTransport{
+			Base: &utils.ProxyTransport{
+				Base: &utils.ResponseLimitTransport{
+					Settings: limits,
+				},
+				Options: proxyOpts,
+			},
+			Tracker: stats,
+		},
+	}
+	headers := utils.GetIdentityHeaders()
+	for name, values := range proxyOpts.GetHeaders() {
+		headers[name] = values
+	}
+	rpcClient, err := rpc.DialOptions(ctx, url, rpc.WithHTTPClient(httpClient), rpc.WithHeaders(headers))
+	if err != nil {
+		return nil, err
+	}
+	return &StatsTrackingExecutionClient{
+		Client: ethclient.NewClient(rpcClient),
+		stats:  stats,
+	}, nil
+}
+
+// Get the latency and availability statistics for the execution client's endpoint
+func (c *StatsTrackingExecutionClient) GetEndpointStats() utils.EndpointStats {
+	return c.stats.GetStats()
+}
@@ -0,0 +1,155 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// The kind of development node behind a test RPC client
+type TestRpcFlavor string
+
+const (
+	// Foundry's anvil
+	TestRpcFlavor_Anvil TestRpcFlavor = "anvil"
+
+	// The Hardhat Network
+	TestRpcFlavor_Hardhat TestRpcFlavor = "hardhat"
+)
+
+// Implemented by Execution clients that expose their underlying RPC client, such as ethclient.Client
+type IRpcClientProvider interface {
+	// Get the underlying RPC client
+	Client() *rpc.Client
+}
+
+// A client for the non-standard RPC methods provided by development nodes like anvil and Hardhat,
+// such as mining on demand, adjusting the chain's time, taking snapshots, and impersonating accounts.
+type TestRpcClient struct {
+	client *rpc.Client
+	flavor TestRpcFlavor
+}
+
+// Creates a new test RPC client for the provided Execution client, detecting the kind of development node it's connected to.
+// Returns an error if the client doesn't expose its RPC client or isn't connected to a supported development node.
+func NewTestRpcClient(ctx context.Context, ec IExecutionClient) (*TestRpcClient, error) {
+	provider, ok := ec.(IRpcClientProvider)
+	if !ok {
+		return nil, fmt.Errorf("execution client does not provide access to its RPC client")
+	}
+	client := provider.Client()
+
+	var version string
+	err := client.CallContext(ctx, &version, "web3_clientVersion")
+	if err != nil {
+		return nil, fmt.Errorf("error getting client version: %w", err)
+	}
+	flavor, err := getTestRpcFlavor(version)
+	if err != nil {
+		return nil, err
+	}
+	return &TestRpcClient{
+		client: client,
+		flavor: flavor,
+	}, nil
+}
+
+// Get the kind of development node the client is connected to
+func (c *TestRpcClient) GetFlavor() TestRpcFlavor {
+	return c.flavor
+}
+
+// Mine a single block, including any pending transactions
+func (c *TestRpcClient) MineBlock(ctx context.Context) error {
+	return c.call(ctx, nil, "evm_mine")
+}
+
+// Mine the provided number of blocks
+func (c *TestRpcClient) Mine(ctx context.Context, blocks uint64) error {
+	return c.call(ctx, nil, c.getMethod("mine"), utils.EncodeQuantity(blocks))
+}
+
+// Move the chain's clock forward by the provided number of seconds, which takes effect on the next block.
+// Returns the total time adjustment (in seconds) that has been applied so far.
+func (c *TestRpcClient) IncreaseTime(ctx context.Context, seconds uint64) (int64, error) {
+	var adjustment int64
+	err := c.call(ctx, &adjustment, "evm_increaseTime", seconds)
+	return adjustment, err
+}
+
+// Set the timestamp of the next block, in seconds since the Unix epoch
+func (c *TestRpcClient) SetNextBlockTimestamp(ctx context.Context, timestamp uint64) error {
+	return c.call(ctx, nil, "evm_setNextBlockTimestamp", timestamp)
+}
+
+// Take a snapshot of the chain's current state, returning an ID that can be passed to Revert
+func (c *TestRpcClient) Snapshot(ctx context.Context) (string, error) {
+	var id string
+	err := c.call(ctx, &id, "evm_snapshot")
+	return id, err
+}
+
+// Revert the chain's state to a previous snapshot. Snapshots can only be reverted to once; take a new one
+// after reverting to use it again.
+func (c *TestRpcClient) Revert(ctx context.Context, snapshotID string) error {
+	var success bool
+	err := c.call(ctx, &success, "evm_revert", snapshotID)
+	if err != nil {
+		return err
+	}
+	if !success {
+		return fmt.Errorf("snapshot %s could not be reverted to", snapshotID)
+	}
+	return nil
+}
+
+// Allow transactions to be sent from the provided address without its private key
+func (c *TestRpcClient) ImpersonateAccount(ctx context.Context, address common.Address) error {
+	return c.call(ctx, nil, c.getMethod("impersonateAccount"), address)
+}
+
+// Stop allowing transactions to be sent from the provided address without its private key
+func (c *TestRpcClient) StopImpersonatingAccount(ctx context.Context, address common.Address) error {
+	return c.call(ctx, nil, c.getMethod("stopImpersonatingAccount"), address)
+}
+
+// Set the ETH balance of an address, in wei
+func (c *TestRpcClient) SetBalance(ctx context.Context, address common.Address, balance *big.Int) error {
+	quantity, err := utils.EncodeBigQuantity(balance)
+	if err != nil {
+		return fmt.Errorf("error encoding balance: %w", err)
+	}
+	return c.call(ctx, nil, c.getMethod("setBalance"), address, quantity)
+}
+
+// Run an RPC method, wrapping any error with its name
+func (c *TestRpcClient) call(ctx context.Context, result any, method string, args ...any) error {
+	err := c.client.CallContext(ctx, result, method, args...)
+	if err != nil {
+		return fmt.Errorf("error calling %s: %w", method, err)
+	}
+	return nil
+}
+
+// Get the name of a method that's namespaced by the development node
+func (c *TestRpcClient) getMethod(name string) string {
+	return string(c.flavor) + "_" + name
+}
+
+// Get the kind of development node from its client version string
+func getTestRpcFlavor(version string) (TestRpcFlavor, error) {
+	lowerVersion := strings.ToLower(version)
+	switch {
+	case strings.HasPrefix(lowerVersion, "anvil"):
+		return TestRpcFlavor_Anvil, nil
+	case strings.HasPrefix(lowerVersion, "hardhatnetwork"):
+		return TestRpcFlavor_Hardhat, nil
+	default:
+		return "", fmt.Errorf("client [%s] is not a supported development node", version)
+	}
+}
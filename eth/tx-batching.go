@@ -0,0 +1,105 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// The ABI for Multicall3's aggregate3Value function
+	Multicall3ValueAbiString string = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3Value[]","name":"calls","type":"tuple[]"}],"name":"aggregate3Value","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+	multicall3ValueMethod string = "aggregate3Value"
+)
+
+// Global container for the parsed ABI above
+var multicall3ValueAbi *abi.ABI
+
+// A single call in a Multicall3 aggregate3Value batch
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+// Enable packing multiple transactions into a single call to the Multicall3 contract at the provided address.
+// See CreateMulticallTransactionInfo for details.
+func (t *TransactionManager) EnableMulticallBatching(multicallAddress common.Address) {
+	t.multicallAddress = &multicallAddress
+}
+
+// Disable packing multiple transactions into a single Multicall3 call
+func (t *TransactionManager) DisableMulticallBatching() {
+	t.multicallAddress = nil
+}
+
+// Check if multiple transactions can be packed into a single Multicall3 call
+func (t *TransactionManager) IsMulticallBatchingEnabled() bool {
+	return t.multicallAddress != nil
+}
+
+// Pack multiple independent transactions into a single Multicall3 aggregate3Value transaction and simulate it.
+// The batch is atomic: if any of the calls reverts, the whole transaction reverts. The value of the new transaction
+// is the sum of the values of the individual ones.
+// NOTE: the Multicall3 contract will be msg.sender for each call, not the sender of the transaction, so this must only
+// be used for calls that don't depend on who the sender is (such as permissionless functions or payments).
+func (t *TransactionManager) CreateMulticallTransactionInfo(txInfos []*TransactionInfo, opts *bind.TransactOpts) (*TransactionInfo, error) {
+	if t.multicallAddress == nil {
+		return nil, fmt.Errorf("multicall batching is not enabled")
+	}
+	if len(txInfos) == 0 {
+		return nil, fmt.Errorf("no transactions were provided")
+	}
+
+	// Parse the ABI
+	if multicall3ValueAbi == nil {
+		abiParsed, err := abi.JSON(strings.NewReader(Multicall3ValueAbiString))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing Multicall3 ABI: %w", err)
+		}
+		multicall3ValueAbi = &abiParsed
+	}
+
+	// Build the calls
+	calls := make([]multicall3Call, len(txInfos))
+	totalValue := big.NewInt(0)
+	for i, txInfo := range txInfos {
+		value := txInfo.Value
+		if value == nil {
+			value = big.NewInt(0)
+		}
+		calls[i] = multicall3Call{
+			Target:       txInfo.To,
+			AllowFailure: false,
+			Value:        value,
+			CallData:     txInfo.Data,
+		}
+		totalValue.Add(totalValue, value)
+	}
+	data, err := multicall3ValueAbi.Pack(multicall3ValueMethod, calls)
+	if err != nil {
+		return nil, fmt.Errorf("error packing multicall input data: %w", err)
+	}
+
+	// Simulate the batch with the total value attached
+	var simOpts *bind.TransactOpts
+	if opts != nil {
+		optsCopy := *opts
+		optsCopy.Value = totalValue
+		simOpts = &optsCopy
+	}
+	simResult := t.SimulateTransaction(t.client, *t.multicallAddress, simOpts, data)
+
+	return &TransactionInfo{
+		Data:             data,
+		To:               *t.multicallAddress,
+		Value:            totalValue,
+		SimulationResult: simResult,
+	}, nil
+}
@@ -0,0 +1,306 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// The number of field elements in a blob
+	FieldElementsPerBlob int = 4096
+
+	// The number of data bytes that fit in each field element when packing data into blobs. The first byte of each
+	// 32-byte element is left as zero so the element is always below the BLS modulus.
+	BlobBytesPerFieldElement int = 31
+
+	// The most data that can be packed into a single blob
+	MaxBlobDataSize int = FieldElementsPerBlob * BlobBytesPerFieldElement
+
+	// The default multiplier applied to the current blob base fee to get a blob fee cap, so the transaction stays
+	// valid if the blob base fee rises for a few blocks before it's included
+	DefaultBlobFeeCapMultiplier uint64 = 2
+)
+
+// A serializable blob-carrying (type 3) transaction, including its blobs and their KZG commitments and proofs
+type BlobTransactionInfo struct {
+	TransactionInfo
+
+	// The blobs the transaction carries, along with their commitments and proofs
+	Sidecar *types.BlobTxSidecar `json:"sidecar"`
+
+	// The most the transaction can pay per unit of blob gas, in wei
+	BlobFeeCap *big.Int `json:"blobFeeCap"`
+}
+
+// Pack arbitrary data into as many blobs as it needs. Each 32-byte field element holds 31 bytes of the data, and the
+// last blob is padded with zeros.
+func EncodeBlobs(data []byte) ([]kzg4844.Blob, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("blob data can't be empty")
+	}
+
+	blobCount := (len(data) + MaxBlobDataSize - 1) / MaxBlobDataSize
+	blobs := make([]kzg4844.Blob, blobCount)
+	for i := range blobs {
+		chunk := data[i*MaxBlobDataSize : min((i+1)*MaxBlobDataSize, len(data))]
+		for element := 0; element*BlobBytesPerFieldElement < len(chunk); element++ {
+			start := element * BlobBytesPerFieldElement
+			end := min(start+BlobBytesPerFieldElement, len(chunk))
+			copy(blobs[i][element*32+1:], chunk[start:end])
+		}
+	}
+	return blobs, nil
+}
+
+// Create the sidecar for a blob transaction, computing the KZG commitment and proof for each blob
+func NewBlobTxSidecar(blobs []kzg4844.Blob) (*types.BlobTxSidecar, error) {
+	if len(blobs) == 0 {
+		return nil, fmt.Errorf("a blob transaction needs at least one blob")
+	}
+
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, len(blobs)),
+	}
+	for i := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(&blobs[i])
+		if err != nil {
+			return nil, fmt.Errorf("error computing commitment for blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(&blobs[i], commitment)
+		if err != nil {
+			return nil, fmt.Errorf("error computing proof for blob %d: %w", i, err)
+		}
+		sidecar.Commitments[i] = commitment
+		sidecar.Proofs[i] = proof
+	}
+	return sidecar, nil
+}
+
+// ================
+// === Blob Fee ===
+// ================
+
+// Get the blob base fee of the next block, in wei, from the excess blob gas of the latest block
+func (t *TransactionManager) GetBlobBaseFee(ctx context.Context) (*big.Int, error) {
+	header, err := t.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	if header.ExcessBlobGas == nil {
+		return nil, fmt.Errorf("block %d doesn't have any excess blob gas info; the network may not support blob transactions yet", header.Number.Uint64())
+	}
+
+	var parentBlobGasUsed uint64
+	if header.BlobGasUsed != nil {
+		parentBlobGasUsed = *header.BlobGasUsed
+	}
+	excessBlobGas := eip4844.CalcExcessBlobGas(*header.ExcessBlobGas, parentBlobGasUsed)
+	return eip4844.CalcBlobFee(excessBlobGas), nil
+}
+
+// Get a blob fee cap for a new transaction by multiplying the blob base fee of the next block. If the multiplier is
+// 0, DefaultBlobFeeCapMultiplier is used.
+func (t *TransactionManager) GetBlobFeeCap(ctx context.Context, multiplier uint64) (*big.Int, error) {
+	if multiplier == 0 {
+		multiplier = DefaultBlobFeeCapMultiplier
+	}
+	baseFee, err := t.GetBlobBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return baseFee.Mul(baseFee, new(big.Int).SetUint64(multiplier)), nil
+}
+
+// ===================
+// === TX Creation ===
+// ===================
+
+// Create a new serializable BlobTransactionInfo from raw data and a blob sidecar, and simulate its execution
+func (t *TransactionManager) CreateBlobTransactionInfo(to common.Address, data []byte, sidecar *types.BlobTxSidecar, blobFeeCap *big.Int, opts *bind.TransactOpts) *BlobTransactionInfo {
+	var simResult SimulationResult
+	var value *big.Int
+	if opts != nil {
+		value = opts.Value
+		simResult = t.simulateCall(t.client, ethereum.CallMsg{
+			From:          opts.From,
+			To:            &to,
+			GasFeeCap:     big.NewInt(0),
+			GasTipCap:     big.NewInt(0),
+			Value:         opts.Value,
+			Data:          data,
+			BlobGasFeeCap: blobFeeCap,
+			BlobHashes:    sidecar.BlobHashes(),
+		})
+		t.stats.recordSimulation(simResult)
+	}
+
+	return &BlobTransactionInfo{
+		TransactionInfo: TransactionInfo{
+			Data:             data,
+			To:               to,
+			Value:            value,
+			SimulationResult: simResult,
+		},
+		Sidecar:    sidecar,
+		BlobFeeCap: blobFeeCap,
+	}
+}
+
+// =================
+// === Execution ===
+// =================
+
+// Signs a blob transaction but does not submit it to the network
+func (t *TransactionManager) SignBlobTransaction(txInfo *BlobTransactionInfo, opts *bind.TransactOpts) (*types.Transaction, error) {
+	opts.NoSend = true
+	return t.ExecuteBlobTransaction(txInfo, opts)
+}
+
+// Signs and submits a blob transaction to the network, along with its sidecar.
+// The nonce, gas limit, and gas fee info in the provided opts will be used if they're set; otherwise a reserved
+// nonce, the safe gas limit from the transaction's simulation, and the suggested fees are used. The value will come
+// from the provided txInfo. It will *not* use the value in the provided opts.
+func (t *TransactionManager) ExecuteBlobTransaction(txInfo *BlobTransactionInfo, opts *bind.TransactOpts) (*types.Transaction, error) {
+	reservation, err := t.reserveNonceForOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	if reservation != nil {
+		reservedOpts := *opts
+		reservedOpts.Nonce = reservation.BigNonce()
+		opts = &reservedOpts
+		defer reservation.Release()
+	}
+
+	tx, err := t.createBlobTransaction(txInfo, opts)
+	if err == nil && !opts.NoSend {
+		err = t.client.SendTransaction(getOptsContext(opts), tx)
+	}
+	if !opts.NoSend {
+		if err != nil {
+			t.stats.submissionFailures.Add(1)
+		} else {
+			t.stats.submissions.Add(1)
+			t.nonces.recordSubmission(opts.From, tx)
+		}
+	}
+	return tx, err
+}
+
+// Build and sign a blob transaction
+func (t *TransactionManager) createBlobTransaction(txInfo *BlobTransactionInfo, opts *bind.TransactOpts) (*types.Transaction, error) {
+	if txInfo.Sidecar == nil || len(txInfo.Sidecar.Blobs) == 0 {
+		return nil, fmt.Errorf("blob transaction doesn't have any blobs")
+	}
+	if txInfo.BlobFeeCap == nil {
+		return nil, fmt.Errorf("blob transaction doesn't have a blob fee cap")
+	}
+	ctx := getOptsContext(opts)
+
+	// Get the nonce
+	var nonce uint64
+	if opts.Nonce != nil {
+		nonce = opts.Nonce.Uint64()
+	} else {
+		pendingNonce, err := t.client.PendingNonceAt(ctx, opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pending nonce: %w", err)
+		}
+		nonce = pendingNonce
+	}
+
+	// Get the gas limit
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		if txInfo.SimulationResult.SimulationError != "" {
+			return nil, fmt.Errorf("blob transaction simulation failed: %s", txInfo.SimulationResult.SimulationError)
+		}
+		if !txInfo.SimulationResult.IsSimulated {
+			return nil, fmt.Errorf("blob transaction wasn't simulated and no gas limit was provided")
+		}
+		gasLimit = txInfo.SimulationResult.SafeGasLimit
+	}
+
+	// Get the fees
+	gasTipCap := opts.GasTipCap
+	if gasTipCap == nil {
+		suggestedTip, err := t.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+		}
+		gasTipCap = suggestedTip
+	}
+	gasFeeCap := opts.GasFeeCap
+	if gasFeeCap == nil {
+		header, err := t.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block header: %w", err)
+		}
+		if header.BaseFee == nil {
+			return nil, fmt.Errorf("block %d doesn't have a base fee", header.Number.Uint64())
+		}
+		gasFeeCap = new(big.Int).Add(gasTipCap, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+	}
+
+	// Get the chain ID
+	chainID, err := t.client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting chain ID: %w", err)
+	}
+
+	// Create and sign the transaction
+	value := txInfo.Value
+	if value == nil {
+		value = common.Big0
+	}
+	blobTx := &types.BlobTx{
+		Nonce:      nonce,
+		Gas:        gasLimit,
+		To:         txInfo.To,
+		Data:       txInfo.Data,
+		BlobHashes: txInfo.Sidecar.BlobHashes(),
+		Sidecar:    txInfo.Sidecar,
+	}
+	for _, field := range []struct {
+		name   string
+		source *big.Int
+		target **uint256.Int
+	}{
+		{"chain ID", chainID, &blobTx.ChainID},
+		{"priority fee", gasTipCap, &blobTx.GasTipCap},
+		{"max fee", gasFeeCap, &blobTx.GasFeeCap},
+		{"value", value, &blobTx.Value},
+		{"blob fee cap", txInfo.BlobFeeCap, &blobTx.BlobFeeCap},
+	} {
+		converted, overflow := uint256.FromBig(field.source)
+		if overflow {
+			return nil, fmt.Errorf("transaction %s [%s] is too large", field.name, field.source.String())
+		}
+		*field.target = converted
+	}
+	tx := types.NewTx(blobTx)
+	signedTx, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		return nil, fmt.Errorf("error signing blob transaction: %w", err)
+	}
+	return signedTx, nil
+}
+
+// Get the context to use for a transaction's requests from its opts
+func getOptsContext(opts *bind.TransactOpts) context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/node-manager-core/events"
+	"github.com/rocket-pool/node-manager-core/utils"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -39,6 +41,21 @@ type TransactionManager struct {
 
 	// The client to use for running transaction simulations
 	client IExecutionClient
+
+	// The address of the Multicall3 contract used to batch transactions, if batching is enabled
+	multicallAddress *common.Address
+
+	// Counts of the manager's activity
+	stats transactionStatsTracker
+
+	// The nonces reserved and used by the manager
+	nonces nonceTracker
+
+	// The context for simulations, nonce lookups, and waiting on transactions
+	ctx context.Context
+
+	// The bus that submissions and confirmations are published to, if any
+	eventBus *events.EventBus
 }
 
 // Creates a new transaction manager, which can simulate and execute transactions.
@@ -53,9 +70,27 @@ func NewTransactionManager(client IExecutionClient, safeGasBuffer uint64, safeGa
 		client:     client,
 		buffer:     safeGasBuffer,
 		multiplier: safeGasMultiplier,
+		ctx:        context.Background(),
 	}, nil
 }
 
+// Set the context the manager uses for simulations, nonce lookups, and waiting on transactions. Cancelling it stops
+// any of those that are in progress, such as when the daemon is shutting down.
+func (t *TransactionManager) SetBaseContext(ctx context.Context) {
+	t.ctx = ctx
+}
+
+// Set the bus to publish an event to whenever the manager submits a transaction or one it's waiting on is included in
+// a block. This must be called before the manager is used.
+func (t *TransactionManager) SetEventBus(bus *events.EventBus) {
+	t.eventBus = bus
+}
+
+// Get the counts of the manager's activity since it was created
+func (t *TransactionManager) GetStats() TransactionStats {
+	return t.stats.get()
+}
+
 // ==================
 // === Simulation ===
 // ==================
@@ -86,8 +121,7 @@ func (t *TransactionManager) SimulateTransaction(client IExecutionClient, to com
 		}
 	}
 
-	// Estimate gas limit
-	gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+	result := t.simulateCall(client, ethereum.CallMsg{
 		From:      opts.From,
 		To:        &to,
 		GasFeeCap: big.NewInt(0),
@@ -95,6 +129,14 @@ func (t *TransactionManager) SimulateTransaction(client IExecutionClient, to com
 		Value:     opts.Value,
 		Data:      input,
 	})
+	t.stats.recordSimulation(result)
+	return result
+}
+
+// Simulate a call to estimate its gas limit, without recording it in the manager's stats
+func (t *TransactionManager) simulateCall(client IExecutionClient, msg ethereum.CallMsg) SimulationResult {
+	// Estimate gas limit
+	gasLimit, err := client.EstimateGas(t.ctx, msg)
 	if err != nil {
 		return SimulationResult{
 			IsSimulated:       true,
@@ -145,6 +187,7 @@ func (t *TransactionManager) CreateTransactionInfo(contract *Contract, method st
 		Data:             data,
 		To:               contract.Address,
 		Value:            value,
+		CallDescription:  describeArguments(contract.Name, contract.ABI.Methods[method], parameters),
 		SimulationResult: simResult,
 	}
 	return txInfo, nil
@@ -181,7 +224,8 @@ func (t *TransactionManager) SignTransaction(txInfo *TransactionInfo, opts *bind
 }
 
 // Signs and submits a transaction to the network.
-// The nonce and gas fee info in the provided opts will be used.
+// The nonce and gas fee info in the provided opts will be used; if there's no nonce, one is reserved for it the same
+// way ReserveNonce does, so it can't collide with other reservations.
 // The value will come from the provided txInfo. It will *not* use the value in the provided opts.
 func (t *TransactionManager) ExecuteTransaction(txInfo *TransactionInfo, opts *bind.TransactOpts) (*types.Transaction, error) {
 	return t.ExecuteTransactionRaw(txInfo.To, txInfo.Data, txInfo.Value, opts)
@@ -209,7 +253,32 @@ func (t *TransactionManager) ExecuteTransactionRaw(to common.Address, data []byt
 		Value: value,
 	}
 
-	return contract.RawTransact(newOpts, data)
+	// Reserve a nonce if one wasn't provided; this does nothing once the transaction has been submitted with it
+	reservation, err := t.reserveNonceForOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	if reservation != nil {
+		newOpts.Nonce = reservation.BigNonce()
+		defer reservation.Release()
+	}
+
+	tx, err := contract.RawTransact(newOpts, data)
+	if !opts.NoSend {
+		if err != nil {
+			t.stats.submissionFailures.Add(1)
+		} else {
+			t.stats.submissions.Add(1)
+			t.nonces.recordSubmission(opts.From, tx)
+			t.eventBus.Publish(events.EventType_TransactionSubmitted, events.TransactionSubmittedData{
+				Hash:  tx.Hash(),
+				From:  opts.From,
+				To:    tx.To(),
+				Nonce: tx.Nonce(),
+			})
+		}
+	}
+	return tx, err
 }
 
 // Signs and submits a bundle of transactions to the network that are all sent from the same address.
@@ -220,7 +289,7 @@ func (t *TransactionManager) ExecuteTransactionRaw(to common.Address, data []byt
 func (t *TransactionManager) BatchExecuteTransactions(txSubmissions []*TransactionSubmission, opts *bind.TransactOpts) ([]*types.Transaction, error) {
 	if opts.Nonce == nil {
 		// Get the latest nonce and use that as the nonce for the first TX
-		nonce, err := t.client.NonceAt(context.Background(), opts.From, nil)
+		nonce, err := t.client.NonceAt(t.ctx, opts.From, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error getting latest nonce for node: %w", err)
 		}
@@ -250,17 +319,26 @@ func (t *TransactionManager) BatchExecuteTransactions(txSubmissions []*Transacti
 // Wait for a transaction to get included in blocks
 func (t *TransactionManager) WaitForTransaction(tx *types.Transaction) error {
 	// Wait for transaction to be included
-	txReceipt, err := bind.WaitMined(context.Background(), t.client, tx)
+	txReceipt, err := bind.WaitMined(t.ctx, t.client, tx)
 	if err != nil {
+		t.stats.waitFailures.Add(1)
 		return fmt.Errorf("error running transaction %s: %w", tx.Hash().Hex(), err)
 	}
 
 	// Check transaction status
+	t.eventBus.Publish(events.EventType_TransactionConfirmed, events.TransactionConfirmedData{
+		Hash:        tx.Hash(),
+		BlockNumber: txReceipt.BlockNumber.Uint64(),
+		GasUsed:     txReceipt.GasUsed,
+		Success:     txReceipt.Status != 0,
+	})
 	if txReceipt.Status == 0 {
+		t.stats.reverts.Add(1)
 		return fmt.Errorf("transaction %s failed with status 0", tx.Hash().Hex())
 	}
 
 	// Return
+	t.stats.confirmations.Add(1)
 	return nil
 }
 
@@ -318,10 +396,12 @@ func (t *TransactionManager) WaitForTransactionsByHash(hashes []common.Hash) err
 func (t *TransactionManager) getTransactionFromHash(hash common.Hash) (*types.Transaction, error) {
 	// Retry for 30 sec if the TX wasn't found
 	for i := 0; i < 30; i++ {
-		tx, _, err := t.client.TransactionByHash(context.Background(), hash)
+		tx, _, err := t.client.TransactionByHash(t.ctx, hash)
 		if err != nil {
 			if err.Error() == "not found" {
-				time.Sleep(1 * time.Second)
+				if utils.SleepWithCancel(t.ctx, 1*time.Second) {
+					return nil, fmt.Errorf("stopped waiting for transaction %s: %w", hash.Hex(), t.ctx.Err())
+				}
 				continue
 			}
 			return nil, err
@@ -0,0 +1,339 @@
+package eth
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// The gas limit of a plain ETH transfer, which is what cancellations are sent as
+	cancelGasLimit uint64 = 21000
+)
+
+// A nonce that's been set aside for a single transaction. Submitting a transaction with it through the transaction
+// manager consumes it; if the transaction is never submitted, release it so the nonce doesn't leave a gap.
+type NonceReservation struct {
+	// The reserved nonce
+	Nonce uint64
+
+	address common.Address
+	tracker *nonceTracker
+}
+
+// Get the nonce as a big.Int, for use in TransactOpts
+func (r *NonceReservation) BigNonce() *big.Int {
+	return new(big.Int).SetUint64(r.Nonce)
+}
+
+// Give the nonce back so it can be reserved again. This does nothing if a transaction has already been submitted
+// with it.
+func (r *NonceReservation) Release() {
+	r.tracker.release(r.address, r.Nonce)
+}
+
+// A transaction that was submitted through the transaction manager but hasn't been included yet
+type PendingTransaction struct {
+	// The latest transaction submitted with the nonce, which may be a replacement of the original
+	Transaction *types.Transaction
+
+	// The time the latest transaction was submitted
+	SubmittedAt time.Time
+}
+
+// The nonces the transaction manager knows about for a single account
+type accountNonces struct {
+	// The next nonce that hasn't been handed out yet
+	next uint64
+
+	// Nonces that have been reserved but not submitted yet
+	reserved map[uint64]bool
+
+	// Nonces that were reserved and then released without being submitted, so they can be handed out again
+	released map[uint64]bool
+
+	// Transactions that have been submitted, by nonce
+	pending map[uint64]PendingTransaction
+}
+
+// Tracks the nonces handed out and used by a transaction manager, for each account; safe to use from multiple
+// goroutines
+type nonceTracker struct {
+	accounts map[common.Address]*accountNonces
+	lock     sync.Mutex
+}
+
+// Get the tracked nonces for an account, creating them if they don't exist yet. The lock must be held.
+func (n *nonceTracker) getAccount(address common.Address) *accountNonces {
+	if n.accounts == nil {
+		n.accounts = map[common.Address]*accountNonces{}
+	}
+	account, exists := n.accounts[address]
+	if !exists {
+		account = &accountNonces{
+			reserved: map[uint64]bool{},
+			released: map[uint64]bool{},
+			pending:  map[uint64]PendingTransaction{},
+		}
+		n.accounts[address] = account
+	}
+	return account
+}
+
+// Bring the tracked nonces for an account up to date with the chain, forgetting the ones that have been included.
+// The lock must be held.
+func (n *nonceTracker) sync(address common.Address, confirmedNonce uint64, pendingNonce uint64) *accountNonces {
+	account := n.getAccount(address)
+	if pendingNonce > account.next {
+		account.next = pendingNonce
+	}
+	if confirmedNonce > account.next {
+		account.next = confirmedNonce
+	}
+	for nonce := range account.pending {
+		if nonce < confirmedNonce {
+			delete(account.pending, nonce)
+		}
+	}
+	for nonce := range account.released {
+		// Released nonces below the pending nonce have been used by a transaction the manager didn't submit
+		if nonce < confirmedNonce || nonce < pendingNonce {
+			delete(account.released, nonce)
+		}
+	}
+	for nonce := range account.reserved {
+		if nonce < confirmedNonce {
+			delete(account.reserved, nonce)
+		}
+	}
+	return account
+}
+
+// Hand out the lowest released nonce, or the next new one if none were released
+func (n *nonceTracker) reserve(address common.Address, confirmedNonce uint64, pendingNonce uint64) *NonceReservation {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	account := n.sync(address, confirmedNonce, pendingNonce)
+	var nonce uint64
+	if len(account.released) > 0 {
+		released := make([]uint64, 0, len(account.released))
+		for candidate := range account.released {
+			released = append(released, candidate)
+		}
+		nonce = slices.Min(released)
+		delete(account.released, nonce)
+	} else {
+		nonce = account.next
+		account.next++
+	}
+	account.reserved[nonce] = true
+
+	return &NonceReservation{
+		Nonce:   nonce,
+		address: address,
+		tracker: n,
+	}
+}
+
+// Give a reserved nonce back if it hasn't been used
+func (n *nonceTracker) release(address common.Address, nonce uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	account := n.getAccount(address)
+	if !account.reserved[nonce] {
+		return
+	}
+	delete(account.reserved, nonce)
+	account.released[nonce] = true
+
+	// Rewind past the released nonces at the top so they're handed out again in order; the ones below a nonce that's
+	// still in use stay released until they're reserved again
+	for account.next > 0 && account.released[account.next-1] {
+		account.next--
+		delete(account.released, account.next)
+	}
+}
+
+// Record a transaction that was submitted to the network, replacing any earlier one with the same nonce
+func (n *nonceTracker) recordSubmission(address common.Address, tx *types.Transaction) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	account := n.getAccount(address)
+	nonce := tx.Nonce()
+	delete(account.reserved, nonce)
+	delete(account.released, nonce)
+	if nonce >= account.next {
+		account.next = nonce + 1
+	}
+	account.pending[nonce] = PendingTransaction{
+		Transaction: tx,
+		SubmittedAt: time.Now(),
+	}
+}
+
+// =================
+// === Reserving ===
+// =================
+
+// Reserve a nonce for a transaction from the provided address, so concurrent submitters don't end up using the same
+// one. Set the reservation's nonce in the TransactOpts before submitting; if the transaction ends up not being
+// submitted, release the reservation.
+func (t *TransactionManager) ReserveNonce(ctx context.Context, address common.Address) (*NonceReservation, error) {
+	confirmedNonce, pendingNonce, err := t.getNonces(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return t.nonces.reserve(address, confirmedNonce, pendingNonce), nil
+}
+
+// Reserve a nonce for a transaction that's about to be submitted without one, so it can't collide with the nonces
+// reserved by other submitters. Returns nil if the opts already have a nonce or the transaction won't be sent.
+func (t *TransactionManager) reserveNonceForOpts(opts *bind.TransactOpts) (*NonceReservation, error) {
+	if opts.Nonce != nil || opts.NoSend {
+		return nil, nil
+	}
+	reservation, err := t.ReserveNonce(getOptsContext(opts), opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("error reserving nonce: %w", err)
+	}
+	return reservation, nil
+}
+
+// Get the nonces between the address's latest confirmed nonce and the highest nonce the manager has handed out that
+// don't have a submitted transaction or an outstanding reservation. Transactions with nonces after a gap can't be
+// included until the gap is filled.
+func (t *TransactionManager) GetNonceGaps(ctx context.Context, address common.Address) ([]uint64, error) {
+	confirmedNonce, pendingNonce, err := t.getNonces(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	t.nonces.lock.Lock()
+	defer t.nonces.lock.Unlock()
+
+	account := t.nonces.sync(address, confirmedNonce, pendingNonce)
+	gaps := []uint64{}
+	for nonce := confirmedNonce; nonce < account.next; nonce++ {
+		if nonce < pendingNonce {
+			// The node already has a transaction with this nonce in its mempool
+			continue
+		}
+		if _, exists := account.pending[nonce]; exists {
+			continue
+		}
+		if account.reserved[nonce] {
+			continue
+		}
+		gaps = append(gaps, nonce)
+	}
+	return gaps, nil
+}
+
+// Get the transactions submitted by the manager from the provided address that still haven't been included after
+// the provided amount of time since they (or their latest replacement) were submitted, sorted by nonce
+func (t *TransactionManager) GetStuckTransactions(ctx context.Context, address common.Address, maxAge time.Duration) ([]PendingTransaction, error) {
+	confirmedNonce, pendingNonce, err := t.getNonces(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	t.nonces.lock.Lock()
+	defer t.nonces.lock.Unlock()
+
+	account := t.nonces.sync(address, confirmedNonce, pendingNonce)
+	stuck := []PendingTransaction{}
+	for _, pending := range account.pending {
+		if time.Since(pending.SubmittedAt) >= maxAge {
+			stuck = append(stuck, pending)
+		}
+	}
+	slices.SortFunc(stuck, func(a PendingTransaction, b PendingTransaction) int {
+		return cmp.Compare(a.Transaction.Nonce(), b.Transaction.Nonce())
+	})
+	return stuck, nil
+}
+
+// Get the latest confirmed nonce and the pending nonce of the address
+func (t *TransactionManager) getNonces(ctx context.Context, address common.Address) (uint64, uint64, error) {
+	confirmedNonce, err := t.client.NonceAt(ctx, address, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting latest nonce for %s: %w", address.Hex(), err)
+	}
+	pendingNonce, err := t.client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting pending nonce for %s: %w", address.Hex(), err)
+	}
+	return confirmedNonce, pendingNonce, nil
+}
+
+// ===================
+// === Replacement ===
+// ===================
+
+// Resubmit a pending transaction with the same nonce and contents, but with its max fee and priority fee increased by
+// the provided percentage (which must be at least MinFeeBumpPercent). If the fees in opts are higher than the bumped
+// ones, they're used instead. The opts must have the signer and sender of the original transaction; their nonce, gas
+// limit, and value are ignored.
+func (t *TransactionManager) ReplaceTransaction(tx *types.Transaction, opts *bind.TransactOpts, bumpPercent uint64) (*types.Transaction, error) {
+	if tx.To() == nil {
+		return nil, fmt.Errorf("contract creation transactions can't be replaced")
+	}
+	return t.resubmitTransaction(tx, *tx.To(), tx.Data(), tx.Value(), tx.Gas(), opts, bumpPercent)
+}
+
+// Cancel a pending transaction by submitting an empty transfer of 0 ETH to the sender with the same nonce, with its
+// max fee and priority fee increased by the provided percentage (which must be at least MinFeeBumpPercent). If the
+// fees in opts are higher than the bumped ones, they're used instead. The opts must have the signer and sender of the
+// original transaction; their nonce, gas limit, and value are ignored.
+// NOTE: the cancellation only takes effect if it's included before the original transaction.
+func (t *TransactionManager) CancelTransaction(tx *types.Transaction, opts *bind.TransactOpts, bumpPercent uint64) (*types.Transaction, error) {
+	return t.resubmitTransaction(tx, opts.From, nil, big.NewInt(0), cancelGasLimit, opts, bumpPercent)
+}
+
+// Submit a new transaction with the same nonce as an existing one and bumped fees
+func (t *TransactionManager) resubmitTransaction(tx *types.Transaction, to common.Address, data []byte, value *big.Int, gasLimit uint64, opts *bind.TransactOpts, bumpPercent uint64) (*types.Transaction, error) {
+	if bumpPercent < MinFeeBumpPercent {
+		return nil, fmt.Errorf("bump percent must be at least %d", MinFeeBumpPercent)
+	}
+	if tx.Type() == types.BlobTxType {
+		return nil, fmt.Errorf("blob transactions must be replaced with a new blob transaction that has the same nonce")
+	}
+
+	feeCap := bumpFee(tx.GasFeeCap(), bumpPercent, nil)
+	if opts.GasFeeCap != nil && opts.GasFeeCap.Cmp(feeCap) > 0 {
+		feeCap = new(big.Int).Set(opts.GasFeeCap)
+	}
+	tipCap := bumpFee(tx.GasTipCap(), bumpPercent, nil)
+	if opts.GasTipCap != nil && opts.GasTipCap.Cmp(tipCap) > 0 {
+		tipCap = new(big.Int).Set(opts.GasTipCap)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		return nil, fmt.Errorf("bumped priority fee of %s is higher than the bumped max fee of %s", tipCap.String(), feeCap.String())
+	}
+
+	newOpts := &bind.TransactOpts{
+		From:      opts.From,
+		Nonce:     new(big.Int).SetUint64(tx.Nonce()),
+		Signer:    opts.Signer,
+		GasFeeCap: feeCap,
+		GasTipCap: tipCap,
+		GasLimit:  gasLimit,
+		Context:   opts.Context,
+	}
+	replacement, err := t.ExecuteTransactionRaw(to, data, value, newOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error submitting replacement for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	return replacement, nil
+}
@@ -0,0 +1,227 @@
+package eth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The version of the stable TransactionInfo JSON format. Older JSON without a version used Go's encoding defaults,
+	// so its data is base64-encoded.
+	transactionInfoJsonVersion uint64 = 1
+)
+
+// The stable JSON format of TransactionInfo. Data is hex-encoded and value is a decimal string so the format can be
+// read by clients that don't share Go's encoding defaults.
+type transactionInfoJson struct {
+	Version          uint64           `json:"version,omitempty"`
+	Data             string           `json:"data"`
+	To               common.Address   `json:"to"`
+	Value            *QuotedBigInt    `json:"value"`
+	CallDescription  *CallDescription `json:"callDescription,omitempty"`
+	SimulationResult SimulationResult `json:"simulationResult"`
+}
+
+// Serialize the transaction info to JSON
+func (t TransactionInfo) MarshalJSON() ([]byte, error) {
+	value := big.NewInt(0)
+	if t.Value != nil {
+		value = t.Value
+	}
+	return json.Marshal(transactionInfoJson{
+		Version:          transactionInfoJsonVersion,
+		Data:             utils.EncodeHexWithPrefix(t.Data),
+		To:               t.To,
+		Value:            (*QuotedBigInt)(value),
+		CallDescription:  t.CallDescription,
+		SimulationResult: t.SimulationResult,
+	})
+}
+
+// Deserialize the transaction info from JSON. For compatibility with older versions, JSON without a format version is
+// read with base64-encoded data, and the value can be a raw number instead of a string.
+func (t *TransactionInfo) UnmarshalJSON(data []byte) error {
+	var info transactionInfoJson
+	err := json.Unmarshal(data, &info)
+	if err != nil {
+		return err
+	}
+
+	var txData []byte
+	switch info.Version {
+	case 0:
+		txData, err = base64.StdEncoding.DecodeString(info.Data)
+	case transactionInfoJsonVersion:
+		txData, err = utils.DecodeHex(info.Data)
+	default:
+		return fmt.Errorf("unsupported transaction info format version %d", info.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("error decoding transaction data: %w", err)
+	}
+
+	t.Data = txData
+	t.To = info.To
+	t.Value = nil
+	if info.Value != nil {
+		t.Value = info.Value.ToInt()
+	}
+	t.CallDescription = info.CallDescription
+	t.SimulationResult = info.SimulationResult
+	return nil
+}
+
+// Serialize the transaction info into a hex-encoded JSON string, which can be passed between processes
+// (such as from a daemon to a CLI and back) as a single argument
+func SerializeTransactionInfo(txInfo *TransactionInfo) (string, error) {
+	return serializeToHexJson(txInfo)
+}
+
+// Deserialize transaction info created by SerializeTransactionInfo
+func DeserializeTransactionInfo(serialized string) (*TransactionInfo, error) {
+	return deserializeFromHexJson[TransactionInfo](serialized)
+}
+
+// Serialize the transaction submission into a hex-encoded JSON string, which can be passed between processes
+// (such as from a CLI back to a daemon after the user approves it) as a single argument
+func SerializeTransactionSubmission(submission *TransactionSubmission) (string, error) {
+	return serializeToHexJson(submission)
+}
+
+// Deserialize a transaction submission created by SerializeTransactionSubmission
+func DeserializeTransactionSubmission(serialized string) (*TransactionSubmission, error) {
+	submission, err := deserializeFromHexJson[TransactionSubmission](serialized)
+	if err != nil {
+		return nil, err
+	}
+	if submission.TxInfo == nil {
+		return nil, fmt.Errorf("transaction submission is missing its transaction info")
+	}
+	return submission, nil
+}
+
+// Describe a contract method call using the contract's ABI and the arguments it was called with
+func DescribeCall(contract *Contract, method string, parameters ...any) (*CallDescription, error) {
+	abiMethod, exists := contract.ABI.Methods[method]
+	if !exists {
+		return nil, fmt.Errorf("contract does not have a method named [%s]", method)
+	}
+	if len(parameters) != len(abiMethod.Inputs) {
+		return nil, fmt.Errorf("method [%s] expects %d arguments but %d were provided", method, len(abiMethod.Inputs), len(parameters))
+	}
+	return describeArguments(contract.Name, abiMethod, parameters), nil
+}
+
+// Describe a contract method call by decoding its calldata with the contract's ABI
+func DescribeCallData(contractAbi *abi.ABI, contractName string, data []byte) (*CallDescription, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata is too short to contain a method selector")
+	}
+	abiMethod, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("error finding method for selector %x: %w", data[:4], err)
+	}
+	parameters, err := abiMethod.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding arguments for method [%s]: %w", abiMethod.Name, err)
+	}
+	return describeArguments(contractName, *abiMethod, parameters), nil
+}
+
+// Build a call description from a method and its argument values
+func describeArguments(contractName string, abiMethod abi.Method, parameters []any) *CallDescription {
+	arguments := make([]CallArgument, len(abiMethod.Inputs))
+	for i, input := range abiMethod.Inputs {
+		arguments[i] = CallArgument{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: FormatAbiValue(parameters[i]),
+		}
+	}
+	return &CallDescription{
+		ContractName: contractName,
+		Method:       abiMethod.Name,
+		Arguments:    arguments,
+	}
+}
+
+// Format a value decoded from (or to be encoded with) an ABI as a string.
+// Addresses are checksummed, byte arrays are hex-encoded, and lists are formatted element-by-element.
+func FormatAbiValue(value any) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case common.Address:
+		return typedValue.Hex()
+	case common.Hash:
+		return typedValue.Hex()
+	case *big.Int:
+		if typedValue == nil {
+			return "0"
+		}
+		return typedValue.String()
+	case []byte:
+		return utils.EncodeHexWithPrefix(typedValue)
+	case string:
+		return typedValue
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Array, reflect.Slice:
+		if reflected.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, reflected.Len())
+			for i := range bytes {
+				bytes[i] = byte(reflected.Index(i).Uint())
+			}
+			return utils.EncodeHexWithPrefix(bytes)
+		}
+		elements := make([]string, reflected.Len())
+		for i := range elements {
+			elements[i] = FormatAbiValue(reflected.Index(i).Interface())
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case reflect.Struct:
+		fields := []string{}
+		for i := 0; i < reflected.NumField(); i++ {
+			field := reflected.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fields = append(fields, field.Name+": "+FormatAbiValue(reflected.Field(i).Interface()))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return fmt.Sprint(value)
+}
+
+// Serialize a value into hex-encoded JSON
+func serializeToHexJson(value any) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error serializing to JSON: %w", err)
+	}
+	return utils.EncodeHexWithPrefix(bytes), nil
+}
+
+// Deserialize a value from hex-encoded JSON
+func deserializeFromHexJson[Type any](serialized string) (*Type, error) {
+	bytes, err := utils.DecodeHex(strings.TrimSpace(serialized))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding hex: %w", err)
+	}
+	var value Type
+	err = json.Unmarshal(bytes, &value)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing JSON: %w", err)
+	}
+	return &value, nil
+}
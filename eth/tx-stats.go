@@ -0,0 +1,64 @@
+package eth
+
+import (
+	"sync/atomic"
+)
+
+// Counts of the activity of a transaction manager since it was created
+type TransactionStats struct {
+	// The number of transactions that were simulated
+	Simulations uint64
+
+	// The number of simulations that failed, such as because the transaction would revert
+	SimulationFailures uint64
+
+	// The number of transactions that were submitted to the network
+	Submissions uint64
+
+	// The number of transactions that couldn't be signed or submitted
+	SubmissionFailures uint64
+
+	// The number of transactions that were included in a block successfully
+	Confirmations uint64
+
+	// The number of transactions that were included in a block but reverted
+	Reverts uint64
+
+	// The number of transactions that couldn't be waited on, such as because their receipts couldn't be retrieved
+	WaitFailures uint64
+}
+
+// Tracks the activity of a transaction manager; the counters are safe to update from multiple goroutines
+type transactionStatsTracker struct {
+	simulations        atomic.Uint64
+	simulationFailures atomic.Uint64
+	submissions        atomic.Uint64
+	submissionFailures atomic.Uint64
+	confirmations      atomic.Uint64
+	reverts            atomic.Uint64
+	waitFailures       atomic.Uint64
+}
+
+// Get a snapshot of the counters
+func (s *transactionStatsTracker) get() TransactionStats {
+	return TransactionStats{
+		Simulations:        s.simulations.Load(),
+		SimulationFailures: s.simulationFailures.Load(),
+		Submissions:        s.submissions.Load(),
+		SubmissionFailures: s.submissionFailures.Load(),
+		Confirmations:      s.confirmations.Load(),
+		Reverts:            s.reverts.Load(),
+		WaitFailures:       s.waitFailures.Load(),
+	}
+}
+
+// Record the result of a simulation
+func (s *transactionStatsTracker) recordSimulation(result SimulationResult) {
+	if !result.IsSimulated {
+		return
+	}
+	s.simulations.Add(1)
+	if result.SimulationError != "" {
+		s.simulationFailures.Add(1)
+	}
+}
@@ -35,10 +35,54 @@ type TransactionInfo struct {
 	// The ETH value, in wei, to send along with the transaction
 	Value *big.Int `json:"value"`
 
+	// A description of the contract method the transaction calls, if it was created from an ABI
+	CallDescription *CallDescription `json:"callDescription,omitempty"`
+
 	// Info about the transaction's simulation
 	SimulationResult SimulationResult `json:"simulationResult"`
 }
 
+// A description of the contract method a transaction calls, decoded from the contract's ABI
+type CallDescription struct {
+	// The name of the contract being called, if known
+	ContractName string `json:"contractName,omitempty"`
+
+	// The name of the method being called
+	Method string `json:"method"`
+
+	// The method's arguments, in order
+	Arguments []CallArgument `json:"arguments"`
+}
+
+// Render the call as "Contract.method(name: value, ...)", omitting the contract if its name isn't known
+func (d *CallDescription) String() string {
+	arguments := make([]string, len(d.Arguments))
+	for i, argument := range d.Arguments {
+		if argument.Name == "" {
+			arguments[i] = argument.Value
+		} else {
+			arguments[i] = argument.Name + ": " + argument.Value
+		}
+	}
+	call := d.Method + "(" + strings.Join(arguments, ", ") + ")"
+	if d.ContractName != "" {
+		call = d.ContractName + "." + call
+	}
+	return call
+}
+
+// A single argument of a contract method call
+type CallArgument struct {
+	// The name of the argument in the ABI
+	Name string `json:"name"`
+
+	// The ABI type of the argument
+	Type string `json:"type"`
+
+	// The argument's value, formatted as a string
+	Value string `json:"value"`
+}
+
 // Information for submitting a candidate transaction to the network
 type TransactionSubmission struct {
 	// The transaction info
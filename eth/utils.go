@@ -1,12 +1,12 @@
 package eth
 
 import (
-	"encoding/hex"
 	"fmt"
 	"reflect"
 	"regexp"
 
 	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/utils"
 )
 
 const (
@@ -92,7 +92,7 @@ func normalizeRevertMessage(err error) error {
 	message := matches[messageIndex]
 
 	// Convert the hex message to ASCII
-	bytes, err2 := hex.DecodeString(message)
+	bytes, err2 := utils.DecodeHex(message)
 	if err2 != nil {
 		return err // Return the original error if decoding failed somehow
 	}
@@ -0,0 +1,147 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A notification that something happened in the daemon
+type Event struct {
+	// The kind of event
+	Type EventType `json:"type"`
+
+	// When the event was published
+	Time time.Time `json:"time"`
+
+	// Details about the event, which depend on its type
+	Data any `json:"data,omitempty"`
+}
+
+// Delivers events published by the daemon's services to anything that's subscribed to them, such as the API server's
+// event stream. Publishing never blocks; subscribers that fall too far behind miss events instead of slowing the
+// services down.
+type EventBus struct {
+	subscribers map[*Subscription]struct{}
+	closed      bool
+	lock        sync.RWMutex
+}
+
+// Creates a new event bus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Send an event to every subscriber that wants events of its type. Publishing to a nil bus does nothing, so services
+// can publish without checking whether they were given one.
+func (b *EventBus) Publish(eventType EventType, data any) {
+	if b == nil {
+		return
+	}
+	event := Event{
+		Type: eventType,
+		Time: time.Now(),
+		Data: data,
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	for subscriber := range b.subscribers {
+		subscriber.deliver(event)
+	}
+}
+
+// Subscribe to events of the provided types, or to every event if no types are provided. The buffer size is how many
+// events can be waiting for the subscriber before new ones are dropped. The subscription must be closed when it's no
+// longer needed.
+func (b *EventBus) Subscribe(bufferSize int, eventTypes ...EventType) *Subscription {
+	subscription := &Subscription{
+		bus:    b,
+		events: make(chan Event, bufferSize),
+	}
+	if len(eventTypes) > 0 {
+		subscription.types = map[EventType]struct{}{}
+		for _, eventType := range eventTypes {
+			subscription.types[eventType] = struct{}{}
+		}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		close(subscription.events)
+		return subscription
+	}
+	b.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Close every subscription, such as when the daemon is shutting down. Subscribing to a closed bus returns a
+// subscription that's already closed.
+func (b *EventBus) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for subscriber := range b.subscribers {
+		close(subscriber.events)
+	}
+	b.subscribers = map[*Subscription]struct{}{}
+}
+
+// Remove a subscription and close its channel
+func (b *EventBus) unsubscribe(subscription *Subscription) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, exists := b.subscribers[subscription]; !exists {
+		return
+	}
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+}
+
+// ====================
+// === Subscription ===
+// ====================
+
+// A subscriber's connection to an event bus
+type Subscription struct {
+	bus     *EventBus
+	events  chan Event
+	types   map[EventType]struct{}
+	dropped atomic.Uint64
+}
+
+// Get the channel the subscription's events are sent on. It's closed when the subscription or its bus is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Get the number of events the subscriber missed because its buffer was full
+func (s *Subscription) GetDroppedCount() uint64 {
+	return s.dropped.Load()
+}
+
+// Stop receiving events and close the channel
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+// Send an event to the subscriber if it wants it, dropping it if the subscriber's buffer is full. This must be called
+// while holding the bus's read lock so the channel can't be closed during the send.
+func (s *Subscription) deliver(event Event) {
+	if s.types != nil {
+		if _, wanted := s.types[event.Type]; !wanted {
+			return
+		}
+	}
+	select {
+	case s.events <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The kind of an event
+type EventType string
+
+const (
+	// A transaction was submitted to the network. The data is a TransactionSubmittedData.
+	EventType_TransactionSubmitted EventType = "transaction-submitted"
+
+	// A transaction was included in a block, whether or not it succeeded. The data is a TransactionConfirmedData.
+	EventType_TransactionConfirmed EventType = "transaction-confirmed"
+
+	// A client manager started using a different client, such as when the primary goes down and a fallback takes
+	// over. The data is a ClientSwitchedData.
+	EventType_ClientSwitched EventType = "client-switched"
+
+	// The node wallet's status changed, such as when a wallet is created or its password is saved. The data is the
+	// wallet's new WalletStatus.
+	EventType_WalletStatusChanged EventType = "wallet-status-changed"
+)
+
+// The data for an EventType_TransactionSubmitted event
+type TransactionSubmittedData struct {
+	// The hash of the transaction
+	Hash common.Hash `json:"hash"`
+
+	// The address the transaction was sent from
+	From common.Address `json:"from"`
+
+	// The address the transaction was sent to
+	To *common.Address `json:"to,omitempty"`
+
+	// The transaction's nonce
+	Nonce uint64 `json:"nonce"`
+}
+
+// The data for an EventType_TransactionConfirmed event
+type TransactionConfirmedData struct {
+	// The hash of the transaction
+	Hash common.Hash `json:"hash"`
+
+	// The number of the block the transaction was included in
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// The amount of gas the transaction used
+	GasUsed uint64 `json:"gasUsed"`
+
+	// True if the transaction succeeded, false if it reverted
+	Success bool `json:"success"`
+}
+
+// The data for an EventType_ClientSwitched event
+type ClientSwitchedData struct {
+	// The type of client that switched, such as "Execution Client"
+	ClientType string `json:"clientType"`
+
+	// The name of the client that was being used, such as "primary"
+	PreviousClient string `json:"previousClient"`
+
+	// The name of the client that's being used now, such as "fallback"
+	Client string `json:"client"`
+}
@@ -0,0 +1,291 @@
+package features
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// Matches valid feature flag names, such as "parallel-duty-scan"
+	flagNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+)
+
+// Where a feature flag's value came from
+type FeatureFlagSource string
+
+const (
+	// The flag wasn't configured anywhere, so its default was used
+	FeatureFlagSource_Default FeatureFlagSource = "default"
+
+	// The flag was set by a remote network settings file
+	FeatureFlagSource_Remote FeatureFlagSource = "remote"
+
+	// The flag was set in the daemon's config
+	FeatureFlagSource_Config FeatureFlagSource = "config"
+
+	// The flag was set by an environment variable
+	FeatureFlagSource_Env FeatureFlagSource = "env"
+)
+
+// A feature that can be turned on or off without a new release. Projects built on NMC define their own flags and
+// register them with the FeatureFlagManager.
+type FeatureFlag struct {
+	// The flag's unique name, such as "parallel-duty-scan". Names can have lowercase letters, numbers, dots,
+	// dashes, and underscores.
+	Name string
+
+	// A description of what the flag changes
+	Description string
+
+	// Whether the feature is on if the flag isn't configured anywhere
+	Default bool
+}
+
+// The value of a feature flag: either on or off for every node, or on for a percentage of nodes
+type FeatureFlagSetting struct {
+	// Turn the feature on or off
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Turn the feature on for this percentage (0 to 100) of nodes. Each node is placed in a stable bucket for each
+	// flag, so raising the percentage only adds nodes and different flags roll out to different nodes.
+	RolloutPercent *float64 `yaml:"rolloutPercent,omitempty" json:"rolloutPercent,omitempty"`
+}
+
+// Check that the setting has exactly one valid value
+func (s FeatureFlagSetting) Validate() error {
+	if s.Enabled != nil && s.RolloutPercent != nil {
+		return fmt.Errorf("setting can't have both an enabled value and a rollout percentage")
+	}
+	if s.Enabled == nil && s.RolloutPercent == nil {
+		return fmt.Errorf("setting must have an enabled value or a rollout percentage")
+	}
+	if s.RolloutPercent != nil {
+		percent := *s.RolloutPercent
+		if math.IsNaN(percent) || percent < 0 || percent > 100 {
+			return fmt.Errorf("rollout percentage must be between 0 and 100")
+		}
+	}
+	return nil
+}
+
+// The feature flag settings in a daemon's config
+type FeatureFlagConfig struct {
+	// The values for flags, by flag name
+	Flags map[string]FeatureFlagSetting `yaml:"flags,omitempty" json:"flags,omitempty"`
+
+	// The prefix of the environment variables that override flags, such as "HYPERDRIVE_FEATURE_". The rest of the
+	// name is the flag's name in uppercase, with dots and dashes replaced by underscores. Variables can be set to a
+	// boolean, or to a percentage such as "25%" for a rollout. If this is empty, flags can't be set by the environment.
+	EnvPrefix string `yaml:"envPrefix,omitempty" json:"envPrefix,omitempty"`
+}
+
+// The current value of a feature flag, for displaying the daemon's flags
+type FeatureFlagStatus struct {
+	// The flag's name
+	Name string `json:"name"`
+
+	// The flag's description
+	Description string `json:"description"`
+
+	// Whether the feature is on for this node
+	Enabled bool `json:"enabled"`
+
+	// Where the value came from
+	Source FeatureFlagSource `json:"source"`
+
+	// The rollout percentage the value was determined by, if it came from a rollout
+	RolloutPercent *float64 `json:"rolloutPercent,omitempty"`
+}
+
+// Keeps track of the feature flags a daemon has registered and works out their values for this node
+type FeatureFlagManager struct {
+	config     FeatureFlagConfig
+	rolloutKey string
+	flags      map[string]FeatureFlag
+	remote     map[string]FeatureFlagSetting
+	lock       sync.RWMutex
+}
+
+// Creates a new feature flag manager. The rollout key identifies the node when placing it in rollout buckets, so it
+// should stay the same across restarts.
+func NewFeatureFlagManager(config FeatureFlagConfig, rolloutKey string) (*FeatureFlagManager, error) {
+	for name, setting := range config.Flags {
+		err := setting.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid setting for feature flag [%s]: %w", name, err)
+		}
+	}
+	return &FeatureFlagManager{
+		config:     config,
+		rolloutKey: rolloutKey,
+		flags:      map[string]FeatureFlag{},
+		remote:     map[string]FeatureFlagSetting{},
+	}, nil
+}
+
+// Register flags so they can be checked and are included in the statuses
+func (m *FeatureFlagManager) Register(flags ...FeatureFlag) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, flag := range flags {
+		if !flagNameRegex.MatchString(flag.Name) {
+			return fmt.Errorf("invalid feature flag name [%s]", flag.Name)
+		}
+		if _, exists := m.flags[flag.Name]; exists {
+			return fmt.Errorf("feature flag [%s] is already registered", flag.Name)
+		}
+		m.flags[flag.Name] = flag
+	}
+	return nil
+}
+
+// Replace the flag settings from the remote network settings, such as when they're fetched again. Settings in the
+// daemon's config and environment take precedence over these.
+func (m *FeatureFlagManager) SetRemoteSettings(settings map[string]FeatureFlagSetting) error {
+	remote := make(map[string]FeatureFlagSetting, len(settings))
+	for name, setting := range settings {
+		err := setting.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid remote setting for feature flag [%s]: %w", name, err)
+		}
+		remote[name] = setting
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.remote = remote
+	return nil
+}
+
+// Check if a flag's feature is on for this node
+func (m *FeatureFlagManager) IsEnabled(flag FeatureFlag) bool {
+	return m.GetStatus(flag).Enabled
+}
+
+// Get the value of a flag and where it came from
+func (m *FeatureFlagManager) GetStatus(flag FeatureFlag) FeatureFlagStatus {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.getStatusImpl(flag)
+}
+
+// Get the statuses of all of the registered flags, sorted by name
+func (m *FeatureFlagManager) GetStatuses() []FeatureFlagStatus {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	statuses := make([]FeatureFlagStatus, 0, len(m.flags))
+	for _, flag := range m.flags {
+		statuses = append(statuses, m.getStatusImpl(flag))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// Get the names of flags that have settings but haven't been registered, which are usually typos or flags that
+// have been removed
+func (m *FeatureFlagManager) GetUnknownFlags() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	unknown := map[string]struct{}{}
+	for _, settings := range []map[string]FeatureFlagSetting{m.config.Flags, m.remote} {
+		for name := range settings {
+			if _, exists := m.flags[name]; !exists {
+				unknown[name] = struct{}{}
+			}
+		}
+	}
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Work out a flag's value, checking the environment, the config, and the remote settings in that order
+func (m *FeatureFlagManager) getStatusImpl(flag FeatureFlag) FeatureFlagStatus {
+	status := FeatureFlagStatus{
+		Name:        flag.Name,
+		Description: flag.Description,
+		Enabled:     flag.Default,
+		Source:      FeatureFlagSource_Default,
+	}
+
+	// Values that can't be parsed are ignored, so a typo doesn't stop the daemon
+	if m.config.EnvPrefix != "" {
+		if setting, err := parseEnvSetting(os.Getenv(m.getEnvVarName(flag.Name))); err == nil && setting != nil {
+			m.applySetting(&status, *setting, FeatureFlagSource_Env)
+			return status
+		}
+	}
+	if setting, exists := m.config.Flags[flag.Name]; exists {
+		m.applySetting(&status, setting, FeatureFlagSource_Config)
+		return status
+	}
+	if setting, exists := m.remote[flag.Name]; exists {
+		m.applySetting(&status, setting, FeatureFlagSource_Remote)
+	}
+	return status
+}
+
+// Set a status's value from a setting
+func (m *FeatureFlagManager) applySetting(status *FeatureFlagStatus, setting FeatureFlagSetting, source FeatureFlagSource) {
+	status.Source = source
+	if setting.Enabled != nil {
+		status.Enabled = *setting.Enabled
+		return
+	}
+	percent := *setting.RolloutPercent
+	status.RolloutPercent = &percent
+	status.Enabled = getRolloutBucket(status.Name, m.rolloutKey) < percent
+}
+
+// Get the name of the environment variable for a flag
+func (m *FeatureFlagManager) getEnvVarName(flagName string) string {
+	name := strings.NewReplacer(".", "_", "-", "_").Replace(flagName)
+	return m.config.EnvPrefix + strings.ToUpper(name)
+}
+
+// Parse an environment variable's value into a setting, or nil if it's empty
+func parseEnvSetting(value string) (*FeatureFlagSetting, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	var setting FeatureFlagSetting
+	if percentString, isPercent := strings.CutSuffix(value, "%"); isPercent {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(percentString), 64)
+		if err != nil {
+			return nil, err
+		}
+		setting.RolloutPercent = &percent
+	} else {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		setting.Enabled = &enabled
+	}
+	err := setting.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// Get the node's rollout bucket for a flag, in [0, 100)
+func getRolloutBucket(flagName string, rolloutKey string) float64 {
+	hash := sha256.Sum256([]byte(flagName + ":" + rolloutKey))
+	value := binary.BigEndian.Uint64(hash[:8])
+	return float64(value) / (float64(math.MaxUint64) + 1) * 100
+}
@@ -0,0 +1,40 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rocket-pool/node-manager-core/store"
+)
+
+const (
+	// The store bucket that holds the feature flag state
+	featureFlagBucket string = "feature-flags"
+)
+
+var (
+	// The key of the node's rollout key in the feature flag bucket
+	rolloutKeyKey []byte = []byte("rollout-key")
+)
+
+// Get the node's rollout key from the store, creating a random one the first time. Since it's persisted, the node
+// stays in the same rollout buckets across restarts.
+func LoadRolloutKey(kvStore store.IKeyValueStore) (string, error) {
+	var rolloutKey string
+	err := kvStore.Update(func(tx store.IStoreTransaction) error {
+		existing, exists, err := store.GetJson[string](tx, featureFlagBucket, rolloutKeyKey)
+		if err != nil {
+			return err
+		}
+		if exists && existing != "" {
+			rolloutKey = existing
+			return nil
+		}
+		rolloutKey = uuid.New().String()
+		return store.PutJson(tx, featureFlagBucket, rolloutKeyKey, rolloutKey)
+	})
+	if err != nil {
+		return "", fmt.Errorf("error loading feature flag rollout key: %w", err)
+	}
+	return rolloutKey, nil
+}
@@ -0,0 +1,82 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+const (
+	blocknativeUrl string = "https://api.blocknative.com/gasprices/blockprices"
+
+	// The likelihood, as a percentage, that a transaction with the suggested fee is included in the next block
+	blocknativeRapidConfidence    int = 99
+	blocknativeFastConfidence     int = 95
+	blocknativeStandardConfidence int = 80
+	blocknativeSlowConfidence     int = 70
+)
+
+// Standard response
+type blocknativeResponse struct {
+	BlockPrices []struct {
+		BlockNumber     uint64  `json:"blockNumber"`
+		BaseFeePerGas   float64 `json:"baseFeePerGas"`
+		EstimatedPrices []struct {
+			Confidence           int     `json:"confidence"`
+			MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+			MaxFeePerGas         float64 `json:"maxFeePerGas"`
+		} `json:"estimatedPrices"`
+	} `json:"blockPrices"`
+}
+
+// A gas oracle that uses the Blocknative gas price API
+type BlocknativeGasOracle struct {
+	url    string
+	apiKey string
+}
+
+// Creates a new Blocknative gas oracle. An empty URL uses the mainnet API; other networks can add their chain ID to it
+// with the "chainid" query parameter.
+func NewBlocknativeGasOracle(url string, apiKey string) *BlocknativeGasOracle {
+	if url == "" {
+		url = blocknativeUrl
+	}
+	return &BlocknativeGasOracle{
+		url:    url,
+		apiKey: apiKey,
+	}
+}
+
+func (o *BlocknativeGasOracle) GetName() string {
+	return string(GasOracleType_Blocknative)
+}
+
+// Get the API's suggestion for the next block, based on how confident it is that each fee gets included
+func (o *BlocknativeGasOracle) GetGasFeeSuggestion(ctx context.Context) (GasFeeSuggestion, error) {
+	var response blocknativeResponse
+	err := getGasPrices(ctx, o.url, map[string]string{"Authorization": o.apiKey}, &response)
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("error getting Blocknative gas prices: %w", err)
+	}
+	if len(response.BlockPrices) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("the Blocknative response didn't include any block prices")
+	}
+
+	// Map the confidence levels to speeds
+	fees := map[int]*big.Int{}
+	for _, price := range response.BlockPrices[0].EstimatedPrices {
+		fees[price.Confidence] = eth.GweiToWei(price.MaxFeePerGas)
+	}
+	suggestion := GasFeeSuggestion{
+		RapidWei:    fees[blocknativeRapidConfidence],
+		FastWei:     fees[blocknativeFastConfidence],
+		StandardWei: fees[blocknativeStandardConfidence],
+		SlowWei:     fees[blocknativeSlowConfidence],
+	}
+	if suggestion.StandardWei == nil {
+		return GasFeeSuggestion{}, fmt.Errorf("the Blocknative response didn't include a price with %d%% confidence", blocknativeStandardConfidence)
+	}
+	return suggestion, nil
+}
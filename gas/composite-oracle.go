@@ -0,0 +1,94 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// A gas oracle that asks several oracles in order, using the first suggestion it gets. Suggestions are cached so
+// repeated requests don't hit the oracles' rate limits.
+type CompositeGasOracle struct {
+	oracles  []IGasOracle
+	cacheTtl time.Duration
+
+	cached     *GasFeeSuggestion
+	cachedTime time.Time
+	lock       sync.Mutex
+}
+
+// Creates a new composite gas oracle that tries the oracles in the order they're provided. Suggestions are reused
+// until they're older than the cache TTL; a TTL of 0 disables caching.
+func NewCompositeGasOracle(cacheTtl time.Duration, oracles ...IGasOracle) *CompositeGasOracle {
+	return &CompositeGasOracle{
+		oracles:  oracles,
+		cacheTtl: cacheTtl,
+	}
+}
+
+func (o *CompositeGasOracle) GetName() string {
+	return "composite"
+}
+
+// Get the cached suggestion if it's still fresh, otherwise ask each oracle in order until one succeeds. The
+// suggestion's source is the oracle that made it.
+func (o *CompositeGasOracle) GetGasFeeSuggestion(ctx context.Context) (GasFeeSuggestion, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.cached != nil && time.Since(o.cachedTime) < o.cacheTtl {
+		return copySuggestion(*o.cached), nil
+	}
+
+	errs := make([]error, 0, len(o.oracles))
+	for _, oracle := range o.oracles {
+		suggestion, err := oracle.GetGasFeeSuggestion(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", oracle.GetName(), err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		suggestion.Source = oracle.GetName()
+		o.cached = &suggestion
+		o.cachedTime = time.Now()
+		return copySuggestion(suggestion), nil
+	}
+	if len(errs) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("no gas oracles are configured")
+	}
+	return GasFeeSuggestion{}, fmt.Errorf("all gas oracles failed: %w", errors.Join(errs...))
+}
+
+// Get the oracles, in the order they're tried
+func (o *CompositeGasOracle) GetOracles() []IGasOracle {
+	return append([]IGasOracle(nil), o.oracles...)
+}
+
+// Clear the cached suggestion, so the next request asks the oracles again
+func (o *CompositeGasOracle) ClearCache() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.cached = nil
+}
+
+// Copy a suggestion so callers can't modify the cached one
+func copySuggestion(suggestion GasFeeSuggestion) GasFeeSuggestion {
+	suggestion.RapidWei = copyBigInt(suggestion.RapidWei)
+	suggestion.FastWei = copyBigInt(suggestion.FastWei)
+	suggestion.StandardWei = copyBigInt(suggestion.StandardWei)
+	suggestion.SlowWei = copyBigInt(suggestion.SlowWei)
+	return suggestion
+}
+
+// Copy a big.Int, keeping nil as nil
+func copyBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return new(big.Int).Set(value)
+}
@@ -1,12 +1,9 @@
 package gas
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
-
-	"github.com/goccy/go-json"
 )
 
 const gasNowUrl string = "https://beaconcha.in/api/v1/execution/gasnow"
@@ -38,31 +35,63 @@ type EtherchainGasFeeSuggestion struct {
 	EthUsd float64
 }
 
-// Get gas prices
-func GetEtherchainGasPrices() (EtherchainGasFeeSuggestion, error) {
-	// Send request
-	response, err := http.Get(gasNowUrl)
-	if err != nil {
-		return EtherchainGasFeeSuggestion{}, err
+// Get gas prices. The request is abandoned if the context is cancelled.
+func GetEtherchainGasPrices(ctx context.Context) (EtherchainGasFeeSuggestion, error) {
+	return NewBeaconchainGasOracle("", "").getGasPrices(ctx)
+}
+
+// A gas oracle that uses the Beaconcha.in gas price API, which took over from Etherchain's
+type BeaconchainGasOracle struct {
+	url    string
+	apiKey string
+}
+
+// Creates a new Beaconcha.in gas oracle. An empty URL uses the mainnet API; other networks need the URL of their
+// explorer's API. The API key is optional.
+func NewBeaconchainGasOracle(url string, apiKey string) *BeaconchainGasOracle {
+	if url == "" {
+		url = gasNowUrl
+	}
+	return &BeaconchainGasOracle{
+		url:    url,
+		apiKey: apiKey,
 	}
-	defer func() {
-		_ = response.Body.Close()
-	}()
+}
+
+func (o *BeaconchainGasOracle) GetName() string {
+	return string(GasOracleType_Beaconchain)
+}
 
-	// Check the response code
-	if response.StatusCode != http.StatusOK {
-		return EtherchainGasFeeSuggestion{}, fmt.Errorf("request failed with code %d", response.StatusCode)
+// Get the API's suggestion, including how long each fee takes to be included
+func (o *BeaconchainGasOracle) GetGasFeeSuggestion(ctx context.Context) (GasFeeSuggestion, error) {
+	prices, err := o.getGasPrices(ctx)
+	if err != nil {
+		return GasFeeSuggestion{}, err
 	}
+	return GasFeeSuggestion{
+		RapidWei:     prices.RapidWei,
+		RapidTime:    prices.RapidTime,
+		FastWei:      prices.FastWei,
+		FastTime:     prices.FastTime,
+		StandardWei:  prices.StandardWei,
+		StandardTime: prices.StandardTime,
+		SlowWei:      prices.SlowWei,
+		SlowTime:     prices.SlowTime,
+	}, nil
+}
 
-	// Get response
-	body, err := io.ReadAll(response.Body)
+// Get the API's prices
+func (o *BeaconchainGasOracle) getGasPrices(ctx context.Context) (EtherchainGasFeeSuggestion, error) {
+	// Add the API key
+	requestUrl, err := addApiKeyParam(o.url, o.apiKey)
 	if err != nil {
-		return EtherchainGasFeeSuggestion{}, err
+		return EtherchainGasFeeSuggestion{}, fmt.Errorf("error parsing Beaconcha.in gas oracle URL: %w", err)
 	}
 
-	// Deserialize response
+	// Send request
 	var gnResponse gasNowResponse
-	if err := json.Unmarshal(body, &gnResponse); err != nil {
+	err = getGasPrices(ctx, requestUrl, nil, &gnResponse)
+	if err != nil {
 		return EtherchainGasFeeSuggestion{}, fmt.Errorf("error getting Etherchain Gas Now response: %w", err)
 	}
 
@@ -85,3 +114,19 @@ func GetEtherchainGasPrices() (EtherchainGasFeeSuggestion, error) {
 	// Return
 	return suggestion, nil
 }
+
+func (o *BeaconchainGasOracle) GetCurrency() string {
+	return "USD"
+}
+
+// Get the price of ETH in USD that the API includes with its gas prices
+func (o *BeaconchainGasOracle) GetEthPrice(ctx context.Context) (float64, error) {
+	prices, err := o.getGasPrices(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if prices.EthUsd <= 0 {
+		return 0, fmt.Errorf("the Beaconcha.in response didn't include an ETH price")
+	}
+	return prices.EthUsd, nil
+}
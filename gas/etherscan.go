@@ -1,12 +1,12 @@
 package gas
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
 
 	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/eth"
 )
 
 const gasOracleUrl string = "https://api.etherscan.io/api?module=gastracker&action=gasoracle"
@@ -28,32 +28,59 @@ type EtherscanGasFeeSuggestion struct {
 	FastGwei     float64
 }
 
-// Get gas prices
-func GetEtherscanGasPrices() (EtherscanGasFeeSuggestion, error) {
-	// Send request
-	response, err := http.Get(gasOracleUrl)
-	if err != nil {
-		return EtherscanGasFeeSuggestion{}, err
+// Get gas prices. The request is abandoned if the context is cancelled.
+func GetEtherscanGasPrices(ctx context.Context) (EtherscanGasFeeSuggestion, error) {
+	return NewEtherscanGasOracle("", "").getGasPrices(ctx)
+}
+
+// A gas oracle that uses the Etherscan gas tracker
+type EtherscanGasOracle struct {
+	url    string
+	apiKey string
+}
+
+// Creates a new Etherscan gas oracle. An empty URL uses the mainnet gas tracker; other networks need the URL of their
+// explorer's gas tracker. The API key is optional, but requests without one are heavily rate limited.
+func NewEtherscanGasOracle(url string, apiKey string) *EtherscanGasOracle {
+	if url == "" {
+		url = gasOracleUrl
 	}
-	defer func() {
-		_ = response.Body.Close()
-	}()
+	return &EtherscanGasOracle{
+		url:    url,
+		apiKey: apiKey,
+	}
+}
 
-	// Check the response code
-	if response.StatusCode != http.StatusOK {
-		return EtherscanGasFeeSuggestion{}, fmt.Errorf("request failed with code %d", response.StatusCode)
+func (o *EtherscanGasOracle) GetName() string {
+	return string(GasOracleType_Etherscan)
+}
+
+// Get the gas tracker's suggestion, which doesn't include a rapid fee
+func (o *EtherscanGasOracle) GetGasFeeSuggestion(ctx context.Context) (GasFeeSuggestion, error) {
+	prices, err := o.getGasPrices(ctx)
+	if err != nil {
+		return GasFeeSuggestion{}, err
 	}
+	return GasFeeSuggestion{
+		FastWei:     eth.GweiToWei(prices.FastGwei),
+		StandardWei: eth.GweiToWei(prices.StandardGwei),
+		SlowWei:     eth.GweiToWei(prices.SlowGwei),
+	}, nil
+}
 
-	// Get response
-	body, err := io.ReadAll(response.Body)
+// Get the gas tracker's prices
+func (o *EtherscanGasOracle) getGasPrices(ctx context.Context) (EtherscanGasFeeSuggestion, error) {
+	// Add the API key
+	requestUrl, err := addApiKeyParam(o.url, o.apiKey)
 	if err != nil {
-		return EtherscanGasFeeSuggestion{}, err
+		return EtherscanGasFeeSuggestion{}, fmt.Errorf("error parsing Etherscan gas oracle URL: %w", err)
 	}
 
-	// Deserialize response
+	// Send request
 	var oracleResponse gasOracleResponse
-	if err := json.Unmarshal(body, &oracleResponse); err != nil {
-		return EtherscanGasFeeSuggestion{}, fmt.Errorf("error deserializing Etherscan gas oracle response: %w", err)
+	err = getGasPrices(ctx, requestUrl, nil, &oracleResponse)
+	if err != nil {
+		return EtherscanGasFeeSuggestion{}, err
 	}
 	if oracleResponse.Status != 1 {
 		return EtherscanGasFeeSuggestion{}, fmt.Errorf("error retrieving Etherscan gas oracle response: %s", oracleResponse.Message)
@@ -0,0 +1,158 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+const (
+	// How many recent blocks the priority fees are taken from
+	feeHistoryBlockCount uint64 = 20
+
+	// How many times the next block's base fee the max fee allows for, so transactions stay valid through a few full
+	// blocks of base fee increases
+	feeHistoryBaseFeeMultiplier int64 = 2
+)
+
+var (
+	// The priority fee percentiles used for each speed, in order from rapid to slow
+	feeHistoryPercentiles []float64 = []float64{99, 90, 50, 10}
+)
+
+// A gas oracle that uses the Execution Client's eth_feeHistory method, so it works on any network without relying on
+// a third party
+type FeeHistoryGasOracle struct {
+	client eth.IExecutionClient
+}
+
+// Creates a new fee history gas oracle
+func NewFeeHistoryGasOracle(client eth.IExecutionClient) *FeeHistoryGasOracle {
+	return &FeeHistoryGasOracle{
+		client: client,
+	}
+}
+
+func (o *FeeHistoryGasOracle) GetName() string {
+	return string(GasOracleType_FeeHistory)
+}
+
+// Get a suggestion from the recent blocks. Each speed's max fee is twice the next block's base fee plus the median of a
+// priority fee percentile over the blocks: the 99th for rapid, 90th for fast, 50th for standard, and 10th for slow.
+func (o *FeeHistoryGasOracle) GetGasFeeSuggestion(ctx context.Context) (GasFeeSuggestion, error) {
+	history, err := o.client.FeeHistory(ctx, feeHistoryBlockCount, nil, feeHistoryPercentiles)
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("error getting fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("fee history didn't include any base fees")
+	}
+
+	// The last base fee is the one for the next block
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+	baseFeeHeadroom := new(big.Int).Mul(nextBaseFee, big.NewInt(feeHistoryBaseFeeMultiplier))
+
+	maxFees := make([]*big.Int, len(feeHistoryPercentiles))
+	for i := range feeHistoryPercentiles {
+		tip := getMedianReward(history.Reward, i)
+		maxFees[i] = new(big.Int).Add(baseFeeHeadroom, tip)
+	}
+	return GasFeeSuggestion{
+		RapidWei:    maxFees[0],
+		FastWei:     maxFees[1],
+		StandardWei: maxFees[2],
+		SlowWei:     maxFees[3],
+	}, nil
+}
+
+// Get the median of one of the reward percentiles across the blocks, or 0 if there aren't any rewards
+func getMedianReward(rewards [][]*big.Int, percentileIndex int) *big.Int {
+	values := make([]*big.Int, 0, len(rewards))
+	for _, blockRewards := range rewards {
+		if percentileIndex < len(blockRewards) && blockRewards[percentileIndex] != nil {
+			values = append(values, blockRewards[percentileIndex])
+		}
+	}
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	return getMedian(values)
+}
+
+// Get the median of the values without modifying them
+func getMedian(values []*big.Int) *big.Int {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a *big.Int, b *big.Int) int {
+		return a.Cmp(b)
+	})
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
+
+// Recent base fees, for showing how a max fee compares to what blocks have needed lately
+type BaseFeeHistory struct {
+	// The number of the first block in the history
+	OldestBlock uint64 `json:"oldestBlock"`
+
+	// The base fee of each block, oldest first
+	BaseFees []*big.Int `json:"baseFees"`
+
+	// The base fee of the next block
+	NextBaseFee *big.Int `json:"nextBaseFee"`
+
+	// The lowest, median, and highest base fees of the blocks
+	MinBaseFee    *big.Int `json:"minBaseFee"`
+	MedianBaseFee *big.Int `json:"medianBaseFee"`
+	MaxBaseFee    *big.Int `json:"maxBaseFee"`
+}
+
+// Get the base fees of the most recent blocks from the Execution Client
+func GetBaseFeeHistory(ctx context.Context, client eth.IExecutionClient, blockCount uint64) (*BaseFeeHistory, error) {
+	if blockCount == 0 {
+		return nil, fmt.Errorf("block count must be greater than 0")
+	}
+	feeHistory, err := client.FeeHistory(ctx, blockCount, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee history: %w", err)
+	}
+
+	// The last base fee is the one for the next block, so there must be at least one more
+	if len(feeHistory.BaseFee) < 2 {
+		return nil, fmt.Errorf("fee history didn't include any blocks")
+	}
+	history := &BaseFeeHistory{
+		BaseFees:    feeHistory.BaseFee[:len(feeHistory.BaseFee)-1],
+		NextBaseFee: feeHistory.BaseFee[len(feeHistory.BaseFee)-1],
+	}
+	if feeHistory.OldestBlock != nil {
+		history.OldestBlock = feeHistory.OldestBlock.Uint64()
+	}
+	history.MinBaseFee = history.BaseFees[0]
+	history.MaxBaseFee = history.BaseFees[0]
+	for _, baseFee := range history.BaseFees[1:] {
+		if baseFee.Cmp(history.MinBaseFee) < 0 {
+			history.MinBaseFee = baseFee
+		}
+		if baseFee.Cmp(history.MaxBaseFee) > 0 {
+			history.MaxBaseFee = baseFee
+		}
+	}
+	history.MedianBaseFee = getMedian(history.BaseFees)
+	return history, nil
+}
+
+// Get the percentage of the blocks whose base fee a max fee would have covered
+func (h *BaseFeeHistory) GetCoverage(maxFee *big.Int) float64 {
+	if len(h.BaseFees) == 0 {
+		return 0
+	}
+	covered := 0
+	for _, baseFee := range h.BaseFees {
+		if maxFee.Cmp(baseFee) >= 0 {
+			covered++
+		}
+	}
+	return float64(covered) / float64(len(h.BaseFees)) * 100
+}
@@ -0,0 +1,222 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// How long gas fee suggestions are cached if the config doesn't say otherwise; about one slot
+	DefaultGasOracleCacheTtl time.Duration = 12 * time.Second
+
+	// The timeout for requests to gas price APIs
+	gasPriceRequestTimeout time.Duration = 10 * time.Second
+
+	// The largest gas price API response to accept, in bytes
+	maxGasPriceResponseSize int64 = 1024 * 1024
+)
+
+var (
+	// The client for requests to gas price APIs
+	gasPriceHttpClient = &http.Client{
+		Timeout: gasPriceRequestTimeout,
+		Transport: &utils.IdentityTransport{
+			Base: &utils.ResponseLimitTransport{
+				Settings: utils.ResponseLimitSettings{
+					MaxResponseSize:   maxGasPriceResponseSize,
+					EnableCompression: true,
+				},
+			},
+		},
+	}
+)
+
+// The kind of service a gas oracle gets its suggestions from
+type GasOracleType string
+
+const (
+	// The Etherscan gas tracker
+	GasOracleType_Etherscan GasOracleType = "etherscan"
+
+	// The Beaconcha.in gas price API
+	GasOracleType_Beaconchain GasOracleType = "beaconchain"
+
+	// The Blocknative gas price API; requires an API key
+	GasOracleType_Blocknative GasOracleType = "blocknative"
+
+	// The Execution Client's eth_feeHistory method, which doesn't rely on a third party
+	GasOracleType_FeeHistory GasOracleType = "fee-history"
+)
+
+// Suggested max fees for a transaction, depending on how quickly it should be included.
+// Oracles that don't suggest a fee for a speed leave it nil.
+type GasFeeSuggestion struct {
+	// The name of the oracle that made the suggestion
+	Source string
+
+	RapidWei  *big.Int
+	RapidTime string
+
+	FastWei  *big.Int
+	FastTime string
+
+	StandardWei  *big.Int
+	StandardTime string
+
+	SlowWei  *big.Int
+	SlowTime string
+}
+
+// A service that suggests max fees for new transactions
+type IGasOracle interface {
+	// Get the oracle's name, for logging and display
+	GetName() string
+
+	// Get the oracle's current suggestion. The request is abandoned if the context is cancelled.
+	GetGasFeeSuggestion(ctx context.Context) (GasFeeSuggestion, error)
+}
+
+// Settings for one of a network's gas oracles
+type GasOracleSettings struct {
+	// The kind of oracle
+	Type GasOracleType `yaml:"type" json:"type"`
+
+	// The oracle's API URL; leave it empty to use the default for the oracle's type. Not used by the fee history
+	// oracle.
+	Url string `yaml:"url,omitempty" json:"url,omitempty"`
+
+	// The key for the oracle's API, if it needs one
+	ApiKey string `yaml:"apiKey,omitempty" json:"apiKey,omitempty"`
+}
+
+// The gas oracles to use for a network, in the order they're tried
+type GasOracleConfig struct {
+	// The oracles; each one is only used if the ones before it fail
+	Oracles []GasOracleSettings `yaml:"oracles" json:"oracles"`
+
+	// How long a suggestion is reused before the oracles are asked again; 0 uses DefaultGasOracleCacheTtl
+	CacheTtlSeconds uint64 `yaml:"cacheTtlSeconds,omitempty" json:"cacheTtlSeconds,omitempty"`
+}
+
+// Check that the oracles are known types with the settings they need
+func (c *GasOracleConfig) Validate() error {
+	if len(c.Oracles) == 0 {
+		return fmt.Errorf("no gas oracles are configured")
+	}
+	for i, oracle := range c.Oracles {
+		switch oracle.Type {
+		case GasOracleType_Etherscan, GasOracleType_Beaconchain, GasOracleType_FeeHistory:
+		case GasOracleType_Blocknative:
+			if oracle.ApiKey == "" {
+				return fmt.Errorf("gas oracle %d [%s] requires an API key", i, oracle.Type)
+			}
+		default:
+			return fmt.Errorf("gas oracle %d has unknown type [%s]", i, oracle.Type)
+		}
+	}
+	return nil
+}
+
+// Create a deep copy of the config
+func (c *GasOracleConfig) Clone() *GasOracleConfig {
+	clone := *c
+	clone.Oracles = append([]GasOracleSettings(nil), c.Oracles...)
+	return &clone
+}
+
+// Create a composite gas oracle from a network's config. If the config is nil, the Execution Client's fee history is
+// the only oracle. The client is only required if one of the oracles is the fee history oracle.
+func NewGasOracle(cfg *GasOracleConfig, client eth.IExecutionClient) (*CompositeGasOracle, error) {
+	if cfg == nil {
+		cfg = &GasOracleConfig{
+			Oracles: []GasOracleSettings{
+				{Type: GasOracleType_FeeHistory},
+			},
+		}
+	}
+	err := cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas oracle config: %w", err)
+	}
+
+	oracles := make([]IGasOracle, len(cfg.Oracles))
+	for i, settings := range cfg.Oracles {
+		switch settings.Type {
+		case GasOracleType_Etherscan:
+			oracles[i] = NewEtherscanGasOracle(settings.Url, settings.ApiKey)
+		case GasOracleType_Beaconchain:
+			oracles[i] = NewBeaconchainGasOracle(settings.Url, settings.ApiKey)
+		case GasOracleType_Blocknative:
+			oracles[i] = NewBlocknativeGasOracle(settings.Url, settings.ApiKey)
+		case GasOracleType_FeeHistory:
+			if client == nil {
+				return nil, fmt.Errorf("the fee history gas oracle requires an Execution Client")
+			}
+			oracles[i] = NewFeeHistoryGasOracle(client)
+		}
+	}
+
+	cacheTtl := DefaultGasOracleCacheTtl
+	if cfg.CacheTtlSeconds > 0 {
+		cacheTtl = time.Duration(cfg.CacheTtlSeconds) * time.Second
+	}
+	return NewCompositeGasOracle(cacheTtl, oracles...), nil
+}
+
+// Send a GET request to a gas price API and deserialize its JSON response into the target
+func getGasPrices(ctx context.Context, url string, headers map[string]string, target any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating gas price request: %w", err)
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	response, err := gasPriceHttpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed with code %d", response.StatusCode)
+	}
+
+	// Get response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return fmt.Errorf("error deserializing gas price response: %w", err)
+	}
+	return nil
+}
+
+// Add an API key to a URL's "apikey" query parameter. The URL is returned unchanged if the key is empty.
+func addApiKeyParam(rawUrl string, apiKey string) (string, error) {
+	if apiKey == "" {
+		return rawUrl, nil
+	}
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	query := parsedUrl.Query()
+	query.Set("apikey", apiKey)
+	parsedUrl.RawQuery = query.Encode()
+	return parsedUrl.String(), nil
+}
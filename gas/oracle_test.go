@@ -0,0 +1,94 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Start a server whose requests hang until the client gives up on them, or the test ends
+func newHangingServer(t *testing.T, started chan<- struct{}) *httptest.Server {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+	return server
+}
+
+func TestOraclesStopWhenCancelled(t *testing.T) {
+	oracles := map[string]func(url string) IGasOracle{
+		"beaconchain": func(url string) IGasOracle {
+			return NewBeaconchainGasOracle(url, "")
+		},
+		"etherscan": func(url string) IGasOracle {
+			return NewEtherscanGasOracle(url, "")
+		},
+		"blocknative": func(url string) IGasOracle {
+			return NewBlocknativeGasOracle(url, "")
+		},
+	}
+	for name, newOracle := range oracles {
+		t.Run(name, func(t *testing.T) {
+			started := make(chan struct{}, 1)
+			server := newHangingServer(t, started)
+			oracle := newOracle(server.URL)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				<-started
+				cancel()
+			}()
+			start := time.Now()
+			_, err := oracle.GetGasFeeSuggestion(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected a context cancellation error, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("oracle took %s to stop after cancellation", elapsed)
+			}
+		})
+	}
+}
+
+func TestCompositeOracleStopsFallingBackWhenCancelled(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := newHangingServer(t, started)
+
+	var fallbackRequests atomic.Int32
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackRequests.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"rapid":4,"fast":3,"standard":2,"slow":1}}`))
+	}))
+	defer fallback.Close()
+
+	oracle := NewCompositeGasOracle(time.Minute,
+		NewBeaconchainGasOracle(server.URL, ""),
+		NewBeaconchainGasOracle(fallback.URL, ""),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := oracle.GetGasFeeSuggestion(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+	if requests := fallbackRequests.Load(); requests != 0 {
+		t.Fatalf("expected the fallback oracle not to be used after cancellation, but it got %d requests", requests)
+	}
+}
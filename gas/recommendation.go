@@ -0,0 +1,144 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+const (
+	// How many recent blocks recommendations compare their fees against; about 20 minutes of blocks
+	DefaultRecommendationHistoryBlocks uint64 = 100
+)
+
+// Provides the price of ETH in another currency, so costs can be shown in it
+type IEthPriceFeed interface {
+	// Get the currency the prices are in, such as "USD"
+	GetCurrency() string
+
+	// Get the current price of 1 ETH. The request is abandoned if the context is cancelled.
+	GetEthPrice(ctx context.Context) (float64, error)
+}
+
+// One of the max fees a user can pick for a transaction, with what it would cost
+type GasRecommendationOption struct {
+	// The name of the speed the fee is for, such as "Fast"
+	Label string `json:"label"`
+
+	// The max fee per gas, in wei
+	MaxFeeWei *big.Int `json:"maxFeeWei"`
+
+	// About how long a transaction with this fee takes to be included, if the oracle provides it
+	WaitTime string `json:"waitTime,omitempty"`
+
+	// The most the transaction can cost with this fee, in wei
+	MaxCostWei *big.Int `json:"maxCostWei"`
+
+	// The most the transaction can cost with this fee in the price feed's currency, or 0 if there's no price
+	MaxCostFiat float64 `json:"maxCostFiat,omitempty"`
+
+	// The percentage of recent blocks whose base fee this fee would have covered, or 0 if there's no history
+	HistoricalCoverage float64 `json:"historicalCoverage,omitempty"`
+}
+
+// The suggested fees for a transaction along with the context needed to choose between them
+type GasRecommendation struct {
+	// The name of the oracle that made the suggestion
+	Source string `json:"source"`
+
+	// The transaction's gas limit, which the costs are based on
+	GasLimit uint64 `json:"gasLimit"`
+
+	// The fees to pick from, fastest first
+	Options []GasRecommendationOption `json:"options"`
+
+	// The index of the option to use if the user doesn't have a preference
+	RecommendedIndex int `json:"recommendedIndex"`
+
+	// The recent base fees, or nil if there's no Execution Client to get them from
+	BaseFeeHistory *BaseFeeHistory `json:"baseFeeHistory,omitempty"`
+
+	// The currency the fiat costs are in, or empty if there's no price
+	FiatCurrency string `json:"fiatCurrency,omitempty"`
+
+	// The price of 1 ETH in the fiat currency, or 0 if there's no price
+	EthPrice float64 `json:"ethPrice,omitempty"`
+}
+
+// Get the recommended option
+func (r *GasRecommendation) GetRecommendedOption() GasRecommendationOption {
+	return r.Options[r.RecommendedIndex]
+}
+
+// Get the oracle's suggested fees for a transaction with the provided gas limit, along with their projected costs and
+// how they compare to the base fees of the last DefaultRecommendationHistoryBlocks blocks. The client and price feed
+// are optional; without them, the history and fiat costs are left out. Failing to get the price is not an error since
+// it's only informational, so the fiat costs are left out then too.
+func GetGasRecommendation(ctx context.Context, oracle IGasOracle, client eth.IExecutionClient, priceFeed IEthPriceFeed, gasLimit uint64) (*GasRecommendation, error) {
+	suggestion, err := oracle.GetGasFeeSuggestion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting gas fee suggestion: %w", err)
+	}
+	source := suggestion.Source
+	if source == "" {
+		source = oracle.GetName()
+	}
+	recommendation := &GasRecommendation{
+		Source:   source,
+		GasLimit: gasLimit,
+	}
+
+	// Get the context
+	if client != nil {
+		recommendation.BaseFeeHistory, err = GetBaseFeeHistory(ctx, client, DefaultRecommendationHistoryBlocks)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if priceFeed != nil {
+		price, err := priceFeed.GetEthPrice(ctx)
+		if err == nil {
+			recommendation.FiatCurrency = priceFeed.GetCurrency()
+			recommendation.EthPrice = price
+		}
+	}
+
+	// Build the options
+	speeds := []struct {
+		label    string
+		maxFee   *big.Int
+		waitTime string
+	}{
+		{"Rapid", suggestion.RapidWei, suggestion.RapidTime},
+		{"Fast", suggestion.FastWei, suggestion.FastTime},
+		{"Standard", suggestion.StandardWei, suggestion.StandardTime},
+		{"Slow", suggestion.SlowWei, suggestion.SlowTime},
+	}
+	for _, speed := range speeds {
+		if speed.maxFee == nil {
+			continue
+		}
+		if speed.label == "Standard" {
+			recommendation.RecommendedIndex = len(recommendation.Options)
+		}
+		option := GasRecommendationOption{
+			Label:      speed.label,
+			MaxFeeWei:  speed.maxFee,
+			WaitTime:   speed.waitTime,
+			MaxCostWei: new(big.Int).Mul(speed.maxFee, new(big.Int).SetUint64(gasLimit)),
+		}
+		if recommendation.EthPrice > 0 {
+			option.MaxCostFiat = eth.WeiToEth(option.MaxCostWei) * recommendation.EthPrice
+		}
+		if recommendation.BaseFeeHistory != nil {
+			option.HistoricalCoverage = recommendation.BaseFeeHistory.GetCoverage(speed.maxFee)
+		}
+		recommendation.Options = append(recommendation.Options, option)
+	}
+	if len(recommendation.Options) == 0 {
+		return nil, fmt.Errorf("gas oracle [%s] didn't suggest any fees", source)
+	}
+	return recommendation, nil
+}
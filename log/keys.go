@@ -10,4 +10,75 @@ const (
 	CauseKey  string = "cause"
 	BodyKey   string = "body"
 	ErrorKey  string = "err"
+
+	IdempotencyKeyKey string = "idempotencyKey"
+)
+
+// Client manager keys
+const (
+	ClientKey   string = "client"
+	TargetKey   string = "target"
+	DurationKey string = "duration"
+)
+
+// Transaction keys
+const (
+	TxHashKey    string = "txHash"
+	NonceKey     string = "nonce"
+	GasFeeCapKey string = "gasFeeCap"
+	GasTipCapKey string = "gasTipCap"
+	BlockKey     string = "block"
+	AttemptKey   string = "attempt"
+)
+
+// Validator keys
+const (
+	PubkeyKey                string = "pubkey"
+	ValidatorIndexKey        string = "index"
+	EpochKey                 string = "epoch"
+	WithdrawalCredentialsKey string = "withdrawalCreds"
+	StatusKey                string = "status"
+)
+
+// Task keys
+const (
+	TaskKey    string = "task"
+	NextRunKey string = "nextRun"
+	JobIdKey   string = "jobId"
+	CountKey   string = "count"
+)
+
+// Beacon keys
+const (
+	EventKey string = "event"
+)
+
+// Disk keys
+const (
+	DirectoryKey     string = "directory"
+	AvailableKey     string = "available"
+	DaysUntilFullKey string = "daysUntilFull"
+)
+
+// Network keys
+const (
+	PortKey string = "port"
+)
+
+// Update keys
+const (
+	CurrentVersionKey string = "currentVersion"
+	LatestVersionKey  string = "latestVersion"
+	UrlKey            string = "url"
+)
+
+// Wallet keys
+const (
+	WalletIndexKey string = "walletIndex"
+	AddressKey     string = "address"
+	IdKey          string = "id"
+	DescriptionKey string = "description"
+	KdfKey         string = "kdf"
+	ServiceKey     string = "service"
+	BackupPathKey  string = "backupPath"
 )
@@ -0,0 +1,137 @@
+package nmctest
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/features"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// A minimal daemon configuration for the test harness that keeps all of its files in a single directory.
+// The client URLs are empty because the harness provides its clients directly.
+type HarnessConfig struct {
+	// The directory to store the log files and wallet in
+	DataDir string
+
+	// The options for the harness's loggers
+	LoggerOptions log.LoggerOptions
+}
+
+// Creates a new harness config that stores its files in the provided directory
+func NewHarnessConfig(dataDir string) *HarnessConfig {
+	return &HarnessConfig{
+		DataDir: dataDir,
+		LoggerOptions: log.LoggerOptions{
+			MaxSize:    20,
+			MaxBackups: 1,
+			Format:     log.LogFormat_Logfmt,
+			Level:      slog.LevelDebug,
+		},
+	}
+}
+
+// The title for the config
+func (c *HarnessConfig) GetTitle() string {
+	return "Test Harness"
+}
+
+// Get the parameters for this config
+func (c *HarnessConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{}
+}
+
+// Get the sections underneath this one
+func (c *HarnessConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// The path to use for the API log file
+func (c *HarnessConfig) GetApiLogFilePath() string {
+	return filepath.Join(c.DataDir, "logs", "api.log")
+}
+
+// The path to use for the tasks log file
+func (c *HarnessConfig) GetTasksLogFilePath() string {
+	return filepath.Join(c.DataDir, "logs", "tasks.log")
+}
+
+// The path to use for the node address file
+func (c *HarnessConfig) GetNodeAddressFilePath() string {
+	return filepath.Join(c.DataDir, "address")
+}
+
+// The path to use for the wallet keystore file
+func (c *HarnessConfig) GetWalletFilePath() string {
+	return filepath.Join(c.DataDir, "wallet")
+}
+
+// The path to use for the wallet keystore's password file
+func (c *HarnessConfig) GetPasswordFilePath() string {
+	return filepath.Join(c.DataDir, "password")
+}
+
+// The harness keeps its password in the password file
+func (c *HarnessConfig) GetPasswordSourceSettings() wallet.PasswordSourceSettings {
+	return wallet.PasswordSourceSettings{
+		Type: wallet.PasswordSourceType_File,
+	}
+}
+
+// The path to use for the key-value store
+func (c *HarnessConfig) GetStoreFilePath() string {
+	return filepath.Join(c.DataDir, "store.db")
+}
+
+// The harness provides its Execution client directly, so this returns empty URLs
+func (c *HarnessConfig) GetExecutionClientUrls() (string, []string) {
+	return "", nil
+}
+
+// The harness provides its Beacon node directly, so this returns empty URLs
+func (c *HarnessConfig) GetBeaconNodeUrls() (string, []string) {
+	return "", nil
+}
+
+// The harness connects to its clients directly, so they don't need any proxy options
+func (c *HarnessConfig) GetExecutionClientProxyOptions() (utils.ProxyOptions, []utils.ProxyOptions) {
+	return utils.ProxyOptions{}, nil
+}
+
+// The harness connects to its clients directly, so they don't need any proxy options
+func (c *HarnessConfig) GetBeaconNodeProxyOptions() (utils.ProxyOptions, []utils.ProxyOptions) {
+	return utils.ProxyOptions{}, nil
+}
+
+// The harness's clients don't fail over, so this returns the default settings
+func (c *HarnessConfig) GetCircuitBreakerSettings() config.CircuitBreakerSettings {
+	return config.DefaultCircuitBreakerSettings
+}
+
+// The harness creates its Beacon node directly, so this just returns the default settings
+func (c *HarnessConfig) GetBeaconRetrySettings() utils.HttpRetrySettings {
+	return utils.DefaultHttpRetrySettings
+}
+
+// The harness creates its clients directly, so this just returns the default settings
+func (c *HarnessConfig) GetResponseLimitSettings() utils.ResponseLimitSettings {
+	return utils.DefaultResponseLimitSettings
+}
+
+// The harness identifies itself as NMC without any custom headers
+func (c *HarnessConfig) GetHttpIdentity() utils.HttpIdentity {
+	return utils.HttpIdentity{}
+}
+
+// The harness doesn't set any feature flags, so they use their remote values or defaults
+func (c *HarnessConfig) GetFeatureFlagConfig() features.FeatureFlagConfig {
+	return features.FeatureFlagConfig{}
+}
+
+// The configuration for the daemon loggers
+func (c *HarnessConfig) GetLoggerOptions() log.LoggerOptions {
+	return c.LoggerOptions
+}
@@ -0,0 +1,193 @@
+package nmctest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dclient "github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/node/services"
+)
+
+const (
+	// The default timeout for requests to the harness's clients
+	DefaultHarnessClientTimeout time.Duration = 10 * time.Second
+
+	// The Beacon chain spec used by the harness
+	HarnessSecondsPerSlot               uint64 = 12
+	HarnessSlotsPerEpoch                uint64 = 32
+	HarnessEpochsPerSyncCommitteePeriod uint64 = 256
+
+	// The proposer index assigned to blocks created by the harness
+	harnessProposerIndex string = "0"
+)
+
+var (
+	// The genesis fork version used by the harness
+	HarnessGenesisForkVersion = []byte{0x10, 0x00, 0x00, 0x38}
+
+	// The multicall address used by the harness's query manager. Include the Multicall3 runtime bytecode at this
+	// address in the genesis allocation to use the query manager on the simulated chain.
+	HarnessMulticallAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+	// The Beacon deposit contract address reported by the harness's mock Beacon node. Nothing is deployed there.
+	HarnessDepositContractAddress = common.HexToAddress("0x4242424242424242424242424242424242424242")
+)
+
+// A self-contained environment for end-to-end tests, made of a simulated Execution chain, a mock Beacon node whose
+// clock follows the simulated chain's block timestamps, and a full service provider connected to both of them.
+// No Docker daemon or external clients are required.
+type Harness struct {
+	// The simulated Execution chain
+	Backend *simulated.Backend
+
+	// The mock Beacon node
+	BeaconNode *MockBeaconNode
+
+	// The daemon config used by the service provider
+	Config *HarnessConfig
+
+	// The network resources describing the simulated chain
+	Resources *config.NetworkResources
+
+	// The service provider connected to the simulated chain and mock Beacon node
+	ServiceProvider services.IServiceProvider
+
+	ecClient eth.IExecutionClient
+}
+
+// Creates a new harness with the provided genesis allocation, storing the daemon's logs and wallet in the data directory
+func NewHarness(dataDir string, alloc types.GenesisAlloc) (*Harness, error) {
+	// Create the simulated chain
+	backend := simulated.NewBackend(alloc)
+	ecClient, ok := backend.Client().(eth.IExecutionClient)
+	if !ok {
+		backend.Close()
+		return nil, fmt.Errorf("simulated backend client does not implement the Execution client interface")
+	}
+
+	// Make the resources with a custom Beacon spec starting now
+	resources := &config.NetworkResources{
+		EthNetworkName:     "simulated",
+		ChainID:            uint(params.AllDevChainProtocolChanges.ChainID.Uint64()),
+		GenesisForkVersion: HarnessGenesisForkVersion,
+		MulticallAddress:   HarnessMulticallAddress,
+		BeaconSpec: &config.BeaconSpec{
+			GenesisTime:                  uint64(time.Now().Unix()),
+			GenesisValidatorsRoot:        make([]byte, common.HashLength),
+			SecondsPerSlot:               HarnessSecondsPerSlot,
+			SlotsPerEpoch:                HarnessSlotsPerEpoch,
+			EpochsPerSyncCommitteePeriod: HarnessEpochsPerSyncCommitteePeriod,
+			DepositContractAddress:       HarnessDepositContractAddress,
+		},
+	}
+	eth2Config, _ := resources.GetEth2Config()
+	depositContract, _ := resources.GetEth2DepositContract()
+	beaconNode := NewMockBeaconNode(eth2Config, depositContract)
+
+	// Create the service provider
+	ecManager := services.NewExecutionClientManager(ecClient, resources.ChainID, DefaultHarnessClientTimeout)
+	bcManager := services.NewBeaconClientManager(beaconNode, resources.ChainID, DefaultHarnessClientTimeout)
+	dockerClient, err := dclient.NewClientWithOpts(dclient.WithVersion(services.DockerApiVersion))
+	if err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("error creating Docker client: %w", err)
+	}
+	cfg := NewHarnessConfig(dataDir)
+	sp, err := services.NewServiceProviderWithCustomServices(cfg, resources, ecManager, bcManager, dockerClient)
+	if err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("error creating service provider: %w", err)
+	}
+
+	return &Harness{
+		Backend:         backend,
+		BeaconNode:      beaconNode,
+		Config:          cfg,
+		Resources:       resources,
+		ServiceProvider: sp,
+		ecClient:        ecClient,
+	}, nil
+}
+
+// Shuts down the service provider and the simulated chain
+func (h *Harness) Close() error {
+	err := h.ServiceProvider.Close()
+	if err != nil {
+		return fmt.Errorf("error closing service provider: %w", err)
+	}
+	return h.Backend.Close()
+}
+
+// Seal the pending transactions into a new block and move the mock Beacon node's clock up to its timestamp
+func (h *Harness) Commit() (common.Hash, error) {
+	hash := h.Backend.Commit()
+	err := h.syncBeaconNode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
+
+// Move the chain forward by the provided duration, rounded down to the nearest second, by creating a new block with
+// an adjusted timestamp. This can't be used while there are pending transactions; call Commit first.
+func (h *Harness) AdvanceTime(duration time.Duration) error {
+	seconds := duration / time.Second
+	if seconds < 1 {
+		return fmt.Errorf("time can only be advanced in increments of at least one second")
+	}
+
+	// The simulated beacon adds the raw adjustment value to the parent block's timestamp, so it's
+	// treated as a number of seconds rather than nanoseconds
+	err := h.Backend.AdjustTime(seconds)
+	if err != nil {
+		return fmt.Errorf("error adjusting simulated chain time: %w", err)
+	}
+	return h.syncBeaconNode()
+}
+
+// Move the chain forward by the provided number of slots
+func (h *Harness) AdvanceSlots(slots uint64) error {
+	return h.AdvanceTime(time.Duration(slots*HarnessSecondsPerSlot) * time.Second)
+}
+
+// Move the chain forward by the provided number of epochs
+func (h *Harness) AdvanceEpochs(epochs uint64) error {
+	return h.AdvanceSlots(epochs * HarnessSlotsPerEpoch)
+}
+
+// Get the slot the mock Beacon node is currently on
+func (h *Harness) GetCurrentSlot() uint64 {
+	return h.BeaconNode.GetCurrentSlot()
+}
+
+// Get the epoch the mock Beacon node is currently on
+func (h *Harness) GetCurrentEpoch() uint64 {
+	return h.BeaconNode.GetCurrentSlot() / HarnessSlotsPerEpoch
+}
+
+// Update the mock Beacon node's clock to the latest block's timestamp and add a block for its slot
+func (h *Harness) syncBeaconNode() error {
+	header, err := h.ecClient.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest simulated block header: %w", err)
+	}
+	h.BeaconNode.SetCurrentTime(time.Unix(int64(header.Time), 0))
+	h.BeaconNode.SetBlock(beacon.BeaconBlock{
+		Header: beacon.BeaconBlockHeader{
+			Slot:          h.BeaconNode.GetCurrentSlot(),
+			ProposerIndex: harnessProposerIndex,
+		},
+		HasExecutionPayload:  true,
+		FeeRecipient:         header.Coinbase,
+		ExecutionBlockNumber: header.Number.Uint64(),
+	})
+	return nil
+}
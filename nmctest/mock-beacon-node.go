@@ -0,0 +1,423 @@
+package nmctest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// The number of epochs the mock Beacon node's justified and finalized checkpoints trail its head by
+const (
+	mockJustifiedEpochLag         uint64 = 1
+	mockPreviousJustifiedEpochLag uint64 = 2
+	mockFinalizedEpochLag         uint64 = 2
+)
+
+// An in-memory Beacon node for end-to-end tests. Its head slot is derived from an externally controlled clock,
+// and its validators, duties, and blocks are set directly by the test instead of coming from a real chain.
+type MockBeaconNode struct {
+	// The version used to compute voluntary exit domains, per EIP-7044
+	CapellaForkVersion []byte
+
+	eth2Config      beacon.Eth2Config
+	depositContract beacon.Eth2DepositContract
+	currentTime     time.Time
+	validators      []beacon.ValidatorStatus
+	indices         map[beacon.ValidatorPubkey]int
+	proposerDuties  map[uint64]map[string]uint64
+	syncDuties      map[uint64]map[string]bool
+	blocks          map[uint64]beacon.BeaconBlock
+	lock            sync.Mutex
+}
+
+// Creates a new mock Beacon node with the provided chain configuration. Its clock starts at genesis.
+func NewMockBeaconNode(eth2Config beacon.Eth2Config, depositContract beacon.Eth2DepositContract) *MockBeaconNode {
+	return &MockBeaconNode{
+		CapellaForkVersion: eth2Config.GenesisForkVersion,
+		eth2Config:         eth2Config,
+		depositContract:    depositContract,
+		currentTime:        time.Unix(int64(eth2Config.GenesisTime), 0),
+		validators:         []beacon.ValidatorStatus{},
+		indices:            map[beacon.ValidatorPubkey]int{},
+		proposerDuties:     map[uint64]map[string]uint64{},
+		syncDuties:         map[uint64]map[string]bool{},
+		blocks:             map[uint64]beacon.BeaconBlock{},
+	}
+}
+
+// ======================
+// === Test Functions ===
+// ======================
+
+// Set the current time of the mock Beacon node's clock, which determines its head slot
+func (m *MockBeaconNode) SetCurrentTime(currentTime time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.currentTime = currentTime
+}
+
+// Get the slot the mock Beacon node is currently on
+func (m *MockBeaconNode) GetCurrentSlot() uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.getCurrentSlot()
+}
+
+// Add a new validator to the mock Beacon node and return its index.
+// The pubkey, index, and exists flag of the provided status are overwritten.
+func (m *MockBeaconNode) AddValidator(pubkey beacon.ValidatorPubkey, status beacon.ValidatorStatus) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, exists := m.indices[pubkey]; exists {
+		return "", fmt.Errorf("validator %s already exists", pubkey.HexWithPrefix())
+	}
+	index := len(m.validators)
+	status.Pubkey = pubkey
+	status.Index = strconv.Itoa(index)
+	status.Exists = true
+	m.validators = append(m.validators, status)
+	m.indices[pubkey] = index
+	return status.Index, nil
+}
+
+// Replace the status of an existing validator. The pubkey, index, and exists flag of the provided status are ignored.
+func (m *MockBeaconNode) SetValidatorStatus(pubkey beacon.ValidatorPubkey, status beacon.ValidatorStatus) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, exists := m.indices[pubkey]
+	if !exists {
+		return fmt.Errorf("validator %s does not exist", pubkey.HexWithPrefix())
+	}
+	status.Pubkey = pubkey
+	status.Index = strconv.Itoa(index)
+	status.Exists = true
+	m.validators[index] = status
+	return nil
+}
+
+// Assign block proposals in the provided epoch to a validator
+func (m *MockBeaconNode) SetProposerDuties(epoch uint64, index string, proposals uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, exists := m.proposerDuties[epoch]; !exists {
+		m.proposerDuties[epoch] = map[string]uint64{}
+	}
+	m.proposerDuties[epoch][index] = proposals
+}
+
+// Set whether a validator is in the sync committee during the provided epoch
+func (m *MockBeaconNode) SetSyncDuty(epoch uint64, index string, isInSyncCommittee bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, exists := m.syncDuties[epoch]; !exists {
+		m.syncDuties[epoch] = map[string]bool{}
+	}
+	m.syncDuties[epoch][index] = isInSyncCommittee
+}
+
+// Add a block to the mock Beacon node, replacing any existing block in the same slot
+func (m *MockBeaconNode) SetBlock(block beacon.BeaconBlock) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.blocks[block.Header.Slot] = block
+}
+
+// =============================
+// === IBeaconClient Methods ===
+// =============================
+
+// Get the Beacon Node's sync status; the mock is always synced
+func (m *MockBeaconNode) GetSyncStatus(ctx context.Context) (beacon.SyncStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return beacon.SyncStatus{
+		Syncing:      false,
+		Progress:     1,
+		HeadSlot:     m.getCurrentSlot(),
+		SyncDistance: 0,
+	}, nil
+}
+
+// Get the Beacon configuration
+func (m *MockBeaconNode) GetEth2Config(ctx context.Context) (beacon.Eth2Config, error) {
+	return m.eth2Config, nil
+}
+
+// Get the deposit contract configuration
+func (m *MockBeaconNode) GetEth2DepositContract(ctx context.Context) (beacon.Eth2DepositContract, error) {
+	return m.depositContract, nil
+}
+
+// Get the attestations in a block; the mock doesn't track attestations, so this is always empty for known blocks
+func (m *MockBeaconNode) GetAttestations(ctx context.Context, blockId string) ([]beacon.AttestationInfo, bool, error) {
+	block, exists, err := m.GetBeaconBlock(ctx, blockId)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+	return block.Attestations, true, nil
+}
+
+// Get a block by its ID
+func (m *MockBeaconNode) GetBeaconBlock(ctx context.Context, blockId string) (beacon.BeaconBlock, bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	slot, err := m.resolveBlockId(blockId)
+	if err != nil {
+		return beacon.BeaconBlock{}, false, err
+	}
+	block, exists := m.blocks[slot]
+	return block, exists, nil
+}
+
+// Get a block header by its ID
+func (m *MockBeaconNode) GetBeaconBlockHeader(ctx context.Context, blockId string) (beacon.BeaconBlockHeader, bool, error) {
+	block, exists, err := m.GetBeaconBlock(ctx, blockId)
+	return block.Header, exists, err
+}
+
+// Get the Beacon head, with checkpoints trailing the current epoch by a fixed number of epochs
+func (m *MockBeaconNode) GetBeaconHead(ctx context.Context) (beacon.BeaconHead, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	epoch := m.getCurrentSlot() / m.eth2Config.SlotsPerEpoch
+	return beacon.BeaconHead{
+		Epoch:                  epoch,
+		FinalizedEpoch:         subtractEpochs(epoch, mockFinalizedEpochLag),
+		JustifiedEpoch:         subtractEpochs(epoch, mockJustifiedEpochLag),
+		PreviousJustifiedEpoch: subtractEpochs(epoch, mockPreviousJustifiedEpochLag),
+	}, nil
+}
+
+// Get a validator's status by its index
+func (m *MockBeaconNode) GetValidatorStatusByIndex(ctx context.Context, index string, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(m.validators) {
+		return beacon.ValidatorStatus{}, nil
+	}
+	return m.validators[i], nil
+}
+
+// Get a validator's status by its pubkey
+func (m *MockBeaconNode) GetValidatorStatus(ctx context.Context, pubkey beacon.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, exists := m.indices[pubkey]
+	if !exists {
+		return beacon.ValidatorStatus{}, nil
+	}
+	return m.validators[index], nil
+}
+
+// Get multiple validators' statuses
+func (m *MockBeaconNode) GetValidatorStatuses(ctx context.Context, pubkeys []beacon.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[beacon.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	statuses := make(map[beacon.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		index, exists := m.indices[pubkey]
+		if !exists {
+			statuses[pubkey] = beacon.ValidatorStatus{}
+			continue
+		}
+		statuses[pubkey] = m.validators[index]
+	}
+	return statuses, nil
+}
+
+// Get multiple validators' statuses by their indices
+func (m *MockBeaconNode) GetValidatorStatusesByIndex(ctx context.Context, indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	statuses := make(map[string]beacon.ValidatorStatus, len(indices))
+	for _, index := range indices {
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(m.validators) {
+			continue
+		}
+		statuses[index] = m.validators[i]
+	}
+	return statuses, nil
+}
+
+// Get a validator's index
+func (m *MockBeaconNode) GetValidatorIndex(ctx context.Context, pubkey beacon.ValidatorPubkey) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	index, exists := m.indices[pubkey]
+	if !exists {
+		return "", fmt.Errorf("validator %s index not found", pubkey.HexWithPrefix())
+	}
+	return strconv.Itoa(index), nil
+}
+
+// Get whether each of the validators is in the sync committee during the provided epoch
+func (m *MockBeaconNode) GetValidatorSyncDuties(ctx context.Context, indices []string, epoch uint64) (map[string]bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	duties := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		duties[index] = m.syncDuties[epoch][index]
+	}
+	return duties, nil
+}
+
+// Get the number of blocks each of the validators is scheduled to propose during the provided epoch
+func (m *MockBeaconNode) GetValidatorProposerDuties(ctx context.Context, indices []string, epoch uint64) (map[string]uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	duties := make(map[string]uint64, len(indices))
+	for _, index := range indices {
+		duties[index] = m.proposerDuties[epoch][index]
+	}
+	return duties, nil
+}
+
+// Get domain data for a domain type at a given epoch
+func (m *MockBeaconNode) GetDomainData(ctx context.Context, domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
+	forkVersion := m.CapellaForkVersion
+	if useGenesisFork {
+		forkVersion = m.eth2Config.GenesisForkVersion
+	}
+
+	var dt [4]byte
+	copy(dt[:], domainType[:])
+	return eth2types.ComputeDomain(dt, forkVersion, m.eth2Config.GenesisValidatorsRoot)
+}
+
+// Exit a validator; the mock doesn't verify the signature
+func (m *MockBeaconNode) ExitValidator(ctx context.Context, validatorIndex string, epoch uint64, signature beacon.ValidatorSignature) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i, err := strconv.Atoi(validatorIndex)
+	if err != nil || i < 0 || i >= len(m.validators) {
+		return fmt.Errorf("validator %s does not exist", validatorIndex)
+	}
+	validator := &m.validators[i]
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		return fmt.Errorf("validator %s cannot exit because its status is [%s]", validatorIndex, validator.Status)
+	}
+	validator.Status = beacon.ValidatorState_ActiveExiting
+	validator.ExitEpoch = epoch
+	return nil
+}
+
+// Close the client; the mock doesn't hold any resources
+func (m *MockBeaconNode) Close(ctx context.Context) error {
+	return nil
+}
+
+// Get the ETH1 data for a block; the mock doesn't track deposits, so this is empty for known blocks
+func (m *MockBeaconNode) GetEth1DataForEth2Block(ctx context.Context, blockId string) (beacon.Eth1Data, bool, error) {
+	_, exists, err := m.GetBeaconBlock(ctx, blockId)
+	if err != nil || !exists {
+		return beacon.Eth1Data{}, exists, err
+	}
+	return beacon.Eth1Data{}, true, nil
+}
+
+// Get the committees for an epoch; the mock doesn't support committees
+func (m *MockBeaconNode) GetCommitteesForEpoch(ctx context.Context, epoch *uint64) (beacon.Committees, error) {
+	return nil, fmt.Errorf("committees are not supported by the mock Beacon node")
+}
+
+// Change a validator's withdrawal credentials to the provided execution address; the mock doesn't verify the signature
+func (m *MockBeaconNode) ChangeWithdrawalCredentials(ctx context.Context, validatorIndex string, fromBlsPubkey beacon.ValidatorPubkey, toExecutionAddress common.Address, signature beacon.ValidatorSignature) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i, err := strconv.Atoi(validatorIndex)
+	if err != nil || i < 0 || i >= len(m.validators) {
+		return fmt.Errorf("validator %s does not exist", validatorIndex)
+	}
+	validator := &m.validators[i]
+	if validator.WithdrawalCredentials[0] != 0x00 {
+		return fmt.Errorf("validator %s does not have BLS withdrawal credentials", validatorIndex)
+	}
+
+	var credentials common.Hash
+	credentials[0] = 0x01
+	copy(credentials[common.HashLength-common.AddressLength:], toExecutionAddress[:])
+	validator.WithdrawalCredentials = credentials
+	return nil
+}
+
+// ========================
+// === Internal Methods ===
+// ========================
+
+// Get the current slot based on the clock; this must be called while holding the lock
+func (m *MockBeaconNode) getCurrentSlot() uint64 {
+	currentTime := m.currentTime.Unix()
+	genesisTime := int64(m.eth2Config.GenesisTime)
+	if currentTime <= genesisTime || m.eth2Config.SecondsPerSlot == 0 {
+		return 0
+	}
+	return uint64(currentTime-genesisTime) / m.eth2Config.SecondsPerSlot
+}
+
+// Get the slot a block ID refers to; this must be called while holding the lock
+func (m *MockBeaconNode) resolveBlockId(blockId string) (uint64, error) {
+	switch blockId {
+	case "genesis":
+		return 0, nil
+	case "head":
+		return m.getLatestBlockSlot(m.getCurrentSlot()), nil
+	case "finalized":
+		epoch := subtractEpochs(m.getCurrentSlot()/m.eth2Config.SlotsPerEpoch, mockFinalizedEpochLag)
+		return m.getLatestBlockSlot(epoch * m.eth2Config.SlotsPerEpoch), nil
+	case "justified":
+		epoch := subtractEpochs(m.getCurrentSlot()/m.eth2Config.SlotsPerEpoch, mockJustifiedEpochLag)
+		return m.getLatestBlockSlot(epoch * m.eth2Config.SlotsPerEpoch), nil
+	}
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("block ID [%s] is not supported by the mock Beacon node", blockId)
+	}
+	return slot, nil
+}
+
+// Get the slot of the latest block at or before the provided slot; this must be called while holding the lock
+func (m *MockBeaconNode) getLatestBlockSlot(maxSlot uint64) uint64 {
+	latest := uint64(math.MaxUint64)
+	for slot := range m.blocks {
+		if slot <= maxSlot && (latest == math.MaxUint64 || slot > latest) {
+			latest = slot
+		}
+	}
+	if latest == math.MaxUint64 {
+		return maxSlot
+	}
+	return latest
+}
+
+// Subtract a number of epochs from an epoch without going below genesis
+func subtractEpochs(epoch uint64, count uint64) uint64 {
+	if epoch < count {
+		return 0
+	}
+	return epoch - count
+}
@@ -0,0 +1,267 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils/sys"
+)
+
+const (
+	// The shortest span of samples a growth rate is estimated from; anything shorter is too noisy to be useful
+	minGrowthSpan time.Duration = time.Hour
+
+	// The number of hours in a day, for converting growth rates
+	hoursPerDay float64 = 24
+)
+
+// How urgently a directory needs attention
+type AlertLevel string
+
+const (
+	// The directory has plenty of space
+	AlertLevel_None AlertLevel = "none"
+
+	// The directory is running low on space and should be cleaned up or pruned soon
+	AlertLevel_Warning AlertLevel = "warning"
+
+	// The directory is about to run out of space
+	AlertLevel_Critical AlertLevel = "critical"
+)
+
+// A directory to watch, such as a client's data directory
+type MonitoredDirectory struct {
+	// A short name for the directory, used in logs and metrics
+	Name string
+
+	// The path of the directory
+	Path string
+
+	// What to recommend when the directory runs low on space, such as how to prune the client that stores its data
+	// there. If it's empty, a generic recommendation to free up space is used.
+	PruningAdvice string
+}
+
+// Settings for when the disk monitor raises alerts
+type MonitorSettings struct {
+	// Raise a warning when a directory is expected to be full in fewer than this many days
+	WarningDays float64
+
+	// Raise a critical alert when a directory is expected to be full in fewer than this many days
+	CriticalDays float64
+
+	// Raise a warning when less than this percentage of the filesystem is free, regardless of its growth rate
+	MinFreePercent float64
+
+	// The span of samples used to estimate the growth rate; older samples are discarded
+	GrowthWindow time.Duration
+}
+
+// The disk monitor settings to use if none are configured
+var DefaultMonitorSettings = MonitorSettings{
+	WarningDays:    30,
+	CriticalDays:   7,
+	MinFreePercent: 10,
+	GrowthWindow:   7 * 24 * time.Hour,
+}
+
+// Check that the settings are usable
+func (s *MonitorSettings) Validate() error {
+	if s.CriticalDays < 0 || s.WarningDays < s.CriticalDays {
+		return fmt.Errorf("warning days must be at least as high as critical days, which can't be negative")
+	}
+	if s.MinFreePercent < 0 || s.MinFreePercent > 100 {
+		return fmt.Errorf("min free percent must be between 0 and 100")
+	}
+	if s.GrowthWindow < minGrowthSpan {
+		return fmt.Errorf("growth window must be at least %s", minGrowthSpan)
+	}
+	return nil
+}
+
+// The latest state of a monitored directory
+type DirectoryStatus struct {
+	// The name of the directory
+	Name string `json:"name"`
+
+	// The path of the directory
+	Path string `json:"path"`
+
+	// The time of the latest check
+	CheckTime time.Time `json:"checkTime"`
+
+	// The size and free space of the directory's filesystem as of the latest check
+	Space sys.DiskSpace `json:"space"`
+
+	// The estimated number of bytes the filesystem's usage grows by each day; this is 0 until there are enough
+	// samples to estimate it
+	GrowthPerDay float64 `json:"growthPerDay"`
+
+	// The estimated number of days until the filesystem is full, or -1 if it isn't growing or there aren't enough
+	// samples to estimate it yet
+	DaysUntilFull float64 `json:"daysUntilFull"`
+
+	// How urgently the directory needs attention
+	AlertLevel AlertLevel `json:"alertLevel"`
+
+	// What to do about the directory if it needs attention
+	Recommendation string `json:"recommendation"`
+
+	// The error from the latest check, if it failed
+	Error string `json:"error"`
+}
+
+// A single measurement of a directory's usage
+type usageSample struct {
+	time time.Time
+	used uint64
+}
+
+// Periodically checks the free space of a set of directories, estimates how long it'll take each one to fill up
+// based on how quickly its usage is growing, and raises alerts with recommendations (such as pruning the client that
+// stores its data there) when one runs low. Run Check on a schedule, such as with a tasks.Scheduler.
+type DiskMonitor struct {
+	logger      *slog.Logger
+	directories []MonitoredDirectory
+	settings    MonitorSettings
+	samples     map[string][]usageSample
+	statuses    map[string]DirectoryStatus
+	lock        sync.Mutex
+}
+
+// Creates a new disk monitor for the provided directories
+func NewDiskMonitor(logger *slog.Logger, directories []MonitoredDirectory, settings MonitorSettings) (*DiskMonitor, error) {
+	err := settings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid disk monitor settings: %w", err)
+	}
+	names := map[string]bool{}
+	for _, directory := range directories {
+		if directory.Name == "" {
+			return nil, fmt.Errorf("directory [%s] doesn't have a name", directory.Path)
+		}
+		if names[directory.Name] {
+			return nil, fmt.Errorf("directory name [%s] is used more than once", directory.Name)
+		}
+		names[directory.Name] = true
+	}
+
+	return &DiskMonitor{
+		logger:      logger,
+		directories: append([]MonitoredDirectory{}, directories...),
+		settings:    settings,
+		samples:     map[string][]usageSample{},
+		statuses:    map[string]DirectoryStatus{},
+	}, nil
+}
+
+// Check the free space of every directory and update their statuses, logging any that need attention. Returns an
+// error if any of the directories couldn't be checked; the others are still updated.
+func (m *DiskMonitor) Check(ctx context.Context) error {
+	errs := []error{}
+	for _, directory := range m.directories {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		status := m.checkDirectory(directory, time.Now())
+		if status.Error != "" {
+			errs = append(errs, fmt.Errorf("error checking directory [%s]: %s", directory.Name, status.Error))
+			continue
+		}
+
+		attrs := []any{
+			slog.String(log.DirectoryKey, directory.Name),
+			slog.Uint64(log.AvailableKey, status.Space.Available),
+			slog.Float64(log.DaysUntilFullKey, status.DaysUntilFull),
+		}
+		switch status.AlertLevel {
+		case AlertLevel_Critical:
+			m.logger.Error("Directory is about to run out of space.", append(attrs, slog.String(log.CauseKey, status.Recommendation))...)
+		case AlertLevel_Warning:
+			m.logger.Warn("Directory is running low on space.", append(attrs, slog.String(log.CauseKey, status.Recommendation))...)
+		default:
+			m.logger.Debug("Checked directory space.", attrs...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Get the latest status of each directory that's been checked, in the order they were provided
+func (m *DiskMonitor) GetStatuses() []DirectoryStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	statuses := make([]DirectoryStatus, 0, len(m.statuses))
+	for _, directory := range m.directories {
+		status, exists := m.statuses[directory.Name]
+		if exists {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// Sample a directory's usage and update its status
+func (m *DiskMonitor) checkDirectory(directory MonitoredDirectory, now time.Time) DirectoryStatus {
+	status := DirectoryStatus{
+		Name:          directory.Name,
+		Path:          directory.Path,
+		CheckTime:     now,
+		DaysUntilFull: -1,
+		AlertLevel:    AlertLevel_None,
+	}
+	space, err := sys.GetDiskSpace(directory.Path)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err != nil {
+		status.Error = err.Error()
+		m.statuses[directory.Name] = status
+		return status
+	}
+	status.Space = space
+
+	// Record the sample and drop the ones that have aged out of the window
+	samples := append(m.samples[directory.Name], usageSample{time: now, used: space.Used()})
+	cutoff := now.Add(-m.settings.GrowthWindow)
+	for len(samples) > 1 && samples[0].time.Before(cutoff) {
+		samples = samples[1:]
+	}
+	m.samples[directory.Name] = samples
+
+	// Estimate the growth rate from the oldest sample in the window
+	oldest := samples[0]
+	span := now.Sub(oldest.time)
+	if span >= minGrowthSpan && space.Used() > oldest.used {
+		status.GrowthPerDay = float64(space.Used()-oldest.used) / (span.Hours() / hoursPerDay)
+		status.DaysUntilFull = float64(space.Available) / status.GrowthPerDay
+	}
+
+	// Determine the alert level
+	freePercent := float64(0)
+	if space.Total > 0 {
+		freePercent = float64(space.Available) / float64(space.Total) * 100
+	}
+	switch {
+	case status.DaysUntilFull >= 0 && status.DaysUntilFull < m.settings.CriticalDays:
+		status.AlertLevel = AlertLevel_Critical
+	case status.DaysUntilFull >= 0 && status.DaysUntilFull < m.settings.WarningDays:
+		status.AlertLevel = AlertLevel_Warning
+	case freePercent < m.settings.MinFreePercent:
+		status.AlertLevel = AlertLevel_Warning
+	}
+	if status.AlertLevel != AlertLevel_None {
+		status.Recommendation = directory.PruningAdvice
+		if status.Recommendation == "" {
+			status.Recommendation = fmt.Sprintf("Free up space on the disk that holds %s, or move it to a larger disk.", directory.Path)
+		}
+	}
+
+	m.statuses[directory.Name] = status
+	return status
+}
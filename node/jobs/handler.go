@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+const (
+	// The longest a wait request can be held open
+	MaxWaitTimeout time.Duration = 60 * time.Second
+
+	// The default time a wait request is held open
+	DefaultWaitTimeout time.Duration = 30 * time.Second
+)
+
+// Serves the status of the manager's jobs over the API. Routes that start jobs belong to the daemon's own handlers,
+// which can use HandleEnqueue to respond.
+type JobHandler struct {
+	logger  *slog.Logger
+	manager *JobManager
+}
+
+// Creates a new job handler
+func NewJobHandler(logger *slog.Logger, manager *JobManager) *JobHandler {
+	return &JobHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+// Register the job routes with the router
+func (h *JobHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/jobs").Subrouter()
+	server.RegisterGet(subrouter, "status", h.logger, h.getStatus)
+	server.RegisterGet(subrouter, "list", h.logger, h.list)
+	server.RegisterGet(subrouter, "wait", h.logger, h.wait)
+	server.RegisterPost(subrouter, "cancel", h.logger, h.cancel)
+}
+
+// Enqueue a job and respond with its status, or with a busy error if the queue is full
+func HandleEnqueue(logger *slog.Logger, w http.ResponseWriter, manager *JobManager, kind string, params any) error {
+	job, err := manager.Enqueue(kind, params)
+	if errors.Is(err, ErrQueueFull) {
+		return server.HandleBusy(logger, w, err)
+	}
+	if err != nil {
+		return server.HandleServerError(logger, w, err)
+	}
+	return server.HandleDataResponse(logger, w, types.ResponseStatus_Success, types.JobData{Job: job}, nil)
+}
+
+// Get the status of a job
+func (h *JobHandler) getStatus(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	var id string
+	err := server.GetStringFromVars("id", args, &id)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+	job, err := h.manager.GetJob(id)
+	if err != nil {
+		return getErrorStatus(err), nil, err
+	}
+	return types.ResponseStatus_Success, types.JobData{Job: job}, nil
+}
+
+// Get the status of all jobs
+func (h *JobHandler) list(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	return types.ResponseStatus_Success, types.JobListData{Jobs: h.manager.ListJobs()}, nil
+}
+
+// Wait for a job to change past the provided version, or for the timeout to elapse
+func (h *JobHandler) wait(ctx context.Context, args url.Values) (types.ResponseStatus, any, error) {
+	var id string
+	err := server.GetStringFromVars("id", args, &id)
+	if err != nil {
+		return types.ResponseStatus_InvalidArguments, nil, err
+	}
+
+	var version uint64
+	var versionArg string
+	if server.GetOptionalStringFromVars("version", args, &versionArg) {
+		version, err = strconv.ParseUint(versionArg, 10, 64)
+		if err != nil {
+			return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("invalid version '%s': %w", versionArg, err)
+		}
+	}
+
+	timeout := DefaultWaitTimeout
+	var timeoutArg string
+	if server.GetOptionalStringFromVars("timeout", args, &timeoutArg) {
+		timeout, err = time.ParseDuration(timeoutArg)
+		if err != nil {
+			return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("invalid timeout '%s': %w", timeoutArg, err)
+		}
+		timeout = min(max(timeout, 0), MaxWaitTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	job, err := h.manager.WaitForUpdate(ctx, id, version)
+	if err != nil {
+		return getErrorStatus(err), nil, err
+	}
+	return types.ResponseStatus_Success, types.JobData{Job: job}, nil
+}
+
+// Cancel a job
+func (h *JobHandler) cancel(ctx context.Context, body types.JobCancelBody) (types.ResponseStatus, any, error) {
+	if body.ID == "" {
+		return types.ResponseStatus_InvalidArguments, nil, fmt.Errorf("missing argument 'id'")
+	}
+	job, err := h.manager.Cancel(body.ID)
+	if err != nil {
+		return getErrorStatus(err), nil, err
+	}
+	return types.ResponseStatus_Success, types.JobData{Job: job}, nil
+}
+
+// Get the response status for a job manager error
+func getErrorStatus(err error) types.ResponseStatus {
+	if errors.Is(err, ErrJobNotFound) {
+		return types.ResponseStatus_ResourceNotFound
+	}
+	return types.ResponseStatus_ResourceConflict
+}
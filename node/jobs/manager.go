@@ -0,0 +1,540 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/store"
+)
+
+const (
+	// The default time finished jobs are kept before they're pruned
+	DefaultJobRetention time.Duration = 7 * 24 * time.Hour
+
+	// The default minimum time between saving a running job's progress to the store
+	DefaultProgressSaveInterval time.Duration = 5 * time.Second
+
+	// The store bucket that holds the jobs, keyed by their ID
+	jobBucket string = "jobs"
+
+	// How often finished jobs past the retention period are pruned
+	pruneInterval time.Duration = time.Hour
+)
+
+var (
+	// Returned when a job is enqueued while the queue is at its limit
+	ErrQueueFull = errors.New("the job queue is full")
+
+	// Returned when a job can't be found
+	ErrJobNotFound = errors.New("job not found")
+)
+
+// A function that performs a job. The context is cancelled if the job is cancelled or the daemon shuts down.
+// The returned result is serialized to JSON and saved with the job.
+type JobRunner func(ctx context.Context, job *JobContext) (any, error)
+
+// A kind of job the manager can run
+type jobKind struct {
+	runner    JobRunner
+	resumable bool
+}
+
+// A job as it's saved in the store
+type jobRecord struct {
+	Info   types.JobInfo   `json:"info"`
+	Params json.RawMessage `json:"params"`
+}
+
+// A job tracked by the manager
+type jobEntry struct {
+	record          jobRecord
+	cancel          context.CancelFunc
+	cancelRequested bool
+	updated         chan struct{}
+	lastSaved       time.Time
+}
+
+// Runs long operations requested through the API in the background, so handlers can return a job ID immediately
+// and clients can poll or wait for its status. The number of jobs running at once and the number waiting in the
+// queue are limited, and jobs are saved in the store so their status survives restarts.
+type JobManager struct {
+	kvStore              store.IKeyValueStore
+	logger               *slog.Logger
+	concurrency          int
+	queueLimit           int
+	retention            time.Duration
+	progressSaveInterval time.Duration
+	kinds                map[string]jobKind
+	jobs                 map[string]*jobEntry
+	queue                []string
+	wake                 chan struct{}
+	started              bool
+	lock                 sync.Mutex
+}
+
+// Creates a new job manager that runs up to the provided number of jobs at once. Use a queue limit of 0 for no limit.
+func NewJobManager(kvStore store.IKeyValueStore, logger *slog.Logger, concurrency int, queueLimit int) (*JobManager, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+	return &JobManager{
+		kvStore:              kvStore,
+		logger:               logger,
+		concurrency:          concurrency,
+		queueLimit:           queueLimit,
+		retention:            DefaultJobRetention,
+		progressSaveInterval: DefaultProgressSaveInterval,
+		kinds:                map[string]jobKind{},
+		jobs:                 map[string]*jobEntry{},
+		queue:                []string{},
+		wake:                 make(chan struct{}, 1),
+	}, nil
+}
+
+// Set how long finished jobs are kept before they're pruned. Use 0 to keep them until PruneJobs is called.
+// This must be called before the manager is started.
+func (m *JobManager) SetRetention(retention time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.retention = retention
+}
+
+// Set the minimum time between saving a running job's progress to the store. Anything waiting on the job still gets
+// every update. This must be called before the manager is started.
+func (m *JobManager) SetProgressSaveInterval(interval time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.progressSaveInterval = interval
+}
+
+// Register a kind of job. Resumable jobs that were running when the daemon stopped are restarted from the
+// beginning after a restart; other jobs are marked as interrupted. Kinds must be registered before the manager
+// is started.
+func (m *JobManager) RegisterJobKind(kind string, runner JobRunner, resumable bool) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.started {
+		return fmt.Errorf("job kinds must be registered before the manager is started")
+	}
+	if _, exists := m.kinds[kind]; exists {
+		return fmt.Errorf("job kind [%s] is already registered", kind)
+	}
+	m.kinds[kind] = jobKind{
+		runner:    runner,
+		resumable: resumable,
+	}
+	return nil
+}
+
+// Load the jobs saved from previous runs, then start the workers and the pruning of old finished jobs. They run until
+// the context is cancelled.
+func (m *JobManager) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.started {
+		return fmt.Errorf("job manager is already started")
+	}
+	err := m.loadJobs()
+	if err != nil {
+		return err
+	}
+	m.started = true
+
+	for i := 0; i < m.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.runWorker(ctx)
+		}()
+	}
+	if m.retention > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.runPruner(ctx)
+		}()
+	}
+	return nil
+}
+
+// Add a job to the queue. Returns ErrQueueFull if the queue is at its limit.
+func (m *JobManager) Enqueue(kind string, params any) (types.JobInfo, error) {
+	paramBytes, err := json.Marshal(params)
+	if err != nil {
+		return types.JobInfo{}, fmt.Errorf("error serializing job parameters: %w", err)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, exists := m.kinds[kind]; !exists {
+		return types.JobInfo{}, fmt.Errorf("unknown job kind [%s]", kind)
+	}
+	if m.queueLimit > 0 && len(m.queue) >= m.queueLimit {
+		return types.JobInfo{}, ErrQueueFull
+	}
+
+	entry := &jobEntry{
+		record: jobRecord{
+			Info: types.JobInfo{
+				ID:          uuid.New().String(),
+				Kind:        kind,
+				State:       types.JobState_Queued,
+				CreatedTime: time.Now().UTC(),
+			},
+			Params: paramBytes,
+		},
+		updated: make(chan struct{}),
+	}
+	m.jobs[entry.record.Info.ID] = entry
+	m.queue = append(m.queue, entry.record.Info.ID)
+	m.update(entry)
+	m.signalWorkers()
+	return entry.record.Info, nil
+}
+
+// Get the status of a job
+func (m *JobManager) GetJob(id string) (types.JobInfo, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry, exists := m.jobs[id]
+	if !exists {
+		return types.JobInfo{}, ErrJobNotFound
+	}
+	return entry.record.Info, nil
+}
+
+// Get the status of all jobs, in the order they were created
+func (m *JobManager) ListJobs() []types.JobInfo {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	jobs := make([]types.JobInfo, 0, len(m.jobs))
+	for _, entry := range m.jobs {
+		jobs = append(jobs, entry.record.Info)
+	}
+	sort.Slice(jobs, func(i int, j int) bool {
+		return jobs[i].CreatedTime.Before(jobs[j].CreatedTime)
+	})
+	return jobs
+}
+
+// Wait until a job's version is newer than the provided one, it finishes, or the context is cancelled, then return
+// its status
+func (m *JobManager) WaitForUpdate(ctx context.Context, id string, sinceVersion uint64) (types.JobInfo, error) {
+	for {
+		m.lock.Lock()
+		entry, exists := m.jobs[id]
+		if !exists {
+			m.lock.Unlock()
+			return types.JobInfo{}, ErrJobNotFound
+		}
+		info := entry.record.Info
+		updated := entry.updated
+		m.lock.Unlock()
+
+		if info.Version > sinceVersion || info.State.IsFinished() {
+			return info, nil
+		}
+		select {
+		case <-ctx.Done():
+			return info, nil
+		case <-updated:
+		}
+	}
+}
+
+// Cancel a job. Queued jobs are removed from the queue, and running jobs have their context cancelled.
+func (m *JobManager) Cancel(id string) (types.JobInfo, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	entry, exists := m.jobs[id]
+	if !exists {
+		return types.JobInfo{}, ErrJobNotFound
+	}
+	switch entry.record.Info.State {
+	case types.JobState_Queued:
+		for i, queuedId := range m.queue {
+			if queuedId == id {
+				m.queue = append(m.queue[:i], m.queue[i+1:]...)
+				break
+			}
+		}
+		m.finish(entry, types.JobState_Cancelled, nil, "")
+	case types.JobState_Running:
+		// The worker marks the job as cancelled once the runner returns
+		entry.cancelRequested = true
+		entry.cancel()
+	default:
+		return entry.record.Info, fmt.Errorf("job is already %s", entry.record.Info.State)
+	}
+	return entry.record.Info, nil
+}
+
+// Delete finished jobs that finished before the provided time. Returns the number of jobs that were deleted.
+func (m *JobManager) PruneJobs(before time.Time) (int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	ids := []string{}
+	for id, entry := range m.jobs {
+		info := entry.record.Info
+		if info.State.IsFinished() && info.FinishedTime != nil && info.FinishedTime.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	err := m.kvStore.Update(func(tx store.IStoreTransaction) error {
+		for _, id := range ids {
+			err := tx.Delete(jobBucket, []byte(id))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error deleting jobs: %w", err)
+	}
+	for _, id := range ids {
+		delete(m.jobs, id)
+	}
+	return len(ids), nil
+}
+
+// Load the jobs saved from previous runs and recover the ones that didn't finish; this must be called while
+// holding the lock
+func (m *JobManager) loadJobs() error {
+	records := []jobRecord{}
+	err := m.kvStore.ForEach(jobBucket, nil, nil, func(key []byte, value []byte) error {
+		var record jobRecord
+		err := json.Unmarshal(value, &record)
+		if err != nil {
+			return fmt.Errorf("error deserializing job [%s]: %w", string(key), err)
+		}
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error loading jobs: %w", err)
+	}
+	sort.Slice(records, func(i int, j int) bool {
+		return records[i].Info.CreatedTime.Before(records[j].Info.CreatedTime)
+	})
+
+	for _, record := range records {
+		entry := &jobEntry{
+			record:  record,
+			updated: make(chan struct{}),
+		}
+		m.jobs[record.Info.ID] = entry
+		if record.Info.State.IsFinished() {
+			continue
+		}
+
+		kind, exists := m.kinds[record.Info.Kind]
+		switch {
+		case !exists:
+			m.finish(entry, types.JobState_Interrupted, nil, fmt.Sprintf("unknown job kind [%s]", record.Info.Kind))
+		case record.Info.State == types.JobState_Running && !kind.resumable:
+			m.finish(entry, types.JobState_Interrupted, nil, "the daemon stopped while the job was running")
+		default:
+			entry.record.Info.State = types.JobState_Queued
+			entry.record.Info.StartedTime = nil
+			entry.record.Info.Progress = 0
+			entry.record.Info.Message = ""
+			m.queue = append(m.queue, record.Info.ID)
+			m.update(entry)
+		}
+	}
+	if len(m.queue) > 0 {
+		m.logger.Info("Resumed queued jobs.", slog.Int(log.CountKey, len(m.queue)))
+	}
+	return nil
+}
+
+// Run queued jobs until the context is cancelled
+func (m *JobManager) runWorker(ctx context.Context) {
+	for {
+		m.lock.Lock()
+		if len(m.queue) == 0 {
+			m.lock.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.wake:
+			}
+			continue
+		}
+		id := m.queue[0]
+		m.queue = m.queue[1:]
+		entry := m.jobs[id]
+		kind := m.kinds[entry.record.Info.Kind]
+
+		// Mark it as running
+		jobCtx, cancel := context.WithCancel(ctx)
+		entry.cancel = cancel
+		now := time.Now().UTC()
+		entry.record.Info.State = types.JobState_Running
+		entry.record.Info.StartedTime = &now
+		m.update(entry)
+
+		// Let another worker pick up the next job
+		if len(m.queue) > 0 {
+			m.signalWorkers()
+		}
+		m.lock.Unlock()
+
+		m.runJob(jobCtx, ctx, entry, kind)
+		cancel()
+	}
+}
+
+// Prune finished jobs past the retention period right away and then periodically, until the context is cancelled
+func (m *JobManager) runPruner(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		count, err := m.PruneJobs(time.Now().Add(-m.retention))
+		if err != nil {
+			m.logger.Warn("Error pruning finished jobs.", log.Err(err))
+		} else if count > 0 {
+			m.logger.Debug("Pruned finished jobs.", slog.Int(log.CountKey, count))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run a job and record its result
+func (m *JobManager) runJob(jobCtx context.Context, daemonCtx context.Context, entry *jobEntry, kind jobKind) {
+	logger := m.logger.With(slog.String(log.JobIdKey, entry.record.Info.ID), slog.String(log.TaskKey, entry.record.Info.Kind))
+	logger.Info("Starting job.")
+	result, err := kind.runner(jobCtx, &JobContext{
+		manager: m,
+		entry:   entry,
+	})
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	switch {
+	case entry.cancelRequested:
+		logger.Info("Job was cancelled.")
+		m.finish(entry, types.JobState_Cancelled, nil, "")
+	case daemonCtx.Err() != nil:
+		// Leave the job running in the store so it's recovered on the next start
+		logger.Info("Job was stopped by shutdown.")
+	case err != nil:
+		logger.Warn("Job failed.", log.Err(err))
+		m.finish(entry, types.JobState_Failed, nil, err.Error())
+	default:
+		resultBytes, err := json.Marshal(result)
+		if err != nil {
+			m.finish(entry, types.JobState_Failed, nil, fmt.Sprintf("error serializing job result: %s", err.Error()))
+			return
+		}
+		logger.Info("Job succeeded.")
+		m.finish(entry, types.JobState_Succeeded, resultBytes, "")
+	}
+}
+
+// Mark a job as finished; this must be called while holding the lock
+func (m *JobManager) finish(entry *jobEntry, state types.JobState, result json.RawMessage, errorMessage string) {
+	now := time.Now().UTC()
+	entry.record.Info.State = state
+	entry.record.Info.Result = result
+	entry.record.Info.Error = errorMessage
+	entry.record.Info.FinishedTime = &now
+	if state == types.JobState_Succeeded {
+		entry.record.Info.Progress = 1
+	}
+	m.update(entry)
+}
+
+// Save a change to a job and notify anything waiting on it; this must be called while holding the lock
+func (m *JobManager) update(entry *jobEntry) {
+	entry.record.Info.Version++
+	m.save(entry)
+	m.notify(entry)
+}
+
+// Save a job to the store; this must be called while holding the lock
+func (m *JobManager) save(entry *jobEntry) {
+	err := m.kvStore.Update(func(tx store.IStoreTransaction) error {
+		return store.PutJson(tx, jobBucket, []byte(entry.record.Info.ID), entry.record)
+	})
+	if err != nil {
+		m.logger.Warn("Error saving job.", slog.String(log.JobIdKey, entry.record.Info.ID), log.Err(err))
+	}
+	entry.lastSaved = time.Now()
+}
+
+// Wake anything waiting on a job's next update; this must be called while holding the lock
+func (m *JobManager) notify(entry *jobEntry) {
+	close(entry.updated)
+	entry.updated = make(chan struct{})
+}
+
+// Wake an idle worker if there is one
+func (m *JobManager) signalWorkers() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ==================
+// === JobContext ===
+// ==================
+
+// Gives a running job access to its parameters and lets it report its progress
+type JobContext struct {
+	manager *JobManager
+	entry   *jobEntry
+}
+
+// Get the job's ID
+func (c *JobContext) GetID() string {
+	return c.entry.record.Info.ID
+}
+
+// Deserialize the job's parameters
+func (c *JobContext) GetParams(params_Out any) error {
+	err := json.Unmarshal(c.entry.record.Params, params_Out)
+	if err != nil {
+		return fmt.Errorf("error deserializing job parameters: %w", err)
+	}
+	return nil
+}
+
+// Report the job's progress, from 0 to 1, and what it's currently doing. Progress is only saved to the store once
+// per save interval, but anything waiting on the job is notified every time.
+func (c *JobContext) SetProgress(progress float64, message string) {
+	c.manager.lock.Lock()
+	defer c.manager.lock.Unlock()
+
+	c.entry.record.Info.Progress = progress
+	c.entry.record.Info.Message = message
+	c.entry.record.Info.Version++
+	if time.Since(c.entry.lastSaved) >= c.manager.progressSaveInterval {
+		c.manager.save(c.entry)
+	}
+	c.manager.notify(c.entry)
+}
@@ -0,0 +1,513 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/node/disk"
+	"github.com/rocket-pool/node-manager-core/node/services"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The route the metrics are served on
+	metricsPath string = "/metrics"
+
+	// The default time limit for collecting the metrics on each scrape
+	DefaultCollectionTimeout time.Duration = 10 * time.Second
+)
+
+// Publishes the state of the node's clients, wallet, and validators as Prometheus metrics. The metrics are collected
+// from the service provider on each scrape, so they're always current.
+type MetricsExporter struct {
+	sp         services.IServiceProvider
+	logger     *slog.Logger
+	registry   *prometheus.Registry
+	ip         string
+	port       uint16
+	timeout    time.Duration
+	socket     net.Listener
+	server     http.Server
+	validators []beacon.ValidatorPubkey
+	disk       *disk.DiskMonitor
+	lock       sync.Mutex
+
+	// Descriptions
+	clientReady           *prometheus.Desc
+	clientFallbackEnabled *prometheus.Desc
+	clientRequests        *prometheus.Desc
+	clientFailures        *prometheus.Desc
+	clientLatency         *prometheus.Desc
+	clientSwitches        *prometheus.Desc
+	executionBlockNumber  *prometheus.Desc
+	beaconHeadSlot        *prometheus.Desc
+	beaconSyncDistance    *prometheus.Desc
+	beaconSyncing         *prometheus.Desc
+	walletBalance         *prometheus.Desc
+	walletLoaded          *prometheus.Desc
+	walletHasAddress      *prometheus.Desc
+	walletPasswordSaved   *prometheus.Desc
+	transactions          *prometheus.Desc
+	validatorBalance      *prometheus.Desc
+	validatorEffective    *prometheus.Desc
+	validatorStatus       *prometheus.Desc
+	diskTotal             *prometheus.Desc
+	diskAvailable         *prometheus.Desc
+	diskGrowth            *prometheus.Desc
+	diskDaysUntilFull     *prometheus.Desc
+	diskAlert             *prometheus.Desc
+	collectionErrors      *prometheus.Desc
+	collectionDuration    *prometheus.Desc
+}
+
+// Creates a new metrics exporter that serves metrics on the provided address. All metric names are prefixed with
+// the namespace (such as the name of the daemon).
+func NewMetricsExporter(sp services.IServiceProvider, logger *slog.Logger, namespace string, ip string, port uint16, timeout time.Duration) (*MetricsExporter, error) {
+	exporter := &MetricsExporter{
+		sp:         sp,
+		logger:     logger,
+		registry:   prometheus.NewRegistry(),
+		ip:         ip,
+		port:       port,
+		timeout:    timeout,
+		validators: []beacon.ValidatorPubkey{},
+
+		clientReady: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "client", "ready"),
+			"Whether a client is ready to use (1) or not (0)",
+			[]string{"layer", "client"}, nil,
+		),
+		clientFallbackEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "client", "fallback_enabled"),
+			"Whether a fallback client is configured (1) or not (0)",
+			[]string{"layer"}, nil,
+		),
+		clientRequests: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "client", "requests_total"),
+			"The number of requests sent to a client's endpoint",
+			[]string{"layer", "client"}, nil,
+		),
+		clientFailures: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "client", "request_failures_total"),
+			"The number of requests to a client's endpoint that failed",
+			[]string{"layer", "client"}, nil,
+		),
+		clientLatency: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "client", "request_latency_seconds"),
+			"Latency percentiles of the recent successful requests to a client's endpoint",
+			[]string{"layer", "client", "quantile"}, nil,
+		),
+		clientSwitches: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "client", "switches_total"),
+			"The number of times the node switched to a different client to serve requests, such as when falling back from the primary",
+			[]string{"layer"}, nil,
+		),
+		executionBlockNumber: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "execution", "block_number"),
+			"The latest block number of the Execution client",
+			nil, nil,
+		),
+		beaconHeadSlot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "beacon", "head_slot"),
+			"The head slot of the Beacon node",
+			nil, nil,
+		),
+		beaconSyncDistance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "beacon", "sync_distance"),
+			"The number of slots the Beacon node's head is behind the current slot",
+			nil, nil,
+		),
+		beaconSyncing: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "beacon", "syncing"),
+			"Whether the Beacon node is syncing (1) or not (0)",
+			nil, nil,
+		),
+		walletBalance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "wallet", "balance_eth"),
+			"The ETH balance of the node wallet",
+			[]string{"address"}, nil,
+		),
+		walletLoaded: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "wallet", "loaded"),
+			"Whether the node wallet is loaded and can sign transactions (1) or not (0)",
+			nil, nil,
+		),
+		walletHasAddress: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "wallet", "has_address"),
+			"Whether the node has an address (1) or not (0)",
+			nil, nil,
+		),
+		walletPasswordSaved: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "wallet", "password_saved"),
+			"Whether the node wallet's password is saved to disk (1) or not (0)",
+			nil, nil,
+		),
+		transactions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "tx", "events_total"),
+			"The number of transaction manager events, such as simulations, submissions, and confirmations",
+			[]string{"event"}, nil,
+		),
+		validatorBalance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "validator", "balance_eth"),
+			"The balance of a validator",
+			[]string{"pubkey", "index"}, nil,
+		),
+		validatorEffective: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "validator", "effective_balance_eth"),
+			"The effective balance of a validator",
+			[]string{"pubkey", "index"}, nil,
+		),
+		validatorStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "validator", "status"),
+			"The status of a validator; the series with the current status is 1",
+			[]string{"pubkey", "index", "status"}, nil,
+		),
+		diskTotal: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "disk", "total_bytes"),
+			"The size of the filesystem that holds a monitored directory",
+			[]string{"directory"}, nil,
+		),
+		diskAvailable: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "disk", "available_bytes"),
+			"The free space on the filesystem that holds a monitored directory",
+			[]string{"directory"}, nil,
+		),
+		diskGrowth: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "disk", "growth_bytes_per_day"),
+			"The estimated daily growth of the usage of the filesystem that holds a monitored directory",
+			[]string{"directory"}, nil,
+		),
+		diskDaysUntilFull: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "disk", "days_until_full"),
+			"The estimated number of days until the filesystem that holds a monitored directory is full, or -1 if it isn't growing",
+			[]string{"directory"}, nil,
+		),
+		diskAlert: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "disk", "alert"),
+			"The alert level of a monitored directory; the series with the current level is 1",
+			[]string{"directory", "level"}, nil,
+		),
+		collectionErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "collection_errors"),
+			"The number of metric groups that couldn't be collected during the scrape",
+			nil, nil,
+		),
+		collectionDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "exporter", "collection_duration_seconds"),
+			"The time it took to collect the metrics during the scrape",
+			nil, nil,
+		),
+	}
+	if exporter.timeout <= 0 {
+		exporter.timeout = DefaultCollectionTimeout
+	}
+
+	// Register the collectors
+	err := exporter.registry.Register(exporter)
+	if err != nil {
+		return nil, fmt.Errorf("error registering metrics collector: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(exporter.registry, promhttp.HandlerOpts{}))
+	exporter.server = http.Server{
+		Handler: mux,
+	}
+	return exporter, nil
+}
+
+// Creates a new metrics exporter from the daemon's metrics settings, serving metrics on the provided IP and the
+// daemon metrics port. Returns nil if metrics are disabled.
+func NewMetricsExporterFromConfig(sp services.IServiceProvider, logger *slog.Logger, namespace string, ip string, cfg *config.MetricsConfig) (*MetricsExporter, error) {
+	if !cfg.EnableMetrics.Value {
+		return nil, nil
+	}
+	return NewMetricsExporter(sp, logger, namespace, ip, cfg.DaemonMetricsPort.Value, DefaultCollectionTimeout)
+}
+
+// Set the validators to publish metrics for
+func (e *MetricsExporter) SetValidators(pubkeys []beacon.ValidatorPubkey) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.validators = append([]beacon.ValidatorPubkey{}, pubkeys...)
+}
+
+// Set the disk monitor to publish the directory statuses of
+func (e *MetricsExporter) SetDiskMonitor(monitor *disk.DiskMonitor) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.disk = monitor
+}
+
+// Get the registry the exporter publishes to, so downstream daemons can add their own collectors
+func (e *MetricsExporter) GetRegistry() *prometheus.Registry {
+	return e.registry
+}
+
+// Starts listening for metrics scrapes
+func (e *MetricsExporter) Start(wg *sync.WaitGroup) error {
+	// Create the socket
+	socket, err := net.Listen("tcp", fmt.Sprintf("%s:%d", e.ip, e.port))
+	if err != nil {
+		return fmt.Errorf("error creating socket: %w", err)
+	}
+	e.socket = socket
+
+	// Get the port if random
+	if e.port == 0 {
+		e.port = uint16(socket.Addr().(*net.TCPAddr).Port)
+	}
+
+	// Start listening
+	wg.Add(1)
+	go func() {
+		err := e.server.Serve(socket)
+		if !errors.Is(err, http.ErrServerClosed) {
+			e.logger.Error("error while listening for metrics scrapes", log.Err(err))
+		}
+		wg.Done()
+	}()
+
+	return nil
+}
+
+// Stops the metrics listener
+func (e *MetricsExporter) Stop() error {
+	err := e.server.Shutdown(context.Background())
+	if err != nil {
+		return fmt.Errorf("error stopping listener: %w", err)
+	}
+	return nil
+}
+
+// Get the port the exporter is running on - useful if the port was automatically assigned
+func (e *MetricsExporter) GetPort() uint16 {
+	return e.port
+}
+
+// =========================
+// === Collector Methods ===
+// =========================
+
+// Send the descriptions of all of the exporter's metrics
+func (e *MetricsExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.clientReady
+	ch <- e.clientFallbackEnabled
+	ch <- e.clientRequests
+	ch <- e.clientFailures
+	ch <- e.clientLatency
+	ch <- e.clientSwitches
+	ch <- e.executionBlockNumber
+	ch <- e.beaconHeadSlot
+	ch <- e.beaconSyncDistance
+	ch <- e.beaconSyncing
+	ch <- e.walletBalance
+	ch <- e.walletLoaded
+	ch <- e.walletHasAddress
+	ch <- e.walletPasswordSaved
+	ch <- e.transactions
+	ch <- e.validatorBalance
+	ch <- e.validatorEffective
+	ch <- e.validatorStatus
+	ch <- e.diskTotal
+	ch <- e.diskAvailable
+	ch <- e.diskGrowth
+	ch <- e.diskDaysUntilFull
+	ch <- e.diskAlert
+	ch <- e.collectionErrors
+	ch <- e.collectionDuration
+}
+
+// Collect the current value of all of the exporter's metrics. Metric groups that can't be collected are skipped
+// and counted as collection errors rather than failing the whole scrape.
+func (e *MetricsExporter) Collect(ch chan<- prometheus.Metric) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(e.sp.GetBaseContext(), e.timeout)
+	defer cancel()
+
+	errorCount := 0
+	collectors := []func(context.Context, chan<- prometheus.Metric) error{
+		e.collectClientMetrics,
+		e.collectExecutionMetrics,
+		e.collectBeaconMetrics,
+		e.collectWalletMetrics,
+		e.collectTransactionMetrics,
+		e.collectValidatorMetrics,
+		e.collectDiskMetrics,
+	}
+	for _, collector := range collectors {
+		err := collector(ctx, ch)
+		if err != nil {
+			e.logger.Warn("Error collecting metrics.", log.Err(err))
+			errorCount++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(e.collectionErrors, prometheus.GaugeValue, float64(errorCount))
+	ch <- prometheus.MustNewConstMetric(e.collectionDuration, prometheus.GaugeValue, time.Since(start).Seconds())
+}
+
+// Collect the readiness, failover state, and request statistics of the clients
+func (e *MetricsExporter) collectClientMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	ec := e.sp.GetEthClient()
+	for i := 0; i < ec.GetClientCount(); i++ {
+		e.collectSingleClientMetrics(ch, "execution", ec.GetClientName(i), ec.IsClientReady(i), ec.GetClient(i))
+	}
+	ch <- prometheus.MustNewConstMetric(e.clientFallbackEnabled, prometheus.GaugeValue, boolToFloat(ec.IsFallbackEnabled()), "execution")
+	ch <- prometheus.MustNewConstMetric(e.clientSwitches, prometheus.CounterValue, float64(ec.GetClientSwitchCount()), "execution")
+
+	bc := e.sp.GetBeaconClient()
+	for i := 0; i < bc.GetClientCount(); i++ {
+		e.collectSingleClientMetrics(ch, "beacon", bc.GetClientName(i), bc.IsClientReady(i), bc.GetClient(i))
+	}
+	ch <- prometheus.MustNewConstMetric(e.clientFallbackEnabled, prometheus.GaugeValue, boolToFloat(bc.IsFallbackEnabled()), "beacon")
+	ch <- prometheus.MustNewConstMetric(e.clientSwitches, prometheus.CounterValue, float64(bc.GetClientSwitchCount()), "beacon")
+	return nil
+}
+
+// Collect the readiness of a client, and its request statistics if it tracks them
+func (e *MetricsExporter) collectSingleClientMetrics(ch chan<- prometheus.Metric, layer string, name string, isReady bool, client any) {
+	ch <- prometheus.MustNewConstMetric(e.clientReady, prometheus.GaugeValue, boolToFloat(isReady), layer, name)
+	statsProvider, ok := client.(utils.IEndpointStatsProvider)
+	if !ok {
+		return
+	}
+	stats := statsProvider.GetEndpointStats()
+	ch <- prometheus.MustNewConstMetric(e.clientRequests, prometheus.CounterValue, float64(stats.TotalRequests), layer, name)
+	ch <- prometheus.MustNewConstMetric(e.clientFailures, prometheus.CounterValue, float64(stats.TotalFailures), layer, name)
+	ch <- prometheus.MustNewConstMetric(e.clientLatency, prometheus.GaugeValue, stats.LatencyP50.Seconds(), layer, name, "0.5")
+	ch <- prometheus.MustNewConstMetric(e.clientLatency, prometheus.GaugeValue, stats.LatencyP90.Seconds(), layer, name, "0.9")
+	ch <- prometheus.MustNewConstMetric(e.clientLatency, prometheus.GaugeValue, stats.LatencyP99.Seconds(), layer, name, "0.99")
+}
+
+// Collect the Execution client's chain state
+func (e *MetricsExporter) collectExecutionMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	blockNumber, err := e.sp.GetEthClient().BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting latest block number: %w", err)
+	}
+	ch <- prometheus.MustNewConstMetric(e.executionBlockNumber, prometheus.GaugeValue, float64(blockNumber))
+	return nil
+}
+
+// Collect the Beacon node's sync state
+func (e *MetricsExporter) collectBeaconMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	syncStatus, err := e.sp.GetBeaconClient().GetSyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Beacon node sync status: %w", err)
+	}
+	ch <- prometheus.MustNewConstMetric(e.beaconHeadSlot, prometheus.GaugeValue, float64(syncStatus.HeadSlot))
+	ch <- prometheus.MustNewConstMetric(e.beaconSyncDistance, prometheus.GaugeValue, float64(syncStatus.SyncDistance))
+	ch <- prometheus.MustNewConstMetric(e.beaconSyncing, prometheus.GaugeValue, boolToFloat(syncStatus.Syncing))
+	return nil
+}
+
+// Collect the node wallet's status, and its balance if the node has an address
+func (e *MetricsExporter) collectWalletMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	status, err := e.sp.GetWallet().GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting wallet status: %w", err)
+	}
+	ch <- prometheus.MustNewConstMetric(e.walletLoaded, prometheus.GaugeValue, boolToFloat(status.Wallet.IsLoaded))
+	ch <- prometheus.MustNewConstMetric(e.walletHasAddress, prometheus.GaugeValue, boolToFloat(status.Address.HasAddress))
+	ch <- prometheus.MustNewConstMetric(e.walletPasswordSaved, prometheus.GaugeValue, boolToFloat(status.Password.IsPasswordSaved))
+	if !status.Address.HasAddress {
+		return nil
+	}
+
+	address := status.Address.NodeAddress
+	balance, err := e.sp.GetEthClient().BalanceAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting balance of %s: %w", address.Hex(), err)
+	}
+	ch <- prometheus.MustNewConstMetric(e.walletBalance, prometheus.GaugeValue, eth.WeiToEth(balance), address.Hex())
+	return nil
+}
+
+// Collect the transaction manager's activity
+func (e *MetricsExporter) collectTransactionMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	stats := e.sp.GetTransactionManager().GetStats()
+	events := []struct {
+		name  string
+		count uint64
+	}{
+		{"simulation", stats.Simulations},
+		{"simulation_failure", stats.SimulationFailures},
+		{"submission", stats.Submissions},
+		{"submission_failure", stats.SubmissionFailures},
+		{"confirmation", stats.Confirmations},
+		{"revert", stats.Reverts},
+		{"wait_failure", stats.WaitFailures},
+	}
+	for _, event := range events {
+		ch <- prometheus.MustNewConstMetric(e.transactions, prometheus.CounterValue, float64(event.count), event.name)
+	}
+	return nil
+}
+
+// Collect the balances and statuses of the validators
+func (e *MetricsExporter) collectValidatorMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	e.lock.Lock()
+	validators := e.validators
+	e.lock.Unlock()
+	if len(validators) == 0 {
+		return nil
+	}
+
+	statuses, err := e.sp.GetBeaconClient().GetValidatorStatuses(ctx, validators, nil)
+	if err != nil {
+		return fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	for _, pubkey := range validators {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		pubkeyLabel := pubkey.HexWithPrefix()
+		ch <- prometheus.MustNewConstMetric(e.validatorBalance, prometheus.GaugeValue, eth.GweiToEth(float64(status.Balance)), pubkeyLabel, status.Index)
+		ch <- prometheus.MustNewConstMetric(e.validatorEffective, prometheus.GaugeValue, eth.GweiToEth(float64(status.EffectiveBalance)), pubkeyLabel, status.Index)
+		ch <- prometheus.MustNewConstMetric(e.validatorStatus, prometheus.GaugeValue, 1, pubkeyLabel, status.Index, string(status.Status))
+	}
+	return nil
+}
+
+// Collect the latest statuses of the directories watched by the disk monitor
+func (e *MetricsExporter) collectDiskMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	e.lock.Lock()
+	monitor := e.disk
+	e.lock.Unlock()
+	if monitor == nil {
+		return nil
+	}
+
+	for _, status := range monitor.GetStatuses() {
+		if status.Error != "" {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(e.diskTotal, prometheus.GaugeValue, float64(status.Space.Total), status.Name)
+		ch <- prometheus.MustNewConstMetric(e.diskAvailable, prometheus.GaugeValue, float64(status.Space.Available), status.Name)
+		ch <- prometheus.MustNewConstMetric(e.diskGrowth, prometheus.GaugeValue, status.GrowthPerDay, status.Name)
+		ch <- prometheus.MustNewConstMetric(e.diskDaysUntilFull, prometheus.GaugeValue, status.DaysUntilFull, status.Name)
+		ch <- prometheus.MustNewConstMetric(e.diskAlert, prometheus.GaugeValue, 1, status.Name, string(status.AlertLevel))
+	}
+	return nil
+}
+
+// Convert a flag to a gauge value
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,269 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils/sys"
+)
+
+// A port to test for reachability
+type CheckedPort struct {
+	// A short name for the port, such as which client uses it
+	Name string
+
+	// The port number
+	Port uint16
+
+	// The protocol the port is used with
+	Protocol types.PortProtocol
+}
+
+// Tests whether a port can be reached through an external IP address. The default prober connects to the address
+// from this machine, which only works if the router supports NAT loopback; a prober that asks an outside service to
+// connect back can be used instead for a more accurate result.
+type IPortProber interface {
+	// Test a port, returning its reachability
+	ProbePort(ctx context.Context, ip net.IP, port CheckedPort) (types.PortReachability, error)
+}
+
+// Settings for discovering the external IP address and testing ports
+type ReachabilitySettings struct {
+	// The external IP providers to query. Each one must respond to a GET request with the caller's address as plain
+	// text.
+	IpProviders []string
+
+	// The number of providers that have to report the same address for it to be used
+	MinAgreement int
+
+	// The time limit for each provider query and port probe
+	Timeout time.Duration
+}
+
+// The reachability settings to use if none are configured
+var DefaultReachabilitySettings = ReachabilitySettings{
+	IpProviders:  sys.DefaultExternalIpProviders,
+	MinAgreement: 2,
+	Timeout:      5 * time.Second,
+}
+
+// Check that the settings are usable
+func (s *ReachabilitySettings) Validate() error {
+	if len(s.IpProviders) == 0 {
+		return fmt.Errorf("at least one external IP provider is required")
+	}
+	if s.MinAgreement < 1 || s.MinAgreement > len(s.IpProviders) {
+		return fmt.Errorf("min agreement must be between 1 and the number of providers (%d)", len(s.IpProviders))
+	}
+	if s.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	return nil
+}
+
+// Get the P2P ports of the locally managed clients. Clients that are managed externally are skipped, since their ports
+// aren't set in the config.
+func GetP2pPorts(clientMode config.ClientMode, ecConfig *config.LocalExecutionConfig, bnConfig *config.LocalBeaconConfig) []CheckedPort {
+	if clientMode != config.ClientMode_Local {
+		return []CheckedPort{}
+	}
+	ports := []CheckedPort{
+		{Name: "ec-p2p", Port: ecConfig.P2pPort.Value, Protocol: types.PortProtocol_Tcp},
+		{Name: "ec-p2p", Port: ecConfig.P2pPort.Value, Protocol: types.PortProtocol_Udp},
+		{Name: "bn-p2p", Port: bnConfig.P2pPort.Value, Protocol: types.PortProtocol_Tcp},
+		{Name: "bn-p2p", Port: bnConfig.P2pPort.Value, Protocol: types.PortProtocol_Udp},
+	}
+	quicPort := bnConfig.GetP2pQuicPort()
+	if quicPort != 0 {
+		ports = append(ports, CheckedPort{Name: "bn-quic", Port: quicPort, Protocol: types.PortProtocol_Udp})
+	}
+	return ports
+}
+
+// Discovers the node's external IP address and tests whether its P2P ports can be reached through it, so operators can
+// diagnose NAT and firewall problems that leave their clients with few peers. Run Check on a schedule, such as with a
+// tasks.Scheduler.
+type ReachabilityChecker struct {
+	logger   *slog.Logger
+	ports    []CheckedPort
+	settings ReachabilitySettings
+	prober   IPortProber
+	status   *types.NetworkReachabilityStatus
+	lock     sync.Mutex
+}
+
+// Creates a new reachability checker for the provided ports. If the prober is nil, ports are tested by connecting to
+// them from this machine.
+func NewReachabilityChecker(logger *slog.Logger, ports []CheckedPort, settings ReachabilitySettings, prober IPortProber) (*ReachabilityChecker, error) {
+	err := settings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid reachability settings: %w", err)
+	}
+	if prober == nil {
+		prober = &DialPortProber{
+			Timeout: settings.Timeout,
+		}
+	}
+	return &ReachabilityChecker{
+		logger:   logger,
+		ports:    append([]CheckedPort{}, ports...),
+		settings: settings,
+		prober:   prober,
+	}, nil
+}
+
+// Discover the external IP address, test each port, and update the status, logging a warning for any port that can't
+// be reached. Returns an error if the external IP address couldn't be determined.
+func (c *ReachabilityChecker) Check(ctx context.Context) error {
+	status := types.NetworkReachabilityStatus{
+		CheckTime: time.Now(),
+		Ports:     make([]types.PortStatus, 0, len(c.ports)),
+	}
+
+	ip, providers, ipErr := c.getExternalIp(ctx)
+	status.Providers = providers
+	if ipErr != nil {
+		status.ExternalIpError = ipErr.Error()
+	} else {
+		status.ExternalIp = ip.String()
+	}
+
+	for _, port := range c.ports {
+		portStatus := c.checkPort(ctx, ip, port)
+		if portStatus.Reachability == types.PortReachability_Unreachable {
+			c.logger.Warn("P2P port can't be reached from outside; check your router's port forwarding and your firewall.",
+				slog.String(log.PortKey, fmt.Sprintf("%d/%s", port.Port, port.Protocol)),
+				slog.String(log.ClientKey, port.Name),
+				slog.String(log.CauseKey, portStatus.Error),
+			)
+		}
+		status.Ports = append(status.Ports, portStatus)
+	}
+
+	c.lock.Lock()
+	c.status = &status
+	c.lock.Unlock()
+
+	if ipErr != nil {
+		return fmt.Errorf("error getting external IP address: %w", ipErr)
+	}
+	return nil
+}
+
+// Get the results of the latest check, or false if it hasn't run yet
+func (c *ReachabilityChecker) GetStatus() (types.NetworkReachabilityStatus, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.status == nil {
+		return types.NetworkReachabilityStatus{}, false
+	}
+	return *c.status, true
+}
+
+// Query every provider in parallel and return the address that enough of them agree on
+func (c *ReachabilityChecker) getExternalIp(ctx context.Context) (net.IP, []types.ExternalIpProviderResult, error) {
+	results := make([]types.ExternalIpProviderResult, len(c.settings.IpProviders))
+	var wg sync.WaitGroup
+	for i, provider := range c.settings.IpProviders {
+		wg.Add(1)
+		go func(i int, provider string) {
+			defer wg.Done()
+			results[i].Provider = provider
+			ip, err := sys.QueryExternalIp(ctx, provider, c.settings.Timeout)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Ip = ip.String()
+		}(i, provider)
+	}
+	wg.Wait()
+
+	// Tally the votes, keeping the provider order so ties go to the earlier provider
+	votes := map[string]int{}
+	best := ""
+	errs := []error{}
+	for _, result := range results {
+		if result.Error != "" {
+			errs = append(errs, errors.New(result.Error))
+			continue
+		}
+		votes[result.Ip]++
+		if best == "" || votes[result.Ip] > votes[best] {
+			best = result.Ip
+		}
+	}
+	if best == "" {
+		return nil, results, fmt.Errorf("none of the external IP providers responded: %w", errors.Join(errs...))
+	}
+	if votes[best] < c.settings.MinAgreement {
+		return nil, results, fmt.Errorf("only %d of the external IP providers agreed on an address, but %d are required", votes[best], c.settings.MinAgreement)
+	}
+	return net.ParseIP(best), results, nil
+}
+
+// Test a single port
+func (c *ReachabilityChecker) checkPort(ctx context.Context, ip net.IP, port CheckedPort) types.PortStatus {
+	status := types.PortStatus{
+		Name:         port.Name,
+		Port:         port.Port,
+		Protocol:     port.Protocol,
+		Reachability: types.PortReachability_Unknown,
+	}
+	if port.Protocol == types.PortProtocol_Tcp {
+		status.IsListening = isListeningLocally(ctx, port.Port, c.settings.Timeout)
+	}
+	if ip == nil {
+		status.Error = "external IP address is unknown"
+		return status
+	}
+
+	reachability, err := c.prober.ProbePort(ctx, ip, port)
+	status.Reachability = reachability
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// Check if something on this machine accepts TCP connections on a port
+func isListeningLocally(ctx context.Context, port uint16, timeout time.Duration) bool {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort("localhost", strconv.FormatUint(uint64(port), 10)))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// Tests ports by connecting to them through the external IP address from this machine. This only works if the router
+// supports NAT loopback (hairpinning); without it, open ports will show up as unreachable. UDP ports can't be tested
+// this way since P2P clients don't respond to arbitrary packets, so they're always reported as unknown.
+type DialPortProber struct {
+	// The time limit for each connection attempt
+	Timeout time.Duration
+}
+
+// Test a port by connecting to it
+func (p *DialPortProber) ProbePort(ctx context.Context, ip net.IP, port CheckedPort) (types.PortReachability, error) {
+	if port.Protocol != types.PortProtocol_Tcp {
+		return types.PortReachability_Unknown, nil
+	}
+	dialer := net.Dialer{Timeout: p.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), strconv.FormatUint(uint64(port.Port), 10)))
+	if err != nil {
+		return types.PortReachability_Unreachable, fmt.Errorf("error connecting to port %d through %s: %w", port.Port, ip.String(), err)
+	}
+	conn.Close()
+	return types.PortReachability_Reachable, nil
+}
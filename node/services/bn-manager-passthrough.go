@@ -0,0 +1,213 @@
+// Code generated by gen-bn-passthrough.go. DO NOT EDIT.
+
+package services
+
+import (
+	"context"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+)
+
+// Make sure the manager covers every route in the provider interface
+var _ client.IBeaconApiProvider = (*BeaconClientManager)(nil)
+
+func (m *BeaconClientManager) Beacon_AttestationRewards_Post(ctx context.Context, epoch uint64, indices []string) (client.AttestationRewardsResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.AttestationRewardsResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.AttestationRewardsResponse{}, err
+		}
+		return provider.Beacon_AttestationRewards_Post(ctx, epoch, indices)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_Attestations(ctx context.Context, blockId string) (client.AttestationsResponse, bool, error) {
+	return runFunction2(m, ctx, func(bc beacon.IBeaconClient) (client.AttestationsResponse, bool, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.AttestationsResponse{}, false, err
+		}
+		return provider.Beacon_Attestations(ctx, blockId)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_BlobSidecars(ctx context.Context, blockId string) (client.BlobSidecarsResponse, bool, error) {
+	return runFunction2(m, ctx, func(bc beacon.IBeaconClient) (client.BlobSidecarsResponse, bool, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.BlobSidecarsResponse{}, false, err
+		}
+		return provider.Beacon_BlobSidecars(ctx, blockId)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_Block(ctx context.Context, blockId string) (client.BeaconBlockResponse, bool, error) {
+	return runFunction2(m, ctx, func(bc beacon.IBeaconClient) (client.BeaconBlockResponse, bool, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.BeaconBlockResponse{}, false, err
+		}
+		return provider.Beacon_Block(ctx, blockId)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_BlockRewards(ctx context.Context, blockId string) (client.BlockRewardsResponse, bool, error) {
+	return runFunction2(m, ctx, func(bc beacon.IBeaconClient) (client.BlockRewardsResponse, bool, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.BlockRewardsResponse{}, false, err
+		}
+		return provider.Beacon_BlockRewards(ctx, blockId)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_BlsToExecutionChanges_Post(ctx context.Context, request client.BLSToExecutionChangeRequest) error {
+	return runFunction0(m, ctx, func(bc beacon.IBeaconClient) error {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return err
+		}
+		return provider.Beacon_BlsToExecutionChanges_Post(ctx, request)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_Committees(ctx context.Context, stateId string, epoch *uint64) (client.CommitteesResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.CommitteesResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.CommitteesResponse{}, err
+		}
+		return provider.Beacon_Committees(ctx, stateId, epoch)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_FinalityCheckpoints(ctx context.Context, stateId string) (client.FinalityCheckpointsResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.FinalityCheckpointsResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.FinalityCheckpointsResponse{}, err
+		}
+		return provider.Beacon_FinalityCheckpoints(ctx, stateId)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_Genesis(ctx context.Context) (client.GenesisResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.GenesisResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.GenesisResponse{}, err
+		}
+		return provider.Beacon_Genesis(ctx)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_Header(ctx context.Context, blockId string) (client.BeaconBlockHeaderResponse, bool, error) {
+	return runFunction2(m, ctx, func(bc beacon.IBeaconClient) (client.BeaconBlockHeaderResponse, bool, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.BeaconBlockHeaderResponse{}, false, err
+		}
+		return provider.Beacon_Header(ctx, blockId)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_SyncCommitteeRewards_Post(ctx context.Context, blockId string, indices []string) (client.SyncCommitteeRewardsResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.SyncCommitteeRewardsResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.SyncCommitteeRewardsResponse{}, err
+		}
+		return provider.Beacon_SyncCommitteeRewards_Post(ctx, blockId, indices)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_ValidatorBalances(ctx context.Context, stateId string, ids []string) (client.ValidatorBalancesResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.ValidatorBalancesResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.ValidatorBalancesResponse{}, err
+		}
+		return provider.Beacon_ValidatorBalances(ctx, stateId, ids)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_Validators(ctx context.Context, stateId string, ids []string) (client.ValidatorsResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.ValidatorsResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.ValidatorsResponse{}, err
+		}
+		return provider.Beacon_Validators(ctx, stateId, ids)
+	})
+}
+
+func (m *BeaconClientManager) Beacon_VoluntaryExits_Post(ctx context.Context, request client.VoluntaryExitRequest) error {
+	return runFunction0(m, ctx, func(bc beacon.IBeaconClient) error {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return err
+		}
+		return provider.Beacon_VoluntaryExits_Post(ctx, request)
+	})
+}
+
+func (m *BeaconClientManager) Config_DepositContract(ctx context.Context) (client.Eth2DepositContractResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.Eth2DepositContractResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.Eth2DepositContractResponse{}, err
+		}
+		return provider.Config_DepositContract(ctx)
+	})
+}
+
+func (m *BeaconClientManager) Config_Spec(ctx context.Context) (client.Eth2ConfigResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.Eth2ConfigResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.Eth2ConfigResponse{}, err
+		}
+		return provider.Config_Spec(ctx)
+	})
+}
+
+func (m *BeaconClientManager) Node_Syncing(ctx context.Context) (client.SyncStatusResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.SyncStatusResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.SyncStatusResponse{}, err
+		}
+		return provider.Node_Syncing(ctx)
+	})
+}
+
+func (m *BeaconClientManager) Validator_DutiesAttester_Post(ctx context.Context, indices []string, epoch uint64) (client.AttesterDutiesResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.AttesterDutiesResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.AttesterDutiesResponse{}, err
+		}
+		return provider.Validator_DutiesAttester_Post(ctx, indices, epoch)
+	})
+}
+
+func (m *BeaconClientManager) Validator_DutiesProposer(ctx context.Context, indices []string, epoch uint64) (client.ProposerDutiesResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.ProposerDutiesResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.ProposerDutiesResponse{}, err
+		}
+		return provider.Validator_DutiesProposer(ctx, indices, epoch)
+	})
+}
+
+func (m *BeaconClientManager) Validator_DutiesSync_Post(ctx context.Context, indices []string, epoch uint64) (client.SyncDutiesResponse, error) {
+	return runFunction1(m, ctx, func(bc beacon.IBeaconClient) (client.SyncDutiesResponse, error) {
+		provider, err := getApiProvider(bc)
+		if err != nil {
+			return client.SyncDutiesResponse{}, err
+		}
+		return provider.Validator_DutiesSync_Post(ctx, indices, epoch)
+	})
+}
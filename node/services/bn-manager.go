@@ -3,80 +3,66 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/node-manager-core/api/types"
 	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
 )
 
+//go:generate go run gen-bn-passthrough.go
+
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
+// The primary client is used whenever it's ready; otherwise each fallback is tried in order.
 type BeaconClientManager struct {
-	primaryBc       beacon.IBeaconClient
-	fallbackBc      beacon.IBeaconClient
-	primaryReady    bool
-	fallbackReady   bool
-	expectedChainID uint
-	fallbackEnabled bool
+	*clientPool[beacon.IBeaconClient]
+
+	// Spec values to use when none of the clients can be reached
+	eth2ConfigOverride      *beacon.Eth2Config
+	depositContractOverride *beacon.Eth2DepositContract
+
+	// The chain config used to work out head ages during health checks, cached after the first one
+	healthCheckConfig *beacon.Eth2Config
+	healthCheckLock   sync.Mutex
 }
 
 // Creates a new BeaconClientManager instance
 func NewBeaconClientManager(primaryBc beacon.IBeaconClient, chainID uint, clientTimeout time.Duration) *BeaconClientManager {
-	return &BeaconClientManager{
-		primaryBc:       primaryBc,
-		primaryReady:    true,
-		fallbackReady:   false,
-		expectedChainID: chainID,
-		fallbackEnabled: false,
-	}
+	return NewBeaconClientManagerWithFallbacks(primaryBc, nil, chainID, clientTimeout)
 }
 
 // Creates a new BeaconClientManager instance with a fallback client
 func NewBeaconClientManagerWithFallback(primaryBc beacon.IBeaconClient, fallbackBc beacon.IBeaconClient, chainID uint, clientTimeout time.Duration) *BeaconClientManager {
-	return &BeaconClientManager{
-		primaryBc:       primaryBc,
-		fallbackBc:      fallbackBc,
-		primaryReady:    true,
-		fallbackReady:   true,
-		expectedChainID: chainID,
-		fallbackEnabled: true,
-	}
+	return NewBeaconClientManagerWithFallbacks(primaryBc, []beacon.IBeaconClient{fallbackBc}, chainID, clientTimeout)
 }
 
-/// ========================
-/// IClientManager Functions
-/// ========================
-
-func (m *BeaconClientManager) GetPrimaryClient() beacon.IBeaconClient {
-	return m.primaryBc
-}
-
-func (m *BeaconClientManager) GetFallbackClient() beacon.IBeaconClient {
-	return m.fallbackBc
+// Creates a new BeaconClientManager instance with any number of fallback clients, which are used in the order
+// they're provided
+func NewBeaconClientManagerWithFallbacks(primaryBc beacon.IBeaconClient, fallbackBcs []beacon.IBeaconClient, chainID uint, clientTimeout time.Duration) *BeaconClientManager {
+	clients := append([]beacon.IBeaconClient{primaryBc}, fallbackBcs...)
+	m := &BeaconClientManager{}
+	m.clientPool = newClientPool("Beacon Node", clients, chainID, clientTimeout, m.checkBcHealth)
+	return m
 }
 
-func (m *BeaconClientManager) IsPrimaryReady() bool {
-	return m.primaryReady
-}
-
-func (m *BeaconClientManager) IsFallbackReady() bool {
-	return m.fallbackReady
-}
-
-func (m *BeaconClientManager) IsFallbackEnabled() bool {
-	return m.fallbackBc != nil
-}
-
-func (m *BeaconClientManager) GetClientTypeName() string {
-	return "Beacon Node"
-}
+/// =================
+/// Manager Functions
+/// =================
 
-func (m *BeaconClientManager) SetPrimaryReady(ready bool) {
-	m.primaryReady = ready
+// Get the status of the primary and fallback clients
+func (m *BeaconClientManager) CheckStatus(ctx context.Context, checkChainIDs bool) *types.ClientManagerStatus {
+	return m.checkStatus(ctx, checkChainIDs, checkBcStatus)
 }
 
-func (m *BeaconClientManager) SetFallbackReady(ready bool) {
-	m.fallbackReady = ready
+// Set the Beacon chain configuration and deposit contract to return when none of the clients can be reached,
+// such as for custom devnets where the values are known ahead of time. Either can be nil to disable its override.
+func (m *BeaconClientManager) SetSpecOverrides(eth2Config *beacon.Eth2Config, depositContract *beacon.Eth2DepositContract) {
+	m.eth2ConfigOverride = eth2Config
+	m.depositContractOverride = depositContract
 }
 
 /// =======================
@@ -92,16 +78,37 @@ func (m *BeaconClientManager) GetSyncStatus(ctx context.Context) (beacon.SyncSta
 
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config(ctx context.Context) (beacon.Eth2Config, error) {
-	return runFunction1(m, ctx, func(client beacon.IBeaconClient) (beacon.Eth2Config, error) {
+	eth2Config, err := runFunction1(m, ctx, func(client beacon.IBeaconClient) (beacon.Eth2Config, error) {
 		return client.GetEth2Config(ctx)
 	})
+	if err != nil && m.eth2ConfigOverride != nil {
+		// Don't mask a cancelled or expired request with the override
+		if ctx.Err() != nil {
+			return beacon.Eth2Config{}, ctx.Err()
+		}
+		if logger, exists := log.FromContext(ctx); exists {
+			logger.Warn("Couldn't get the Beacon config from the clients, using the configured spec instead.", log.Err(err))
+		}
+		return *m.eth2ConfigOverride, nil
+	}
+	return eth2Config, err
 }
 
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2DepositContract(ctx context.Context) (beacon.Eth2DepositContract, error) {
-	return runFunction1(m, ctx, func(client beacon.IBeaconClient) (beacon.Eth2DepositContract, error) {
+	depositContract, err := runFunction1(m, ctx, func(client beacon.IBeaconClient) (beacon.Eth2DepositContract, error) {
 		return client.GetEth2DepositContract(ctx)
 	})
+	if err != nil && m.depositContractOverride != nil {
+		if ctx.Err() != nil {
+			return beacon.Eth2DepositContract{}, ctx.Err()
+		}
+		if logger, exists := log.FromContext(ctx); exists {
+			logger.Warn("Couldn't get the deposit contract from the clients, using the configured spec instead.", log.Err(err))
+		}
+		return *m.depositContractOverride, nil
+	}
+	return depositContract, err
 }
 
 // Get the attestations in a Beacon chain block
@@ -153,6 +160,13 @@ func (m *BeaconClientManager) GetValidatorStatuses(ctx context.Context, pubkeys
 	})
 }
 
+// Get the statuses of multiple validators by their indices
+func (m *BeaconClientManager) GetValidatorStatusesByIndex(ctx context.Context, indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+	return runFunction1(m, ctx, func(client beacon.IBeaconClient) (map[string]beacon.ValidatorStatus, error) {
+		return client.GetValidatorStatusesByIndex(ctx, indices, opts)
+	})
+}
+
 // Get a validator's index
 func (m *BeaconClientManager) GetValidatorIndex(ctx context.Context, pubkey beacon.ValidatorPubkey) (string, error) {
 	return runFunction1(m, ctx, func(client beacon.IBeaconClient) (string, error) {
@@ -216,45 +230,13 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(ctx context.Context, v
 	})
 }
 
-/// =================
-/// Manager Functions
-/// =================
-
-// Get the status of the primary and fallback clients
-func (m *BeaconClientManager) CheckStatus(ctx context.Context, checkChainIDs bool) *types.ClientManagerStatus {
-	status := &types.ClientManagerStatus{
-		FallbackEnabled: m.fallbackEnabled,
-	}
-
-	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(ctx, m.primaryBc, checkChainIDs)
-	if checkChainIDs && status.PrimaryClientStatus.Error == "" && status.PrimaryClientStatus.ChainId != m.expectedChainID {
-		m.primaryReady = false
-		status.PrimaryClientStatus.Error = fmt.Sprintf("The primary client is using a different chain (%d) than what your node is configured for (%d)", status.PrimaryClientStatus.ChainId, m.expectedChainID)
-	} else {
-		// Flag if primary client is ready
-		m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	}
-
-	// Get the fallback BC status if applicable
-	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(ctx, m.fallbackBc, checkChainIDs)
-		// Check if fallback is using the expected network
-		if checkChainIDs && status.FallbackClientStatus.Error == "" && status.FallbackClientStatus.ChainId != m.expectedChainID {
-			m.fallbackReady = false
-			status.FallbackClientStatus.Error = fmt.Sprintf("The fallback client is using a different chain (%d) than what your node is configured for (%d)", status.FallbackClientStatus.ChainId, m.expectedChainID)
-			return status
-		}
-	}
-
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
-	return status
-}
-
 // Check the client status
 func checkBcStatus(ctx context.Context, client beacon.IBeaconClient, checkChainIDs bool) types.ClientStatus {
 	status := types.ClientStatus{}
+	if statsProvider, ok := client.(utils.IEndpointStatsProvider); ok {
+		stats := statsProvider.GetEndpointStats()
+		status.Stats = &stats
+	}
 
 	if checkChainIDs {
 		// Get the Chain ID
@@ -290,3 +272,53 @@ func checkBcStatus(ctx context.Context, client beacon.IBeaconClient, checkChainI
 	}
 	return status
 }
+
+// Check the client's health for scoring; the head age is based on the time of its head slot
+func (m *BeaconClientManager) checkBcHealth(ctx context.Context, client beacon.IBeaconClient) (ClientHealth, error) {
+	syncStatus, err := client.GetSyncStatus(ctx)
+	if err != nil {
+		return ClientHealth{}, fmt.Errorf("error getting sync status: %w", err)
+	}
+	eth2Config, err := m.getHealthCheckConfig(ctx, client)
+	if err != nil {
+		return ClientHealth{}, fmt.Errorf("error getting Beacon chain config: %w", err)
+	}
+
+	headTime := time.Unix(int64(eth2Config.GenesisTime+syncStatus.HeadSlot*eth2Config.SecondsPerSlot), 0)
+	return ClientHealth{
+		IsWorking: true,
+		IsSynced:  !syncStatus.Syncing,
+		HeadAge:   max(time.Since(headTime), 0),
+	}, nil
+}
+
+// Get the chain config for health checks, using the override if there is one or otherwise getting it from the
+// provided client the first time it's needed
+func (m *BeaconClientManager) getHealthCheckConfig(ctx context.Context, client beacon.IBeaconClient) (*beacon.Eth2Config, error) {
+	m.healthCheckLock.Lock()
+	defer m.healthCheckLock.Unlock()
+	if m.healthCheckConfig != nil {
+		return m.healthCheckConfig, nil
+	}
+	if m.eth2ConfigOverride != nil {
+		return m.eth2ConfigOverride, nil
+	}
+
+	eth2Config, err := client.GetEth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.healthCheckConfig = &eth2Config
+	return m.healthCheckConfig, nil
+}
+
+// Get the API provider a Beacon client uses, so the manager can pass provider routes through to it
+func getApiProvider(bc beacon.IBeaconClient) (client.IBeaconApiProvider, error) {
+	providerClient, ok := bc.(interface {
+		GetApiProvider() client.IBeaconApiProvider
+	})
+	if !ok {
+		return nil, fmt.Errorf("client doesn't provide direct access to the Beacon API")
+	}
+	return providerClient.GetApiProvider(), nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+// The state of a client endpoint's circuit breaker
+type CircuitState string
+
+const (
+	// The endpoint is in service
+	CircuitState_Closed CircuitState = "closed"
+
+	// The endpoint failed and is out of service until its cooldown ends
+	CircuitState_Open CircuitState = "open"
+
+	// The endpoint's cooldown ended and requests are being sent to it as probes to see if it has recovered
+	CircuitState_HalfOpen CircuitState = "half-open"
+)
+
+// Tracks the failures of a client endpoint and takes it out of service once they pass a threshold, so a manager
+// doesn't keep switching back and forth between a marginal primary and the fallback. After a cooldown, requests are
+// sent to the endpoint again as probes; if enough of them succeed in a row it goes back into service, and if one
+// fails it's taken out of service again for twice as long.
+type CircuitBreaker struct {
+	settings            config.CircuitBreakerSettings
+	state               CircuitState
+	consecutiveFailures uint64
+	probeSuccesses      uint64
+	cooldown            time.Duration
+	openedTime          time.Time
+	lock                sync.Mutex
+}
+
+// Creates a new circuit breaker for an endpoint that's in service
+func NewCircuitBreaker(settings config.CircuitBreakerSettings) *CircuitBreaker {
+	if settings.FailureThreshold == 0 {
+		settings.FailureThreshold = 1
+	}
+	if settings.ProbeCount == 0 {
+		settings.ProbeCount = 1
+	}
+	if settings.MaxCooldown < settings.Cooldown {
+		settings.MaxCooldown = settings.Cooldown
+	}
+	return &CircuitBreaker{
+		settings: settings,
+		state:    CircuitState_Closed,
+		cooldown: settings.Cooldown,
+	}
+}
+
+// Get the breaker's state
+func (b *CircuitBreaker) GetState() CircuitState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.updateState()
+	return b.state
+}
+
+// Get the time the endpoint will be probed again, or the zero time if it isn't out of service
+func (b *CircuitBreaker) GetRetryTime() time.Time {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.updateState()
+	if b.state != CircuitState_Open {
+		return time.Time{}
+	}
+	return b.openedTime.Add(b.cooldown)
+}
+
+// Check if requests can be sent to the endpoint
+func (b *CircuitBreaker) IsAvailable() bool {
+	return b.GetState() != CircuitState_Open
+}
+
+// Record a request that reached the endpoint
+func (b *CircuitBreaker) RecordSuccess() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.updateState()
+
+	b.consecutiveFailures = 0
+	if b.state == CircuitState_HalfOpen {
+		b.probeSuccesses++
+		if b.probeSuccesses >= b.settings.ProbeCount {
+			b.state = CircuitState_Closed
+			b.cooldown = b.settings.Cooldown
+		}
+	}
+}
+
+// Record a request that couldn't reach the endpoint
+func (b *CircuitBreaker) RecordFailure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.updateState()
+
+	switch b.state {
+	case CircuitState_Closed:
+		b.consecutiveFailures++
+		if b.consecutiveFailures >= b.settings.FailureThreshold {
+			b.open()
+		}
+	case CircuitState_HalfOpen:
+		// The endpoint hasn't recovered yet, so back off for longer
+		b.cooldown = min(b.cooldown*2, b.settings.MaxCooldown)
+		b.open()
+	}
+}
+
+// Take the endpoint out of service; this must be called while holding the lock
+func (b *CircuitBreaker) open() {
+	b.state = CircuitState_Open
+	b.openedTime = time.Now()
+	b.consecutiveFailures = 0
+	b.probeSuccesses = 0
+}
+
+// Start probing the endpoint if its cooldown has ended; this must be called while holding the lock
+func (b *CircuitBreaker) updateState() {
+	if b.state == CircuitState_Open && time.Since(b.openedTime) >= b.cooldown {
+		b.state = CircuitState_HalfOpen
+		b.probeSuccesses = 0
+	}
+}
+
+// Record the result of a client status check on its breaker; checks that reach the client count as probes
+func recordStatusCheck(breaker *CircuitBreaker, status types.ClientStatus) {
+	if status.IsWorking {
+		breaker.RecordSuccess()
+	} else {
+		breaker.RecordFailure()
+	}
+}
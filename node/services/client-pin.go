@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// Pins a manager to its primary client or its fallbacks for a limited time, such as while the others are being pruned
+// or resynced
+type clientPin struct {
+	target     types.ClientPinTarget
+	expiration time.Time
+	lock       sync.Mutex
+}
+
+// Get the current pin, clearing it if it's expired
+func (p *clientPin) get() types.ClientPin {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.target != types.ClientPinTarget_None && !time.Now().Before(p.expiration) {
+		p.target = types.ClientPinTarget_None
+		p.expiration = time.Time{}
+	}
+	return types.ClientPin{
+		Target:     p.target,
+		Expiration: p.expiration,
+	}
+}
+
+// Pin the manager to a client for the provided duration
+func (p *clientPin) set(target types.ClientPinTarget, duration time.Duration, fallbackEnabled bool) (types.ClientPin, error) {
+	switch target {
+	case types.ClientPinTarget_Primary:
+	case types.ClientPinTarget_Fallback:
+		if !fallbackEnabled {
+			return types.ClientPin{}, fmt.Errorf("can't pin to the fallback clients because no fallbacks are configured")
+		}
+	default:
+		return types.ClientPin{}, fmt.Errorf("unknown pin target [%s]", target)
+	}
+	if duration <= 0 {
+		return types.ClientPin{}, fmt.Errorf("pin duration must be positive")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.target = target
+	p.expiration = time.Now().Add(duration)
+	return types.ClientPin{
+		Target:     p.target,
+		Expiration: p.expiration,
+	}, nil
+}
+
+// Remove the pin
+func (p *clientPin) clear() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.target = types.ClientPinTarget_None
+	p.expiration = time.Time{}
+}
+
+// Check if the pin excludes the client at the provided index. Pinning to the primary excludes all of the fallbacks,
+// and pinning to the fallbacks excludes the primary.
+func (p *clientPin) excludes(index int) bool {
+	switch p.get().Target {
+	case types.ClientPinTarget_Primary:
+		return index > 0
+	case types.ClientPinTarget_Fallback:
+		return index == 0
+	default:
+		return false
+	}
+}
+
+// Get the status to report for a client that isn't being checked because of a pin
+func getPinnedAwayStatus(pin types.ClientPin) types.ClientStatus {
+	target := "primary client"
+	if pin.Target == types.ClientPinTarget_Fallback {
+		target = "fallback clients"
+	}
+	return types.ClientStatus{
+		Error: fmt.Sprintf("Not checked because the manager is pinned to the %s until %s", target, pin.Expiration.Format(time.RFC3339)),
+	}
+}
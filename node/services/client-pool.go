@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/events"
+)
+
+// One of a manager's clients, along with its health state
+type managedClient[ClientType any] struct {
+	client    ClientType
+	ready     bool
+	lastError *ClientError
+	breaker   *CircuitBreaker
+	health    ClientHealth
+}
+
+// An ordered list of clients that a manager runs functions on. The first client is the primary and the rest are
+// fallbacks, which are tried in order whenever the clients before them can't be used. This provides the
+// IClientManager functions for the Execution Client and Beacon Node managers.
+type clientPool[ClientType any] struct {
+	typeName        string
+	clients         []*managedClient[ClientType]
+	pin             clientPin
+	expectedChainID uint
+	timeout         time.Duration
+
+	// The index of the client that last served a request, and the number of times that's changed
+	activeClient   atomic.Int64
+	clientSwitches atomic.Uint64
+
+	// The bus that client switches are published to, if any
+	eventBus *events.EventBus
+
+	// Health-check state for picking the healthiest client
+	checkHealth   func(context.Context, ClientType) (ClientHealth, error)
+	selectionMode ClientSelectionMode
+	scoringPolicy HealthScoringPolicy
+	healthLock    sync.RWMutex
+}
+
+// Creates a new client pool with the primary client first, then the fallbacks in the order they should be tried
+func newClientPool[ClientType any](typeName string, clients []ClientType, chainID uint, timeout time.Duration, checkHealth func(context.Context, ClientType) (ClientHealth, error)) *clientPool[ClientType] {
+	pool := &clientPool[ClientType]{
+		typeName:        typeName,
+		clients:         make([]*managedClient[ClientType], 0, len(clients)),
+		expectedChainID: chainID,
+		timeout:         timeout,
+		checkHealth:     checkHealth,
+		selectionMode:   ClientSelectionMode_Ordered,
+		scoringPolicy:   DefaultHealthScoringPolicy,
+	}
+	for i, client := range clients {
+		if i > 0 && any(client) == nil {
+			// Skip missing fallbacks
+			continue
+		}
+		pool.clients = append(pool.clients, &managedClient[ClientType]{
+			client:  client,
+			ready:   true,
+			breaker: NewCircuitBreaker(config.DefaultCircuitBreakerSettings),
+		})
+	}
+	return pool
+}
+
+/// ========================
+/// IClientManager Functions
+/// ========================
+
+func (p *clientPool[ClientType]) GetClientTypeName() string {
+	return p.typeName
+}
+
+func (p *clientPool[ClientType]) GetClientCount() int {
+	return len(p.clients)
+}
+
+func (p *clientPool[ClientType]) GetClient(index int) ClientType {
+	return p.clients[index].client
+}
+
+func (p *clientPool[ClientType]) GetClientName(index int) string {
+	return getClientName(index)
+}
+
+func (p *clientPool[ClientType]) IsClientReady(index int) bool {
+	client := p.clients[index]
+	return client.ready && client.breaker.IsAvailable() && !p.pin.excludes(index)
+}
+
+func (p *clientPool[ClientType]) SetClientReady(index int, ready bool) {
+	p.clients[index].ready = ready
+}
+
+func (p *clientPool[ClientType]) GetClientError(index int) *ClientError {
+	return p.clients[index].lastError
+}
+
+func (p *clientPool[ClientType]) GetClientCircuitState(index int) CircuitState {
+	return p.clients[index].breaker.GetState()
+}
+
+func (p *clientPool[ClientType]) RecordClientSuccess(index int) {
+	p.clients[index].breaker.RecordSuccess()
+	if previous := p.activeClient.Swap(int64(index)); previous != int64(index) {
+		p.clientSwitches.Add(1)
+		p.eventBus.Publish(events.EventType_ClientSwitched, events.ClientSwitchedData{
+			ClientType:     p.typeName,
+			PreviousClient: getClientName(int(previous)),
+			Client:         getClientName(index),
+		})
+	}
+}
+
+func (p *clientPool[ClientType]) GetClientSwitchCount() uint64 {
+	return p.clientSwitches.Load()
+}
+
+func (p *clientPool[ClientType]) RecordClientFailure(index int, err error) {
+	client := p.clients[index]
+	client.lastError = newClientError(err)
+	client.breaker.RecordFailure()
+}
+
+func (p *clientPool[ClientType]) GetPrimaryClient() ClientType {
+	return p.GetClient(0)
+}
+
+// Get the first fallback client, or the zero value if there are no fallbacks
+func (p *clientPool[ClientType]) GetFallbackClient() ClientType {
+	if !p.IsFallbackEnabled() {
+		var blank ClientType
+		return blank
+	}
+	return p.GetClient(1)
+}
+
+func (p *clientPool[ClientType]) IsPrimaryReady() bool {
+	return p.IsClientReady(0)
+}
+
+// Check if any of the fallback clients are ready
+func (p *clientPool[ClientType]) IsFallbackReady() bool {
+	for i := 1; i < len(p.clients); i++ {
+		if p.IsClientReady(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *clientPool[ClientType]) IsFallbackEnabled() bool {
+	return len(p.clients) > 1
+}
+
+func (p *clientPool[ClientType]) SetPrimaryReady(ready bool) {
+	p.SetClientReady(0, ready)
+}
+
+// Set whether all of the fallback clients are ready
+func (p *clientPool[ClientType]) SetFallbackReady(ready bool) {
+	for i := 1; i < len(p.clients); i++ {
+		p.SetClientReady(i, ready)
+	}
+}
+
+func (p *clientPool[ClientType]) GetPrimaryError() *ClientError {
+	return p.GetClientError(0)
+}
+
+// Get the last error from the first fallback client, or nil if it hasn't had one or there are no fallbacks
+func (p *clientPool[ClientType]) GetFallbackError() *ClientError {
+	if !p.IsFallbackEnabled() {
+		return nil
+	}
+	return p.GetClientError(1)
+}
+
+func (p *clientPool[ClientType]) GetPrimaryCircuitState() CircuitState {
+	return p.GetClientCircuitState(0)
+}
+
+// Get the state of the first fallback client's circuit breaker, or closed if there are no fallbacks
+func (p *clientPool[ClientType]) GetFallbackCircuitState() CircuitState {
+	if !p.IsFallbackEnabled() {
+		return CircuitState_Closed
+	}
+	return p.GetClientCircuitState(1)
+}
+
+/// =================
+/// Manager Functions
+/// =================
+
+// Pin the manager to its primary client or its fallbacks for the provided duration. While it's pinned, the clients
+// it's pinned away from won't be used or checked, so they can be taken down for maintenance without the manager
+// probing them.
+func (p *clientPool[ClientType]) PinClient(target types.ClientPinTarget, duration time.Duration) (types.ClientPin, error) {
+	return p.pin.set(target, duration, p.IsFallbackEnabled())
+}
+
+// Remove the manager's pin so it goes back to using the primary and falling back as normal
+func (p *clientPool[ClientType]) UnpinClient() {
+	p.pin.clear()
+}
+
+// Get the client the manager is pinned to
+func (p *clientPool[ClientType]) GetClientPin() types.ClientPin {
+	return p.pin.get()
+}
+
+// Set the settings for the circuit breakers that take the clients out of service when they fail. This resets the
+// state of every client's breaker.
+func (p *clientPool[ClientType]) SetCircuitBreakerSettings(settings config.CircuitBreakerSettings) {
+	for _, client := range p.clients {
+		client.breaker = NewCircuitBreaker(settings)
+	}
+}
+
+// Set the bus to publish an event to whenever the manager switches to a different client. This must be called before
+// the manager is used.
+func (p *clientPool[ClientType]) SetEventBus(bus *events.EventBus) {
+	p.eventBus = bus
+}
+
+// Check the status of each client that the manager isn't pinned away from, and update their health state
+func (p *clientPool[ClientType]) checkStatus(ctx context.Context, checkChainIDs bool, checkClient func(context.Context, ClientType, bool) types.ClientStatus) *types.ClientManagerStatus {
+	status := &types.ClientManagerStatus{
+		FallbackEnabled: p.IsFallbackEnabled(),
+	}
+	pin := p.pin.get()
+	if pin.Target != types.ClientPinTarget_None {
+		status.Pin = &pin
+	}
+
+	statuses := make([]types.ClientStatus, len(p.clients))
+	for i, client := range p.clients {
+		if p.pin.excludes(i) {
+			statuses[i] = getPinnedAwayStatus(pin)
+			continue
+		}
+
+		clientStatus := checkClient(ctx, client.client, checkChainIDs)
+		if checkChainIDs && clientStatus.Error == "" && clientStatus.ChainId != p.expectedChainID {
+			// Make sure the client is using the expected network
+			clientStatus.Error = fmt.Sprintf("The %s client is using a different chain (%d) than what your node is configured for (%d)", getClientName(i), clientStatus.ChainId, p.expectedChainID)
+			client.ready = false
+		} else {
+			client.ready = clientStatus.IsWorking && clientStatus.IsSynced
+		}
+		recordStatusCheck(client.breaker, clientStatus)
+		if clientStatus.Error != "" {
+			client.lastError = newClientError(errors.New(clientStatus.Error))
+		}
+		if health := p.GetClientHealth(i); !health.CheckTime.IsZero() {
+			clientStatus.HealthScore = &health.Score
+		}
+		statuses[i] = clientStatus
+	}
+
+	status.PrimaryClientStatus = statuses[0]
+	if status.FallbackEnabled {
+		status.FallbackClientStatus = statuses[1]
+		status.FallbackClientStatuses = statuses[1:]
+	}
+	return status
+}
+
+// Get the name of the client at the provided index, for logs and errors
+func getClientName(index int) string {
+	switch index {
+	case 0:
+		return "primary"
+	case 1:
+		return "fallback"
+	default:
+		return fmt.Sprintf("fallback %d", index)
+	}
+}
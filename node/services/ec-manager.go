@@ -10,80 +10,35 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	apitypes "github.com/rocket-pool/node-manager-core/api/types"
 	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils"
 )
 
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
+// The primary client is used whenever it's ready; otherwise each fallback is tried in order.
 type ExecutionClientManager struct {
-	primaryEc       eth.IExecutionClient
-	fallbackEc      eth.IExecutionClient
-	primaryReady    bool
-	fallbackReady   bool
-	expectedChainID uint
-	timeout         time.Duration
-	fallbackEnabled bool
+	*clientPool[eth.IExecutionClient]
 }
 
 // Creates a new ExecutionClientManager instance
 func NewExecutionClientManager(primaryEc eth.IExecutionClient, chainID uint, clientTimeout time.Duration) *ExecutionClientManager {
-	return &ExecutionClientManager{
-		primaryEc:       primaryEc,
-		primaryReady:    true,
-		fallbackReady:   false,
-		expectedChainID: chainID,
-		timeout:         clientTimeout,
-		fallbackEnabled: false,
-	}
+	return NewExecutionClientManagerWithFallbacks(primaryEc, nil, chainID, clientTimeout)
 }
 
 // Creates a new ExecutionClientManager instance that includes a fallback client
 func NewExecutionClientManagerWithFallback(primaryEc eth.IExecutionClient, fallbackEc eth.IExecutionClient, chainID uint, clientTimeout time.Duration) *ExecutionClientManager {
-	return &ExecutionClientManager{
-		primaryEc:       primaryEc,
-		fallbackEc:      fallbackEc,
-		primaryReady:    true,
-		fallbackReady:   true,
-		expectedChainID: chainID,
-		timeout:         clientTimeout,
-		fallbackEnabled: true,
-	}
-}
-
-/// ========================
-/// IClientManager Functions
-/// ========================
-
-func (m *ExecutionClientManager) GetPrimaryClient() eth.IExecutionClient {
-	return m.primaryEc
-}
-
-func (m *ExecutionClientManager) GetFallbackClient() eth.IExecutionClient {
-	return m.fallbackEc
-}
-
-func (m *ExecutionClientManager) IsPrimaryReady() bool {
-	return m.primaryReady
+	return NewExecutionClientManagerWithFallbacks(primaryEc, []eth.IExecutionClient{fallbackEc}, chainID, clientTimeout)
 }
 
-func (m *ExecutionClientManager) IsFallbackReady() bool {
-	return m.fallbackReady
-}
-
-func (m *ExecutionClientManager) IsFallbackEnabled() bool {
-	return m.fallbackEc != nil
-}
-
-func (m *ExecutionClientManager) GetClientTypeName() string {
-	return "Execution Client"
-}
-
-func (m *ExecutionClientManager) SetPrimaryReady(ready bool) {
-	m.primaryReady = ready
-}
-
-func (m *ExecutionClientManager) SetFallbackReady(ready bool) {
-	m.fallbackReady = ready
+// Creates a new ExecutionClientManager instance with any number of fallback clients, which are used in the order
+// they're provided
+func NewExecutionClientManagerWithFallbacks(primaryEc eth.IExecutionClient, fallbackEcs []eth.IExecutionClient, chainID uint, clientTimeout time.Duration) *ExecutionClientManager {
+	clients := append([]eth.IExecutionClient{primaryEc}, fallbackEcs...)
+	return &ExecutionClientManager{
+		clientPool: newClientPool("Execution Client", clients, chainID, clientTimeout, checkEcHealth),
+	}
 }
 
 /// ========================
@@ -155,6 +110,14 @@ func (m *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	})
 }
 
+// FeeHistory retrieves the base fees, gas usage ratios, and priority fee percentiles of a range of recent blocks
+// ending with lastBlock. If lastBlock is nil, the range ends with the latest block.
+func (m *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return runFunction1(m, ctx, func(client eth.IExecutionClient) (*ethereum.FeeHistory, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
@@ -265,41 +228,57 @@ func (m *ExecutionClientManager) ChainID(ctx context.Context) (*big.Int, error)
 
 // Get the status of the primary and fallback clients
 func (m *ExecutionClientManager) CheckStatus(ctx context.Context, checkChainIDs bool) *apitypes.ClientManagerStatus {
-	status := &apitypes.ClientManagerStatus{
-		FallbackEnabled: m.fallbackEnabled,
-	}
+	return m.checkStatus(ctx, checkChainIDs, checkEcStatus)
+}
 
-	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(ctx, m.primaryEc, checkChainIDs)
+// Get a client for the development node RPC methods (such as evm_mine and evm_snapshot) of whichever client is
+// currently active. Returns an error if that client isn't a supported development node like anvil or Hardhat.
+func (m *ExecutionClientManager) GetTestRpcClient(ctx context.Context) (*eth.TestRpcClient, error) {
+	return runFunction1(m, ctx, func(client eth.IExecutionClient) (*eth.TestRpcClient, error) {
+		return eth.NewTestRpcClient(ctx, client)
+	})
+}
 
-	// Check if primary is using the expected network
-	if checkChainIDs && status.PrimaryClientStatus.Error == "" && status.PrimaryClientStatus.ChainId != m.expectedChainID {
-		m.primaryReady = false
-		status.PrimaryClientStatus.Error = fmt.Sprintf("The primary client is using a different chain (%d) than what your node is configured for (%d)", status.PrimaryClientStatus.ChainId, m.expectedChainID)
-	} else {
-		// Flag if primary client is ready
-		m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	}
+// Send the requests to whichever client is currently active as JSON-RPC batches. If a batch fails, the requests are
+// retried on the next client. See eth.BatchCall for details.
+func (m *ExecutionClientManager) BatchCall(ctx context.Context, requests []rpc.BatchElem, maxBatchSize int) error {
+	return runFunction0(m, ctx, func(client eth.IExecutionClient) error {
+		return eth.BatchCall(ctx, client, requests, maxBatchSize)
+	})
+}
 
-	// Get the fallback EC status if applicable
-	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(ctx, m.fallbackEc, checkChainIDs)
-		// Check if fallback is using the expected network
-		if checkChainIDs && status.FallbackClientStatus.Error == "" && status.FallbackClientStatus.ChainId != m.expectedChainID {
-			m.fallbackReady = false
-			status.FallbackClientStatus.Error = fmt.Sprintf("The fallback client is using a different chain (%d) than what your node is configured for (%d)", status.FallbackClientStatus.ChainId, m.expectedChainID)
-			return status
-		}
-	}
+// Get the receipts for the transactions with the provided hashes using JSON-RPC batches. The receipt for a
+// transaction that hasn't been included yet will be nil.
+func (m *ExecutionClientManager) BatchGetTransactionReceipts(ctx context.Context, hashes []common.Hash, maxBatchSize int) ([]*types.Receipt, error) {
+	return runFunction1(m, ctx, func(client eth.IExecutionClient) ([]*types.Receipt, error) {
+		return eth.BatchGetTransactionReceipts(ctx, client, hashes, maxBatchSize)
+	})
+}
 
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
+// Get the ETH balances (in wei) of the provided addresses as of the provided block (or the latest block if it's nil)
+// using JSON-RPC batches
+func (m *ExecutionClientManager) BatchGetBalances(ctx context.Context, addresses []common.Address, blockNumber *big.Int, maxBatchSize int) ([]*big.Int, error) {
+	return runFunction1(m, ctx, func(client eth.IExecutionClient) ([]*big.Int, error) {
+		return eth.BatchGetBalances(ctx, client, addresses, blockNumber, maxBatchSize)
+	})
+}
 
-	return status
+// Get the parent Beacon block root that the EIP-4788 beacon roots contract recorded for the Execution block with the
+// provided timestamp, as of the provided block (or the latest block if it's nil). Returns false if the contract
+// doesn't have a root for the timestamp. See eth.GetParentBeaconBlockRoot for details.
+func (m *ExecutionClientManager) GetParentBeaconBlockRoot(ctx context.Context, timestamp uint64, blockNumber *big.Int) (common.Hash, bool, error) {
+	return runFunction2(m, ctx, func(client eth.IExecutionClient) (common.Hash, bool, error) {
+		return eth.GetParentBeaconBlockRoot(ctx, client, timestamp, blockNumber)
+	})
 }
 
 // Check the client status
 func checkEcStatus(ctx context.Context, client eth.IExecutionClient, checkChainIDs bool) apitypes.ClientStatus {
 	status := apitypes.ClientStatus{}
+	if statsProvider, ok := client.(utils.IEndpointStatsProvider); ok {
+		stats := statsProvider.GetEndpointStats()
+		status.Stats = &stats
+	}
 
 	if checkChainIDs {
 		// Get the Chain ID
@@ -328,7 +307,7 @@ func checkEcStatus(ctx context.Context, client eth.IExecutionClient, checkChainI
 	// Make sure it's up to date
 	if progress == nil {
 
-		isUpToDate, blockTime, err := IsSyncWithinThreshold(client)
+		isUpToDate, blockTime, err := IsSyncWithinThreshold(ctx, client)
 		if err != nil {
 			status.Error = fmt.Sprintf("Error checking if client's sync progress is up to date: [%s]", err.Error())
 			status.IsSynced = false
@@ -365,3 +344,20 @@ func checkEcStatus(ctx context.Context, client eth.IExecutionClient, checkChainI
 
 	return status
 }
+
+// Check the client's health for scoring; the head age is based on the timestamp of its latest block
+func checkEcHealth(ctx context.Context, client eth.IExecutionClient) (ClientHealth, error) {
+	progress, err := client.SyncProgress(ctx)
+	if err != nil {
+		return ClientHealth{}, fmt.Errorf("error getting sync progress: %w", err)
+	}
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return ClientHealth{}, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	return ClientHealth{
+		IsWorking: true,
+		IsSynced:  progress == nil,
+		HeadAge:   time.Since(time.Unix(int64(header.Time), 0)),
+	}, nil
+}
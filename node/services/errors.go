@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// An error returned by one of a manager's clients, and when it happened
+type ClientError struct {
+	Err  error
+	Time time.Time
+}
+
+// Creates a new client error that happened now
+func newClientError(err error) *ClientError {
+	return &ClientError{
+		Err:  err,
+		Time: time.Now(),
+	}
+}
+
+func (e *ClientError) Error() string {
+	return fmt.Sprintf("%s (at %s)", e.Err.Error(), e.Time.Format(time.RFC3339))
+}
+
+func (e *ClientError) Unwrap() error {
+	return e.Err
+}
+
+// Returned when a function couldn't be run on any of a manager's clients. It includes the last error from each
+// client so callers can show which one failed and why.
+type AllClientsFailedError struct {
+	// The name of the type of client, such as "Execution Client"
+	ClientTypeName string
+
+	// True if none of the clients were ready so the function wasn't attempted, false if they failed while running it
+	NoneReady bool
+
+	// The last error from the primary client, or nil if it hasn't had one
+	Primary *ClientError
+
+	// The last error from each fallback client in the order they're used, with nil entries for the ones that haven't
+	// had one
+	Fallbacks []*ClientError
+}
+
+func (e *AllClientsFailedError) Error() string {
+	var builder strings.Builder
+	if e.NoneReady {
+		builder.WriteString("no " + e.ClientTypeName + "s were ready")
+	} else {
+		builder.WriteString("all " + e.ClientTypeName + "s failed")
+	}
+
+	details := []string{}
+	for i, clientErr := range e.getClientErrors() {
+		if clientErr != nil {
+			details = append(details, getClientName(i)+": "+clientErr.Error())
+		}
+	}
+	if len(details) > 0 {
+		builder.WriteString(" [" + strings.Join(details, "; ") + "]")
+	}
+	return builder.String()
+}
+
+// The API server responds to these with the clients offline code, so callers can tell them apart from other errors
+func (e *AllClientsFailedError) GetErrorCode() types.ErrorCode {
+	return types.ErrorCode_ClientsOffline
+}
+
+func (e *AllClientsFailedError) Unwrap() []error {
+	errs := []error{}
+	for _, clientErr := range e.getClientErrors() {
+		if clientErr != nil {
+			errs = append(errs, clientErr)
+		}
+	}
+	return errs
+}
+
+// Get the errors for every client, with the primary first
+func (e *AllClientsFailedError) getClientErrors() []*ClientError {
+	return append([]*ClientError{e.Primary}, e.Fallbacks...)
+}
@@ -2,7 +2,7 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 
 	"github.com/rocket-pool/node-manager-core/log"
 )
@@ -18,53 +18,60 @@ type function2[ClientType any, ReturnType1 any, ReturnType2 any] func(ClientType
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 // Expects functions with 1 output and an error; for functions with other signatures, see the other runFunctionX functions.
+// Panics in the function are recovered and returned as a ClientPanicError.
+// Clients are tried in the manager's client order, which is the primary and then each fallback unless the manager picks
+// the healthiest client first, skipping any that aren't ready.
+// Connection failures are recorded on each client's circuit breaker, which takes the client out of service once they
+// pass its threshold; other errors mean the client was reached, so they're returned directly.
 func runFunction1[ClientType any, ReturnType any](m iClientManagerImpl[ClientType], ctx context.Context, function function1[ClientType, ReturnType]) (ReturnType, error) {
 	logger, _ := log.FromContext(ctx)
 	var blank ReturnType
 	typeName := m.GetClientTypeName()
+	clientCount := m.GetClientCount()
+	attempted := false
 
-	// Check if we can use the primary
-	if m.IsPrimaryReady() {
-		// Try to run the function on the primary
-		result, err := function(m.GetPrimaryClient())
-		if err != nil {
-			if isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.SetPrimaryReady(false)
-				if m.IsFallbackEnabled() {
-					logger.Warn("Primary "+typeName+" client disconnected, using fallback...", log.Err(err))
-					return runFunction1[ClientType, ReturnType](m, ctx, function)
-				} else {
-					logger.Warn("Primary "+typeName+" disconnected and no fallback is configured.", log.Err(err))
-					return blank, fmt.Errorf("all " + typeName + "s failed")
-				}
-			}
-			// If it's a different error, just return it
-			return blank, err
+	order := m.GetClientOrder()
+	for position, i := range order {
+		if !m.IsClientReady(i) {
+			continue
 		}
-		// If there's no error, return the result
-		return result, nil
-	}
 
-	if m.IsFallbackReady() {
-		// Try to run the function on the fallback
-		result, err := function(m.GetFallbackClient())
-		if err != nil {
-			if isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				logger.Warn("Fallback "+typeName+" disconnected", log.Err(err))
-				m.SetFallbackReady(false)
-				return blank, fmt.Errorf("all " + typeName + "s failed")
-			}
+		// Try to run the function on the client
+		attempted = true
+		result, err := runSafely(logger, typeName, m.GetClient(i), function)
+		if err == nil || !isDisconnected(err) {
+			// If there's no error or it's a different error, the client is still reachable
+			m.RecordClientSuccess(i)
+			return result, err
+		}
 
-			// If it's a different error, just return it
-			return blank, err
+		// If it's disconnected, log it and try the next client
+		m.RecordClientFailure(i, err)
+		clientAttr := slog.String(log.ClientKey, m.GetClientName(i))
+		switch {
+		case clientCount == 1:
+			logger.Warn(typeName+" disconnected and no fallback is configured.", clientAttr, log.Err(err))
+		case position < clientCount-1:
+			logger.Warn(typeName+" disconnected, trying the next client...", clientAttr, log.Err(err))
+		default:
+			logger.Warn(typeName+" disconnected", clientAttr, log.Err(err))
 		}
-		// If there's no error, return the result
-		return result, nil
 	}
 
-	return blank, fmt.Errorf("no " + typeName + "s were ready")
+	return blank, getAllClientsFailedError(m, !attempted)
+}
+
+// Create an error with the last error from each of the manager's clients
+func getAllClientsFailedError[ClientType any](m iClientManagerImpl[ClientType], noneReady bool) *AllClientsFailedError {
+	err := &AllClientsFailedError{
+		ClientTypeName: m.GetClientTypeName(),
+		NoneReady:      noneReady,
+		Primary:        m.GetClientError(0),
+	}
+	for i := 1; i < m.GetClientCount(); i++ {
+		err.Fallbacks = append(err.Fallbacks, m.GetClientError(i))
+	}
+	return err
 }
 
 // Run a function with 0 outputs and an error
@@ -0,0 +1,200 @@
+//go:build ignore
+
+// Generates the BeaconClientManager methods that pass each IBeaconApiProvider route through to the manager's clients
+// with fallback support. Run it with `go generate` from this directory whenever a route is added to the provider
+// interface; the manager won't compile until it's regenerated.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"strings"
+)
+
+const (
+	interfacePath string = "../../beacon/client/api-provider-interface.go"
+	interfaceName string = "IBeaconApiProvider"
+	outputPath    string = "bn-manager-passthrough.go"
+	clientPackage string = "client"
+)
+
+func main() {
+	err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error generating passthrough methods: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// Read the provider interface and write the passthrough methods for it
+func generate() error {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, interfacePath, nil, 0)
+	if err != nil {
+		return fmt.Errorf("error parsing [%s]: %w", interfacePath, err)
+	}
+	methods, err := getInterfaceMethods(file)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by gen-bn-passthrough.go. DO NOT EDIT.\n\n")
+	buffer.WriteString("package services\n\n")
+	buffer.WriteString("import (\n\t\"context\"\n\n\t\"github.com/rocket-pool/node-manager-core/beacon\"\n\t\"github.com/rocket-pool/node-manager-core/beacon/client\"\n)\n\n")
+	buffer.WriteString("// Make sure the manager covers every route in the provider interface\n")
+	fmt.Fprintf(&buffer, "var _ %s.%s = (*BeaconClientManager)(nil)\n", clientPackage, interfaceName)
+	for _, method := range methods {
+		err = writeMethod(&buffer, fileSet, method)
+		if err != nil {
+			return fmt.Errorf("error generating method %s: %w", method.Names[0].Name, err)
+		}
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting generated code: %w", err)
+	}
+	err = os.WriteFile(outputPath, source, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing [%s]: %w", outputPath, err)
+	}
+	return nil
+}
+
+// Get the methods of the provider interface
+func getInterfaceMethods(file *ast.File) ([]*ast.Field, error) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name != interfaceName {
+				continue
+			}
+			interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%s isn't an interface", interfaceName)
+			}
+			return interfaceType.Methods.List, nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found in [%s]", interfaceName, interfacePath)
+}
+
+// Write the passthrough method for an interface method
+func writeMethod(buffer *bytes.Buffer, fileSet *token.FileSet, method *ast.Field) error {
+	name := method.Names[0].Name
+	funcType := method.Type.(*ast.FuncType)
+
+	// Get the parameters
+	params := []string{}
+	args := []string{}
+	for i, param := range funcType.Params.List {
+		if len(param.Names) == 0 {
+			return fmt.Errorf("parameter %d doesn't have a name", i)
+		}
+		paramType, err := getTypeString(fileSet, param.Type)
+		if err != nil {
+			return err
+		}
+		for _, paramName := range param.Names {
+			params = append(params, paramName.Name+" "+paramType)
+			args = append(args, paramName.Name)
+		}
+	}
+	if len(args) == 0 || args[0] != "ctx" {
+		return fmt.Errorf("the first parameter must be ctx")
+	}
+
+	// Get the results, which must end with an error
+	results := []string{}
+	zeroValues := []string{}
+	for _, result := range funcType.Results.List {
+		resultType, err := getTypeString(fileSet, result.Type)
+		if err != nil {
+			return err
+		}
+		count := max(len(result.Names), 1)
+		for i := 0; i < count; i++ {
+			results = append(results, resultType)
+			zeroValues = append(zeroValues, getZeroValue(resultType))
+		}
+	}
+	if len(results) == 0 || results[len(results)-1] != "error" || len(results) > 3 {
+		return fmt.Errorf("methods must return up to 2 values and an error")
+	}
+	zeroValues = zeroValues[:len(zeroValues)-1]
+
+	// Write the method
+	resultList := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultList = "(" + resultList + ")"
+	}
+	fmt.Fprintf(buffer, "\nfunc (m *BeaconClientManager) %s(%s) %s {\n", name, strings.Join(params, ", "), resultList)
+	fmt.Fprintf(buffer, "\treturn runFunction%d(m, ctx, func(bc beacon.IBeaconClient) %s {\n", len(results)-1, resultList)
+	buffer.WriteString("\t\tprovider, err := getApiProvider(bc)\n")
+	buffer.WriteString("\t\tif err != nil {\n")
+	fmt.Fprintf(buffer, "\t\t\treturn %s\n", strings.Join(append(zeroValues, "err"), ", "))
+	buffer.WriteString("\t\t}\n")
+	fmt.Fprintf(buffer, "\t\treturn provider.%s(%s)\n", name, strings.Join(args, ", "))
+	buffer.WriteString("\t})\n}\n")
+	return nil
+}
+
+// Get the source for a type, qualifying the types declared in the client package
+func getTypeString(fileSet *token.FileSet, expr ast.Expr) (string, error) {
+	qualified := qualifyType(expr)
+	var buffer bytes.Buffer
+	err := printer.Fprint(&buffer, fileSet, qualified)
+	if err != nil {
+		return "", fmt.Errorf("error printing type: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// Qualify the exported identifiers in a type with the client package name
+func qualifyType(expr ast.Expr) ast.Expr {
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(typed.Name) {
+			return &ast.SelectorExpr{
+				X:   ast.NewIdent(clientPackage),
+				Sel: ast.NewIdent(typed.Name),
+			}
+		}
+		return typed
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyType(typed.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: typed.Len, Elt: qualifyType(typed.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualifyType(typed.Key), Value: qualifyType(typed.Value)}
+	default:
+		return expr
+	}
+}
+
+// Get the zero value for a result type
+func getZeroValue(resultType string) string {
+	switch {
+	case strings.HasPrefix(resultType, "*"), strings.HasPrefix(resultType, "[]"), strings.HasPrefix(resultType, "map["), resultType == "error":
+		return "nil"
+	case resultType == "bool":
+		return "false"
+	case resultType == "string":
+		return `""`
+	case strings.HasPrefix(resultType, "int"), strings.HasPrefix(resultType, "uint"):
+		return "0"
+	default:
+		return resultType + "{}"
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// How a manager picks which client to run a function on
+type ClientSelectionMode string
+
+const (
+	// Use the primary whenever it's ready, then each fallback in order
+	ClientSelectionMode_Ordered ClientSelectionMode = "ordered"
+
+	// Use the ready client with the best health score from the health-check loop. Clients that haven't been checked
+	// yet keep their configured order.
+	ClientSelectionMode_Healthiest ClientSelectionMode = "healthiest"
+)
+
+// Settings for scoring the health of a manager's clients. Scores start at 100 for a working, synced, responsive
+// client and go down from there; a client that couldn't be reached scores 0.
+type HealthScoringPolicy struct {
+	// The points a client loses if it's still syncing
+	UnsyncedPenalty float64
+
+	// The most points a client can lose for being slow to respond
+	LatencyPenalty float64
+
+	// The response time that costs a client the full latency penalty; faster responses cost proportionally less
+	MaxLatency time.Duration
+
+	// The most points a client can lose for failing requests; it loses the full penalty if all of its recent requests
+	// failed
+	FailurePenalty float64
+
+	// The most points a client can lose for having a stale head
+	StaleHeadPenalty float64
+
+	// How old a client's head can get before it starts losing points; it loses the full stale head penalty once its
+	// head is twice this old
+	StaleHeadAge time.Duration
+
+	// The points subtracted for each position a client is down the configured order, so clients earlier in the order
+	// are preferred unless a later one is clearly healthier. This keeps the manager from flapping between clients
+	// with similar scores.
+	OrderPenalty float64
+}
+
+// The scoring policy to use if none is configured
+var DefaultHealthScoringPolicy = HealthScoringPolicy{
+	UnsyncedPenalty:  50,
+	LatencyPenalty:   20,
+	MaxLatency:       2 * time.Second,
+	FailurePenalty:   40,
+	StaleHeadPenalty: 30,
+	StaleHeadAge:     time.Minute,
+	OrderPenalty:     5,
+}
+
+// The results of a health check on one of a manager's clients
+type ClientHealth struct {
+	// True if the client responded to the health check
+	IsWorking bool
+
+	// True if the client is synced
+	IsSynced bool
+
+	// How long the client takes to respond: the average latency of its recent requests if it tracks endpoint
+	// statistics, or how long it took to respond to the health check if it doesn't
+	Latency time.Duration
+
+	// The fraction of the client's recent requests that succeeded, from 0 to 1. This is 1 if it doesn't track
+	// endpoint statistics or hasn't made any requests yet.
+	SuccessRate float64
+
+	// How long ago the client's head block was produced
+	HeadAge time.Duration
+
+	// The client's score based on these results, from 0 to 100
+	Score float64
+
+	// When the check ran, or the zero time if the client hasn't been checked yet
+	CheckTime time.Time
+}
+
+// Get the score for the results of a health check
+func (p HealthScoringPolicy) getScore(health ClientHealth) float64 {
+	if !health.IsWorking {
+		return 0
+	}
+
+	score := 100.0
+	if !health.IsSynced {
+		score -= p.UnsyncedPenalty
+	}
+	if p.MaxLatency > 0 {
+		score -= p.LatencyPenalty * math.Min(float64(health.Latency)/float64(p.MaxLatency), 1)
+	}
+	score -= p.FailurePenalty * (1 - health.SuccessRate)
+	if p.StaleHeadAge > 0 && health.HeadAge > p.StaleHeadAge {
+		staleness := float64(health.HeadAge-p.StaleHeadAge) / float64(p.StaleHeadAge)
+		score -= p.StaleHeadPenalty * math.Min(staleness, 1)
+	}
+	return math.Max(score, 0)
+}
+
+/// =====================
+/// Client Pool Functions
+/// =====================
+
+// Set how the manager picks which client to run a function on
+func (p *clientPool[ClientType]) SetClientSelectionMode(mode ClientSelectionMode) {
+	p.healthLock.Lock()
+	defer p.healthLock.Unlock()
+	p.selectionMode = mode
+}
+
+// Set the policy for scoring the health of the clients
+func (p *clientPool[ClientType]) SetHealthScoringPolicy(policy HealthScoringPolicy) {
+	p.healthLock.Lock()
+	defer p.healthLock.Unlock()
+	p.scoringPolicy = policy
+	for _, client := range p.clients {
+		if !client.health.CheckTime.IsZero() {
+			client.health.Score = policy.getScore(client.health)
+		}
+	}
+}
+
+// Get the results of the latest health check on the client at the provided index
+func (p *clientPool[ClientType]) GetClientHealth(index int) ClientHealth {
+	p.healthLock.RLock()
+	defer p.healthLock.RUnlock()
+	return p.clients[index].health
+}
+
+// Get the indices of the clients in the order they'll be tried
+func (p *clientPool[ClientType]) GetClientOrder() []int {
+	order := make([]int, len(p.clients))
+	for i := range order {
+		order[i] = i
+	}
+
+	p.healthLock.RLock()
+	defer p.healthLock.RUnlock()
+	if p.selectionMode != ClientSelectionMode_Healthiest {
+		return order
+	}
+
+	// Clients that haven't been checked yet are treated as perfectly healthy so they keep their place
+	scores := make([]float64, len(p.clients))
+	for i, client := range p.clients {
+		score := 100.0
+		if !client.health.CheckTime.IsZero() {
+			score = client.health.Score
+		}
+		scores[i] = score - float64(i)*p.scoringPolicy.OrderPenalty
+	}
+	slices.SortStableFunc(order, func(a int, b int) int {
+		switch {
+		case scores[a] > scores[b]:
+			return -1
+		case scores[a] < scores[b]:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return order
+}
+
+// Start checking the health of the clients on the provided interval until the context is cancelled. The results are
+// used to pick clients when the selection mode is ClientSelectionMode_Healthiest. Clients that fail the check are
+// recorded on their circuit breakers like any other connection failure, and clients the manager is pinned away from
+// aren't checked.
+func (p *clientPool[ClientType]) StartHealthChecks(ctx context.Context, logger *slog.Logger, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			p.runHealthChecks(ctx, logger)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Check the health of each client the manager isn't pinned away from
+func (p *clientPool[ClientType]) runHealthChecks(ctx context.Context, logger *slog.Logger) {
+	for i, client := range p.clients {
+		if p.pin.excludes(i) || ctx.Err() != nil {
+			continue
+		}
+
+		checkCtx, cancel := ctx, context.CancelFunc(func() {})
+		if p.timeout > 0 {
+			checkCtx, cancel = context.WithTimeout(ctx, p.timeout)
+		}
+		start := time.Now()
+		health, err := p.checkHealth(checkCtx, client.client)
+		health.Latency = time.Since(start)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		health.CheckTime = time.Now()
+		if err != nil {
+			health = ClientHealth{
+				CheckTime: health.CheckTime,
+			}
+			logger.Debug("Client health check failed", slog.String(log.ClientKey, getClientName(i)), log.Err(err))
+			client.breaker.RecordFailure()
+		} else {
+			client.breaker.RecordSuccess()
+			applyEndpointStats(&health, client.client)
+		}
+
+		p.healthLock.Lock()
+		health.Score = p.scoringPolicy.getScore(health)
+		client.health = health
+		p.healthLock.Unlock()
+	}
+}
+
+// Replace a health check's response time with the client's recent endpoint statistics if it tracks them, since
+// they're based on many real requests instead of a single probe
+func applyEndpointStats(health *ClientHealth, client any) {
+	health.SuccessRate = 1
+	statsProvider, ok := client.(utils.IEndpointStatsProvider)
+	if !ok {
+		return
+	}
+	stats := statsProvider.GetEndpointStats()
+	if stats.Requests == 0 {
+		return
+	}
+	health.SuccessRate = stats.SuccessRate
+	if stats.LatencyAverage > 0 {
+		health.Latency = stats.LatencyAverage
+	}
+}
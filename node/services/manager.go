@@ -7,12 +7,52 @@ type IClientManager[ClientType any] interface {
 	IsFallbackReady() bool
 	IsFallbackEnabled() bool
 	GetClientTypeName() string
+
+	// Get the number of clients, including the primary
+	GetClientCount() int
+
+	// Get the client at the provided index; the primary is first, then the fallbacks in the order they're used
+	GetClient(index int) ClientType
+
+	// Get the name of the client at the provided index, such as "primary" or "fallback 2"
+	GetClientName(index int) string
+
+	// Check if the client at the provided index can be used
+	IsClientReady(index int) bool
+
+	// Get the last error from the client at the provided index, or nil if it hasn't had one
+	GetClientError(index int) *ClientError
+
+	// Get the state of the circuit breaker for the client at the provided index
+	GetClientCircuitState(index int) CircuitState
+
+	// Get the results of the latest health check on the client at the provided index
+	GetClientHealth(index int) ClientHealth
+
+	// Get the indices of the clients in the order they'll be tried
+	GetClientOrder() []int
+
+	// Get the number of times the manager has switched to a different client to serve requests, such as when
+	// falling back from the primary or returning to it
+	GetClientSwitchCount() uint64
+
+	// Get the last error from the primary client, or nil if it hasn't had one
+	GetPrimaryError() *ClientError
+
+	// Get the last error from the first fallback client, or nil if it hasn't had one
+	GetFallbackError() *ClientError
+
+	// Get the state of the primary client's circuit breaker
+	GetPrimaryCircuitState() CircuitState
+
+	// Get the state of the first fallback client's circuit breaker
+	GetFallbackCircuitState() CircuitState
 }
 
 type iClientManagerImpl[ClientType any] interface {
 	IClientManager[ClientType]
 
 	// Internal functions
-	SetPrimaryReady(bool)
-	SetFallbackReady(bool)
+	RecordClientSuccess(index int)
+	RecordClientFailure(index int, err error)
 }
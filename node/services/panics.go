@@ -0,0 +1,55 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Returned when a function panics while running on one of a manager's clients, such as when a client sends a
+// malformed response, so one bad call doesn't take down the daemon's task loops
+type ClientPanicError struct {
+	// The name of the type of client, such as "Execution Client"
+	ClientTypeName string
+
+	// The value the function panicked with
+	Value any
+
+	// The stack trace of the panic
+	Stack []byte
+}
+
+func (e *ClientPanicError) Error() string {
+	return fmt.Sprintf("%s function panicked: %v", e.ClientTypeName, e.Value)
+}
+
+// Unwrap the panic value if it's an error
+func (e *ClientPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Run a function on a client, recovering from any panic and returning it as a ClientPanicError. The stack trace is
+// logged if there's a logger.
+func runSafely[ClientType any, ReturnType any](logger *log.Logger, typeName string, client ClientType, function function1[ClientType, ReturnType]) (result ReturnType, err error) {
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+		panicErr := &ClientPanicError{
+			ClientTypeName: typeName,
+			Value:          value,
+			Stack:          debug.Stack(),
+		}
+		if logger != nil {
+			logger.Error("Recovered from panic in "+typeName+" function", slog.Any("panic", value), slog.String("stack", string(panicErr.Stack)))
+		}
+		var blank ReturnType
+		result = blank
+		err = panicErr
+	}()
+	return function(client)
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"path/filepath"
 	"runtime"
 	"time"
 
 	dclient "github.com/docker/docker/client"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/beacon/client"
 	"github.com/rocket-pool/node-manager-core/config"
 	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/events"
+	"github.com/rocket-pool/node-manager-core/features"
+	"github.com/rocket-pool/node-manager-core/gas"
 	"github.com/rocket-pool/node-manager-core/log"
 	"github.com/rocket-pool/node-manager-core/node/wallet"
+	"github.com/rocket-pool/node-manager-core/store"
+	"github.com/rocket-pool/node-manager-core/utils"
+	nmcwallet "github.com/rocket-pool/node-manager-core/wallet"
 )
 
 const (
@@ -64,6 +71,38 @@ type IWalletProvider interface {
 	GetWallet() *wallet.Wallet
 }
 
+// Provides access to the daemon's persistent state
+type IStoreProvider interface {
+	// Gets the key-value store for the daemon's persistent state
+	GetStore() store.IKeyValueStore
+}
+
+// Provides access to the address book used to label addresses in user-facing output
+type IAddressBookProvider interface {
+	// Gets the address book
+	GetAddressBook() *eth.AddressBook
+}
+
+// Provides access to the bus the daemon's services publish events to
+type IEventBusProvider interface {
+	// Gets the event bus. The transaction manager, client managers, and wallet publish to it; daemons can publish
+	// their own events too, and the API servers can stream them to clients.
+	GetEventBus() *events.EventBus
+}
+
+// Provides access to the daemon's feature flags
+type IFeatureFlagProvider interface {
+	// Gets the feature flag manager. Daemons register their flags with it on startup, and pass it the flags from
+	// their network settings whenever they're loaded.
+	GetFeatureFlags() *features.FeatureFlagManager
+}
+
+// Provides access to the gas oracles for suggesting transaction fees
+type IGasOracleProvider interface {
+	// Gets the gas oracle, which tries the network's configured oracles in order and caches their suggestions
+	GetGasOracle() *gas.CompositeGasOracle
+}
+
 // Provides access to a context for cancelling long operations upon daemon shutdown
 type IContextProvider interface {
 	// Gets a base context for the daemon that all operations can derive from
@@ -80,6 +119,11 @@ type IServiceProvider interface {
 	IDockerProvider
 	ILoggerProvider
 	IWalletProvider
+	IStoreProvider
+	IAddressBookProvider
+	IEventBusProvider
+	IFeatureFlagProvider
+	IGasOracleProvider
 	IContextProvider
 	io.Closer
 }
@@ -91,12 +135,17 @@ type IServiceProvider interface {
 // A container for all of the various services used by the node service
 type serviceProvider struct {
 	// Services
-	nodeWallet *wallet.Wallet
-	ecManager  *ExecutionClientManager
-	bcManager  *BeaconClientManager
-	docker     dclient.APIClient
-	txMgr      *eth.TransactionManager
-	queryMgr   *eth.QueryManager
+	nodeWallet  *wallet.Wallet
+	ecManager   *ExecutionClientManager
+	bcManager   *BeaconClientManager
+	docker      dclient.APIClient
+	txMgr       *eth.TransactionManager
+	queryMgr    *eth.QueryManager
+	store       store.IKeyValueStore
+	addressBook *eth.AddressBook
+	flags       *features.FeatureFlagManager
+	eventBus    *events.EventBus
+	gasOracle   *gas.CompositeGasOracle
 
 	// Context for cancelling long operations
 	ctx    context.Context
@@ -109,55 +158,157 @@ type serviceProvider struct {
 
 // Creates a new ServiceProvider instance based on the given config
 func NewServiceProvider(cfg config.IConfig, resources *config.NetworkResources, clientTimeout time.Duration) (IServiceProvider, error) {
+	// Set how outbound requests identify the daemon before any clients are created
+	err := utils.SetHttpIdentity(cfg.GetHttpIdentity())
+	if err != nil {
+		return nil, fmt.Errorf("error setting HTTP identity: %w", err)
+	}
+
 	// EC Manager
-	var ecManager *ExecutionClientManager
-	primaryEcUrl, fallbackEcUrl := cfg.GetExecutionClientUrls()
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	responseLimits := cfg.GetResponseLimitSettings()
+	primaryEcUrl, fallbackEcUrls := cfg.GetExecutionClientUrls()
+	primaryEcProxyOpts, fallbackEcProxyOpts := cfg.GetExecutionClientProxyOptions()
+	primaryEc, err := eth.DialWithStatsAndLimits(context.Background(), primaryEcUrl, primaryEcProxyOpts, responseLimits)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
-	if fallbackEcUrl != "" {
-		// Get the fallback EC url, if applicable
-		fallbackEc, err := ethclient.Dial(fallbackEcUrl)
+	fallbackEcs := make([]eth.IExecutionClient, len(fallbackEcUrls))
+	for i, fallbackEcUrl := range fallbackEcUrls {
+		fallbackEc, err := eth.DialWithStatsAndLimits(context.Background(), fallbackEcUrl, getFallbackProxyOptions(fallbackEcProxyOpts, i), responseLimits)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
-		ecManager = NewExecutionClientManagerWithFallback(primaryEc, fallbackEc, resources.ChainID, clientTimeout)
-	} else {
-		ecManager = NewExecutionClientManager(primaryEc, resources.ChainID, clientTimeout)
+		fallbackEcs[i] = fallbackEc
 	}
+	ecManager := NewExecutionClientManagerWithFallbacks(primaryEc, fallbackEcs, resources.ChainID, clientTimeout)
+	breakerSettings := cfg.GetCircuitBreakerSettings()
+	ecManager.SetCircuitBreakerSettings(breakerSettings)
 
 	// Beacon manager
-	var bcManager *BeaconClientManager
-	primaryBnUrl, fallbackBnUrl := cfg.GetBeaconNodeUrls()
-	primaryBc := client.NewStandardHttpClient(primaryBnUrl, clientTimeout)
-	if fallbackBnUrl != "" {
-		fallbackBc := client.NewStandardHttpClient(fallbackBnUrl, clientTimeout)
-		bcManager = NewBeaconClientManagerWithFallback(primaryBc, fallbackBc, resources.ChainID, clientTimeout)
-	} else {
-		bcManager = NewBeaconClientManager(primaryBc, resources.ChainID, clientTimeout)
+	primaryBnUrl, fallbackBnUrls := cfg.GetBeaconNodeUrls()
+	primaryBnProxyOpts, fallbackBnProxyOpts := cfg.GetBeaconNodeProxyOptions()
+	retrySettings := cfg.GetBeaconRetrySettings()
+	primaryBc := client.NewStandardHttpClientWithOptions(primaryBnUrl, clientTimeout, primaryBnProxyOpts)
+	primaryBc.SetRetrySettings(retrySettings)
+	primaryBc.SetResponseLimits(responseLimits)
+	fallbackBcs := make([]beacon.IBeaconClient, len(fallbackBnUrls))
+	for i, fallbackBnUrl := range fallbackBnUrls {
+		fallbackBc := client.NewStandardHttpClientWithOptions(fallbackBnUrl, clientTimeout, getFallbackProxyOptions(fallbackBnProxyOpts, i))
+		fallbackBc.SetRetrySettings(retrySettings)
+		fallbackBc.SetResponseLimits(responseLimits)
+		fallbackBcs[i] = fallbackBc
 	}
+	bcManager := NewBeaconClientManagerWithFallbacks(primaryBc, fallbackBcs, resources.ChainID, clientTimeout)
+	bcManager.SetCircuitBreakerSettings(breakerSettings)
+	if eth2Config, exists := resources.GetEth2Config(); exists {
+		depositContract, _ := resources.GetEth2DepositContract()
+		bcManager.SetSpecOverrides(&eth2Config, &depositContract)
+	}
+
+	// Context for handling task cancellation during shutdown
+	ctx, cancel := context.WithCancel(context.Background())
 
 	// Docker client
-	dockerClient, err := dclient.NewClientWithOpts(dclient.WithVersion(DockerApiVersion))
+	dockerClient, err := NewDockerClientWithContext(ctx)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("error creating Docker client: %w", err)
 	}
 
-	return NewServiceProviderWithCustomServices(cfg, resources, ecManager, bcManager, dockerClient)
+	provider, err := newServiceProviderImpl(ctx, cancel, cfg, resources, ecManager, bcManager, dockerClient)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return provider, nil
 }
 
 // Creates a new ServiceProvider instance with custom services instead of creating them from the config
 func NewServiceProviderWithCustomServices(cfg config.IConfig, resources *config.NetworkResources, ecManager *ExecutionClientManager, bcManager *BeaconClientManager, dockerClient dclient.APIClient) (IServiceProvider, error) {
+	// Context for handling task cancellation during shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	provider, err := newServiceProviderImpl(ctx, cancel, cfg, resources, ecManager, bcManager, dockerClient)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return provider, nil
+}
+
+// Creates a Docker client whose requests are cancelled when the context is, even if the caller's own context isn't
+func NewDockerClientWithContext(ctx context.Context) (*dclient.Client, error) {
+	return newDockerClientWithContext(ctx, dclient.DefaultDockerHost)
+}
+
+// Creates a Docker client for the daemon at the provided host, with its requests tied to the context
+func newDockerClientWithContext(ctx context.Context, host string) (*dclient.Client, error) {
+	httpClient := &http.Client{
+		Transport:     &http.Transport{},
+		CheckRedirect: dclient.CheckRedirect,
+	}
+	dockerClient, err := dclient.NewClientWithOpts(
+		dclient.WithHTTPClient(httpClient),
+		dclient.WithHost(host),
+		dclient.WithVersion(DockerApiVersion),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Docker client has wrapped the transport by now, so wrap it again to tie its requests to the context
+	httpClient.Transport = &utils.ContextTransport{
+		Base:    httpClient.Transport,
+		Context: ctx,
+	}
+	return dockerClient, nil
+}
+
+// Get the proxy options for a fallback client, or empty options if the config doesn't have any for it
+func getFallbackProxyOptions(options []utils.ProxyOptions, index int) utils.ProxyOptions {
+	if index < len(options) {
+		return options[index]
+	}
+	return utils.ProxyOptions{}
+}
+
+// Creates the service provider with the base context its services are cancelled with
+func newServiceProviderImpl(ctx context.Context, cancel context.CancelFunc, cfg config.IConfig, resources *config.NetworkResources, ecManager *ExecutionClientManager, bcManager *BeaconClientManager, dockerClient dclient.APIClient) (*serviceProvider, error) {
+	// Event bus for publishing what the services do
+	eventBus := events.NewEventBus()
+	ecManager.SetEventBus(eventBus)
+	bcManager.SetEventBus(eventBus)
+
+	// Close everything that was opened if any of the services can't be created
+	var apiLogger *log.Logger
+	var tasksLogger *log.Logger
+	var kvStore store.IKeyValueStore
+	succeeded := false
+	defer func() {
+		if succeeded {
+			return
+		}
+		if kvStore != nil {
+			_ = kvStore.Close()
+		}
+		if tasksLogger != nil {
+			tasksLogger.Close()
+		}
+		if apiLogger != nil {
+			apiLogger.Close()
+		}
+		eventBus.Close()
+	}()
+
 	// Make the API logger
 	loggerOpts := cfg.GetLoggerOptions()
-	apiLogger, err := log.NewLogger(cfg.GetApiLogFilePath(), loggerOpts)
+	var err error
+	apiLogger, err = log.NewLogger(cfg.GetApiLogFilePath(), loggerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("error creating API logger: %w", err)
 	}
 
 	// Make the tasks logger
-	tasksLogger, err := log.NewLogger(cfg.GetTasksLogFilePath(), loggerOpts)
+	tasksLogger, err = log.NewLogger(cfg.GetTasksLogFilePath(), loggerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("error creating tasks logger: %w", err)
 	}
@@ -165,17 +316,29 @@ func NewServiceProviderWithCustomServices(cfg config.IConfig, resources *config.
 	// Wallet
 	nodeAddressPath := filepath.Join(cfg.GetNodeAddressFilePath())
 	walletDataPath := filepath.Join(cfg.GetWalletFilePath())
-	passwordPath := filepath.Join(cfg.GetPasswordFilePath())
-	nodeWallet, err := wallet.NewWallet(tasksLogger.Logger, walletDataPath, nodeAddressPath, passwordPath, resources.ChainID)
+	passwordSource := cfg.GetPasswordSourceSettings()
+	if (passwordSource.Type == "" || passwordSource.Type == nmcwallet.PasswordSourceType_File) && passwordSource.FilePath == "" {
+		passwordSource.FilePath = filepath.Join(cfg.GetPasswordFilePath())
+	}
+	nodeWallet, err := wallet.NewWalletWithPasswordSource(ctx, tasksLogger.Logger, walletDataPath, nodeAddressPath, passwordSource, resources.ChainID, nmcwallet.DefaultKdfSettings)
 	if err != nil {
 		return nil, fmt.Errorf("error creating node wallet: %w", err)
 	}
+	nodeWallet.SetEventBus(eventBus)
 
 	// TX Manager
 	txMgr, err := eth.NewTransactionManager(ecManager, eth.DefaultSafeGasBuffer, eth.DefaultSafeGasMultiplier)
 	if err != nil {
 		return nil, fmt.Errorf("error creating transaction manager: %w", err)
 	}
+	txMgr.SetBaseContext(ctx)
+	txMgr.SetEventBus(eventBus)
+
+	// Gas oracle
+	gasOracle, err := gas.NewGasOracle(resources.GasOracles, ecManager)
+	if err != nil {
+		return nil, fmt.Errorf("error creating gas oracle: %w", err)
+	}
 
 	// Query Manager - set the default concurrent run limit to half the CPUs so the EC doesn't get overwhelmed
 	concurrentCallLimit := runtime.NumCPU() / 2
@@ -184,8 +347,33 @@ func NewServiceProviderWithCustomServices(cfg config.IConfig, resources *config.
 	}
 	queryMgr := eth.NewQueryManager(ecManager, resources.MulticallAddress, concurrentCallLimit)
 
-	// Context for handling task cancellation during shutdown
-	ctx, cancel := context.WithCancel(context.Background())
+	// Key-value store
+	storePath := cfg.GetStoreFilePath()
+	if storePath == "" {
+		kvStore = store.NewMemoryStore()
+	} else {
+		boltStore, err := store.NewBoltStore(storePath)
+		if err != nil {
+			return nil, fmt.Errorf("error creating key-value store: %w", err)
+		}
+		kvStore = boltStore
+	}
+
+	// Address book
+	addressBook, err := eth.NewAddressBook(kvStore)
+	if err != nil {
+		return nil, fmt.Errorf("error creating address book: %w", err)
+	}
+
+	// Feature flags
+	rolloutKey, err := features.LoadRolloutKey(kvStore)
+	if err != nil {
+		return nil, err
+	}
+	flags, err := features.NewFeatureFlagManager(cfg.GetFeatureFlagConfig(), rolloutKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating feature flag manager: %w", err)
+	}
 
 	// Log startup
 	apiLogger.Info("Starting API logger.")
@@ -199,11 +387,17 @@ func NewServiceProviderWithCustomServices(cfg config.IConfig, resources *config.
 		docker:      dockerClient,
 		txMgr:       txMgr,
 		queryMgr:    queryMgr,
+		store:       kvStore,
+		addressBook: addressBook,
+		flags:       flags,
+		eventBus:    eventBus,
+		gasOracle:   gasOracle,
 		ctx:         ctx,
 		cancel:      cancel,
 		apiLogger:   apiLogger,
 		tasksLogger: tasksLogger,
 	}
+	succeeded = true
 	return provider, nil
 }
 
@@ -211,7 +405,8 @@ func NewServiceProviderWithCustomServices(cfg config.IConfig, resources *config.
 func (p *serviceProvider) Close() error {
 	p.apiLogger.Close()
 	p.tasksLogger.Close()
-	return nil
+	p.eventBus.Close()
+	return p.store.Close()
 }
 
 // ===============
@@ -250,6 +445,26 @@ func (p *serviceProvider) GetTasksLogger() *log.Logger {
 	return p.tasksLogger
 }
 
+func (p *serviceProvider) GetStore() store.IKeyValueStore {
+	return p.store
+}
+
+func (p *serviceProvider) GetAddressBook() *eth.AddressBook {
+	return p.addressBook
+}
+
+func (p *serviceProvider) GetEventBus() *events.EventBus {
+	return p.eventBus
+}
+
+func (p *serviceProvider) GetFeatureFlags() *features.FeatureFlagManager {
+	return p.flags
+}
+
+func (p *serviceProvider) GetGasOracle() *gas.CompositeGasOracle {
+	return p.gasOracle
+}
+
 func (p *serviceProvider) GetBaseContext() context.Context {
 	return p.ctx
 }
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDockerClientStopsWhenCancelled(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dockerClient, err := newDockerClientWithContext(ctx, "tcp://"+strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dockerClient.Close()
+
+	// The caller's context is never cancelled, so only the base context can stop the request
+	go func() {
+		<-started
+		cancel()
+	}()
+	start := time.Now()
+	_, err = dockerClient.ServerVersion(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the Docker request took %s to stop after cancellation", elapsed)
+	}
+}
@@ -15,8 +15,8 @@ const (
 )
 
 // Confirm the EC's latest block is within the threshold of the current system clock
-func IsSyncWithinThreshold(ec eth.IExecutionClient) (bool, time.Time, error) {
-	timestamp, err := GetEthClientLatestBlockTimestamp(ec)
+func IsSyncWithinThreshold(ctx context.Context, ec eth.IExecutionClient) (bool, time.Time, error) {
+	timestamp, err := GetEthClientLatestBlockTimestamp(ctx, ec)
 	if err != nil {
 		return false, time.Time{}, err
 	}
@@ -30,9 +30,9 @@ func IsSyncWithinThreshold(ec eth.IExecutionClient) (bool, time.Time, error) {
 	return false, blockTime, nil
 }
 
-func GetEthClientLatestBlockTimestamp(ec eth.IExecutionClient) (uint64, error) {
+func GetEthClientLatestBlockTimestamp(ctx context.Context, ec eth.IExecutionClient) (uint64, error) {
 	// Get latest block
-	header, err := ec.HeaderByNumber(context.Background(), nil)
+	header, err := ec.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// A function run by the scheduler
+type TaskFunction func(ctx context.Context) error
+
+// A task registered with the scheduler
+type scheduledTask struct {
+	name     string
+	trigger  ITrigger
+	function TaskFunction
+}
+
+// Runs tasks when their triggers fire. Each task runs in its own goroutine, so a slow task doesn't delay the others;
+// a task's next run is scheduled once its current run finishes, so runs of the same task never overlap.
+type Scheduler struct {
+	logger *slog.Logger
+	tasks  []*scheduledTask
+	lock   sync.Mutex
+}
+
+// Creates a new scheduler
+func NewScheduler(logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		tasks:  []*scheduledTask{},
+	}
+}
+
+// Add a task to the scheduler. Tasks must be added before the scheduler is started.
+func (s *Scheduler) AddTask(name string, trigger ITrigger, function TaskFunction) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, task := range s.tasks {
+		if task.name == name {
+			return fmt.Errorf("task [%s] is already scheduled", name)
+		}
+	}
+	s.tasks = append(s.tasks, &scheduledTask{
+		name:     name,
+		trigger:  trigger,
+		function: function,
+	})
+	return nil
+}
+
+// Start running the scheduled tasks until the context is cancelled
+func (s *Scheduler) Start(ctx context.Context, wg *sync.WaitGroup) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func(task *scheduledTask) {
+			defer wg.Done()
+			s.runTask(ctx, task)
+		}(task)
+	}
+}
+
+// Run a task each time its trigger fires until the context is cancelled
+func (s *Scheduler) runTask(ctx context.Context, task *scheduledTask) {
+	logger := s.logger.With(slog.String(log.TaskKey, task.name))
+	for {
+		now := time.Now()
+		next := task.trigger.GetNextRunTime(now)
+		logger.Debug("Scheduled next run.", slog.Time(log.NextRunKey, next))
+		if utils.SleepWithCancel(ctx, next.Sub(now)) {
+			return
+		}
+
+		err := task.function(ctx)
+		if err != nil {
+			logger.Error("Error running task.", log.Err(err))
+		}
+	}
+}
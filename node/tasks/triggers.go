@@ -0,0 +1,149 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// Determines when a scheduled task should run
+type ITrigger interface {
+	// Get the next time the task should run, which must be after the provided time
+	GetNextRunTime(now time.Time) time.Time
+}
+
+// =======================
+// === IntervalTrigger ===
+// =======================
+
+// Runs a task at a fixed wall-clock interval, starting from when the scheduler starts
+type IntervalTrigger struct {
+	interval time.Duration
+}
+
+// Creates a new interval trigger
+func NewIntervalTrigger(interval time.Duration) (*IntervalTrigger, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	return &IntervalTrigger{
+		interval: interval,
+	}, nil
+}
+
+// Get the next time the task should run
+func (t *IntervalTrigger) GetNextRunTime(now time.Time) time.Time {
+	return now.Add(t.interval)
+}
+
+// ====================
+// === EpochTrigger ===
+// ====================
+
+// Runs a task at a fixed offset into each epoch, aligned to chain time
+type EpochTrigger struct {
+	eth2Config beacon.Eth2Config
+	offset     time.Duration
+}
+
+// Creates a new trigger that runs at the provided offset after the start of each epoch
+func NewEpochTrigger(eth2Config beacon.Eth2Config, offset time.Duration) (*EpochTrigger, error) {
+	if eth2Config.SecondsPerSlot == 0 || eth2Config.SlotsPerEpoch == 0 {
+		return nil, fmt.Errorf("Beacon config has an invalid epoch length")
+	}
+	period := time.Duration(eth2Config.SecondsPerSlot*eth2Config.SlotsPerEpoch) * time.Second
+	if offset < 0 || offset >= period {
+		return nil, fmt.Errorf("offset %s must be within an epoch (%s)", offset, period)
+	}
+	return &EpochTrigger{
+		eth2Config: eth2Config,
+		offset:     offset,
+	}, nil
+}
+
+// Get the next time the task should run
+func (t *EpochTrigger) GetNextRunTime(now time.Time) time.Time {
+	first := t.eth2Config.EpochStartTime(0).Add(t.offset)
+	if now.Before(first) {
+		return first
+	}
+	epoch := t.eth2Config.EpochAt(now.Add(-t.offset))
+	return t.eth2Config.EpochStartTime(epoch + 1).Add(t.offset)
+}
+
+// ===================
+// === SlotTrigger ===
+// ===================
+
+// Runs a task at a fixed offset into each slot, aligned to chain time
+type SlotTrigger struct {
+	eth2Config beacon.Eth2Config
+	offset     time.Duration
+}
+
+// Creates a new trigger that runs at the provided offset after the start of each slot
+func NewSlotTrigger(eth2Config beacon.Eth2Config, offset time.Duration) (*SlotTrigger, error) {
+	if eth2Config.SecondsPerSlot == 0 {
+		return nil, fmt.Errorf("Beacon config has an invalid slot length")
+	}
+	period := time.Duration(eth2Config.SecondsPerSlot) * time.Second
+	if offset < 0 || offset >= period {
+		return nil, fmt.Errorf("offset %s must be within a slot (%s)", offset, period)
+	}
+	return &SlotTrigger{
+		eth2Config: eth2Config,
+		offset:     offset,
+	}, nil
+}
+
+// Get the next time the task should run
+func (t *SlotTrigger) GetNextRunTime(now time.Time) time.Time {
+	first := t.eth2Config.SlotStartTime(0).Add(t.offset)
+	if now.Before(first) {
+		return first
+	}
+	slot := t.eth2Config.SlotAt(now.Add(-t.offset))
+	return t.eth2Config.SlotStartTime(slot + 1).Add(t.offset)
+}
+
+// ====================
+// === DailyTrigger ===
+// ====================
+
+// Runs a task once per day at a time of day in a specific timezone
+type DailyTrigger struct {
+	hour     int
+	minute   int
+	location *time.Location
+}
+
+// Creates a new trigger that runs once per day at the provided time of day. Daylight saving changes are
+// respected, so the task runs at the same local time each day. Use time.Local for the system's timezone.
+func NewDailyTrigger(hour int, minute int, location *time.Location) (*DailyTrigger, error) {
+	if hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("hour %d must be between 0 and 23", hour)
+	}
+	if minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("minute %d must be between 0 and 59", minute)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("location is required")
+	}
+	return &DailyTrigger{
+		hour:     hour,
+		minute:   minute,
+		location: location,
+	}, nil
+}
+
+// Get the next time the task should run
+func (t *DailyTrigger) GetNextRunTime(now time.Time) time.Time {
+	local := now.In(t.location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), t.hour, t.minute, 0, 0, t.location)
+	if !next.After(now) {
+		// Rebuild the time for the next day instead of adding 24 hours, in case of a daylight saving change
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, t.hour, t.minute, 0, 0, t.location)
+	}
+	return next
+}
@@ -0,0 +1,208 @@
+package timesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils/sys"
+)
+
+// Settings for checking the system clock
+type CheckerSettings struct {
+	// The NTP servers to compare the system clock against, in the order they're tried. Leave this empty to skip the
+	// NTP check.
+	NtpServers []string
+
+	// The time limit for each NTP query
+	NtpTimeout time.Duration
+
+	// The largest offset from NTP time the system clock can have before the checker raises an alert
+	MaxNtpOffset time.Duration
+
+	// The largest number of slots the Beacon node's head can be from the slot the system clock says it is before the
+	// checker raises an alert. Missed blocks put the head behind the current slot, so this should allow for a few.
+	MaxSlotDrift uint64
+}
+
+// The time sync settings to use if none are configured
+var DefaultCheckerSettings = CheckerSettings{
+	NtpServers:   []string{"pool.ntp.org", "time.cloudflare.com"},
+	NtpTimeout:   5 * time.Second,
+	MaxNtpOffset: 500 * time.Millisecond,
+	MaxSlotDrift: 4,
+}
+
+// The results of the latest time sync check
+type TimeSyncStatus struct {
+	// The time of the check
+	CheckTime time.Time `json:"checkTime"`
+
+	// The NTP server the system clock was compared against, or an empty string if none could be reached
+	NtpServer string `json:"ntpServer"`
+
+	// The offset of the system clock from NTP time; a positive offset means the system clock is behind
+	NtpOffset time.Duration `json:"ntpOffset"`
+
+	// The error from the NTP check, if it failed
+	NtpError string `json:"ntpError"`
+
+	// The slot the system clock says it is, based on the Beacon chain's genesis time
+	LocalSlot uint64 `json:"localSlot"`
+
+	// The head slot of the Beacon node
+	BeaconHeadSlot uint64 `json:"beaconHeadSlot"`
+
+	// The number of slots the Beacon node's head is ahead of (positive) or behind (negative) the local slot
+	SlotDrift int64 `json:"slotDrift"`
+
+	// The error from the Beacon node check, if it failed or was skipped
+	BeaconError string `json:"beaconError"`
+
+	// True if the system clock is too far off from one of the references
+	IsDrifting bool `json:"isDrifting"`
+
+	// Why the system clock is considered to be drifting
+	Reason string `json:"reason"`
+}
+
+// Checks the system clock against NTP time and against the Beacon chain's slot clock, since a skewed clock makes
+// validators attest and propose at the wrong times without any obvious errors. Run Check on a schedule, such as with
+// a tasks.Scheduler.
+type TimeSyncChecker struct {
+	logger   *slog.Logger
+	bc       beacon.IBeaconClient
+	settings CheckerSettings
+	status   *TimeSyncStatus
+	lock     sync.Mutex
+}
+
+// Creates a new time sync checker. The Beacon client managed by the service provider can be used directly.
+func NewTimeSyncChecker(logger *slog.Logger, bc beacon.IBeaconClient, settings CheckerSettings) *TimeSyncChecker {
+	return &TimeSyncChecker{
+		logger:   logger,
+		bc:       bc,
+		settings: settings,
+	}
+}
+
+// Compare the system clock with the references and update the status, logging a warning if it's drifting. Returns an
+// error if none of the references could be checked.
+func (c *TimeSyncChecker) Check(ctx context.Context) error {
+	status := TimeSyncStatus{
+		CheckTime: time.Now(),
+	}
+	reasons := []string{}
+
+	// Check against NTP
+	ntpErr := c.checkNtp(ctx, &status)
+	if ntpErr != nil {
+		status.NtpError = ntpErr.Error()
+	} else if absDuration(status.NtpOffset) > c.settings.MaxNtpOffset {
+		reasons = append(reasons, fmt.Sprintf("system clock is %s off from NTP server [%s]", status.NtpOffset, status.NtpServer))
+	}
+
+	// Check against the Beacon chain's slot clock
+	beaconErr := c.checkBeacon(ctx, &status)
+	if beaconErr != nil {
+		status.BeaconError = beaconErr.Error()
+	} else if uint64(absInt(status.SlotDrift)) > c.settings.MaxSlotDrift {
+		reasons = append(reasons, fmt.Sprintf("Beacon node head is %d slots from the slot the system clock says it is", status.SlotDrift))
+	}
+
+	if len(reasons) > 0 {
+		status.IsDrifting = true
+		status.Reason = strings.Join(reasons, "; ")
+		c.logger.Warn("System clock is out of sync; this will hurt validator performance.", slog.String(log.CauseKey, status.Reason))
+	}
+
+	c.lock.Lock()
+	c.status = &status
+	c.lock.Unlock()
+
+	if ntpErr != nil && beaconErr != nil {
+		return fmt.Errorf("error checking time sync: %w", errors.Join(ntpErr, beaconErr))
+	}
+	return nil
+}
+
+// Get the results of the latest check, or false if it hasn't run yet
+func (c *TimeSyncChecker) GetStatus() (TimeSyncStatus, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.status == nil {
+		return TimeSyncStatus{}, false
+	}
+	return *c.status, true
+}
+
+// Get the system clock's offset from the first NTP server that responds
+func (c *TimeSyncChecker) checkNtp(ctx context.Context, status *TimeSyncStatus) error {
+	if len(c.settings.NtpServers) == 0 {
+		return fmt.Errorf("no NTP servers are configured")
+	}
+	errs := []error{}
+	for _, server := range c.settings.NtpServers {
+		offset, err := sys.QueryNtpOffset(ctx, server, c.settings.NtpTimeout)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		status.NtpServer = server
+		status.NtpOffset = offset
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// Compare the Beacon node's head slot with the slot the system clock says it is. This is skipped while the Beacon
+// node is syncing, since its head won't be near the current slot.
+func (c *TimeSyncChecker) checkBeacon(ctx context.Context, status *TimeSyncStatus) error {
+	if c.bc == nil {
+		return fmt.Errorf("no Beacon client is configured")
+	}
+	config, err := c.bc.GetEth2Config(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	if config.SecondsPerSlot == 0 {
+		return fmt.Errorf("Beacon config has an invalid slot length")
+	}
+	syncStatus, err := c.bc.GetSyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Beacon node sync status: %w", err)
+	}
+	if syncStatus.Syncing {
+		return fmt.Errorf("Beacon node is syncing")
+	}
+
+	if status.CheckTime.Before(config.GenesisTimestamp()) {
+		return fmt.Errorf("system clock is before the Beacon chain's genesis time")
+	}
+	status.LocalSlot = config.SlotAt(status.CheckTime)
+	status.BeaconHeadSlot = syncStatus.HeadSlot
+	status.SlotDrift = int64(syncStatus.HeadSlot) - int64(status.LocalSlot)
+	return nil
+}
+
+// Get the absolute value of a duration
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Get the absolute value of an integer
+func absInt(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
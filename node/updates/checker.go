@@ -0,0 +1,113 @@
+package updates
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"golang.org/x/mod/semver"
+)
+
+// Convert a version string to canonical semantic version form with a v prefix, such as v1.2.3 or v1.2.3-rc.1. Build
+// metadata is dropped since it doesn't affect ordering.
+func NormalizeVersion(version string) (string, error) {
+	trimmed := strings.TrimSpace(version)
+	if !strings.HasPrefix(trimmed, "v") {
+		trimmed = "v" + trimmed
+	}
+	if !semver.IsValid(trimmed) {
+		return "", fmt.Errorf("[%s] isn't a semantic version", version)
+	}
+	return semver.Canonical(trimmed), nil
+}
+
+// Compare two semantic versions, with or without v prefixes. Returns -1 if a is older than b, 0 if they're the same,
+// or 1 if a is newer than b.
+func CompareVersions(a string, b string) (int, error) {
+	normalizedA, err := NormalizeVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	normalizedB, err := NormalizeVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return semver.Compare(normalizedA, normalizedB), nil
+}
+
+// Checks a release source for versions newer than the running daemon, so it can tell operators when an update is
+// available. Run Check on a schedule, such as with a tasks.Scheduler.
+type UpdateChecker struct {
+	logger          *slog.Logger
+	currentVersion  string
+	source          IReleaseSource
+	status          *types.UpdateStatus
+	notifiedVersion string
+	lock            sync.Mutex
+}
+
+// Creates a new update checker for a daemon running the provided version
+func NewUpdateChecker(logger *slog.Logger, currentVersion string, source IReleaseSource) (*UpdateChecker, error) {
+	version, err := NormalizeVersion(currentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current version: %w", err)
+	}
+	return &UpdateChecker{
+		logger:         logger,
+		currentVersion: version,
+		source:         source,
+	}, nil
+}
+
+// Get the latest release from the source and update the status. The first time a newer release is found, a warning
+// is logged (or an error, if the release is critical).
+func (c *UpdateChecker) Check(ctx context.Context) error {
+	status := types.UpdateStatus{
+		CheckTime:      time.Now(),
+		CurrentVersion: c.currentVersion,
+	}
+	release, err := c.source.GetLatestRelease(ctx)
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.LatestRelease = &release
+		status.IsUpdateAvailable = semver.Compare(release.Version, c.currentVersion) > 0
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.status = &status
+	if err != nil {
+		return fmt.Errorf("error checking for updates: %w", err)
+	}
+
+	if status.IsUpdateAvailable && release.Version != c.notifiedVersion {
+		c.notifiedVersion = release.Version
+		attrs := []any{
+			slog.String(log.CurrentVersionKey, c.currentVersion),
+			slog.String(log.LatestVersionKey, release.Version),
+			slog.String(log.UrlKey, release.Url),
+		}
+		if release.IsCritical {
+			c.logger.Error("A critical update is available; please update as soon as possible.", attrs...)
+		} else {
+			c.logger.Warn("An update is available.", attrs...)
+		}
+	}
+	return nil
+}
+
+// Get the results of the latest check, or false if it hasn't run yet
+func (c *UpdateChecker) GetStatus() (types.UpdateStatus, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.status == nil {
+		return types.UpdateStatus{}, false
+	}
+	return *c.status, true
+}
@@ -0,0 +1,185 @@
+package updates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/rocket-pool/node-manager-core/utils/artifacts"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// The default timeout for each release source request
+	DefaultReleaseSourceTimeout time.Duration = 30 * time.Second
+
+	// The suffix appended to a manifest URL to get its minisign signature
+	MinisignSignatureSuffix string = ".minisig"
+
+	// The base URL of the GitHub REST API
+	githubApiUrl string = "https://api.github.com"
+
+	// The number of GitHub releases to look through for the latest one
+	githubReleasesPerPage int = 30
+
+	// The largest response a release source can send
+	maxReleaseResponseSize int64 = 4 * 1024 * 1024
+)
+
+// A source of release information for a daemon
+type IReleaseSource interface {
+	// Get the latest release
+	GetLatestRelease(ctx context.Context) (types.ReleaseInfo, error)
+}
+
+// ==============
+// === GitHub ===
+// ==============
+
+// A release as reported by the GitHub API
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	HtmlUrl     string    `json:"html_url"`
+	Body        string    `json:"body"`
+	PublishedAt time.Time `json:"published_at"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+}
+
+// Gets releases from a GitHub repository. Releases with tags that aren't semantic versions are ignored.
+type GithubReleaseSource struct {
+	// The repository to check, in owner/name form
+	Repository string
+
+	// Whether pre-releases count as the latest release
+	IncludePrereleases bool
+
+	// The timeout for each request; if 0, DefaultReleaseSourceTimeout is used
+	Timeout time.Duration
+}
+
+// Get the release with the highest version
+func (s *GithubReleaseSource) GetLatestRelease(ctx context.Context) (types.ReleaseInfo, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=%d", githubApiUrl, s.Repository, githubReleasesPerPage)
+	body, err := download(ctx, url, s.Timeout)
+	if err != nil {
+		return types.ReleaseInfo{}, fmt.Errorf("error getting releases of [%s]: %w", s.Repository, err)
+	}
+	var releases []githubRelease
+	err = json.Unmarshal(body, &releases)
+	if err != nil {
+		return types.ReleaseInfo{}, fmt.Errorf("error deserializing releases of [%s]: %w", s.Repository, err)
+	}
+
+	var latest *types.ReleaseInfo
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && !s.IncludePrereleases) {
+			continue
+		}
+		version, err := NormalizeVersion(release.TagName)
+		if err != nil {
+			continue
+		}
+		if latest != nil && semver.Compare(version, latest.Version) <= 0 {
+			continue
+		}
+		latest = &types.ReleaseInfo{
+			Version:      version,
+			Url:          release.HtmlUrl,
+			Notes:        release.Body,
+			PublishedAt:  release.PublishedAt,
+			IsPrerelease: release.Prerelease,
+		}
+	}
+	if latest == nil {
+		return types.ReleaseInfo{}, fmt.Errorf("repository [%s] doesn't have any releases with a semantic version", s.Repository)
+	}
+	return *latest, nil
+}
+
+// ================
+// === Manifest ===
+// ================
+
+// Gets the latest release from a JSON manifest hosted at a custom URL, which has the same fields as ReleaseInfo
+type ManifestReleaseSource struct {
+	// The URL of the manifest
+	Url string
+
+	// An optional minisign public key; if set, the manifest must have a valid signature at its URL with
+	// MinisignSignatureSuffix appended.
+	MinisignPublicKey string
+
+	// The timeout for each request; if 0, DefaultReleaseSourceTimeout is used
+	Timeout time.Duration
+}
+
+// Download the manifest, verify its signature, and get the release it describes
+func (s *ManifestReleaseSource) GetLatestRelease(ctx context.Context) (types.ReleaseInfo, error) {
+	// Parse the public key first so a bad key doesn't cost a download
+	var publicKey artifacts.MinisignPublicKey
+	var err error
+	if s.MinisignPublicKey != "" {
+		publicKey, err = artifacts.ParseMinisignPublicKey(s.MinisignPublicKey)
+		if err != nil {
+			return types.ReleaseInfo{}, fmt.Errorf("error parsing release manifest public key: %w", err)
+		}
+	}
+
+	data, err := download(ctx, s.Url, s.Timeout)
+	if err != nil {
+		return types.ReleaseInfo{}, fmt.Errorf("error downloading release manifest: %w", err)
+	}
+	if s.MinisignPublicKey != "" {
+		signatureUrl := s.Url + MinisignSignatureSuffix
+		signature, err := download(ctx, signatureUrl, s.Timeout)
+		if err != nil {
+			return types.ReleaseInfo{}, fmt.Errorf("error downloading release manifest signature: %w", err)
+		}
+		_, err = artifacts.VerifyMinisignSignature(data, signature, publicKey)
+		if err != nil {
+			return types.ReleaseInfo{}, fmt.Errorf("error verifying release manifest signature: %w", err)
+		}
+	}
+
+	var release types.ReleaseInfo
+	err = json.Unmarshal(data, &release)
+	if err != nil {
+		return types.ReleaseInfo{}, fmt.Errorf("error deserializing release manifest: %w", err)
+	}
+	release.Version, err = NormalizeVersion(release.Version)
+	if err != nil {
+		return types.ReleaseInfo{}, fmt.Errorf("release manifest has an invalid version: %w", err)
+	}
+	return release, nil
+}
+
+// Download the contents of a URL
+func download(ctx context.Context, url string, timeout time.Duration) ([]byte, error) {
+	if timeout == 0 {
+		timeout = DefaultReleaseSourceTimeout
+	}
+	client := utils.NewIdentityHttpClient(timeout)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading [%s]: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading [%s]: HTTP status %d", url, response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxReleaseResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", url, err)
+	}
+	return data, nil
+}
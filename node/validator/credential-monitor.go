@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/node/tasks"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The exit epoch of validators that haven't started exiting (FAR_FUTURE_EPOCH)
+	farFutureEpoch uint64 = math.MaxUint64
+
+	// Withdrawal credential prefixes that end with an execution address
+	eth1AddressWithdrawalPrefix byte = 0x01
+	compoundingWithdrawalPrefix byte = 0x02
+)
+
+// A kind of unexpected change to a watched validator
+type CredentialAlertKind string
+
+const (
+	// The validator's withdrawal credentials changed since the last check
+	CredentialAlertKind_CredentialsChanged CredentialAlertKind = "credentials_changed"
+
+	// The validator's withdrawal credentials don't match the ones it was expected to have
+	CredentialAlertKind_UnexpectedCredentials CredentialAlertKind = "unexpected_credentials"
+
+	// The validator's withdrawal credentials point to an address that isn't on the allowed list
+	CredentialAlertKind_UnknownWithdrawalAddress CredentialAlertKind = "unknown_withdrawal_address"
+
+	// The validator started exiting since the last check
+	CredentialAlertKind_ExitInitiated CredentialAlertKind = "exit_initiated"
+
+	// The validator was slashed since the last check
+	CredentialAlertKind_Slashed CredentialAlertKind = "slashed"
+)
+
+// An unexpected change to a watched validator
+type CredentialAlert struct {
+	Pubkey   beacon.ValidatorPubkey
+	Index    string
+	Kind     CredentialAlertKind
+	Epoch    uint64
+	Previous string
+	Current  string
+	Message  string
+}
+
+// Called for each alert the monitor raises
+type CredentialAlertHandler func(alert CredentialAlert)
+
+// A validator being watched by the monitor
+type watchedValidator struct {
+	expectedCredentials *common.Hash
+	lastStatus          *beacon.ValidatorStatus
+}
+
+// Watches validators' withdrawal credentials and exit status each epoch, raising alerts when they change
+// unexpectedly (such as credentials being rotated to an unknown address).
+type CredentialMonitor struct {
+	bc               beacon.IBeaconClient
+	logger           *slog.Logger
+	handler          CredentialAlertHandler
+	watched          map[beacon.ValidatorPubkey]*watchedValidator
+	allowedAddresses map[common.Address]bool
+	lock             sync.Mutex
+}
+
+// Creates a new credential monitor. The handler is optional; alerts are always logged as warnings.
+func NewCredentialMonitor(bc beacon.IBeaconClient, logger *slog.Logger, handler CredentialAlertHandler) *CredentialMonitor {
+	return &CredentialMonitor{
+		bc:               bc,
+		logger:           logger,
+		handler:          handler,
+		watched:          map[beacon.ValidatorPubkey]*watchedValidator{},
+		allowedAddresses: map[common.Address]bool{},
+	}
+}
+
+// Start watching a validator. If expected credentials are provided, an alert is raised whenever the validator's
+// withdrawal credentials don't match them.
+func (m *CredentialMonitor) Watch(pubkey beacon.ValidatorPubkey, expectedCredentials *common.Hash) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.watched[pubkey] = &watchedValidator{
+		expectedCredentials: expectedCredentials,
+	}
+}
+
+// Stop watching a validator
+func (m *CredentialMonitor) Unwatch(pubkey beacon.ValidatorPubkey) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.watched, pubkey)
+}
+
+// Set the addresses that the watched validators are allowed to withdraw to. If any are set, an alert is raised for
+// validators with execution address credentials that point anywhere else.
+func (m *CredentialMonitor) SetAllowedWithdrawalAddresses(addresses []common.Address) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.allowedAddresses = map[common.Address]bool{}
+	for _, address := range addresses {
+		m.allowedAddresses[address] = true
+	}
+}
+
+// Check the watched validators once, raising alerts for any unexpected changes since the last check.
+// The first check of a validator establishes its baseline, so it only raises alerts if the validator is already
+// slashed or its credentials are unexpected or point to an unknown address.
+func (m *CredentialMonitor) Check(ctx context.Context) ([]CredentialAlert, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(m.watched) == 0 {
+		return []CredentialAlert{}, nil
+	}
+	head, err := m.bc.GetBeaconHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	pubkeys := make([]beacon.ValidatorPubkey, 0, len(m.watched))
+	for pubkey := range m.watched {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	statuses, err := m.bc.GetValidatorStatuses(ctx, pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+
+	alerts := []CredentialAlert{}
+	for _, pubkey := range pubkeys {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		watched := m.watched[pubkey]
+		alerts = append(alerts, m.checkValidator(pubkey, head.Epoch, watched, status)...)
+		watched.lastStatus = &status
+	}
+
+	for _, alert := range alerts {
+		m.logger.Warn("Unexpected validator change.",
+			slog.String(log.PubkeyKey, alert.Pubkey.HexWithPrefix()),
+			slog.String(log.ValidatorIndexKey, alert.Index),
+			slog.Uint64(log.EpochKey, alert.Epoch),
+			slog.String(log.CauseKey, alert.Message),
+		)
+		if m.handler != nil {
+			m.handler(alert)
+		}
+	}
+	return alerts, nil
+}
+
+// Check the watched validators at the start of every epoch until the context is cancelled.
+// Errors from individual checks are logged and don't stop the monitor.
+func (m *CredentialMonitor) Run(ctx context.Context) error {
+	eth2Config, err := m.bc.GetEth2Config(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	trigger, err := tasks.NewEpochTrigger(eth2Config, 0)
+	if err != nil {
+		return err
+	}
+	for {
+		_, err := m.Check(ctx)
+		if err != nil {
+			m.logger.Warn("Error checking watched validators.", log.Err(err))
+		}
+		now := time.Now()
+		if utils.SleepWithCancel(ctx, trigger.GetNextRunTime(now).Sub(now)) {
+			return nil
+		}
+	}
+}
+
+// Compare a validator's current status to its previous one and what's expected of it
+func (m *CredentialMonitor) checkValidator(pubkey beacon.ValidatorPubkey, epoch uint64, watched *watchedValidator, status beacon.ValidatorStatus) []CredentialAlert {
+	alerts := []CredentialAlert{}
+	newAlert := func(kind CredentialAlertKind, previous string, current string, message string) {
+		alerts = append(alerts, CredentialAlert{
+			Pubkey:   pubkey,
+			Index:    status.Index,
+			Kind:     kind,
+			Epoch:    epoch,
+			Previous: previous,
+			Current:  current,
+			Message:  message,
+		})
+	}
+
+	previous := watched.lastStatus
+	credentials := status.WithdrawalCredentials
+	credentialsChanged := previous == nil || previous.WithdrawalCredentials != credentials
+	if previous != nil && credentialsChanged {
+		newAlert(CredentialAlertKind_CredentialsChanged, previous.WithdrawalCredentials.Hex(), credentials.Hex(),
+			fmt.Sprintf("withdrawal credentials changed from %s to %s", previous.WithdrawalCredentials.Hex(), credentials.Hex()))
+	}
+	if credentialsChanged {
+		if watched.expectedCredentials != nil && *watched.expectedCredentials != credentials {
+			newAlert(CredentialAlertKind_UnexpectedCredentials, watched.expectedCredentials.Hex(), credentials.Hex(),
+				fmt.Sprintf("withdrawal credentials are %s but %s was expected", credentials.Hex(), watched.expectedCredentials.Hex()))
+		}
+		if len(m.allowedAddresses) > 0 && (credentials[0] == eth1AddressWithdrawalPrefix || credentials[0] == compoundingWithdrawalPrefix) {
+			address := common.BytesToAddress(credentials[12:])
+			if !m.allowedAddresses[address] {
+				newAlert(CredentialAlertKind_UnknownWithdrawalAddress, "", address.Hex(),
+					fmt.Sprintf("withdrawal credentials point to %s, which is not an allowed withdrawal address", address.Hex()))
+			}
+		}
+	}
+
+	if previous != nil && previous.ExitEpoch == farFutureEpoch && status.ExitEpoch != farFutureEpoch {
+		newAlert(CredentialAlertKind_ExitInitiated, string(previous.Status), string(status.Status),
+			fmt.Sprintf("validator started exiting and will exit in epoch %d", status.ExitEpoch))
+	}
+	if (previous == nil || !previous.Slashed) && status.Slashed {
+		previousStatus := ""
+		if previous != nil {
+			previousStatus = string(previous.Status)
+		}
+		newAlert(CredentialAlertKind_Slashed, previousStatus, string(status.Status), "validator has been slashed")
+	}
+	return alerts
+}
@@ -0,0 +1,116 @@
+package dvt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/node/validator/keystore"
+	"github.com/rocket-pool/node-manager-core/utils"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+const (
+	// The folder in each charon node's data directory that holds its key shares
+	charonValidatorKeysDir string = "validator_keys"
+)
+
+// A validator split into key shares for an Obol cluster
+type CharonValidator struct {
+	// The validator's pubkey, which the cluster signs for
+	Pubkey beacon.ValidatorPubkey `json:"pubkey"`
+
+	// The pubkeys of each node's share, in node order, as recorded in the cluster lock's public_shares
+	PublicShares []beacon.ValidatorPubkey `json:"publicShares"`
+}
+
+// Get the number of charon nodes that must sign together for a cluster of the provided size; this is the threshold
+// charon itself uses
+func GetCharonThreshold(nodeCount int) int {
+	return (2*nodeCount + 2) / 3
+}
+
+// Split validator keys into shares for an Obol cluster with the provided number of nodes and write them to the output
+// directory as node0, node1, etc., the way `charon create cluster --split-existing-keys` does. Each node folder holds
+// a validator_keys directory with a keystore-N.json and keystore-N.txt password file per validator, in the same order
+// as the keys, which can be copied into the node's .charon directory.
+func ExportCharonKeyShares(keys []*eth2types.BLSPrivateKey, nodeCount int, outputDir string) ([]CharonValidator, error) {
+	if nodeCount < 1 {
+		return nil, fmt.Errorf("cluster must have at least 1 node")
+	}
+	threshold := GetCharonThreshold(nodeCount)
+	indices := make([]uint64, nodeCount)
+	for i := range indices {
+		indices[i] = uint64(i + 1)
+	}
+
+	// Create the node folders
+	nodeDirs := make([]string, nodeCount)
+	for i := range nodeDirs {
+		nodeDirs[i] = filepath.Join(outputDir, fmt.Sprintf("node%d", i), charonValidatorKeysDir)
+		err := os.MkdirAll(nodeDirs[i], keystore.DirMode)
+		if err != nil {
+			return nil, fmt.Errorf("error creating key share folder [%s]: %w", nodeDirs[i], err)
+		}
+	}
+
+	// Split each key and write its shares
+	encryptor := eth2ks.New()
+	validators := make([]CharonValidator, len(keys))
+	for i, key := range keys {
+		pubkey := beacon.ValidatorPubkey(key.PublicKey().Marshal())
+		shares, err := SplitKey(key, threshold, indices)
+		if err != nil {
+			return nil, fmt.Errorf("error splitting key for validator %s: %w", pubkey.HexWithPrefix(), err)
+		}
+		publicShares := make([]beacon.ValidatorPubkey, nodeCount)
+		for j, share := range shares {
+			publicShares[j] = beacon.ValidatorPubkey(share.Key.PublicKey().Marshal())
+			err = writeCharonKeystore(encryptor, share.Key, nodeDirs[j], i)
+			if err != nil {
+				return nil, fmt.Errorf("error writing share %d of validator %s: %w", share.Index, pubkey.HexWithPrefix(), err)
+			}
+		}
+		validators[i] = CharonValidator{
+			Pubkey:       pubkey,
+			PublicShares: publicShares,
+		}
+	}
+	return validators, nil
+}
+
+// Write a key share to a charon node's key folder as an EIP-2335 keystore and its password file
+func writeCharonKeystore(encryptor *eth2ks.Encryptor, key *eth2types.BLSPrivateKey, dir string, validatorIndex int) error {
+	password, err := utils.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("error generating random password: %w", err)
+	}
+	encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("error encrypting key share: %w", err)
+	}
+	keystoreBytes, err := json.Marshal(beacon.ValidatorKeystore{
+		Crypto:  encryptedKey,
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Pubkey:  beacon.ValidatorPubkey(key.PublicKey().Marshal()),
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding key share: %w", err)
+	}
+
+	baseName := filepath.Join(dir, fmt.Sprintf("keystore-%d", validatorIndex))
+	err = os.WriteFile(baseName+".txt", []byte(password), keystore.FileMode)
+	if err != nil {
+		return fmt.Errorf("error writing key share password: %w", err)
+	}
+	err = os.WriteFile(baseName+".json", keystoreBytes, keystore.FileMode)
+	if err != nil {
+		return fmt.Errorf("error writing key share: %w", err)
+	}
+	return nil
+}
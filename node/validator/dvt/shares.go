@@ -0,0 +1,137 @@
+package dvt
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/node-manager-core/node/validator"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// The length of a serialized BLS private key
+	blsPrivateKeyLength int = 32
+)
+
+// The order of the BLS12-381 scalar field, which key shares are computed in
+var blsCurveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// A share of a validator key, created by splitting the key with Shamir's secret sharing
+type KeyShare struct {
+	// The point the share was evaluated at. Obol numbers shares from 1 to the number of nodes in the cluster, and SSV
+	// uses the ID of the operator the share is for.
+	Index uint64
+
+	// The share itself, which is a regular BLS key that signs partial signatures
+	Key *eth2types.BLSPrivateKey
+}
+
+// Split a validator key into shares at the provided indices, any threshold of which can sign (or recover the key)
+// together. The shares are checked by recovering the key from them before they're returned.
+func SplitKey(key *eth2types.BLSPrivateKey, threshold int, indices []uint64) ([]KeyShare, error) {
+	if threshold < 1 || threshold > len(indices) {
+		return nil, fmt.Errorf("threshold must be between 1 and the number of shares (%d), but it was %d", len(indices), threshold)
+	}
+	seen := map[uint64]bool{}
+	for _, index := range indices {
+		if index == 0 {
+			return nil, fmt.Errorf("share indices can't be 0, since that's where the key itself is")
+		}
+		if seen[index] {
+			return nil, fmt.Errorf("share index %d is used more than once", index)
+		}
+		seen[index] = true
+	}
+	err := validator.InitializeBls()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing BLS library: %w", err)
+	}
+
+	// Build a random polynomial of degree threshold-1 with the key as its constant term
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = new(big.Int).SetBytes(key.Marshal())
+	for i := 1; i < threshold; i++ {
+		coefficients[i], err = rand.Int(rand.Reader, blsCurveOrder)
+		if err != nil {
+			return nil, fmt.Errorf("error generating polynomial coefficient: %w", err)
+		}
+	}
+
+	// Evaluate it at each index
+	shares := make([]KeyShare, len(indices))
+	for i, index := range indices {
+		x := new(big.Int).SetUint64(index)
+		value := new(big.Int)
+		for j := threshold - 1; j >= 0; j-- {
+			value.Mul(value, x)
+			value.Add(value, coefficients[j])
+			value.Mod(value, blsCurveOrder)
+		}
+		shareKey, err := scalarToPrivateKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("error creating share %d: %w", index, err)
+		}
+		shares[i] = KeyShare{
+			Index: index,
+			Key:   shareKey,
+		}
+	}
+
+	// Make sure the key can be recovered from the shares
+	recoveredKey, err := RecoverKey(shares[:threshold])
+	if err != nil {
+		return nil, fmt.Errorf("error checking key shares: %w", err)
+	}
+	if new(big.Int).SetBytes(recoveredKey.Marshal()).Cmp(coefficients[0]) != 0 {
+		return nil, fmt.Errorf("key recovered from the shares doesn't match the original key")
+	}
+	return shares, nil
+}
+
+// Recover a validator key from its shares. At least the threshold number of shares the key was split with must be
+// provided; if fewer are, the recovered key will be wrong.
+func RecoverKey(shares []KeyShare) (*eth2types.BLSPrivateKey, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("no shares were provided")
+	}
+	err := validator.InitializeBls()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing BLS library: %w", err)
+	}
+
+	// Interpolate the polynomial at 0 with Lagrange's formula
+	key := new(big.Int)
+	for i, share := range shares {
+		xi := new(big.Int).SetUint64(share.Index)
+		numerator := big.NewInt(1)
+		denominator := big.NewInt(1)
+		for j, other := range shares {
+			if i == j {
+				continue
+			}
+			if other.Index == share.Index {
+				return nil, fmt.Errorf("share index %d is used more than once", share.Index)
+			}
+			xj := new(big.Int).SetUint64(other.Index)
+			numerator.Mul(numerator, xj)
+			numerator.Mod(numerator, blsCurveOrder)
+			denominator.Mul(denominator, new(big.Int).Sub(xj, xi))
+			denominator.Mod(denominator, blsCurveOrder)
+		}
+		term := new(big.Int).SetBytes(share.Key.Marshal())
+		term.Mul(term, numerator)
+		term.Mul(term, new(big.Int).ModInverse(denominator, blsCurveOrder))
+		key.Add(key, term)
+		key.Mod(key, blsCurveOrder)
+	}
+	return scalarToPrivateKey(key)
+}
+
+// Convert a scalar into a BLS private key
+func scalarToPrivateKey(value *big.Int) (*eth2types.BLSPrivateKey, error) {
+	if value.Sign() == 0 {
+		return nil, fmt.Errorf("key is zero")
+	}
+	return eth2types.BLSPrivateKeyFromBytes(value.FillBytes(make([]byte, blsPrivateKeyLength)))
+}
@@ -0,0 +1,187 @@
+package dvt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/node-manager-core/utils"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// The keyshares file format version produced by BuildSsvKeyShares, matching ssv-keys
+	SsvKeySharesVersion string = "v1.1.0"
+)
+
+// An SSV operator that will run a share of a validator
+type SsvOperator struct {
+	// The operator's ID in the SSV network contract
+	ID uint64
+
+	// The operator's RSA public key, as registered in the SSV network contract (a base64-encoded PEM)
+	PublicKey string
+}
+
+// A keyshares file, which the SSV web app and contracts accept for registering validators
+type SsvKeySharesFile struct {
+	Version   string         `json:"version"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Shares    []SsvKeyShares `json:"shares"`
+}
+
+// The key shares of a single validator in a keyshares file
+type SsvKeyShares struct {
+	Data    SsvKeySharesData    `json:"data"`
+	Payload SsvKeySharesPayload `json:"payload"`
+}
+
+// Information about a validator's key shares
+type SsvKeySharesData struct {
+	OwnerNonce   uint64                 `json:"ownerNonce"`
+	OwnerAddress common.Address         `json:"ownerAddress"`
+	PublicKey    string                 `json:"publicKey"`
+	Operators    []SsvKeySharesOperator `json:"operators"`
+}
+
+// An operator that holds one of a validator's key shares
+type SsvKeySharesOperator struct {
+	ID          uint64 `json:"id"`
+	OperatorKey string `json:"operatorKey"`
+}
+
+// The arguments for registering a validator with the SSV network contract
+type SsvKeySharesPayload struct {
+	PublicKey   string        `json:"publicKey"`
+	OperatorIDs []uint64      `json:"operatorIds"`
+	SharesData  hexutil.Bytes `json:"sharesData"`
+}
+
+// Get the number of SSV operators that must sign together for a cluster of the provided size. SSV clusters tolerate
+// (n-1)/3 faulty operators, so only sizes of 3f+1 are allowed.
+func GetSsvThreshold(operatorCount int) (int, error) {
+	if operatorCount < 4 || (operatorCount-1)%3 != 0 {
+		return 0, fmt.Errorf("SSV clusters must have 3f+1 operators (4, 7, 10, ...), but %d were provided", operatorCount)
+	}
+	return operatorCount - (operatorCount-1)/3, nil
+}
+
+// Split validator keys into shares for the provided SSV operators and build the keyshares file used to register them.
+// Each registration uses up one of the owner's nonces in the SSV network contract, so the owner's current nonce must be
+// provided; the keys are assigned sequential nonces starting from it, in order.
+func BuildSsvKeyShares(keys []*eth2types.BLSPrivateKey, operators []SsvOperator, owner common.Address, ownerNonce uint64) (*SsvKeySharesFile, error) {
+	threshold, err := GetSsvThreshold(len(operators))
+	if err != nil {
+		return nil, err
+	}
+
+	// SSV expects operators in order of their IDs, and uses the IDs as the share indices
+	operators = slices.Clone(operators)
+	slices.SortFunc(operators, func(first SsvOperator, second SsvOperator) int {
+		switch {
+		case first.ID < second.ID:
+			return -1
+		case first.ID > second.ID:
+			return 1
+		default:
+			return 0
+		}
+	})
+	indices := make([]uint64, len(operators))
+	operatorKeys := make([]*rsa.PublicKey, len(operators))
+	fileOperators := make([]SsvKeySharesOperator, len(operators))
+	for i, operator := range operators {
+		indices[i] = operator.ID
+		operatorKeys[i], err = ParseSsvOperatorKey(operator.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key of operator %d: %w", operator.ID, err)
+		}
+		fileOperators[i] = SsvKeySharesOperator{
+			ID:          operator.ID,
+			OperatorKey: operator.PublicKey,
+		}
+	}
+
+	file := &SsvKeySharesFile{
+		Version:   SsvKeySharesVersion,
+		CreatedAt: time.Now().UTC(),
+		Shares:    make([]SsvKeyShares, len(keys)),
+	}
+	for i, key := range keys {
+		nonce := ownerNonce + uint64(i)
+		pubkey := utils.EncodeHexWithPrefix(key.PublicKey().Marshal())
+		shares, err := SplitKey(key, threshold, indices)
+		if err != nil {
+			return nil, fmt.Errorf("error splitting key for validator %s: %w", pubkey, err)
+		}
+
+		// The shares data is the owner signature, then the share pubkeys, then the encrypted shares
+		sharesData := getSsvOwnerSignature(key, owner, nonce)
+		for _, share := range shares {
+			sharesData = append(sharesData, share.Key.PublicKey().Marshal()...)
+		}
+		for j, share := range shares {
+			encryptedShare, err := rsa.EncryptPKCS1v15(rand.Reader, operatorKeys[j], []byte(utils.EncodeHexWithPrefix(share.Key.Marshal())))
+			if err != nil {
+				return nil, fmt.Errorf("error encrypting share of validator %s for operator %d: %w", pubkey, share.Index, err)
+			}
+			sharesData = append(sharesData, encryptedShare...)
+		}
+
+		file.Shares[i] = SsvKeyShares{
+			Data: SsvKeySharesData{
+				OwnerNonce:   nonce,
+				OwnerAddress: owner,
+				PublicKey:    pubkey,
+				Operators:    fileOperators,
+			},
+			Payload: SsvKeySharesPayload{
+				PublicKey:   pubkey,
+				OperatorIDs: indices,
+				SharesData:  sharesData,
+			},
+		}
+	}
+	return file, nil
+}
+
+// Parse an SSV operator's public key, which is a base64-encoded PEM of an RSA key
+func ParseSsvOperatorKey(operatorKey string) (*rsa.PublicKey, error) {
+	pemBytes, err := base64.StdEncoding.DecodeString(operatorKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding base64: %w", err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("key isn't a PEM block")
+	}
+
+	// Operator keys are normally PKCS #1, but accept PKIX too
+	key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+	if err == nil {
+		return key, nil
+	}
+	genericKey, pkixErr := x509.ParsePKIXPublicKey(block.Bytes)
+	if pkixErr != nil {
+		return nil, fmt.Errorf("error parsing RSA public key: %w", err)
+	}
+	key, isRsa := genericKey.(*rsa.PublicKey)
+	if !isRsa {
+		return nil, fmt.Errorf("key is a %T, not an RSA key", genericKey)
+	}
+	return key, nil
+}
+
+// Sign the owner and nonce with the validator key, proving the owner controls the key being registered
+func getSsvOwnerSignature(key *eth2types.BLSPrivateKey, owner common.Address, nonce uint64) []byte {
+	message := crypto.Keccak256([]byte(fmt.Sprintf("%s:%d", owner.Hex(), nonce)))
+	return key.Sign(message).Marshal()
+}
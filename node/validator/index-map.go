@@ -0,0 +1,218 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/store"
+)
+
+const (
+	// The store bucket that holds validator indices, keyed by pubkey
+	validatorIndexBucket string = "validator-indices"
+)
+
+// A persistent map between validator pubkeys and their indices on the Beacon chain. A validator's index never changes
+// once it's assigned, so each pubkey only has to be resolved by the Beacon Node once; after that, status queries can
+// look validators up by index, which is much cheaper for the Beacon Node than looking them up by pubkey. Pubkeys are
+// resolved lazily the first time they're requested.
+type ValidatorIndexMap struct {
+	bc       beacon.IBeaconClient
+	kvStore  store.IKeyValueStore
+	byPubkey map[beacon.ValidatorPubkey]string
+	byIndex  map[string]beacon.ValidatorPubkey
+	lock     sync.Mutex
+}
+
+// Creates a new validator index map, loading the indices that were already resolved from the store
+func NewValidatorIndexMap(bc beacon.IBeaconClient, kvStore store.IKeyValueStore) (*ValidatorIndexMap, error) {
+	m := &ValidatorIndexMap{
+		bc:       bc,
+		kvStore:  kvStore,
+		byPubkey: map[beacon.ValidatorPubkey]string{},
+		byIndex:  map[string]beacon.ValidatorPubkey{},
+	}
+	err := kvStore.ForEach(validatorIndexBucket, nil, nil, func(key []byte, value []byte) error {
+		if len(key) != beacon.ValidatorPubkeyLength {
+			return fmt.Errorf("stored validator pubkey %x has an invalid length", key)
+		}
+		pubkey := beacon.ValidatorPubkey(key)
+		index := string(value)
+		m.byPubkey[pubkey] = index
+		m.byIndex[index] = pubkey
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading validator indices: %w", err)
+	}
+	return m, nil
+}
+
+// Get the index of a validator, or false if it isn't on the Beacon chain yet
+func (m *ValidatorIndexMap) GetIndex(ctx context.Context, pubkey beacon.ValidatorPubkey) (string, bool, error) {
+	indices, err := m.GetIndices(ctx, []beacon.ValidatorPubkey{pubkey})
+	if err != nil {
+		return "", false, err
+	}
+	index, exists := indices[pubkey]
+	return index, exists, nil
+}
+
+// Get the indices of the provided validators, resolving the ones that aren't known yet with the Beacon Node. Validators
+// that aren't on the Beacon chain yet are left out of the results.
+func (m *ValidatorIndexMap) GetIndices(ctx context.Context, pubkeys []beacon.ValidatorPubkey) (map[beacon.ValidatorPubkey]string, error) {
+	indices := make(map[beacon.ValidatorPubkey]string, len(pubkeys))
+	missing := []beacon.ValidatorPubkey{}
+	m.lock.Lock()
+	for _, pubkey := range pubkeys {
+		index, exists := m.byPubkey[pubkey]
+		if exists {
+			indices[pubkey] = index
+		} else {
+			missing = append(missing, pubkey)
+		}
+	}
+	m.lock.Unlock()
+	if len(missing) == 0 {
+		return indices, nil
+	}
+
+	// Resolve the missing ones
+	statuses, err := m.bc.GetValidatorStatuses(ctx, missing, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving validator indices: %w", err)
+	}
+	resolved := map[beacon.ValidatorPubkey]string{}
+	for _, pubkey := range missing {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists || status.Index == "" {
+			continue
+		}
+		resolved[pubkey] = status.Index
+		indices[pubkey] = status.Index
+	}
+	err = m.add(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+// Get the pubkeys of the validators with the provided indices, resolving the ones that aren't known yet with the Beacon
+// Node. Indices that don't belong to a validator are left out of the results.
+func (m *ValidatorIndexMap) GetPubkeys(ctx context.Context, indices []string) (map[string]beacon.ValidatorPubkey, error) {
+	pubkeys := make(map[string]beacon.ValidatorPubkey, len(indices))
+	missing := []string{}
+	m.lock.Lock()
+	for _, index := range indices {
+		pubkey, exists := m.byIndex[index]
+		if exists {
+			pubkeys[index] = pubkey
+		} else {
+			missing = append(missing, index)
+		}
+	}
+	m.lock.Unlock()
+	if len(missing) == 0 {
+		return pubkeys, nil
+	}
+
+	// Resolve the missing ones
+	statuses, err := m.bc.GetValidatorStatusesByIndex(ctx, missing, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving validator pubkeys: %w", err)
+	}
+	resolved := map[beacon.ValidatorPubkey]string{}
+	for _, index := range missing {
+		status, exists := statuses[index]
+		if !exists || !status.Exists {
+			continue
+		}
+		resolved[status.Pubkey] = index
+		pubkeys[index] = status.Pubkey
+	}
+	err = m.add(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return pubkeys, nil
+}
+
+// Get the statuses of the provided validators, looking them up by index. Validators that aren't on the Beacon chain yet
+// get an empty status.
+func (m *ValidatorIndexMap) GetValidatorStatuses(ctx context.Context, pubkeys []beacon.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[beacon.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	indices, err := m.GetIndices(ctx, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	indexList := make([]string, 0, len(indices))
+	for _, index := range indices {
+		indexList = append(indexList, index)
+	}
+	statusesByIndex, err := m.bc.GetValidatorStatusesByIndex(ctx, indexList, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+
+	statuses := make(map[beacon.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		index, exists := indices[pubkey]
+		if !exists {
+			statuses[pubkey] = beacon.ValidatorStatus{}
+			continue
+		}
+		statuses[pubkey] = statusesByIndex[index]
+	}
+	return statuses, nil
+}
+
+// Forget all of the resolved indices, such as after switching to a different network
+func (m *ValidatorIndexMap) Clear() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	err := m.kvStore.Update(func(tx store.IStoreTransaction) error {
+		for pubkey := range m.byPubkey {
+			err := tx.Delete(validatorIndexBucket, pubkey[:])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error clearing validator indices: %w", err)
+	}
+	m.byPubkey = map[beacon.ValidatorPubkey]string{}
+	m.byIndex = map[string]beacon.ValidatorPubkey{}
+	return nil
+}
+
+// Save newly resolved indices
+func (m *ValidatorIndexMap) add(resolved map[beacon.ValidatorPubkey]string) error {
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	err := m.kvStore.Update(func(tx store.IStoreTransaction) error {
+		for pubkey, index := range resolved {
+			err := tx.Put(validatorIndexBucket, pubkey[:], []byte(index))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error saving validator indices: %w", err)
+	}
+	for pubkey, index := range resolved {
+		m.byPubkey[pubkey] = index
+		m.byIndex[index] = pubkey
+	}
+	return nil
+}
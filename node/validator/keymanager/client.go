@@ -0,0 +1,317 @@
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	RequestContentType = "application/json"
+
+	RequestKeystoresPath    = "/eth/v1/keystores"
+	RequestRemoteKeysPath   = "/eth/v1/remotekeys"
+	RequestFeeRecipientPath = "/eth/v1/validator/%s/feerecipient"
+	RequestGasLimitPath     = "/eth/v1/validator/%s/gas_limit"
+	RequestGraffitiPath     = "/eth/v1/validator/%s/graffiti"
+)
+
+// A client for a Validator Client's Keymanager API, which manages the keys the VC validates with and their per-key
+// settings while it's running
+type KeymanagerClient struct {
+	providerAddress string
+	token           string
+	client          http.Client
+}
+
+// Creates a new Keymanager API client. The token is the bearer token the VC generated for the API.
+func NewKeymanagerClient(providerAddress string, token string, timeout time.Duration) *KeymanagerClient {
+	return &KeymanagerClient{
+		providerAddress: providerAddress,
+		token:           token,
+		client: http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Creates a new Keymanager API client, reading its bearer token from the file the VC wrote it to
+func NewKeymanagerClientFromTokenFile(providerAddress string, tokenPath string, timeout time.Duration) (*KeymanagerClient, error) {
+	token, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Keymanager API token from [%s]: %w", tokenPath, err)
+	}
+	return NewKeymanagerClient(providerAddress, strings.TrimSpace(string(token)), timeout), nil
+}
+
+// =================
+// === Keystores ===
+// =================
+
+// Get the local keystores the VC is validating with
+func (c *KeymanagerClient) ListKeystores(ctx context.Context) ([]Keystore, error) {
+	response, err := sendRequest[ListKeystoresResponse](ctx, c, http.MethodGet, RequestKeystoresPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing keystores: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Import keystores into the VC, along with their passwords (in the same order) and optionally their EIP-3076 slashing
+// protection data. The results are in the same order as the keystores.
+func (c *KeymanagerClient) ImportKeystores(ctx context.Context, keystores []beacon.ValidatorKeystore, passwords []string, slashingProtection []byte) ([]ImportResult, error) {
+	if len(keystores) != len(passwords) {
+		return nil, fmt.Errorf("there are %d keystores but %d passwords", len(keystores), len(passwords))
+	}
+	request := importKeystoresRequest{
+		Keystores:          make([]string, len(keystores)),
+		Passwords:          passwords,
+		SlashingProtection: string(slashingProtection),
+	}
+	for i, keystore := range keystores {
+		keystoreBytes, err := json.Marshal(keystore)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding keystore for pubkey %s: %w", keystore.Pubkey.HexWithPrefix(), err)
+		}
+		request.Keystores[i] = string(keystoreBytes)
+	}
+	response, err := sendRequest[ImportResponse](ctx, c, http.MethodPost, RequestKeystoresPath, request)
+	if err != nil {
+		return nil, fmt.Errorf("error importing keystores: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Delete keystores from the VC. The response has the results in the same order as the pubkeys, and the slashing
+// protection data for the keys, which should be kept in case they're imported somewhere else.
+func (c *KeymanagerClient) DeleteKeystores(ctx context.Context, pubkeys []beacon.ValidatorPubkey) (DeleteKeystoresResponse, error) {
+	response, err := sendRequest[DeleteKeystoresResponse](ctx, c, http.MethodDelete, RequestKeystoresPath, getDeleteKeysRequest(pubkeys))
+	if err != nil {
+		return DeleteKeystoresResponse{}, fmt.Errorf("error deleting keystores: %w", err)
+	}
+	return response, nil
+}
+
+// ===================
+// === Remote Keys ===
+// ===================
+
+// Get the keys the VC is validating with through remote signers
+func (c *KeymanagerClient) ListRemoteKeys(ctx context.Context) ([]ListedRemoteKey, error) {
+	response, err := sendRequest[ListRemoteKeysResponse](ctx, c, http.MethodGet, RequestRemoteKeysPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing remote keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Import keys held by remote signers into the VC. The results are in the same order as the keys.
+func (c *KeymanagerClient) ImportRemoteKeys(ctx context.Context, keys []RemoteKey) ([]ImportResult, error) {
+	request := importRemoteKeysRequest{
+		RemoteKeys: make([]remoteKeyRequest, len(keys)),
+	}
+	for i, key := range keys {
+		request.RemoteKeys[i] = remoteKeyRequest{
+			Pubkey: key.Pubkey.HexWithPrefix(),
+			Url:    key.Url,
+		}
+	}
+	response, err := sendRequest[ImportResponse](ctx, c, http.MethodPost, RequestRemoteKeysPath, request)
+	if err != nil {
+		return nil, fmt.Errorf("error importing remote keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Delete remote keys from the VC. The results are in the same order as the pubkeys.
+func (c *KeymanagerClient) DeleteRemoteKeys(ctx context.Context, pubkeys []beacon.ValidatorPubkey) ([]DeleteResult, error) {
+	response, err := sendRequest[DeleteRemoteKeysResponse](ctx, c, http.MethodDelete, RequestRemoteKeysPath, getDeleteKeysRequest(pubkeys))
+	if err != nil {
+		return nil, fmt.Errorf("error deleting remote keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// =====================
+// === Fee Recipient ===
+// =====================
+
+// Get the fee recipient the VC uses for a validator
+func (c *KeymanagerClient) GetFeeRecipient(ctx context.Context, pubkey beacon.ValidatorPubkey) (common.Address, error) {
+	response, err := sendRequest[FeeRecipientResponse](ctx, c, http.MethodGet, fmt.Sprintf(RequestFeeRecipientPath, pubkey.HexWithPrefix()), nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting fee recipient for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return response.Data.EthAddress, nil
+}
+
+// Set the fee recipient the VC uses for a validator, overriding its default one
+func (c *KeymanagerClient) SetFeeRecipient(ctx context.Context, pubkey beacon.ValidatorPubkey, feeRecipient common.Address) error {
+	request := setFeeRecipientRequest{
+		EthAddress: feeRecipient,
+	}
+	_, err := sendRequest[struct{}](ctx, c, http.MethodPost, fmt.Sprintf(RequestFeeRecipientPath, pubkey.HexWithPrefix()), request)
+	if err != nil {
+		return fmt.Errorf("error setting fee recipient for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
+
+// Remove a validator's fee recipient override so the VC uses its default one again
+func (c *KeymanagerClient) DeleteFeeRecipient(ctx context.Context, pubkey beacon.ValidatorPubkey) error {
+	_, err := sendRequest[struct{}](ctx, c, http.MethodDelete, fmt.Sprintf(RequestFeeRecipientPath, pubkey.HexWithPrefix()), nil)
+	if err != nil {
+		return fmt.Errorf("error deleting fee recipient for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
+
+// =================
+// === Gas Limit ===
+// =================
+
+// Get the gas limit the VC uses for blocks proposed by a validator
+func (c *KeymanagerClient) GetGasLimit(ctx context.Context, pubkey beacon.ValidatorPubkey) (uint64, error) {
+	response, err := sendRequest[GasLimitResponse](ctx, c, http.MethodGet, fmt.Sprintf(RequestGasLimitPath, pubkey.HexWithPrefix()), nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting gas limit for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return uint64(response.Data.GasLimit), nil
+}
+
+// Set the gas limit the VC uses for blocks proposed by a validator, overriding its default one
+func (c *KeymanagerClient) SetGasLimit(ctx context.Context, pubkey beacon.ValidatorPubkey, gasLimit uint64) error {
+	request := setGasLimitRequest{
+		GasLimit: utils.Uinteger(gasLimit),
+	}
+	_, err := sendRequest[struct{}](ctx, c, http.MethodPost, fmt.Sprintf(RequestGasLimitPath, pubkey.HexWithPrefix()), request)
+	if err != nil {
+		return fmt.Errorf("error setting gas limit for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
+
+// Remove a validator's gas limit override so the VC uses its default one again
+func (c *KeymanagerClient) DeleteGasLimit(ctx context.Context, pubkey beacon.ValidatorPubkey) error {
+	_, err := sendRequest[struct{}](ctx, c, http.MethodDelete, fmt.Sprintf(RequestGasLimitPath, pubkey.HexWithPrefix()), nil)
+	if err != nil {
+		return fmt.Errorf("error deleting gas limit for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
+
+// ================
+// === Graffiti ===
+// ================
+
+// Get the graffiti the VC uses for blocks proposed by a validator
+func (c *KeymanagerClient) GetGraffiti(ctx context.Context, pubkey beacon.ValidatorPubkey) (string, error) {
+	response, err := sendRequest[GraffitiResponse](ctx, c, http.MethodGet, fmt.Sprintf(RequestGraffitiPath, pubkey.HexWithPrefix()), nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting graffiti for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return response.Data.Graffiti, nil
+}
+
+// Set the graffiti the VC uses for blocks proposed by a validator, overriding its default one
+func (c *KeymanagerClient) SetGraffiti(ctx context.Context, pubkey beacon.ValidatorPubkey, graffiti string) error {
+	request := setGraffitiRequest{
+		Graffiti: graffiti,
+	}
+	_, err := sendRequest[struct{}](ctx, c, http.MethodPost, fmt.Sprintf(RequestGraffitiPath, pubkey.HexWithPrefix()), request)
+	if err != nil {
+		return fmt.Errorf("error setting graffiti for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
+
+// Remove a validator's graffiti override so the VC uses its default one again
+func (c *KeymanagerClient) DeleteGraffiti(ctx context.Context, pubkey beacon.ValidatorPubkey) error {
+	_, err := sendRequest[struct{}](ctx, c, http.MethodDelete, fmt.Sprintf(RequestGraffitiPath, pubkey.HexWithPrefix()), nil)
+	if err != nil {
+		return fmt.Errorf("error deleting graffiti for validator %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
+
+// ==========================
+// === Internal Functions ===
+// ==========================
+
+// Create the body of a request to delete keys
+func getDeleteKeysRequest(pubkeys []beacon.ValidatorPubkey) deleteKeysRequest {
+	request := deleteKeysRequest{
+		Pubkeys: make([]string, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.Pubkeys[i] = pubkey.HexWithPrefix()
+	}
+	return request
+}
+
+// Send an authenticated request with the provided body serialized as JSON (if it isn't nil), check the status code,
+// and decode the response body if there is one
+func sendRequest[ResponseType any](ctx context.Context, c *KeymanagerClient, method string, requestPath string, requestBody any) (ResponseType, error) {
+	var response ResponseType
+
+	// Create the request
+	var bodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return response, fmt.Errorf("error encoding request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(requestBodyBytes)
+	}
+	path := utils.JoinUrlPath(c.providerAddress, requestPath)
+	request, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+	if err != nil {
+		return response, fmt.Errorf("error creating %s request to [%s]: %w", method, path, err)
+	}
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Submit the request
+	httpResponse, err := c.client.Do(request)
+	if err != nil {
+		return response, fmt.Errorf("error running %s request to [%s]: %w", method, path, err)
+	}
+	defer func() {
+		_ = httpResponse.Body.Close()
+	}()
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return response, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	// Check the status; setters respond with 202 and deletions of overrides with 204
+	switch httpResponse.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+	default:
+		var errResponse errorResponse
+		if json.Unmarshal(body, &errResponse) == nil && errResponse.Message != "" {
+			return response, fmt.Errorf("HTTP status %d: %s", httpResponse.StatusCode, errResponse.Message)
+		}
+		return response, fmt.Errorf("HTTP status %d; response body: '%s'", httpResponse.StatusCode, string(body))
+	}
+	if len(body) == 0 {
+		return response, nil
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return response, fmt.Errorf("error decoding response body: %w", err)
+	}
+	return response, nil
+}
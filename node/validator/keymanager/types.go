@@ -0,0 +1,127 @@
+package keymanager
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// The result of importing a key
+type ImportStatus string
+
+const (
+	// The key was imported
+	ImportStatus_Imported ImportStatus = "imported"
+
+	// The VC already had the key
+	ImportStatus_Duplicate ImportStatus = "duplicate"
+
+	// The key couldn't be imported; see the message for details
+	ImportStatus_Error ImportStatus = "error"
+)
+
+// The result of deleting a key
+type DeleteStatus string
+
+const (
+	// The key was deleted
+	DeleteStatus_Deleted DeleteStatus = "deleted"
+
+	// The VC doesn't have the key anymore, but it still has slashing protection data for it, which is included in the
+	// response
+	DeleteStatus_NotActive DeleteStatus = "not_active"
+
+	// The VC doesn't have the key or any slashing protection data for it
+	DeleteStatus_NotFound DeleteStatus = "not_found"
+
+	// The key couldn't be deleted; see the message for details
+	DeleteStatus_Error DeleteStatus = "error"
+)
+
+// Request types
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type deleteKeysRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+type RemoteKey struct {
+	Pubkey beacon.ValidatorPubkey `json:"pubkey"`
+	Url    string                 `json:"url"`
+}
+type importRemoteKeysRequest struct {
+	RemoteKeys []remoteKeyRequest `json:"remote_keys"`
+}
+type remoteKeyRequest struct {
+	Pubkey string `json:"pubkey"`
+	Url    string `json:"url"`
+}
+type setFeeRecipientRequest struct {
+	EthAddress common.Address `json:"ethaddress"`
+}
+type setGasLimitRequest struct {
+	GasLimit utils.Uinteger `json:"gas_limit"`
+}
+type setGraffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
+
+// Response types
+type Keystore struct {
+	ValidatingPubkey beacon.ValidatorPubkey `json:"validating_pubkey"`
+	DerivationPath   string                 `json:"derivation_path"`
+	ReadOnly         bool                   `json:"readonly"`
+}
+type ListKeystoresResponse struct {
+	Data []Keystore `json:"data"`
+}
+type ImportResult struct {
+	Status  ImportStatus `json:"status"`
+	Message string       `json:"message"`
+}
+type ImportResponse struct {
+	Data []ImportResult `json:"data"`
+}
+type DeleteResult struct {
+	Status  DeleteStatus `json:"status"`
+	Message string       `json:"message"`
+}
+type DeleteKeystoresResponse struct {
+	Data               []DeleteResult `json:"data"`
+	SlashingProtection string         `json:"slashing_protection"`
+}
+type DeleteRemoteKeysResponse struct {
+	Data []DeleteResult `json:"data"`
+}
+type ListedRemoteKey struct {
+	Pubkey   beacon.ValidatorPubkey `json:"pubkey"`
+	Url      string                 `json:"url"`
+	ReadOnly bool                   `json:"readonly"`
+}
+type ListRemoteKeysResponse struct {
+	Data []ListedRemoteKey `json:"data"`
+}
+type FeeRecipientResponse struct {
+	Data struct {
+		Pubkey     beacon.ValidatorPubkey `json:"pubkey"`
+		EthAddress common.Address         `json:"ethaddress"`
+	} `json:"data"`
+}
+type GasLimitResponse struct {
+	Data struct {
+		Pubkey   beacon.ValidatorPubkey `json:"pubkey"`
+		GasLimit utils.Uinteger         `json:"gas_limit"`
+	} `json:"data"`
+}
+type GraffitiResponse struct {
+	Data struct {
+		Pubkey   beacon.ValidatorPubkey `json:"pubkey"`
+		Graffiti string                 `json:"graffiti"`
+	} `json:"data"`
+}
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
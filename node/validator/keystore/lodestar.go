@@ -156,3 +156,19 @@ func (ks *LodestarKeystoreManager) LoadValidatorKey(pubkey beacon.ValidatorPubke
 
 	return privateKey, nil
 }
+
+// Get the pubkeys of all of the validator keys in the keystore
+func (ks *LodestarKeystoreManager) GetStoredPubkeys() ([]beacon.ValidatorPubkey, error) {
+	return getPubkeysFromDirs(filepath.Join(ks.keystoreDir, ks.validatorsDir), ks.keyFileName)
+}
+
+// Re-encrypt a validator key with a new random password
+func (ks *LodestarKeystoreManager) ChangeValidatorKeyPassword(pubkey beacon.ValidatorPubkey) error {
+	keyFilePath := filepath.Join(ks.keystoreDir, ks.validatorsDir, pubkey.HexWithPrefix(), ks.keyFileName)
+	secretFilePath := filepath.Join(ks.keystoreDir, ks.secretsDir, pubkey.HexWithPrefix())
+	err := changeKeystorePassword(ks.encryptor, keyFilePath, secretFilePath)
+	if err != nil {
+		return fmt.Errorf("error changing the password of the Lodestar keystore for pubkey %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
@@ -83,12 +83,6 @@ func (ks *PrysmKeystoreManager) StoreValidatorKey(key *eth2types.BLSPrivateKey,
 	ks.as.PrivateKeys = append(ks.as.PrivateKeys, key.Marshal())
 	ks.as.PublicKeys = append(ks.as.PublicKeys, key.PublicKey().Marshal())
 
-	// Encode account store
-	asBytes, err := json.Marshal(ks.as)
-	if err != nil {
-		return fmt.Errorf("error encoding validator account store: %w", err)
-	}
-
 	// Get the keystore account password
 	passwordFilePath := filepath.Join(ks.keystoreDir, ks.walletDir, ks.accountsDir, ks.keystorePasswordFileName)
 	passwordBytes, err := os.ReadFile(passwordFilePath)
@@ -97,24 +91,10 @@ func (ks *PrysmKeystoreManager) StoreValidatorKey(key *eth2types.BLSPrivateKey,
 	}
 	password := string(passwordBytes)
 
-	// Encrypt account store
-	asEncrypted, err := ks.encryptor.Encrypt(asBytes, password)
-	if err != nil {
-		return fmt.Errorf("error encrypting validator account store: %w", err)
-	}
-
-	// Create new keystore
-	keystore := PrysmKeystore{
-		Crypto:  asEncrypted,
-		Name:    ks.encryptor.Name(),
-		Version: ks.encryptor.Version(),
-		UUID:    uuid.New(),
-	}
-
-	// Encode key store
-	ksBytes, err := json.Marshal(keystore)
+	// Encrypt and encode the account store
+	ksBytes, err := ks.encodeAccountStore(password)
 	if err != nil {
-		return fmt.Errorf("error encoding validator keystore: %w", err)
+		return err
 	}
 
 	// Get file paths
@@ -253,3 +233,103 @@ func (ks *PrysmKeystoreManager) LoadValidatorKey(pubkey beacon.ValidatorPubkey)
 	// Return nothing if the private key wasn't found
 	return nil, nil
 }
+
+// Get the pubkeys of all of the validator keys in the keystore
+func (ks *PrysmKeystoreManager) GetStoredPubkeys() ([]beacon.ValidatorPubkey, error) {
+	// Initialize the account store
+	err := ks.initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	pubkeys := make([]beacon.ValidatorPubkey, 0, len(ks.as.PublicKeys))
+	for _, pubkeyBytes := range ks.as.PublicKeys {
+		if len(pubkeyBytes) != beacon.ValidatorPubkeyLength {
+			return nil, fmt.Errorf("prysm's keystore has a pubkey with an invalid length of %d", len(pubkeyBytes))
+		}
+		pubkeys = append(pubkeys, beacon.ValidatorPubkey(pubkeyBytes))
+	}
+	return pubkeys, nil
+}
+
+// Re-encrypt the account store with a new random password. Prysm protects all of its keys with a single password, so
+// this changes it for every key in the keystore, not just the provided one.
+func (ks *PrysmKeystoreManager) ChangeValidatorKeyPassword(pubkey beacon.ValidatorPubkey) error {
+	// Make sure the key is in the account store
+	key, err := ks.LoadValidatorKey(pubkey)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return fmt.Errorf("prysm's keystore doesn't have a key for validator %s", pubkey.HexWithPrefix())
+	}
+
+	// Encrypt the account store with a new password
+	password, err := utils.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("error generating random password: %w", err)
+	}
+	ksBytes, err := ks.encodeAccountStore(password)
+	if err != nil {
+		return err
+	}
+
+	// Write the new files, then swap them in
+	keystoreFilePath := filepath.Join(ks.keystoreDir, ks.walletDir, ks.accountsDir, ks.keystoreFileName)
+	passwordFilePath := filepath.Join(ks.keystoreDir, ks.walletDir, ks.accountsDir, ks.keystorePasswordFileName)
+	oldPassword, err := os.ReadFile(passwordFilePath)
+	if err != nil {
+		return fmt.Errorf("error reading account password file: %w", err)
+	}
+	tempKeystoreFilePath := keystoreFilePath + ".tmp"
+	tempPasswordFilePath := passwordFilePath + ".tmp"
+	defer os.Remove(tempKeystoreFilePath)
+	defer os.Remove(tempPasswordFilePath)
+	if err := os.WriteFile(tempKeystoreFilePath, ksBytes, FileMode); err != nil {
+		return fmt.Errorf("error writing new keystore to disk: %w", err)
+	}
+	if err := os.WriteFile(tempPasswordFilePath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("error writing new account password file: %w", err)
+	}
+	if err := os.Rename(tempPasswordFilePath, passwordFilePath); err != nil {
+		return fmt.Errorf("error replacing account password file: %w", err)
+	}
+	if err := os.Rename(tempKeystoreFilePath, keystoreFilePath); err != nil {
+		// Put the old password back so it matches the old keystore again
+		if restoreErr := os.WriteFile(passwordFilePath, oldPassword, FileMode); restoreErr != nil {
+			return fmt.Errorf("error replacing keystore (%w) and error restoring the old account password: %w", err, restoreErr)
+		}
+		return fmt.Errorf("error replacing keystore: %w", err)
+	}
+	return nil
+}
+
+// Encrypt the account store with the provided password and encode it as a keystore
+func (ks *PrysmKeystoreManager) encodeAccountStore(password string) ([]byte, error) {
+	// Encode account store
+	asBytes, err := json.Marshal(ks.as)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding validator account store: %w", err)
+	}
+
+	// Encrypt account store
+	asEncrypted, err := ks.encryptor.Encrypt(asBytes, password)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting validator account store: %w", err)
+	}
+
+	// Create new keystore
+	keystore := PrysmKeystore{
+		Crypto:  asEncrypted,
+		Name:    ks.encryptor.Name(),
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+	}
+
+	// Encode key store
+	ksBytes, err := json.Marshal(keystore)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding validator keystore: %w", err)
+	}
+	return ksBytes, nil
+}
@@ -154,3 +154,19 @@ func (ks *TekuKeystoreManager) LoadValidatorKey(pubkey beacon.ValidatorPubkey) (
 
 	return privateKey, nil
 }
+
+// Get the pubkeys of all of the validator keys in the keystore
+func (ks *TekuKeystoreManager) GetStoredPubkeys() ([]beacon.ValidatorPubkey, error) {
+	return getPubkeysFromFiles(filepath.Join(ks.keystoreDir, ks.validatorsDir), ".json")
+}
+
+// Re-encrypt a validator key with a new random password
+func (ks *TekuKeystoreManager) ChangeValidatorKeyPassword(pubkey beacon.ValidatorPubkey) error {
+	keyFilePath := filepath.Join(ks.keystoreDir, ks.validatorsDir, pubkey.HexWithPrefix()+".json")
+	secretFilePath := filepath.Join(ks.keystoreDir, ks.secretsDir, pubkey.HexWithPrefix()+".txt")
+	err := changeKeystorePassword(ks.encryptor, keyFilePath, secretFilePath)
+	if err != nil {
+		return fmt.Errorf("error changing the password of the Teku keystore for pubkey %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	return nil
+}
@@ -17,6 +17,12 @@ type IKeystoreManager interface {
 	// Load a validator key from disk corresponding to the provided pubkey
 	LoadValidatorKey(pubkey beacon.ValidatorPubkey) (*eth2types.BLSPrivateKey, error)
 
+	// Get the pubkeys of all of the validator keys stored on disk
+	GetStoredPubkeys() ([]beacon.ValidatorPubkey, error)
+
+	// Re-encrypt a stored validator key with a new random password, such as after its password file was exposed
+	ChangeValidatorKeyPassword(pubkey beacon.ValidatorPubkey) error
+
 	// Get the path of the keystore directory managed by this manager
 	GetKeystoreDir() string
 }
@@ -0,0 +1,131 @@
+package keystore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// Get the pubkeys of the keystores in a directory that has one subdirectory per key, named after the key's pubkey.
+// Entries that aren't named after a pubkey are ignored.
+func getPubkeysFromDirs(dir string, keyFileName string) ([]beacon.ValidatorPubkey, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []beacon.ValidatorPubkey{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading keystore directory [%s]: %w", dir, err)
+	}
+
+	pubkeys := []beacon.ValidatorPubkey{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pubkey, err := beacon.HexToValidatorPubkey(entry.Name())
+		if err != nil {
+			continue
+		}
+		_, err = os.Stat(filepath.Join(dir, entry.Name(), keyFileName))
+		if err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Get the pubkeys of the keystores in a directory that has one file per key, named after the key's pubkey with the
+// provided extension. Entries that aren't named after a pubkey are ignored.
+func getPubkeysFromFiles(dir string, extension string) ([]beacon.ValidatorPubkey, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []beacon.ValidatorPubkey{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading keystore directory [%s]: %w", dir, err)
+	}
+
+	pubkeys := []beacon.ValidatorPubkey{}
+	for _, entry := range entries {
+		name, hasExtension := strings.CutSuffix(entry.Name(), extension)
+		if entry.IsDir() || !hasExtension {
+			continue
+		}
+		pubkey, err := beacon.HexToValidatorPubkey(name)
+		if err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Re-encrypt a keystore that has its password in a separate secret file with a new random password. The new files are
+// written next to the old ones before replacing them, so an error partway through leaves the old keystore usable.
+func changeKeystorePassword(encryptor *eth2ks.Encryptor, keyFilePath string, secretFilePath string) error {
+	// Read the keystore and its password
+	keystoreBytes, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return fmt.Errorf("error reading keystore: %w", err)
+	}
+	var keystore beacon.ValidatorKeystore
+	err = json.Unmarshal(keystoreBytes, &keystore)
+	if err != nil {
+		return fmt.Errorf("error deserializing keystore: %w", err)
+	}
+	oldPassword, err := os.ReadFile(secretFilePath)
+	if err != nil {
+		return fmt.Errorf("error reading keystore password: %w", err)
+	}
+
+	// Re-encrypt the key
+	decryptedKey, err := encryptor.Decrypt(keystore.Crypto, string(oldPassword))
+	if err != nil {
+		return fmt.Errorf("error decrypting keystore: %w", err)
+	}
+	newPassword, err := utils.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("error generating random password: %w", err)
+	}
+	keystore.Crypto, err = encryptor.Encrypt(decryptedKey, newPassword)
+	if err != nil {
+		return fmt.Errorf("error encrypting keystore: %w", err)
+	}
+	keystoreBytes, err = json.Marshal(keystore)
+	if err != nil {
+		return fmt.Errorf("error encoding keystore: %w", err)
+	}
+
+	// Write the new files, then swap them in
+	tempKeyFilePath := keyFilePath + ".tmp"
+	tempSecretFilePath := secretFilePath + ".tmp"
+	defer os.Remove(tempKeyFilePath)
+	defer os.Remove(tempSecretFilePath)
+	err = os.WriteFile(tempKeyFilePath, keystoreBytes, FileMode)
+	if err != nil {
+		return fmt.Errorf("error writing new keystore: %w", err)
+	}
+	err = os.WriteFile(tempSecretFilePath, []byte(newPassword), FileMode)
+	if err != nil {
+		return fmt.Errorf("error writing new keystore password: %w", err)
+	}
+	err = os.Rename(tempSecretFilePath, secretFilePath)
+	if err != nil {
+		return fmt.Errorf("error replacing keystore password: %w", err)
+	}
+	err = os.Rename(tempKeyFilePath, keyFilePath)
+	if err != nil {
+		// Put the old password back so it matches the old keystore again
+		restoreErr := os.WriteFile(secretFilePath, oldPassword, FileMode)
+		if restoreErr != nil {
+			return fmt.Errorf("error replacing keystore (%w) and error restoring its old password: %w", err, restoreErr)
+		}
+		return fmt.Errorf("error replacing keystore: %w", err)
+	}
+	return nil
+}
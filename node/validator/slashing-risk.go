@@ -0,0 +1,372 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// A kind of slashing risk found while inspecting a validator's recent activity
+type SlashingRiskKind string
+
+const (
+	// The validator proposed a block for a slot that its signing history has a different block for
+	SlashingRiskKind_DoubleProposal SlashingRiskKind = "double_proposal"
+
+	// The validator attested to a target epoch that its signing history has a different attestation for
+	SlashingRiskKind_DoubleVote SlashingRiskKind = "double_vote"
+
+	// The validator made an attestation that surrounds, or is surrounded by, one in its signing history
+	SlashingRiskKind_SurroundVote SlashingRiskKind = "surround_vote"
+
+	// The validator was active on-chain but its signing history doesn't account for it, so its keys are probably
+	// still being used by another validator client
+	SlashingRiskKind_UnrecordedActivity SlashingRiskKind = "unrecorded_activity"
+)
+
+// A proposal the validator client has signed, as recorded in its slashing protection database
+type SignedProposalRecord struct {
+	Slot uint64
+
+	// The root of the signed block, if known
+	BlockRoot *common.Hash
+}
+
+// An attestation the validator client has signed, as recorded in its slashing protection database
+type SignedAttestationRecord struct {
+	SourceEpoch uint64
+	TargetEpoch uint64
+}
+
+// The blocks and attestations a validator client has signed for a validator
+type SigningHistory struct {
+	Proposals    []SignedProposalRecord
+	Attestations []SignedAttestationRecord
+}
+
+// A block proposal by one of the inspected validators that was found on-chain
+type ObservedProposal struct {
+	Slot      uint64
+	BlockRoot common.Hash
+}
+
+// An attestation by one of the inspected validators that was found on-chain
+type ObservedAttestation struct {
+	Slot            uint64
+	BeaconBlockRoot common.Hash
+	SourceEpoch     uint64
+	TargetEpoch     uint64
+}
+
+// A potential slashing condition for a validator
+type SlashingRisk struct {
+	ValidatorIndex string
+	Kind           SlashingRiskKind
+	Slot           uint64
+	Description    string
+}
+
+// The on-chain activity of a set of validators over a range of slots, and the slashing risks it poses
+type SlashingRiskReport struct {
+	StartSlot    uint64
+	EndSlot      uint64
+	Proposals    map[string][]ObservedProposal
+	Attestations map[string][]ObservedAttestation
+	Risks        []SlashingRisk
+}
+
+// True if no slashing risks were found
+func (r *SlashingRiskReport) IsSafe() bool {
+	return len(r.Risks) == 0
+}
+
+// Inspect the blocks in the provided range of slots (inclusive) for proposals and attestations by the provided
+// validators, and compare them to the validators' signing histories to find anything that would be slashable if
+// their keys were imported into a validator client with those histories. Run this before importing keys to complement
+// doppelganger detection, which only watches for activity after the keys are loaded.
+// Validators without a signing history are treated as having an empty one, so any recent activity is a risk.
+func InspectSlashingRisk(ctx context.Context, bc beacon.IBeaconClient, indices []string, histories map[string]*SigningHistory, startSlot uint64, endSlot uint64) (*SlashingRiskReport, error) {
+	if endSlot < startSlot {
+		return nil, fmt.Errorf("end slot %d is before start slot %d", endSlot, startSlot)
+	}
+	eth2Config, err := bc.GetEth2Config(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+
+	report := &SlashingRiskReport{
+		StartSlot:    startSlot,
+		EndSlot:      endSlot,
+		Proposals:    map[string][]ObservedProposal{},
+		Attestations: map[string][]ObservedAttestation{},
+		Risks:        []SlashingRisk{},
+	}
+	inspected := map[string]bool{}
+	for _, index := range indices {
+		inspected[index] = true
+	}
+	committees := map[uint64]*epochCommittees{}
+	seenAttestations := map[string]map[uint64]bool{}
+
+	for slot := startSlot; slot <= endSlot; slot++ {
+		slotString := strconv.FormatUint(slot, 10)
+		block, exists, err := bc.GetBeaconBlock(ctx, slotString)
+		if err != nil {
+			return nil, fmt.Errorf("error getting block for slot %d: %w", slot, err)
+		}
+		if !exists {
+			continue
+		}
+
+		// Record proposals
+		if inspected[block.Header.ProposerIndex] {
+			header, _, err := bc.GetBeaconBlockHeader(ctx, slotString)
+			if err != nil {
+				return nil, fmt.Errorf("error getting block header for slot %d: %w", slot, err)
+			}
+			report.Proposals[block.Header.ProposerIndex] = append(report.Proposals[block.Header.ProposerIndex], ObservedProposal{
+				Slot:      slot,
+				BlockRoot: header.Root,
+			})
+		}
+
+		// Record attestations
+		for _, attestation := range block.Attestations {
+			epoch := attestation.SlotIndex / eth2Config.SlotsPerEpoch
+			epochCommittees, exists := committees[epoch]
+			if !exists {
+				epochCommittees, err = getEpochCommittees(ctx, bc, epoch, inspected)
+				if err != nil {
+					return nil, err
+				}
+				committees[epoch] = epochCommittees
+			}
+			attesters, err := epochCommittees.getAttesters(attestation)
+			if err != nil {
+				return nil, fmt.Errorf("error getting attesters for an attestation in block %d: %w", slot, err)
+			}
+			for _, validatorIndex := range attesters {
+				// The same attestation can be included in multiple blocks
+				if seenAttestations[validatorIndex] == nil {
+					seenAttestations[validatorIndex] = map[uint64]bool{}
+				}
+				if seenAttestations[validatorIndex][attestation.SlotIndex] {
+					continue
+				}
+				seenAttestations[validatorIndex][attestation.SlotIndex] = true
+				report.Attestations[validatorIndex] = append(report.Attestations[validatorIndex], ObservedAttestation{
+					Slot:            attestation.SlotIndex,
+					BeaconBlockRoot: attestation.BeaconBlockRoot,
+					SourceEpoch:     attestation.SourceEpoch,
+					TargetEpoch:     attestation.TargetEpoch,
+				})
+			}
+		}
+	}
+
+	// Compare the activity to the signing histories
+	for _, index := range indices {
+		history := histories[index]
+		if history == nil {
+			history = &SigningHistory{}
+		}
+		report.Risks = append(report.Risks, checkProposals(index, report.Proposals[index], history)...)
+		report.Risks = append(report.Risks, checkAttestations(index, report.Attestations[index], history, eth2Config.SlotsPerEpoch)...)
+	}
+	return report, nil
+}
+
+// Inspect the last few epochs of blocks (up to the current head) for slashing risks. See InspectSlashingRisk for details.
+func InspectRecentSlashingRisk(ctx context.Context, bc beacon.IBeaconClient, indices []string, histories map[string]*SigningHistory, epochs uint64) (*SlashingRiskReport, error) {
+	eth2Config, err := bc.GetEth2Config(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	head, err := bc.GetBeaconHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+
+	startEpoch := uint64(0)
+	if head.Epoch > epochs {
+		startEpoch = head.Epoch - epochs
+	}
+	startSlot := startEpoch * eth2Config.SlotsPerEpoch
+	endSlot := (head.Epoch+1)*eth2Config.SlotsPerEpoch - 1
+	return InspectSlashingRisk(ctx, bc, indices, histories, startSlot, endSlot)
+}
+
+// Identifies a committee by its slot and index
+type committeeKey struct {
+	slot  uint64
+	index uint64
+}
+
+// The committees of an epoch
+type epochCommittees struct {
+	// The number of validators in each committee
+	sizes map[committeeKey]uint64
+
+	// The inspected validators in each committee, keyed by their position in the committee
+	positions map[committeeKey]map[uint64]string
+}
+
+// Get the committees of an epoch, along with the positions of the inspected validators in them
+func getEpochCommittees(ctx context.Context, bc beacon.IBeaconClient, epoch uint64, inspected map[string]bool) (*epochCommittees, error) {
+	committees, err := bc.GetCommitteesForEpoch(ctx, &epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting committees for epoch %d: %w", epoch, err)
+	}
+	defer committees.Release()
+
+	result := &epochCommittees{
+		sizes:     map[committeeKey]uint64{},
+		positions: map[committeeKey]map[uint64]string{},
+	}
+	for i := 0; i < committees.Count(); i++ {
+		key := committeeKey{
+			slot:  committees.Slot(i),
+			index: committees.Index(i),
+		}
+		validators := committees.Validators(i)
+		result.sizes[key] = uint64(len(validators))
+		for position, validatorIndex := range validators {
+			if !inspected[validatorIndex] {
+				continue
+			}
+			if result.positions[key] == nil {
+				result.positions[key] = map[uint64]string{}
+			}
+			result.positions[key][uint64(position)] = validatorIndex
+		}
+	}
+	return result, nil
+}
+
+// Get the inspected validators whose aggregation bits are set in an attestation. Before Electra, the bits only cover
+// the committee at the attestation's committee index. As of Electra (EIP-7549) the committee index is always 0, and
+// the bits cover each committee in the committee bits one after another, so each committee's bits start after those of
+// the committees before it.
+func (c *epochCommittees) getAttesters(attestation beacon.AttestationInfo) ([]string, error) {
+	committeeIndices := []int{int(attestation.CommitteeIndex)}
+	if attestation.CommitteeBits != nil {
+		if len(attestation.CommitteeBits) != 8 {
+			return nil, fmt.Errorf("committee bits have %d bytes but must have 8", len(attestation.CommitteeBits))
+		}
+		committeeIndices = attestation.CommitteeBits.BitIndices()
+	}
+
+	attesters := []string{}
+	offset := uint64(0)
+	for _, committeeIndex := range committeeIndices {
+		key := committeeKey{slot: attestation.SlotIndex, index: uint64(committeeIndex)}
+		size, exists := c.sizes[key]
+		if !exists {
+			return nil, fmt.Errorf("slot %d doesn't have a committee with index %d", attestation.SlotIndex, committeeIndex)
+		}
+		for position, validatorIndex := range c.positions[key] {
+			bit := offset + position
+			if bit < attestation.AggregationBits.Len() && attestation.AggregationBits.BitAt(bit) {
+				attesters = append(attesters, validatorIndex)
+			}
+		}
+		offset += size
+	}
+	return attesters, nil
+}
+
+// Check a validator's on-chain proposals against its signing history
+func checkProposals(index string, proposals []ObservedProposal, history *SigningHistory) []SlashingRisk {
+	risks := []SlashingRisk{}
+	for _, proposal := range proposals {
+		var record *SignedProposalRecord
+		for i := range history.Proposals {
+			if history.Proposals[i].Slot == proposal.Slot {
+				record = &history.Proposals[i]
+				break
+			}
+		}
+
+		switch {
+		case record == nil && !hasLaterProposal(history, proposal.Slot):
+			risks = append(risks, SlashingRisk{
+				ValidatorIndex: index,
+				Kind:           SlashingRiskKind_UnrecordedActivity,
+				Slot:           proposal.Slot,
+				Description:    fmt.Sprintf("proposed block %s in slot %d, which is missing from its signing history", proposal.BlockRoot.Hex(), proposal.Slot),
+			})
+		case record != nil && record.BlockRoot != nil && *record.BlockRoot != proposal.BlockRoot:
+			risks = append(risks, SlashingRisk{
+				ValidatorIndex: index,
+				Kind:           SlashingRiskKind_DoubleProposal,
+				Slot:           proposal.Slot,
+				Description:    fmt.Sprintf("proposed block %s in slot %d, but its signing history has block %s for that slot", proposal.BlockRoot.Hex(), proposal.Slot, record.BlockRoot.Hex()),
+			})
+		}
+	}
+	return risks
+}
+
+// Check a validator's on-chain attestations against its signing history
+func checkAttestations(index string, attestations []ObservedAttestation, history *SigningHistory, slotsPerEpoch uint64) []SlashingRisk {
+	risks := []SlashingRisk{}
+	for _, attestation := range attestations {
+		recorded := false
+		for _, record := range history.Attestations {
+			switch {
+			case record.TargetEpoch == attestation.TargetEpoch && record.SourceEpoch == attestation.SourceEpoch:
+				recorded = true
+			case record.TargetEpoch == attestation.TargetEpoch:
+				risks = append(risks, SlashingRisk{
+					ValidatorIndex: index,
+					Kind:           SlashingRiskKind_DoubleVote,
+					Slot:           attestation.Slot,
+					Description:    fmt.Sprintf("attested to source %d and target %d, but its signing history has source %d for that target", attestation.SourceEpoch, attestation.TargetEpoch, record.SourceEpoch),
+				})
+			case (attestation.SourceEpoch < record.SourceEpoch && record.TargetEpoch < attestation.TargetEpoch) ||
+				(record.SourceEpoch < attestation.SourceEpoch && attestation.TargetEpoch < record.TargetEpoch):
+				risks = append(risks, SlashingRisk{
+					ValidatorIndex: index,
+					Kind:           SlashingRiskKind_SurroundVote,
+					Slot:           attestation.Slot,
+					Description:    fmt.Sprintf("attested to source %d and target %d, which conflicts with source %d and target %d in its signing history", attestation.SourceEpoch, attestation.TargetEpoch, record.SourceEpoch, record.TargetEpoch),
+				})
+			}
+		}
+
+		// Signing histories are often pruned down to their latest entries, so only attestations newer than anything in
+		// the history are evidence that the keys are being used somewhere else
+		if !recorded && !hasLaterAttestation(history, attestation.TargetEpoch) {
+			risks = append(risks, SlashingRisk{
+				ValidatorIndex: index,
+				Kind:           SlashingRiskKind_UnrecordedActivity,
+				Slot:           attestation.Slot,
+				Description:    fmt.Sprintf("attested in epoch %d, which is missing from its signing history", attestation.Slot/slotsPerEpoch),
+			})
+		}
+	}
+	return risks
+}
+
+// Check if the signing history has a proposal for the provided slot or a later one
+func hasLaterProposal(history *SigningHistory, slot uint64) bool {
+	for _, record := range history.Proposals {
+		if record.Slot >= slot {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if the signing history has an attestation with the provided target epoch or a later one
+func hasLaterAttestation(history *SigningHistory, targetEpoch uint64) bool {
+	for _, record := range history.Attestations {
+		if record.TargetEpoch >= targetEpoch {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -69,3 +71,79 @@ func (m *ValidatorManager) LoadKey(pubkey beacon.ValidatorPubkey) (*types.BLSPri
 		return nil, fmt.Errorf("couldn't find the key for validator %s in any of the validator manager's keystores", pubkey.Hex())
 	}
 }
+
+// Gets the pubkeys of the validator keys stored in any of the manager's client keystores, in sorted order
+func (m *ValidatorManager) GetStoredPubkeys() ([]beacon.ValidatorPubkey, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.getStoredPubkeys()
+}
+
+// Calls the provided function with each validator key stored in the manager's client keystores, in pubkey order.
+// Iteration stops if the function returns an error or a key can't be loaded.
+func (m *ValidatorManager) ForEachKey(fn func(pubkey beacon.ValidatorPubkey, key *types.BLSPrivateKey) error) error {
+	pubkeys, err := m.GetStoredPubkeys()
+	if err != nil {
+		return err
+	}
+	for _, pubkey := range pubkeys {
+		key, err := m.LoadKey(pubkey)
+		if err != nil {
+			return err
+		}
+		err = fn(pubkey, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Re-encrypts a validator key with a new random password in each of the manager's client keystores that has it
+func (m *ValidatorManager) ChangeKeyPassword(pubkey beacon.ValidatorPubkey) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	found := false
+	for name, mgr := range m.keystoreManagers {
+		key, err := mgr.LoadValidatorKey(pubkey)
+		if err != nil {
+			return fmt.Errorf("error loading validator key %s from the %s keystore: %w", pubkey.HexWithPrefix(), name, err)
+		}
+		if key == nil {
+			continue
+		}
+		found = true
+		err = mgr.ChangeValidatorKeyPassword(pubkey)
+		if err != nil {
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("couldn't find the key for validator %s in any of the validator manager's keystores", pubkey.Hex())
+	}
+	return nil
+}
+
+// Gets the sorted union of the pubkeys in the manager's client keystores; the lock must be held
+func (m *ValidatorManager) getStoredPubkeys() ([]beacon.ValidatorPubkey, error) {
+	pubkeyMap := map[beacon.ValidatorPubkey]bool{}
+	for name, mgr := range m.keystoreManagers {
+		pubkeys, err := mgr.GetStoredPubkeys()
+		if err != nil {
+			return nil, fmt.Errorf("error getting the validator keys in the %s keystore: %w", name, err)
+		}
+		for _, pubkey := range pubkeys {
+			pubkeyMap[pubkey] = true
+		}
+	}
+
+	pubkeys := make([]beacon.ValidatorPubkey, 0, len(pubkeyMap))
+	for pubkey := range pubkeyMap {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	slices.SortFunc(pubkeys, func(a beacon.ValidatorPubkey, b beacon.ValidatorPubkey) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return pubkeys, nil
+}
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// A withdrawal to a validator that was included in a block
+type WithdrawalRecord struct {
+	// The global index of the withdrawal
+	Index uint64
+
+	// The slot of the block that included the withdrawal
+	Slot uint64
+
+	// The execution block that included the withdrawal
+	ExecutionBlockNumber uint64
+
+	// The address the withdrawal was sent to
+	Address common.Address
+
+	// The amount withdrawn, in gwei
+	Amount uint64
+}
+
+// The withdrawals to a validator over a range of slots
+type ValidatorWithdrawalHistory struct {
+	Withdrawals []WithdrawalRecord
+
+	// The total amount withdrawn, in gwei
+	TotalAmount uint64
+}
+
+// Get the total amount withdrawn, in wei
+func (h *ValidatorWithdrawalHistory) GetTotalAmountWei() *big.Int {
+	total := new(big.Int).SetUint64(h.TotalAmount)
+	return total.Mul(total, big.NewInt(1e9))
+}
+
+// The withdrawals to a set of validators over a range of slots
+type WithdrawalHistory struct {
+	StartSlot  uint64
+	EndSlot    uint64
+	Validators map[string]*ValidatorWithdrawalHistory
+}
+
+// Walk the blocks in the provided range of slots (inclusive) and collect the withdrawals to each of the provided
+// validators. Every provided validator has an entry in the history, even if it didn't have any withdrawals.
+func GetWithdrawalHistory(ctx context.Context, bc beacon.IBeaconClient, indices []string, startSlot uint64, endSlot uint64) (*WithdrawalHistory, error) {
+	if endSlot < startSlot {
+		return nil, fmt.Errorf("end slot %d is before start slot %d", endSlot, startSlot)
+	}
+
+	history := &WithdrawalHistory{
+		StartSlot:  startSlot,
+		EndSlot:    endSlot,
+		Validators: map[string]*ValidatorWithdrawalHistory{},
+	}
+	for _, index := range indices {
+		history.Validators[index] = &ValidatorWithdrawalHistory{
+			Withdrawals: []WithdrawalRecord{},
+		}
+	}
+
+	for slot := startSlot; slot <= endSlot; slot++ {
+		block, exists, err := bc.GetBeaconBlock(ctx, strconv.FormatUint(slot, 10))
+		if err != nil {
+			return nil, fmt.Errorf("error getting block for slot %d: %w", slot, err)
+		}
+		if !exists || !block.HasExecutionPayload {
+			continue
+		}
+		for _, withdrawal := range block.Withdrawals {
+			validatorHistory, exists := history.Validators[withdrawal.ValidatorIndex]
+			if !exists {
+				continue
+			}
+			validatorHistory.Withdrawals = append(validatorHistory.Withdrawals, WithdrawalRecord{
+				Index:                withdrawal.Index,
+				Slot:                 slot,
+				ExecutionBlockNumber: block.ExecutionBlockNumber,
+				Address:              withdrawal.Address,
+				Amount:               withdrawal.Amount,
+			})
+			validatorHistory.TotalAmount += withdrawal.Amount
+		}
+	}
+	return history, nil
+}
+
+// Collect the withdrawals to each of the provided validators in blocks between the provided times (inclusive).
+// See GetWithdrawalHistory for details.
+func GetWithdrawalHistoryForTimeRange(ctx context.Context, bc beacon.IBeaconClient, indices []string, start time.Time, end time.Time) (*WithdrawalHistory, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("end time %s is before start time %s", end, start)
+	}
+	eth2Config, err := bc.GetEth2Config(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	if eth2Config.SecondsPerSlot == 0 {
+		return nil, fmt.Errorf("Beacon config has an invalid slot length")
+	}
+
+	// Round the start up and the end down so only slots within the range are included
+	if end.Before(eth2Config.GenesisTimestamp()) {
+		return nil, fmt.Errorf("end time %s is before genesis", end)
+	}
+	startSlot := eth2Config.SlotAt(start)
+	if eth2Config.SlotStartTime(startSlot).Before(start) {
+		startSlot++
+	}
+	endSlot := eth2Config.SlotAt(end)
+	if endSlot < startSlot {
+		return nil, fmt.Errorf("there are no slots between %s and %s", start, end)
+	}
+	return GetWithdrawalHistory(ctx, bc, indices, startSlot, endSlot)
+}
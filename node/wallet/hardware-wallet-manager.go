@@ -0,0 +1,209 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/wallet"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Wallet manager for node keys held on a Ledger or Trezor device. Signing requests are sent to the device, where the
+// operator has to confirm them.
+type hardwareWalletManager struct {
+	// The ID of the execution layer chain currently being used
+	chainID *big.Int
+
+	// The context for the manager's requests, which is cancelled when the daemon shuts down
+	ctx context.Context
+
+	// The connected device
+	device accounts.Wallet
+
+	// The node account on the device
+	account accounts.Account
+
+	// Serialized data of the loaded wallet
+	data *wallet.HardwareWalletData
+
+	// Transactor for signing transactions
+	transactor *bind.TransactOpts
+}
+
+// Creates a new wallet manager for hardware wallets
+func newHardwareWalletManager(ctx context.Context, chainID uint) *hardwareWalletManager {
+	return &hardwareWalletManager{
+		chainID: big.NewInt(int64(chainID)),
+		ctx:     ctx,
+	}
+}
+
+// Get the type of this wallet manager
+func (m *hardwareWalletManager) GetType() wallet.WalletType {
+	return wallet.WalletType_Hardware
+}
+
+// Get the address of the node account on the device
+func (m *hardwareWalletManager) GetAddress() (common.Address, error) {
+	if m.device == nil {
+		return common.Address{}, fmt.Errorf("wallet is not initialized")
+	}
+	return m.account.Address, nil
+}
+
+// Get the transactor for the wallet
+func (m *hardwareWalletManager) GetTransactor() (*bind.TransactOpts, error) {
+	if m.transactor == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	return m.transactor, nil
+}
+
+// Connect to the first device of the provided kind, derive the node account at the provided path, and load it up
+func (m *hardwareWalletManager) InitializeDevice(device wallet.HardwareWalletDevice, derivationPath string, walletIndex uint) (*wallet.HardwareWalletData, error) {
+	if derivationPath == "" {
+		derivationPath = wallet.DefaultNodeKeyPath
+	}
+	data := &wallet.HardwareWalletData{
+		Device:         device,
+		DerivationPath: derivationPath,
+		WalletIndex:    walletIndex,
+	}
+	err := m.LoadWallet(data)
+	if err != nil {
+		return nil, err
+	}
+	data.Address = m.account.Address
+	return data, nil
+}
+
+// Connect to the device described by the wallet data and load the node account from it. If the data has an address,
+// the device must hold the key for it.
+func (m *hardwareWalletManager) LoadWallet(data *wallet.HardwareWalletData) error {
+	// Get the hub for the device type
+	var hub *usbwallet.Hub
+	var err error
+	switch data.Device {
+	case wallet.HardwareWalletDevice_Ledger:
+		hub, err = usbwallet.NewLedgerHub()
+	case wallet.HardwareWalletDevice_Trezor:
+		hub, err = usbwallet.NewTrezorHubWithHID()
+	default:
+		return fmt.Errorf("unsupported hardware wallet device [%s]", data.Device)
+	}
+	if err != nil {
+		return fmt.Errorf("error connecting to %s devices: %w", data.Device, err)
+	}
+	devices := hub.Wallets()
+	if len(devices) == 0 {
+		return fmt.Errorf("no %s device is connected", data.Device)
+	}
+	device := devices[0]
+
+	// Open it; a Trezor that needs a PIN or passphrase can't be unlocked from the daemon
+	err = device.Open("")
+	if err != nil && !errors.Is(err, accounts.ErrWalletAlreadyOpen) {
+		return fmt.Errorf("error opening %s device (make sure it's unlocked and the Ethereum app is open): %w", data.Device, err)
+	}
+
+	// Derive the node account
+	derivationPath := data.DerivationPath
+	if derivationPath == "" {
+		derivationPath = wallet.DefaultNodeKeyPath
+	}
+	formattedDerivationPath := fmt.Sprintf(derivationPath, data.WalletIndex)
+	path, err := accounts.ParseDerivationPath(formattedDerivationPath)
+	if err != nil {
+		_ = device.Close()
+		return fmt.Errorf("invalid node key derivation path '%s': %w", formattedDerivationPath, err)
+	}
+	account, err := device.Derive(path, true)
+	if err != nil {
+		_ = device.Close()
+		return fmt.Errorf("error deriving node account at path '%s' on %s device: %w", formattedDerivationPath, data.Device, err)
+	}
+	if data.Address != (common.Address{}) && account.Address != data.Address {
+		_ = device.Close()
+		return fmt.Errorf("connected %s device has address %s at path '%s' instead of the wallet's address %s", data.Device, account.Address.Hex(), formattedDerivationPath, data.Address.Hex())
+	}
+
+	// Make a transactor that sends transactions to the device for signing
+	transactor := &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return device.SignTx(account, tx, m.chainID)
+		},
+		Context: m.ctx,
+	}
+
+	// Store everything if there are no errors
+	m.device = device
+	m.account = account
+	m.data = data
+	m.transactor = transactor
+	return nil
+}
+
+// Signs a message with the node account on the device. Ledger and Trezor devices don't support this through the USB
+// backend, so it returns ErrNotSupported for them.
+func (m *hardwareWalletManager) SignMessage(message []byte) ([]byte, error) {
+	if m.device == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	signedMessage, err := m.device.SignText(m.account, message)
+	if errors.Is(err, accounts.ErrNotSupported) {
+		return nil, ErrNotSupported
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error signing message: %w", err)
+	}
+	return signedMessage, nil
+}
+
+// Signs a transaction with the node account on the device
+func (m *hardwareWalletManager) SignTransaction(serializedTx []byte) ([]byte, error) {
+	if m.device == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	tx := types.Transaction{}
+	err := tx.UnmarshalBinary(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling TX: %w", err)
+	}
+
+	signedTx, err := m.device.SignTx(m.account, &tx, m.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error signing TX: %w", err)
+	}
+
+	signedData, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling signed TX to binary: %w", err)
+	}
+
+	return signedData, nil
+}
+
+// Serialize the wallet data as JSON
+func (m *hardwareWalletManager) SerializeData() (string, error) {
+	if m.data == nil {
+		return "", fmt.Errorf("wallet is not initialized")
+	}
+
+	bytes, err := json.Marshal(m.data)
+	if err != nil {
+		return "", fmt.Errorf("error serializing wallet data: %w", err)
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,101 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+const (
+	// The default profile in an AWS shared credentials file
+	awsDefaultProfile string = "default"
+)
+
+var (
+	// Checks region names before they're handed to the SDK
+	awsRegionRegex = regexp.MustCompile(wallet.AwsRegionRegex)
+)
+
+// A secp256k1 key in AWS KMS, accessed through the AWS SDK
+type awsKmsSigner struct {
+	keyId  string
+	client *kms.Client
+}
+
+// Creates a new signer for an AWS KMS key. If the wallet data has a credentials file, its profile is used; otherwise
+// the credentials come from the SDK's default chain (environment variables, web identity tokens, the shared config
+// files, or the instance's role), which refreshes temporary credentials before they expire.
+func newAwsKmsSigner(ctx context.Context, data *wallet.KmsWalletData) (*awsKmsSigner, error) {
+	if data.KeyId == "" {
+		return nil, fmt.Errorf("key ID is required")
+	}
+	if data.Region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+	if !awsRegionRegex.MatchString(data.Region) {
+		return nil, fmt.Errorf("invalid region [%s]", data.Region)
+	}
+
+	options := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(data.Region),
+		awsconfig.WithHTTPClient(&http.Client{Timeout: kmsTimeout}),
+	}
+	if data.CredentialsPath != "" {
+		// Setting the profile explicitly makes the SDK use the file instead of any credentials in the environment
+		profile := data.Profile
+		if profile == "" {
+			profile = awsDefaultProfile
+		}
+		options = append(options,
+			awsconfig.WithSharedCredentialsFiles([]string{data.CredentialsPath}),
+			awsconfig.WithSharedConfigProfile(profile),
+		)
+	} else if data.Profile != "" {
+		options = append(options, awsconfig.WithSharedConfigProfile(data.Profile))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config: %w", err)
+	}
+
+	return &awsKmsSigner{
+		keyId:  data.KeyId,
+		client: kms.NewFromConfig(cfg),
+	}, nil
+}
+
+// Get the public key of the key
+func (s *awsKmsSigner) GetPublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	response, err := s.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{
+		KeyId: aws.String(s.keyId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting public key: %w", err)
+	}
+	if response.KeySpec != kmstypes.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("key has spec [%s] instead of %s", response.KeySpec, kmstypes.KeySpecEccSecgP256k1)
+	}
+	return parseSecp256k1PublicKeyInfo(response.PublicKey)
+}
+
+// Sign a 32-byte digest with the key, returning the DER-encoded ECDSA signature
+func (s *awsKmsSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	response, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyId),
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error signing digest: %w", err)
+	}
+	return response.Signature, nil
+}
@@ -0,0 +1,224 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+const (
+	// The Cloud KMS API endpoint
+	gcpKmsEndpoint string = "https://cloudkms.googleapis.com/v1/"
+
+	// The OAuth scope needed to use Cloud KMS keys
+	gcpKmsScope string = "https://www.googleapis.com/auth/cloudkms"
+
+	// The metadata server endpoint that provides access tokens for the instance's service account
+	gcpMetadataTokenUrl string = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// How long before an access token expires to get a new one
+	gcpTokenRefreshMargin time.Duration = time.Minute
+)
+
+// The fields used from a Google service account key file
+type gcpServiceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenUri    string `json:"token_uri"`
+}
+
+// A secp256k1 key version in Google Cloud KMS, accessed through the Cloud KMS REST API
+type gcpKmsSigner struct {
+	keyVersion     string
+	serviceAccount *gcpServiceAccountKey
+	client         *http.Client
+
+	accessToken string
+	tokenExpiry time.Time
+	tokenLock   sync.Mutex
+}
+
+// Creates a new signer for a Cloud KMS key version
+func newGcpKmsSigner(data *wallet.KmsWalletData) (*gcpKmsSigner, error) {
+	if data.KeyId == "" {
+		return nil, fmt.Errorf("key version resource name is required")
+	}
+	signer := &gcpKmsSigner{
+		keyVersion: strings.TrimPrefix(data.KeyId, "/"),
+		client:     &http.Client{Timeout: kmsTimeout},
+	}
+
+	// Load the service account key if there is one; otherwise the metadata server is used
+	if data.CredentialsPath != "" {
+		keyBytes, err := os.ReadFile(data.CredentialsPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading service account key [%s]: %w", data.CredentialsPath, err)
+		}
+		serviceAccount := new(gcpServiceAccountKey)
+		err = json.Unmarshal(keyBytes, serviceAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing service account key [%s]: %w", data.CredentialsPath, err)
+		}
+		if serviceAccount.ClientEmail == "" || serviceAccount.PrivateKey == "" || serviceAccount.TokenUri == "" {
+			return nil, fmt.Errorf("service account key [%s] is missing its client email, private key, or token URI", data.CredentialsPath)
+		}
+		signer.serviceAccount = serviceAccount
+	}
+	return signer, nil
+}
+
+// Get the public key of the key version
+func (s *gcpKmsSigner) GetPublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	var response struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	err := s.call(ctx, http.MethodGet, s.keyVersion+"/publicKey", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Algorithm != "EC_SIGN_SECP256K1_SHA256" {
+		return nil, fmt.Errorf("key has algorithm [%s] instead of EC_SIGN_SECP256K1_SHA256", response.Algorithm)
+	}
+	block, _ := pem.Decode([]byte(response.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("public key is not PEM-encoded")
+	}
+	return parseSecp256k1PublicKeyInfo(block.Bytes)
+}
+
+// Sign a 32-byte digest with the key version, returning the DER-encoded ECDSA signature
+func (s *gcpKmsSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	request := map[string]any{
+		"digest": map[string][]byte{
+			"sha256": digest,
+		},
+	}
+	var response struct {
+		Signature []byte `json:"signature"`
+	}
+	err := s.call(ctx, http.MethodPost, s.keyVersion+":asymmetricSign", request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Signature, nil
+}
+
+// Call a Cloud KMS API method
+func (s *gcpKmsSigner) call(ctx context.Context, method string, path string, request any, response any) error {
+	token, err := s.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if request != nil {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("error serializing request for [%s]: %w", path, err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, method, gcpKmsEndpoint+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request for [%s]: %w", path, err)
+	}
+	httpRequest.Header.Set("Authorization", "Bearer "+token)
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+
+	return s.send(httpRequest, response)
+}
+
+// Get an access token for Cloud KMS, reusing the last one until it's about to expire
+func (s *gcpKmsSigner) getAccessToken(ctx context.Context) (string, error) {
+	s.tokenLock.Lock()
+	defer s.tokenLock.Unlock()
+	if s.accessToken != "" && time.Now().Add(gcpTokenRefreshMargin).Before(s.tokenExpiry) {
+		return s.accessToken, nil
+	}
+
+	var request *http.Request
+	var err error
+	if s.serviceAccount == nil {
+		// Get a token for the instance's service account
+		request, err = http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenUrl, nil)
+		if err != nil {
+			return "", fmt.Errorf("error creating metadata token request: %w", err)
+		}
+		request.Header.Set("Metadata-Flavor", "Google")
+	} else {
+		// Exchange a signed assertion for a token
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(s.serviceAccount.PrivateKey))
+		if err != nil {
+			return "", fmt.Errorf("error parsing service account private key: %w", err)
+		}
+		now := time.Now()
+		assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":   s.serviceAccount.ClientEmail,
+			"scope": gcpKmsScope,
+			"aud":   s.serviceAccount.TokenUri,
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+		}).SignedString(privateKey)
+		if err != nil {
+			return "", fmt.Errorf("error signing service account assertion: %w", err)
+		}
+		form := url.Values{
+			"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+			"assertion":  {assertion},
+		}
+		request, err = http.NewRequestWithContext(ctx, http.MethodPost, s.serviceAccount.TokenUri, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", fmt.Errorf("error creating token request: %w", err)
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	err = s.send(request, &response)
+	if err != nil {
+		return "", fmt.Errorf("error getting access token: %w", err)
+	}
+	s.accessToken = response.AccessToken
+	s.tokenExpiry = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	return s.accessToken, nil
+}
+
+// Send a request and deserialize its JSON response
+func (s *gcpKmsSigner) send(request *http.Request, response any) error {
+	httpResponse, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending request to [%s]: %w", request.URL.Redacted(), err)
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response from [%s]: %w", request.URL.Redacted(), err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to [%s] failed with code %d: %s", request.URL.Redacted(), httpResponse.StatusCode, string(responseBody))
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("error deserializing response from [%s]: %w", request.URL.Redacted(), err)
+	}
+	return nil
+}
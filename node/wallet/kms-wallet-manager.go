@@ -0,0 +1,283 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/wallet"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// The time to wait for the key management service to respond to a request
+	kmsTimeout time.Duration = 30 * time.Second
+)
+
+var (
+	// The order of the secp256k1 curve, and half of it for normalizing signatures
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// A secp256k1 key held by a cloud key management service
+type IKmsSigner interface {
+	// Get the public key of the key
+	GetPublicKey(ctx context.Context) (*ecdsa.PublicKey, error)
+
+	// Sign a 32-byte digest with the key, returning the DER-encoded ECDSA signature
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Wallet manager for node keys held by a cloud key management service, such as AWS KMS or Google Cloud KMS
+type kmsWalletManager struct {
+	// The ID of the execution layer chain currently being used
+	chainID *big.Int
+
+	// The context for the manager's requests, which is cancelled when the daemon shuts down
+	ctx context.Context
+
+	// The key on the key management service
+	signer IKmsSigner
+
+	// The public key of the node account
+	publicKey *ecdsa.PublicKey
+
+	// The node account's address
+	address common.Address
+
+	// Serialized data of the loaded wallet
+	data *wallet.KmsWalletData
+
+	// Transactor for signing transactions
+	transactor *bind.TransactOpts
+}
+
+// Creates a new wallet manager for KMS keys
+func newKmsWalletManager(ctx context.Context, chainID uint) *kmsWalletManager {
+	return &kmsWalletManager{
+		chainID: big.NewInt(int64(chainID)),
+		ctx:     ctx,
+	}
+}
+
+// Get the type of this wallet manager
+func (m *kmsWalletManager) GetType() wallet.WalletType {
+	return wallet.WalletType_Kms
+}
+
+// Get the address of the node account
+func (m *kmsWalletManager) GetAddress() (common.Address, error) {
+	if m.signer == nil {
+		return common.Address{}, fmt.Errorf("wallet is not initialized")
+	}
+	return m.address, nil
+}
+
+// Get the transactor for the wallet
+func (m *kmsWalletManager) GetTransactor() (*bind.TransactOpts, error) {
+	if m.transactor == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	return m.transactor, nil
+}
+
+// Connect to the key described by the wallet data and load the node account from it. If the data has an address, the
+// key must have that address.
+func (m *kmsWalletManager) LoadWallet(data *wallet.KmsWalletData) error {
+	var signer IKmsSigner
+	var err error
+	switch data.Provider {
+	case wallet.KmsProvider_Aws:
+		signer, err = newAwsKmsSigner(m.ctx, data)
+	case wallet.KmsProvider_Gcp:
+		signer, err = newGcpKmsSigner(data)
+	default:
+		return fmt.Errorf("unsupported KMS provider [%s]", data.Provider)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating %s KMS client: %w", data.Provider, err)
+	}
+
+	// Get the node account
+	ctx, cancel := context.WithTimeout(m.ctx, kmsTimeout)
+	defer cancel()
+	publicKey, err := signer.GetPublicKey(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting public key of KMS key [%s]: %w", data.KeyId, err)
+	}
+	address := crypto.PubkeyToAddress(*publicKey)
+	if data.Address != (common.Address{}) && address != data.Address {
+		return fmt.Errorf("KMS key [%s] has address %s instead of the wallet's address %s", data.KeyId, address.Hex(), data.Address.Hex())
+	}
+
+	// Make a transactor that sends transactions to the key management service for signing
+	transactor := &bind.TransactOpts{
+		From: address,
+		Signer: func(signerAddress common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if signerAddress != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return m.signTx(tx)
+		},
+		Context: m.ctx,
+	}
+
+	// Store everything if there are no errors
+	loadedData := *data
+	loadedData.Address = address
+	m.signer = signer
+	m.publicKey = publicKey
+	m.address = address
+	m.data = &loadedData
+	m.transactor = transactor
+	return nil
+}
+
+// Signs a message with the node account's key
+func (m *kmsWalletManager) SignMessage(message []byte) ([]byte, error) {
+	if m.signer == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	signedMessage, err := m.sign(accounts.TextHash(message))
+	if err != nil {
+		return nil, fmt.Errorf("error signing message: %w", err)
+	}
+
+	// fix the ECDSA 'v' (see https://medium.com/mycrypto/the-magic-of-digital-signatures-on-ethereum-98fe184dc9c7#:~:text=The%20version%20number,2%E2%80%9D%20was%20introduced)
+	signedMessage[crypto.RecoveryIDOffset] += 27
+	return signedMessage, nil
+}
+
+// Signs a transaction with the node account's key
+func (m *kmsWalletManager) SignTransaction(serializedTx []byte) ([]byte, error) {
+	if m.signer == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	tx := types.Transaction{}
+	err := tx.UnmarshalBinary(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling TX: %w", err)
+	}
+
+	signedTx, err := m.signTx(&tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling signed TX to binary: %w", err)
+	}
+
+	return signedData, nil
+}
+
+// Serialize the wallet data as JSON
+func (m *kmsWalletManager) SerializeData() (string, error) {
+	if m.data == nil {
+		return "", fmt.Errorf("wallet is not initialized")
+	}
+
+	bytes, err := json.Marshal(m.data)
+	if err != nil {
+		return "", fmt.Errorf("error serializing wallet data: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// Sign a transaction with the node account's key
+func (m *kmsWalletManager) signTx(tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(m.chainID)
+	signature, err := m.sign(signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error signing TX: %w", err)
+	}
+	signedTx, err := tx.WithSignature(signer, signature)
+	if err != nil {
+		return nil, fmt.Errorf("error applying signature to TX: %w", err)
+	}
+	return signedTx, nil
+}
+
+// Sign a digest with the node account's key, converting the DER signature from the key management service into the
+// 65-byte [R || S || V] format Ethereum uses (with V being 0 or 1)
+func (m *kmsWalletManager) sign(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, kmsTimeout)
+	defer cancel()
+	derSignature, err := m.signer.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the signature
+	var parsed struct {
+		R *big.Int
+		S *big.Int
+	}
+	rest, err := asn1.Unmarshal(derSignature, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KMS signature: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("KMS signature has %d extra bytes after it", len(rest))
+	}
+
+	// R and S must both be in [1, N-1]; anything else would be truncated or wrapped when it's packed into 32 bytes
+	if !isValidSignatureScalar(parsed.R) || !isValidSignatureScalar(parsed.S) {
+		return nil, fmt.Errorf("KMS signature has an R or S value outside of the curve order")
+	}
+
+	// Ethereum requires the lower of the two valid S values
+	s := parsed.S
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	signature := make([]byte, crypto.SignatureLength)
+	parsed.R.FillBytes(signature[0:32])
+	s.FillBytes(signature[32:64])
+
+	// KMS doesn't provide the recovery ID, so find the one that recovers the node account's key
+	expectedPubkey := crypto.FromECDSAPub(m.publicKey)
+	for v := byte(0); v < 2; v++ {
+		signature[crypto.RecoveryIDOffset] = v
+		pubkey, err := crypto.Ecrecover(digest, signature)
+		if err == nil && string(pubkey) == string(expectedPubkey) {
+			return signature, nil
+		}
+	}
+	return nil, fmt.Errorf("KMS signature doesn't match the key's public key")
+}
+
+// Check if a signature's R or S value is in the range [1, N-1]
+func isValidSignatureScalar(value *big.Int) bool {
+	return value != nil && value.Sign() > 0 && value.Cmp(secp256k1N) < 0
+}
+
+// Parse a DER-encoded SubjectPublicKeyInfo holding a secp256k1 public key. The x509 package doesn't support the
+// secp256k1 curve, so this only unwraps the structure and leaves the point parsing to the crypto package.
+func parseSecp256k1PublicKeyInfo(der []byte) (*ecdsa.PublicKey, error) {
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	_, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key info: %w", err)
+	}
+	publicKey, err := crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("key is not a secp256k1 key: %w", err)
+	}
+	return publicKey, nil
+}
@@ -32,13 +32,19 @@ type localWalletManager struct {
 	// The ID of the execution layer chain currently being used
 	chainID *big.Int
 
-	// Encryptor used to encrypt the account seed
+	// The context for the manager's requests, which is cancelled when the daemon shuts down
+	ctx context.Context
+
+	// Encryptor for wallets still using the legacy keystore format
 	encryptor *eth2ks.Encryptor
 
 	// Root secret used for EL node wallet derivation and BLS validator key derivation
 	// This is the "master" constructed directly from the mnemonic
 	seed []byte
 
+	// The master key derived from the seed, used to derive the accounts at each index
+	masterKey *hdkeychain.ExtendedKey
+
 	// Derived node wallet private key on the EL
 	nodePrivateKey *ecdsa.PrivateKey
 
@@ -50,9 +56,10 @@ type localWalletManager struct {
 }
 
 // Creates a new wallet manager for local wallets
-func newLocalWalletManager(chainID uint) *localWalletManager {
+func newLocalWalletManager(ctx context.Context, chainID uint) *localWalletManager {
 	return &localWalletManager{
 		chainID:   big.NewInt(int64(chainID)),
+		ctx:       ctx,
 		encryptor: eth2ks.New(),
 	}
 }
@@ -104,13 +111,14 @@ func (m *localWalletManager) GetTransactor() (*bind.TransactOpts, error) {
 	return m.transactor, nil
 }
 
-// Initialize a new keystore from a mnemonic and derivation info, derive the corresponding key, and load it all up
-func (m *localWalletManager) InitializeKeystore(derivationPath string, walletIndex uint, mnemonic string, password string) (*wallet.LocalWalletData, error) {
+// Initialize a new keystore from a mnemonic and derivation info, derive the corresponding key, and load it all up. The
+// seed is encrypted with a key derived from the password with the provided KDF settings.
+func (m *localWalletManager) InitializeKeystore(derivationPath string, walletIndex uint, mnemonic string, password string, kdfSettings wallet.KdfSettings) (*wallet.LocalWalletData, error) {
 	// Generate the seed from the mnemonic
 	seed := bip39.NewSeed(mnemonic, "")
 
 	// Encrypt the seed with the password
-	encryptedSeed, err := m.encryptor.Encrypt(seed, password)
+	encryptedSeed, err := encryptSeed(seed, password, kdfSettings)
 	if err != nil {
 		return nil, fmt.Errorf("error encrypting wallet seed: %w", err)
 	}
@@ -118,8 +126,8 @@ func (m *localWalletManager) InitializeKeystore(derivationPath string, walletInd
 	// Create a new wallet data
 	data := &wallet.LocalWalletData{
 		Crypto:         encryptedSeed,
-		Name:           m.encryptor.Name(),
-		Version:        m.encryptor.Version(),
+		Name:           seedEncryptorName,
+		Version:        seedEncryptorVersion,
 		UUID:           uuid.New(),
 		DerivationPath: derivationPath,
 		WalletIndex:    walletIndex,
@@ -134,6 +142,28 @@ func (m *localWalletManager) InitializeKeystore(derivationPath string, walletInd
 	return data, nil
 }
 
+// Check if the loaded wallet's seed is still in the legacy keystore format, which is protected by PBKDF2 instead of a
+// memory-hard KDF
+func (m *localWalletManager) IsLegacyFormat() bool {
+	return m.data != nil && m.data.Name != seedEncryptorName
+}
+
+// Encrypt the loaded wallet's seed again with the provided password and KDF settings. The wallet data is updated in
+// place, so it has to be saved again for the change to persist.
+func (m *localWalletManager) ReencryptSeed(password string, kdfSettings wallet.KdfSettings) error {
+	if len(m.seed) == 0 {
+		return fmt.Errorf("wallet is not initialized")
+	}
+	encryptedSeed, err := encryptSeed(m.seed, password, kdfSettings)
+	if err != nil {
+		return fmt.Errorf("error encrypting wallet seed: %w", err)
+	}
+	m.data.Crypto = encryptedSeed
+	m.data.Name = seedEncryptorName
+	m.data.Version = seedEncryptorVersion
+	return nil
+}
+
 // Verifies that the provided password is correct for this wallet's keystore
 func (m *localWalletManager) VerifyPassword(password string) (bool, error) {
 	if m.data == nil {
@@ -141,7 +171,7 @@ func (m *localWalletManager) VerifyPassword(password string) (bool, error) {
 	}
 
 	// Make a new local manager and load the data with the candidate password
-	candidateMgr := newLocalWalletManager(0)
+	candidateMgr := newLocalWalletManager(m.ctx, 0)
 	err := candidateMgr.LoadWallet(m.data, password)
 	if err != nil {
 		return false, fmt.Errorf("error verifying wallet with candidate password: %w", err)
@@ -155,8 +185,17 @@ func (m *localWalletManager) VerifyPassword(password string) (bool, error) {
 // Load the node wallet's private key from the keystore
 func (m *localWalletManager) LoadWallet(data *wallet.LocalWalletData, password string) error {
 	// Decrypt the seed
+	var seed []byte
 	var err error
-	seed, err := m.encryptor.Decrypt(data.Crypto, password)
+	switch data.Name {
+	case seedEncryptorName:
+		seed, err = decryptSeed(data.Crypto, password)
+	case m.encryptor.Name():
+		// Legacy keystore format
+		seed, err = m.encryptor.Decrypt(data.Crypto, password)
+	default:
+		return fmt.Errorf("unsupported wallet keystore format [%s]", data.Name)
+	}
 	if err != nil {
 		return fmt.Errorf("error decrypting wallet keystore: %w", err)
 	}
@@ -172,68 +211,100 @@ func (m *localWalletManager) LoadWallet(data *wallet.LocalWalletData, password s
 		data.DerivationPath = wallet.DefaultNodeKeyPath
 	}
 
-	// Get the derived key
-	derivedKey, index, err := getDerivedKey(masterKey, data.DerivationPath, data.WalletIndex)
+	// Get the private key
+	privateKeyECDSA, index, err := derivePrivateKey(masterKey, data.DerivationPath, data.WalletIndex)
 	if err != nil {
-		return fmt.Errorf("error getting node wallet derived key: %w", err)
+		return err
 	}
 	data.WalletIndex = index // Update the index in case of the ErrInvalidChild issue
 
-	// Get the private key from it
-	privateKey, err := derivedKey.ECPrivKey()
-	if err != nil {
-		return fmt.Errorf("error getting node wallet private key: %w", err)
-	}
-	privateKeyECDSA := privateKey.ToECDSA()
-
 	// Make a transactor from it
-	transactor, err := bind.NewKeyedTransactorWithChainID(privateKeyECDSA, m.chainID)
+	transactor, err := m.createTransactor(privateKeyECDSA)
 	if err != nil {
-		return fmt.Errorf("error creating transactor for node private key: %w", err)
+		return err
 	}
-	transactor.Context = context.Background()
 
 	// Store everything if there are no errors
 	m.seed = seed
+	m.masterKey = masterKey
 	m.nodePrivateKey = privateKeyECDSA
 	m.data = data
 	m.transactor = transactor
 	return nil
 }
 
-// Signs a message with the node wallet's private key
-func (m *localWalletManager) SignMessage(message []byte) ([]byte, error) {
-	messageHash := accounts.TextHash(message)
-	signedMessage, err := crypto.Sign(messageHash, m.nodePrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("error signing message: %w", err)
+// Get the accounts derived from the wallet's seed at the provided range of indices. Indices that can't be derived
+// (see getDerivedKey) are skipped, so the returned indices may not be contiguous.
+func (m *localWalletManager) GetDerivedAccounts(startIndex uint, count uint) ([]wallet.DerivedAccount, error) {
+	if m.masterKey == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
 	}
 
-	// fix the ECDSA 'v' (see https://medium.com/mycrypto/the-magic-of-digital-signatures-on-ethereum-98fe184dc9c7#:~:text=The%20version%20number,2%E2%80%9D%20was%20introduced)
-	signedMessage[crypto.RecoveryIDOffset] += 27
-	return signedMessage, nil
+	derivedAccounts := make([]wallet.DerivedAccount, 0, count)
+	for index := startIndex; index < startIndex+count; index++ {
+		privateKey, derivedIndex, err := derivePrivateKey(m.masterKey, m.data.DerivationPath, index)
+		if err != nil {
+			return nil, err
+		}
+		if derivedIndex != index {
+			continue
+		}
+		derivedAccounts = append(derivedAccounts, wallet.DerivedAccount{
+			Index:   index,
+			Address: crypto.PubkeyToAddress(privateKey.PublicKey),
+		})
+	}
+	return derivedAccounts, nil
 }
 
-// Signs a transaction with the node wallet's private key
-func (m *localWalletManager) SignTransaction(serializedTx []byte) ([]byte, error) {
-	tx := types.Transaction{}
-	err := tx.UnmarshalBinary(serializedTx)
+// Switch the node account to the one at the provided index. The wallet data is updated with the new index, so it has
+// to be saved again for the switch to persist.
+func (m *localWalletManager) SetWalletIndex(index uint) error {
+	if m.masterKey == nil {
+		return fmt.Errorf("wallet is not initialized")
+	}
+
+	privateKey, derivedIndex, err := derivePrivateKey(m.masterKey, m.data.DerivationPath, index)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling TX: %w", err)
+		return err
+	}
+	transactor, err := m.createTransactor(privateKey)
+	if err != nil {
+		return err
 	}
 
-	signer := types.NewLondonSigner(m.chainID)
-	signedTx, err := types.SignTx(&tx, signer, m.nodePrivateKey)
+	m.nodePrivateKey = privateKey
+	m.data.WalletIndex = derivedIndex
+	m.transactor = transactor
+	return nil
+}
+
+// Signs a message with the private key of the account at the provided index
+func (m *localWalletManager) SignMessageWithIndex(index uint, message []byte) ([]byte, error) {
+	privateKey, err := m.getPrivateKeyForIndex(index)
 	if err != nil {
-		return nil, fmt.Errorf("error signing TX: %w", err)
+		return nil, err
 	}
+	return signMessage(privateKey, message)
+}
 
-	signedData, err := signedTx.MarshalBinary()
+// Signs a transaction with the private key of the account at the provided index
+func (m *localWalletManager) SignTransactionWithIndex(index uint, serializedTx []byte) ([]byte, error) {
+	privateKey, err := m.getPrivateKeyForIndex(index)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling signed TX to binary: %w", err)
+		return nil, err
 	}
+	return signTransaction(privateKey, m.chainID, serializedTx)
+}
 
-	return signedData, nil
+// Signs a message with the node wallet's private key
+func (m *localWalletManager) SignMessage(message []byte) ([]byte, error) {
+	return signMessage(m.nodePrivateKey, message)
+}
+
+// Signs a transaction with the node wallet's private key
+func (m *localWalletManager) SignTransaction(serializedTx []byte) ([]byte, error) {
+	return signTransaction(m.nodePrivateKey, m.chainID, serializedTx)
 }
 
 // Serialize the wallet data as JSON
@@ -270,6 +341,82 @@ func (m *localWalletManager) GenerateValidatorKey(path string) ([]byte, error) {
 	return privateKey.Marshal(), nil
 }
 
+// Get the private key of the account at the provided index
+func (m *localWalletManager) getPrivateKeyForIndex(index uint) (*ecdsa.PrivateKey, error) {
+	if m.masterKey == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	if index == m.data.WalletIndex {
+		return m.nodePrivateKey, nil
+	}
+	privateKey, derivedIndex, err := derivePrivateKey(m.masterKey, m.data.DerivationPath, index)
+	if err != nil {
+		return nil, err
+	}
+	if derivedIndex != index {
+		return nil, fmt.Errorf("account index %d can't be derived", index)
+	}
+	return privateKey, nil
+}
+
+// Make a transactor for a private key
+func (m *localWalletManager) createTransactor(privateKey *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, m.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating transactor for node private key: %w", err)
+	}
+	transactor.Context = m.ctx
+	return transactor, nil
+}
+
+// Signs a message with a private key
+func signMessage(privateKey *ecdsa.PrivateKey, message []byte) ([]byte, error) {
+	messageHash := accounts.TextHash(message)
+	signedMessage, err := crypto.Sign(messageHash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing message: %w", err)
+	}
+
+	// fix the ECDSA 'v' (see https://medium.com/mycrypto/the-magic-of-digital-signatures-on-ethereum-98fe184dc9c7#:~:text=The%20version%20number,2%E2%80%9D%20was%20introduced)
+	signedMessage[crypto.RecoveryIDOffset] += 27
+	return signedMessage, nil
+}
+
+// Signs a transaction with a private key
+func signTransaction(privateKey *ecdsa.PrivateKey, chainID *big.Int, serializedTx []byte) ([]byte, error) {
+	tx := types.Transaction{}
+	err := tx.UnmarshalBinary(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling TX: %w", err)
+	}
+
+	signer := types.NewLondonSigner(chainID)
+	signedTx, err := types.SignTx(&tx, signer, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing TX: %w", err)
+	}
+
+	signedData, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling signed TX to binary: %w", err)
+	}
+
+	return signedData, nil
+}
+
+// Get the private key of the account at the index, along with the index that was actually used (see getDerivedKey)
+func derivePrivateKey(masterKey *hdkeychain.ExtendedKey, derivationPath string, index uint) (*ecdsa.PrivateKey, uint, error) {
+	derivedKey, derivedIndex, err := getDerivedKey(masterKey, derivationPath, index)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting node wallet derived key: %w", err)
+	}
+	privateKey, err := derivedKey.ECPrivKey()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting node wallet private key: %w", err)
+	}
+	return privateKey.ToECDSA(), derivedIndex, nil
+}
+
 // Get the derived key & derivation path for the account at the index
 func getDerivedKey(masterKey *hdkeychain.ExtendedKey, derivationPath string, index uint) (*hdkeychain.ExtendedKey, uint, error) {
 	formattedDerivationPath := fmt.Sprintf(derivationPath, index)
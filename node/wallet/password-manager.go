@@ -1,56 +1,126 @@
 package wallet
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+
+	"github.com/rocket-pool/node-manager-core/wallet"
 )
 
 const (
 	passwordFileMode fs.FileMode = 0600
 )
 
-// Simple class to wrap the node's password file
+var (
+	// Attempted to save or delete the password, but its source is read-only
+	ErrPasswordSourceReadOnly = errors.New("the wallet password source is read-only")
+)
+
+// A place the wallet password can be retrieved from
+type IPasswordSource interface {
+	// Get the password, or false if the source doesn't have one
+	GetPassword() (string, bool, error)
+
+	// Save the password to the source, or return ErrPasswordSourceReadOnly if it can't be saved
+	SavePassword(password string) error
+
+	// Delete the password from the source, or return ErrPasswordSourceReadOnly if it can't be deleted
+	DeletePassword() error
+}
+
+// Simple class to wrap the node's password source
 type passwordManager struct {
-	path string
+	source IPasswordSource
 }
 
-// Creates a new password manager
-func newPasswordManager(path string) *passwordManager {
+// Creates a new password manager that gets the password from the source in the provided settings. Sources that run
+// commands or make requests stop when the context is cancelled.
+func newPasswordManagerWithSource(ctx context.Context, settings wallet.PasswordSourceSettings) (*passwordManager, error) {
+	var source IPasswordSource
+	var err error
+	switch settings.Type {
+	case wallet.PasswordSourceType_File, "":
+		if settings.FilePath == "" {
+			return nil, fmt.Errorf("password file path cannot be empty")
+		}
+		source = newFilePasswordSource(settings.FilePath)
+	case wallet.PasswordSourceType_Env:
+		source, err = newEnvPasswordSource(settings.EnvVar)
+	case wallet.PasswordSourceType_Command:
+		source, err = newCommandPasswordSource(ctx, settings.Command)
+	case wallet.PasswordSourceType_FileDescriptor:
+		source = newFdPasswordSource(settings.FileDescriptor)
+	case wallet.PasswordSourceType_Vault:
+		source, err = newVaultPasswordSource(ctx, settings.Vault)
+	default:
+		return nil, fmt.Errorf("unknown password source type [%s]", settings.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s password source: %w", settings.Type, err)
+	}
 	return &passwordManager{
+		source: source,
+	}, nil
+}
+
+// Gets the password from the source. Returns false if the source doesn't have one.
+func (m *passwordManager) GetPassword() (string, bool, error) {
+	return m.source.GetPassword()
+}
+
+// Save the password to the source
+func (m *passwordManager) SavePassword(password string) error {
+	return m.source.SavePassword(password)
+}
+
+// Delete the password from the source
+func (m *passwordManager) DeletePassword() error {
+	return m.source.DeletePassword()
+}
+
+// A password stored in a file on disk
+type filePasswordSource struct {
+	path string
+}
+
+// Creates a new file password source
+func newFilePasswordSource(path string) *filePasswordSource {
+	return &filePasswordSource{
 		path: path,
 	}
 }
 
-// Gets the password saved on disk. Returns nil if the password file doesn't exist.
-func (m *passwordManager) GetPasswordFromDisk() (string, bool, error) {
-	_, err := os.Stat(m.path)
+// Gets the password saved on disk. Returns false if the password file doesn't exist.
+func (s *filePasswordSource) GetPassword() (string, bool, error) {
+	_, err := os.Stat(s.path)
 	if errors.Is(err, fs.ErrNotExist) {
 		return "", false, nil
 	}
 
-	bytes, err := os.ReadFile(m.path)
+	bytes, err := os.ReadFile(s.path)
 	if err != nil {
-		return "", false, fmt.Errorf("error reading password file [%s]: %w", m.path, err)
+		return "", false, fmt.Errorf("error reading password file [%s]: %w", s.path, err)
 	}
 	return string(bytes), true, nil
 }
 
 // Save the password to disk
-func (m *passwordManager) SavePassword(password string) error {
-	err := os.WriteFile(m.path, []byte(password), passwordFileMode)
+func (s *filePasswordSource) SavePassword(password string) error {
+	err := os.WriteFile(s.path, []byte(password), passwordFileMode)
 	if err != nil {
-		return fmt.Errorf("error saving password to [%s]: %w", m.path, err)
+		return fmt.Errorf("error saving password to [%s]: %w", s.path, err)
 	}
 	return nil
 }
 
 // Delete the password from disk
-func (m *passwordManager) DeletePassword() error {
-	err := os.Remove(m.path)
+func (s *filePasswordSource) DeletePassword() error {
+	err := os.Remove(s.path)
 	if err != nil {
-		return fmt.Errorf("error deleting password [%s]: %w", m.path, err)
+		return fmt.Errorf("error deleting password [%s]: %w", s.path, err)
 	}
 	return nil
 }
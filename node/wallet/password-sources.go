@@ -0,0 +1,303 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+const (
+	// How long to wait for a password command or Vault request before giving up
+	passwordSourceTimeout time.Duration = 30 * time.Second
+
+	// The environment variable Vault's own tools read the token from
+	vaultTokenEnvVar string = "VAULT_TOKEN"
+)
+
+// A password read from an environment variable
+type envPasswordSource struct {
+	name string
+}
+
+// Creates a new environment variable password source
+func newEnvPasswordSource(name string) (*envPasswordSource, error) {
+	if name == "" {
+		return nil, fmt.Errorf("environment variable name cannot be empty")
+	}
+	return &envPasswordSource{
+		name: name,
+	}, nil
+}
+
+// Get the password from the environment variable. Returns false if it isn't set.
+func (s *envPasswordSource) GetPassword() (string, bool, error) {
+	password, exists := os.LookupEnv(s.name)
+	return password, exists, nil
+}
+
+// Environment variables are read-only
+func (s *envPasswordSource) SavePassword(password string) error {
+	return ErrPasswordSourceReadOnly
+}
+
+// Environment variables are read-only
+func (s *envPasswordSource) DeletePassword() error {
+	return ErrPasswordSourceReadOnly
+}
+
+// A password printed by an external command
+type commandPasswordSource struct {
+	ctx     context.Context
+	command []string
+}
+
+// Creates a new command password source
+func newCommandPasswordSource(ctx context.Context, command []string) (*commandPasswordSource, error) {
+	if len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("command cannot be empty")
+	}
+	return &commandPasswordSource{
+		ctx:     ctx,
+		command: command,
+	}, nil
+}
+
+// Run the command and get the password from its output
+func (s *commandPasswordSource) GetPassword() (string, bool, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, passwordSourceTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil && ctx.Err() != nil {
+		return "", false, fmt.Errorf("password command [%s] was stopped: %w", s.command[0], ctx.Err())
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error running password command [%s]: %w (%s)", s.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	password := trimTrailingNewline(string(output))
+	if password == "" {
+		return "", false, nil
+	}
+	return password, true, nil
+}
+
+// Commands are read-only
+func (s *commandPasswordSource) SavePassword(password string) error {
+	return ErrPasswordSourceReadOnly
+}
+
+// Commands are read-only
+func (s *commandPasswordSource) DeletePassword() error {
+	return ErrPasswordSourceReadOnly
+}
+
+// A password read from a file descriptor inherited from the parent process. Pipes can only be read once, so the
+// password is kept in memory after the first read.
+type fdPasswordSource struct {
+	fd       uint
+	password string
+	read     bool
+	err      error
+	lock     sync.Mutex
+}
+
+// Creates a new file descriptor password source
+func newFdPasswordSource(fd uint) *fdPasswordSource {
+	return &fdPasswordSource{
+		fd: fd,
+	}
+}
+
+// Get the password from the file descriptor, reading it the first time this is called
+func (s *fdPasswordSource) GetPassword() (string, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.read {
+		s.read = true
+		file := os.NewFile(uintptr(s.fd), fmt.Sprintf("password-fd-%d", s.fd))
+		if file == nil {
+			s.err = fmt.Errorf("file descriptor %d is invalid", s.fd)
+		} else {
+			defer file.Close()
+			bytes, err := io.ReadAll(file)
+			if err != nil {
+				s.err = fmt.Errorf("error reading password from file descriptor %d: %w", s.fd, err)
+			} else {
+				s.password = trimTrailingNewline(string(bytes))
+			}
+		}
+	}
+	if s.err != nil {
+		return "", false, s.err
+	}
+	return s.password, s.password != "", nil
+}
+
+// File descriptors are read-only
+func (s *fdPasswordSource) SavePassword(password string) error {
+	return ErrPasswordSourceReadOnly
+}
+
+// Forget the password read from the file descriptor. It can't be read again, so the wallet will need a new password
+// to be set after this.
+func (s *fdPasswordSource) DeletePassword() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.password = ""
+	return nil
+}
+
+// A password stored in a HashiCorp Vault secret
+type vaultPasswordSource struct {
+	ctx        context.Context
+	settings   wallet.VaultPasswordSettings
+	secretUrl  string
+	httpClient *http.Client
+}
+
+// The response to a Vault secret read. KV version 1 puts the fields in data; version 2 nests them in data.data.
+type vaultSecretResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []string       `json:"errors"`
+}
+
+// Creates a new Vault password source
+func newVaultPasswordSource(ctx context.Context, settings wallet.VaultPasswordSettings) (*vaultPasswordSource, error) {
+	if settings.Address == "" {
+		return nil, fmt.Errorf("Vault address cannot be empty")
+	}
+	if settings.Path == "" || settings.Field == "" {
+		return nil, fmt.Errorf("Vault secret path and field cannot be empty")
+	}
+	secretUrl, err := url.JoinPath(settings.Address, "v1", settings.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error building Vault secret URL: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if settings.CaCertPath != "" {
+		caCert, err := os.ReadFile(settings.CaCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading Vault CA certificate [%s]: %w", settings.CaCertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("Vault CA certificate [%s] doesn't contain any PEM certificates", settings.CaCertPath)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+	return &vaultPasswordSource{
+		ctx:       ctx,
+		settings:  settings,
+		secretUrl: secretUrl,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   passwordSourceTimeout,
+		},
+	}, nil
+}
+
+// Read the password from the Vault secret. Returns false if the secret or its field doesn't exist.
+func (s *vaultPasswordSource) GetPassword() (string, bool, error) {
+	token, err := s.getToken()
+	if err != nil {
+		return "", false, err
+	}
+	request, err := http.NewRequestWithContext(s.ctx, http.MethodGet, s.secretUrl, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("error creating Vault request: %w", err)
+	}
+	request.Header.Set("X-Vault-Token", token)
+	if s.settings.Namespace != "" {
+		request.Header.Set("X-Vault-Namespace", s.settings.Namespace)
+	}
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return "", false, fmt.Errorf("error reading Vault secret [%s]: %w", s.settings.Path, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", false, fmt.Errorf("error reading Vault response: %w", err)
+	}
+	var secret vaultSecretResponse
+	err = json.Unmarshal(body, &secret)
+	if err != nil {
+		return "", false, fmt.Errorf("error deserializing Vault response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("Vault returned status %d reading secret [%s]: %s", response.StatusCode, s.settings.Path, strings.Join(secret.Errors, "; "))
+	}
+
+	// Check KV version 2's nested data first, then version 1's
+	fields := secret.Data
+	if nested, isMap := secret.Data["data"].(map[string]any); isMap {
+		fields = nested
+	}
+	value, exists := fields[s.settings.Field]
+	if !exists || value == nil {
+		return "", false, nil
+	}
+	password, isString := value.(string)
+	if !isString {
+		return "", false, fmt.Errorf("field [%s] of Vault secret [%s] isn't a string", s.settings.Field, s.settings.Path)
+	}
+	return password, true, nil
+}
+
+// Vault secrets are managed in Vault, so they're read-only here
+func (s *vaultPasswordSource) SavePassword(password string) error {
+	return ErrPasswordSourceReadOnly
+}
+
+// Vault secrets are managed in Vault, so they're read-only here
+func (s *vaultPasswordSource) DeletePassword() error {
+	return ErrPasswordSourceReadOnly
+}
+
+// Get the Vault token from the token file, or the environment if there isn't one. The file is read each time so
+// rotated tokens (such as ones written by Vault Agent) are picked up.
+func (s *vaultPasswordSource) getToken() (string, error) {
+	if s.settings.TokenPath == "" {
+		token := os.Getenv(vaultTokenEnvVar)
+		if token == "" {
+			return "", fmt.Errorf("no Vault token file is configured and %s isn't set", vaultTokenEnvVar)
+		}
+		return token, nil
+	}
+	bytes, err := os.ReadFile(s.settings.TokenPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading Vault token file [%s]: %w", s.settings.TokenPath, err)
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
+
+// Remove a single trailing newline (as printed by most tools) from a password
+func trimTrailingNewline(password string) string {
+	password = strings.TrimSuffix(password, "\n")
+	return strings.TrimSuffix(password, "\r")
+}
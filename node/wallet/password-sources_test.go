@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestCommandPasswordSourceStopsWhenCancelled(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep isn't available")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source, err := newCommandPasswordSource(ctx, []string{"sleep", "30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	_, _, err = source.GetPassword()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the command took %s to stop after cancellation", elapsed)
+	}
+}
+
+func TestCommandPasswordSourceReadsOutput(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo isn't available")
+	}
+	source, err := newCommandPasswordSource(context.Background(), []string{"echo", "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, exists, err := source.GetPassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists || password != "hunter2" {
+		t.Fatalf("expected the password \"hunter2\", got %q (exists = %t)", password, exists)
+	}
+}
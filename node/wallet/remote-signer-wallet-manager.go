@@ -0,0 +1,353 @@
+package wallet
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/wallet"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// The time to wait for the remote signer to respond to a request
+	remoteSignerTimeout time.Duration = 30 * time.Second
+)
+
+// The arguments for an eth_signTransaction request
+type remoteSignerTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// Wallet manager for node keys held by a remote signing service, such as Web3Signer. Signing requests are sent to the
+// service over HTTPS, so the private key is never stored on the node.
+type remoteSignerWalletManager struct {
+	// The ID of the execution layer chain currently being used
+	chainID *big.Int
+
+	// The context for the manager's requests, which is cancelled when the daemon shuts down
+	ctx context.Context
+
+	// The JSON-RPC client for the signing service
+	client *rpc.Client
+
+	// Serialized data of the loaded wallet
+	data *wallet.RemoteSignerWalletData
+
+	// Transactor for signing transactions
+	transactor *bind.TransactOpts
+}
+
+// Creates a new wallet manager for remote signers
+func newRemoteSignerWalletManager(ctx context.Context, chainID uint) *remoteSignerWalletManager {
+	return &remoteSignerWalletManager{
+		chainID: big.NewInt(int64(chainID)),
+		ctx:     ctx,
+	}
+}
+
+// Get the type of this wallet manager
+func (m *remoteSignerWalletManager) GetType() wallet.WalletType {
+	return wallet.WalletType_RemoteSigner
+}
+
+// Get the address of the node account on the signing service
+func (m *remoteSignerWalletManager) GetAddress() (common.Address, error) {
+	if m.data == nil {
+		return common.Address{}, fmt.Errorf("wallet is not initialized")
+	}
+	return m.data.Address, nil
+}
+
+// Get the transactor for the wallet
+func (m *remoteSignerWalletManager) GetTransactor() (*bind.TransactOpts, error) {
+	if m.transactor == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	return m.transactor, nil
+}
+
+// Connect to the signing service described by the wallet data and load the node account from it. If the data doesn't
+// have an address, the signing service must hold exactly one account, which is used as the node account.
+func (m *remoteSignerWalletManager) InitializeSigner(data wallet.RemoteSignerWalletData) (*wallet.RemoteSignerWalletData, error) {
+	err := m.LoadWallet(&data)
+	if err != nil {
+		return nil, err
+	}
+	return m.data, nil
+}
+
+// Connect to the signing service described by the wallet data and make sure it holds the key for the wallet's address
+func (m *remoteSignerWalletManager) LoadWallet(data *wallet.RemoteSignerWalletData) error {
+	signerUrl, err := url.Parse(data.Url)
+	if err != nil {
+		return fmt.Errorf("invalid remote signer URL [%s]: %w", data.Url, err)
+	}
+	if signerUrl.Scheme != "https" && !(signerUrl.Scheme == "http" && data.AllowInsecureHttp) {
+		return fmt.Errorf("remote signer URL [%s] must use HTTPS", data.Url)
+	}
+
+	httpClient, err := createRemoteSignerHttpClient(data)
+	if err != nil {
+		return err
+	}
+	client, err := rpc.DialOptions(m.ctx, data.Url, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return fmt.Errorf("error connecting to remote signer at [%s]: %w", data.Url, err)
+	}
+
+	// Make sure the signer has the node account
+	ctx, cancel := context.WithTimeout(m.ctx, remoteSignerTimeout)
+	defer cancel()
+	var accounts []common.Address
+	err = client.CallContext(ctx, &accounts, "eth_accounts")
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("error getting accounts from remote signer at [%s]: %w", data.Url, err)
+	}
+	address := data.Address
+	if address == (common.Address{}) {
+		if len(accounts) != 1 {
+			client.Close()
+			return fmt.Errorf("remote signer at [%s] has %d accounts; the node account's address must be provided", data.Url, len(accounts))
+		}
+		address = accounts[0]
+	} else {
+		found := false
+		for _, account := range accounts {
+			if account == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			client.Close()
+			return fmt.Errorf("remote signer at [%s] doesn't have the key for address %s", data.Url, address.Hex())
+		}
+	}
+
+	// Make a transactor that sends transactions to the signer
+	transactor := &bind.TransactOpts{
+		From: address,
+		Signer: func(signerAddress common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if signerAddress != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return m.signTx(address, tx)
+		},
+		Context: m.ctx,
+	}
+
+	// Store everything if there are no errors
+	if m.client != nil {
+		m.client.Close()
+	}
+	loadedData := *data
+	loadedData.Address = address
+	m.client = client
+	m.data = &loadedData
+	m.transactor = transactor
+	return nil
+}
+
+// Signs a message with the node account on the signing service, making sure the signature recovers to its address
+func (m *remoteSignerWalletManager) SignMessage(message []byte) ([]byte, error) {
+	if m.client == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	ctx, cancel := context.WithTimeout(m.ctx, remoteSignerTimeout)
+	defer cancel()
+
+	var signedMessage hexutil.Bytes
+	err := m.client.CallContext(ctx, &signedMessage, "eth_sign", m.data.Address, hexutil.Bytes(message))
+	if err != nil {
+		return nil, fmt.Errorf("error signing message: %w", err)
+	}
+	if len(signedMessage) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer returned a %d-byte signature, but it must be %d bytes", len(signedMessage), crypto.SignatureLength)
+	}
+
+	// Make sure the signature is from the node account, normalizing V to 27 or 28 like the other wallets use
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature, signedMessage)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(accounts.TextHash(message), signature)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering the remote signer's message signature: %w", err)
+	}
+	signer := crypto.PubkeyToAddress(*publicKey)
+	if signer != m.data.Address {
+		return nil, fmt.Errorf("remote signer signed the message with %s instead of the node account %s", signer.Hex(), m.data.Address.Hex())
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// Signs a transaction with the node account on the signing service
+func (m *remoteSignerWalletManager) SignTransaction(serializedTx []byte) ([]byte, error) {
+	if m.client == nil {
+		return nil, fmt.Errorf("wallet is not initialized")
+	}
+	tx := types.Transaction{}
+	err := tx.UnmarshalBinary(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling TX: %w", err)
+	}
+
+	signedTx, err := m.signTx(m.data.Address, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling signed TX to binary: %w", err)
+	}
+
+	return signedData, nil
+}
+
+// Serialize the wallet data as JSON
+func (m *remoteSignerWalletManager) SerializeData() (string, error) {
+	if m.data == nil {
+		return "", fmt.Errorf("wallet is not initialized")
+	}
+
+	bytes, err := json.Marshal(m.data)
+	if err != nil {
+		return "", fmt.Errorf("error serializing wallet data: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// Have the signing service sign a transaction, and make sure the result is the same transaction signed by the
+// provided address
+func (m *remoteSignerWalletManager) signTx(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, remoteSignerTimeout)
+	defer cancel()
+
+	// Build the request
+	args := remoteSignerTxArgs{
+		From:    address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(m.chainID),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("remote signer doesn't support transaction type %d", tx.Type())
+	}
+
+	// Web3Signer returns the signed transaction directly, while Clef-style signers wrap it in an object
+	var response json.RawMessage
+	err := m.client.CallContext(ctx, &response, "eth_signTransaction", args)
+	if err != nil {
+		return nil, fmt.Errorf("error signing TX: %w", err)
+	}
+	var rawTx hexutil.Bytes
+	err = json.Unmarshal(response, &rawTx)
+	if err != nil {
+		var wrapped struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		err = json.Unmarshal(response, &wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding signed TX: %w", err)
+		}
+		rawTx = wrapped.Raw
+	}
+	signedTx := new(types.Transaction)
+	err = signedTx.UnmarshalBinary(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling signed TX: %w", err)
+	}
+
+	// Make sure the signer didn't change anything
+	sender, err := types.Sender(types.LatestSignerForChainID(m.chainID), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering signed TX sender: %w", err)
+	}
+	if sender != address {
+		return nil, fmt.Errorf("remote signer signed TX with %s instead of %s", sender.Hex(), address.Hex())
+	}
+	if signedTx.Type() != tx.Type() || signedTx.Nonce() != tx.Nonce() || signedTx.Gas() != tx.Gas() ||
+		signedTx.Value().Cmp(tx.Value()) != 0 || signedTx.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 ||
+		signedTx.GasTipCap().Cmp(tx.GasTipCap()) != 0 || !equalToAddress(signedTx.To(), tx.To()) ||
+		string(signedTx.Data()) != string(tx.Data()) {
+		return nil, fmt.Errorf("remote signer returned a TX that doesn't match the one it was asked to sign")
+	}
+	return signedTx, nil
+}
+
+// Create the HTTP client for a remote signer, using its TLS credentials if it has any
+func createRemoteSignerHttpClient(data *wallet.RemoteSignerWalletData) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if data.CaCertPath != "" {
+		caCert, err := os.ReadFile(data.CaCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading remote signer CA certificate [%s]: %w", data.CaCertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("remote signer CA certificate [%s] doesn't contain any PEM certificates", data.CaCertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if data.ClientCertPath != "" || data.ClientKeyPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(data.ClientCertPath, data.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading remote signer client certificate [%s]: %w", data.ClientCertPath, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{
+		Transport: transport,
+		Timeout:   remoteSignerTimeout,
+	}, nil
+}
+
+// Check if two transaction recipients are the same
+func equalToAddress(a *common.Address, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
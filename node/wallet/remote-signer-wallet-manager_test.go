@@ -0,0 +1,174 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// Start a JSON-RPC signing service that reports the account but signs messages with the provided key, which may not
+// be the account's
+func newTestRemoteSigner(t *testing.T, account common.Address, signingKey *ecdsa.PrivateKey) (*httptest.Server, string) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var result any
+		switch request.Method {
+		case "eth_accounts":
+			result = []common.Address{account}
+		case "eth_sign":
+			var message hexutil.Bytes
+			err = json.Unmarshal(request.Params[1], &message)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			signature, err := signMessage(signingKey, message)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			result = hexutil.Bytes(signature)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	// Trust the test server's certificate
+	caCertPath := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err := os.WriteFile(caCertPath, caCert, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, caCertPath
+}
+
+func TestRemoteSignerRequiresHttps(t *testing.T) {
+	manager := newRemoteSignerWalletManager(context.Background(), 1)
+	err := manager.LoadWallet(&wallet.RemoteSignerWalletData{
+		Url: "http://127.0.0.1:9000",
+	})
+	if err == nil || !strings.Contains(err.Error(), "must use HTTPS") {
+		t.Fatalf("expected a plain HTTP URL to be rejected, got %v", err)
+	}
+}
+
+func TestRemoteSignerVerifiesMessageSignatures(t *testing.T) {
+	nodeKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeAddress := crypto.PubkeyToAddress(nodeKey.PublicKey)
+	message := []byte("hello")
+
+	// A signer using the node account's key
+	server, caCertPath := newTestRemoteSigner(t, nodeAddress, nodeKey)
+	manager := newRemoteSignerWalletManager(context.Background(), 1)
+	err = manager.LoadWallet(&wallet.RemoteSignerWalletData{
+		Url:        server.URL,
+		CaCertPath: caCertPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := manager.SignMessage(message)
+	if err != nil {
+		t.Fatalf("expected the node account's signature to be accepted, got %v", err)
+	}
+	expected, err := signMessage(nodeKey, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(signature, expected) {
+		t.Fatalf("expected signature %x, got %x", expected, signature)
+	}
+
+	// A signer that claims to have the node account but signs with a different key
+	server, caCertPath = newTestRemoteSigner(t, nodeAddress, otherKey)
+	manager = newRemoteSignerWalletManager(context.Background(), 1)
+	err = manager.LoadWallet(&wallet.RemoteSignerWalletData{
+		Url:        server.URL,
+		CaCertPath: caCertPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = manager.SignMessage(message)
+	if err == nil {
+		t.Fatal("expected a signature from a different key to be rejected")
+	}
+}
+
+func TestRemoteSignerLoadStopsWhenCancelled(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// Trust the test server's certificate
+	caCertPath := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err := os.WriteFile(caCertPath, caCert, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := newRemoteSignerWalletManager(ctx, 1)
+	go func() {
+		<-started
+		cancel()
+	}()
+	start := time.Now()
+	err = manager.LoadWallet(&wallet.RemoteSignerWalletData{
+		Url:        server.URL,
+		CaCertPath: caCertPath,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("loading the wallet took %s to stop after cancellation", elapsed)
+	}
+}
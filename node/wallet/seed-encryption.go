@@ -0,0 +1,149 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// The name and version recorded in wallet data encrypted with encryptSeed
+	seedEncryptorName    string = "seed-aes-256-gcm"
+	seedEncryptorVersion uint   = 1
+
+	// The lengths of the derived key and KDF salt
+	seedKeyLength  int = 32
+	seedSaltLength int = 32
+)
+
+// The encrypted seed stored in a local wallet's Crypto field
+type encryptedSeed struct {
+	// The KDF used to derive the encryption key from the password, and its parameters
+	Kdf wallet.KdfSettings `json:"kdf"`
+
+	// The random KDF salt
+	Salt []byte `json:"salt"`
+
+	// The AES-GCM nonce
+	Nonce []byte `json:"nonce"`
+
+	// The encrypted seed, including the GCM authentication tag
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Encrypt a seed with a password, deriving the key with the provided KDF settings. The result is meant for a local
+// wallet's Crypto field, with seedEncryptorName and seedEncryptorVersion as its name and version.
+func encryptSeed(seed []byte, password string, settings wallet.KdfSettings) (map[string]any, error) {
+	err := settings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid KDF settings: %w", err)
+	}
+
+	salt := make([]byte, seedSaltLength)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+	key, err := deriveSeedKey(password, salt, settings)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newSeedCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	encrypted := encryptedSeed{
+		Kdf:        settings,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, seed, nil),
+	}
+
+	// Convert it to the generic map used by the wallet data
+	bytes, err := json.Marshal(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing encrypted seed: %w", err)
+	}
+	var crypto map[string]any
+	err = json.Unmarshal(bytes, &crypto)
+	if err != nil {
+		return nil, fmt.Errorf("error converting encrypted seed: %w", err)
+	}
+	return crypto, nil
+}
+
+// Decrypt a seed encrypted with encryptSeed
+func decryptSeed(crypto map[string]any, password string) ([]byte, error) {
+	bytes, err := json.Marshal(crypto)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing encrypted seed: %w", err)
+	}
+	var encrypted encryptedSeed
+	err = json.Unmarshal(bytes, &encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing encrypted seed: %w", err)
+	}
+	err = encrypted.Kdf.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("encrypted seed has invalid KDF settings: %w", err)
+	}
+
+	key, err := deriveSeedKey(password, encrypted.Salt, encrypted.Kdf)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newSeedCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted seed has an invalid nonce length")
+	}
+	seed, err := aead.Open(nil, encrypted.Nonce, encrypted.Ciphertext, nil)
+	if err != nil {
+		// GCM authentication fails if the key is wrong
+		return nil, fmt.Errorf("invalid password")
+	}
+	return seed, nil
+}
+
+// Derive the seed encryption key from a password
+func deriveSeedKey(password string, salt []byte, settings wallet.KdfSettings) ([]byte, error) {
+	switch settings.Function {
+	case wallet.KdfFunction_Scrypt:
+		key, err := scrypt.Key([]byte(password), salt, settings.ScryptN, settings.ScryptR, settings.ScryptP, seedKeyLength)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving key with scrypt: %w", err)
+		}
+		return key, nil
+	case wallet.KdfFunction_Argon2id:
+		return argon2.IDKey([]byte(password), salt, settings.Argon2Time, settings.Argon2Memory, settings.Argon2Threads, uint32(seedKeyLength)), nil
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function [%s]", settings.Function)
+	}
+}
+
+// Create the AES-256-GCM cipher for a seed encryption key
+func newSeedCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GCM cipher: %w", err)
+	}
+	return aead, nil
+}
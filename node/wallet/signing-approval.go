@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	apitypes "github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// The default time to wait for the operator to approve a signing request
+	DefaultSigningApprovalTimeout time.Duration = time.Hour
+)
+
+var (
+	// The operator rejected the signing request
+	ErrSigningRequestRejected = errors.New("signing request was rejected")
+
+	// The operator didn't approve the signing request in time
+	ErrSigningRequestExpired = errors.New("signing request expired before it was approved")
+)
+
+// A signing request and the channel its decision is sent on
+type pendingSigningRequest struct {
+	request  apitypes.SigningRequest
+	decision chan error
+}
+
+// A queue of transactions waiting for the operator's approval before the wallet signs them. When a wallet has one,
+// transactions signed with its transactor block until the operator approves or rejects them through the API, giving
+// cautious operators a human in the loop for automated transactions.
+type SigningApprovalQueue struct {
+	logger   *slog.Logger
+	decoder  *eth.CalldataDecoder
+	timeout  time.Duration
+	requests map[string]*pendingSigningRequest
+	lock     sync.Mutex
+}
+
+// Creates a new signing approval queue. The decoder is optional; if provided, it's used to describe the calls in
+// each request. Requests that aren't approved within the timeout are rejected.
+func NewSigningApprovalQueue(logger *slog.Logger, decoder *eth.CalldataDecoder, timeout time.Duration) *SigningApprovalQueue {
+	return &SigningApprovalQueue{
+		logger:   logger,
+		decoder:  decoder,
+		timeout:  timeout,
+		requests: map[string]*pendingSigningRequest{},
+	}
+}
+
+// Queue a transaction for approval and wait until the operator approves it (returning nil), rejects it, or the
+// request expires
+func (q *SigningApprovalQueue) WaitForApproval(from common.Address, tx *types.Transaction) error {
+	now := time.Now()
+	request := apitypes.SigningRequest{
+		ID:          uuid.New().String(),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(q.timeout),
+		From:        from,
+		To:          tx.To(),
+		Value:       tx.Value(),
+		Nonce:       tx.Nonce(),
+		GasLimit:    tx.Gas(),
+		GasFeeCap:   tx.GasFeeCap(),
+		GasTipCap:   tx.GasTipCap(),
+		Data:        tx.Data(),
+		Description: q.describe(tx),
+	}
+	pending := &pendingSigningRequest{
+		request:  request,
+		decision: make(chan error, 1),
+	}
+
+	q.lock.Lock()
+	q.requests[request.ID] = pending
+	q.lock.Unlock()
+	q.logger.Warn("Transaction is waiting for approval", slog.String(log.IdKey, request.ID), slog.String(log.DescriptionKey, request.Description))
+
+	timer := time.NewTimer(q.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-pending.decision:
+		return err
+	case <-timer.C:
+		q.lock.Lock()
+		delete(q.requests, request.ID)
+		q.lock.Unlock()
+		q.logger.Warn("Signing request expired", slog.String(log.IdKey, request.ID))
+		return ErrSigningRequestExpired
+	}
+}
+
+// Get the requests waiting for approval, oldest first
+func (q *SigningApprovalQueue) GetRequests() []apitypes.SigningRequest {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	requests := make([]apitypes.SigningRequest, 0, len(q.requests))
+	for _, pending := range q.requests {
+		requests = append(requests, pending.request)
+	}
+	slices.SortFunc(requests, func(first apitypes.SigningRequest, second apitypes.SigningRequest) int {
+		return first.CreatedAt.Compare(second.CreatedAt)
+	})
+	return requests
+}
+
+// Approve a request so the wallet signs its transaction
+func (q *SigningApprovalQueue) Approve(id string) error {
+	pending, err := q.remove(id)
+	if err != nil {
+		return err
+	}
+	pending.decision <- nil
+	q.logger.Info("Signing request approved", slog.String(log.IdKey, id))
+	return nil
+}
+
+// Reject a request so the wallet refuses to sign its transaction
+func (q *SigningApprovalQueue) Reject(id string, reason string) error {
+	pending, err := q.remove(id)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		pending.decision <- ErrSigningRequestRejected
+	} else {
+		pending.decision <- fmt.Errorf("%w: %s", ErrSigningRequestRejected, reason)
+	}
+	q.logger.Info("Signing request rejected", slog.String(log.IdKey, id))
+	return nil
+}
+
+// Remove a request from the queue so it can be decided
+func (q *SigningApprovalQueue) remove(id string) (*pendingSigningRequest, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	pending, exists := q.requests[id]
+	if !exists {
+		return nil, fmt.Errorf("signing request [%s] doesn't exist or was already decided", id)
+	}
+	delete(q.requests, id)
+	return pending, nil
+}
+
+// Describe the call a transaction makes
+func (q *SigningApprovalQueue) describe(tx *types.Transaction) string {
+	if tx.To() == nil {
+		return "contract deployment"
+	}
+	txInfo := &eth.TransactionInfo{
+		To:    *tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	if q.decoder == nil {
+		return eth.NewCalldataDecoder().Render(txInfo)
+	}
+	return q.decoder.Render(txInfo)
+}
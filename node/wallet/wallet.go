@@ -1,20 +1,25 @@
 package wallet
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"math/big"
 	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/events"
 	"github.com/rocket-pool/node-manager-core/log"
 	"github.com/rocket-pool/node-manager-core/wallet"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip39"
 )
@@ -23,6 +28,9 @@ import (
 const (
 	EntropyBits = 256
 	FileMode    = 0600
+
+	// The suffix of the copy of a wallet data file that's kept before it's migrated from the legacy format
+	LegacyBackupSuffix = ".legacy.bak"
 )
 
 // Errors
@@ -53,9 +61,22 @@ type Wallet struct {
 	addressManager  *addressManager
 	passwordManager *passwordManager
 
+	// The queue transactions wait in for the operator's approval, if required
+	approvalQueue *SigningApprovalQueue
+
+	// The context for the wallet's requests to signers and password sources, which is cancelled when the daemon shuts down
+	ctx context.Context
+
+	// The bus that status changes are published to, and the last status that was published
+	eventBus   *events.EventBus
+	lastStatus *wallet.WalletStatus
+	eventLock  *sync.Mutex
+
 	// Misc cache
 	chainID        uint
 	walletDataPath string
+	kdfSettings    wallet.KdfSettings
+	logger         *slog.Logger
 
 	// Sync
 	lock *sync.Mutex
@@ -63,16 +84,46 @@ type Wallet struct {
 
 // Create new wallet
 func NewWallet(logger *slog.Logger, walletDataPath string, walletAddressPath string, passwordFilePath string, chainID uint) (*Wallet, error) {
+	return NewWalletWithKdfSettings(logger, walletDataPath, walletAddressPath, passwordFilePath, chainID, wallet.DefaultKdfSettings)
+}
+
+// Create new wallet that encrypts local wallet seeds with the provided KDF settings. Local wallets still using the
+// legacy keystore format are migrated to the new format with these settings when they're loaded.
+func NewWalletWithKdfSettings(logger *slog.Logger, walletDataPath string, walletAddressPath string, passwordFilePath string, chainID uint, kdfSettings wallet.KdfSettings) (*Wallet, error) {
+	passwordSource := wallet.PasswordSourceSettings{
+		Type:     wallet.PasswordSourceType_File,
+		FilePath: passwordFilePath,
+	}
+	return NewWalletWithPasswordSource(context.Background(), logger, walletDataPath, walletAddressPath, passwordSource, chainID, kdfSettings)
+}
+
+// Create new wallet that gets its password from the provided source instead of a password file. Sources other than
+// files are read-only, so the password can't be saved or deleted through the wallet when using them. Requests to remote
+// signers, key management services, and password sources stop when the context is cancelled.
+func NewWalletWithPasswordSource(ctx context.Context, logger *slog.Logger, walletDataPath string, walletAddressPath string, passwordSource wallet.PasswordSourceSettings, chainID uint, kdfSettings wallet.KdfSettings) (*Wallet, error) {
+	err := kdfSettings.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid KDF settings: %w", err)
+	}
+	passwordManager, err := newPasswordManagerWithSource(ctx, passwordSource)
+	if err != nil {
+		return nil, fmt.Errorf("error creating password manager: %w", err)
+	}
+
 	// Create the wallet
 	w := &Wallet{
 		// Create managers
 		addressManager:  newAddressManager(walletAddressPath),
-		passwordManager: newPasswordManager(passwordFilePath),
+		passwordManager: passwordManager,
 
 		// Initialize other fields
+		ctx:            ctx,
 		chainID:        chainID,
 		walletDataPath: walletDataPath,
+		kdfSettings:    kdfSettings,
+		logger:         logger,
 		lock:           &sync.Mutex{},
+		eventLock:      &sync.Mutex{},
 	}
 
 	// Load the wallet
@@ -89,7 +140,7 @@ func (w *Wallet) GetStatus() (wallet.WalletStatus, error) {
 
 	// Get the password details
 	var err error
-	_, status.Password.IsPasswordSaved, err = w.passwordManager.GetPasswordFromDisk()
+	_, status.Password.IsPasswordSaved, err = w.passwordManager.GetPassword()
 	if err != nil {
 		return status, fmt.Errorf("error checking password manager status: %w", err)
 	}
@@ -117,11 +168,12 @@ func (w *Wallet) GetStatus() (wallet.WalletStatus, error) {
 
 // Reloads the wallet artifacts from disk
 func (w *Wallet) Reload(logger *slog.Logger) error {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
 	// Load the password
-	password, isPasswordSaved, err := w.passwordManager.GetPasswordFromDisk()
+	password, isPasswordSaved, err := w.passwordManager.GetPassword()
 	if err != nil {
 		return fmt.Errorf("error loading password: %w", err)
 	}
@@ -135,7 +187,14 @@ func (w *Wallet) Reload(logger *slog.Logger) error {
 			w.walletManager = walletMgr
 		}
 	} else {
+		// Hardware, remote signer, and KMS wallets don't have a password, so they can be loaded without one
 		w.walletManager = nil
+		walletMgr, err := w.loadPasswordlessWalletData()
+		if err != nil && logger != nil {
+			logger.Warn("Loading passwordless wallet failed", slog.String(log.PathKey, w.walletDataPath), log.Err(err))
+		} else if walletMgr != nil {
+			w.walletManager = walletMgr
+		}
 	}
 
 	// Load the node address
@@ -154,8 +213,71 @@ func (w *Wallet) GetAddress() (common.Address, bool) {
 	return w.addressManager.GetAddress()
 }
 
-// Get the transactor that can sign transactions
+// Get the transactor that can sign transactions. If the wallet has a signing approval queue, the transactor waits for
+// the operator to approve each transaction before signing it.
 func (w *Wallet) GetTransactor() (*bind.TransactOpts, error) {
+	return w.getTransactor(true)
+}
+
+// Get a transactor that signs transactions without waiting for approval, even if the wallet has a signing approval
+// queue. Only use this for transactions the operator has already confirmed directly, such as ones they submitted
+// through the CLI.
+func (w *Wallet) GetDirectTransactor() (*bind.TransactOpts, error) {
+	return w.getTransactor(false)
+}
+
+// Require the operator's approval through the provided queue before the wallet's transactor signs transactions, or
+// sign them automatically again if the queue is nil
+func (w *Wallet) SetSigningApprovalQueue(queue *SigningApprovalQueue) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.approvalQueue = queue
+}
+
+// Get the signing approval queue, or nil if transactions are signed automatically
+func (w *Wallet) GetSigningApprovalQueue() *SigningApprovalQueue {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.approvalQueue
+}
+
+// Set the bus to publish the wallet's status to whenever it changes, such as when a wallet is created or its password
+// is saved
+func (w *Wallet) SetEventBus(bus *events.EventBus) {
+	status, err := w.GetStatus()
+	w.eventLock.Lock()
+	defer w.eventLock.Unlock()
+	w.eventBus = bus
+	w.lastStatus = nil
+	if err == nil {
+		w.lastStatus = &status
+	}
+}
+
+// Publish the wallet's status if it's different from the last one that was published. This must be called without
+// holding the wallet's lock.
+func (w *Wallet) publishStatusChange() {
+	w.eventLock.Lock()
+	defer w.eventLock.Unlock()
+	if w.eventBus == nil {
+		return
+	}
+	status, err := w.GetStatus()
+	if err != nil {
+		if w.logger != nil {
+			w.logger.Warn("Error getting wallet status for status change event", log.Err(err))
+		}
+		return
+	}
+	if w.lastStatus != nil && *w.lastStatus == status {
+		return
+	}
+	w.lastStatus = &status
+	w.eventBus.Publish(events.EventType_WalletStatusChanged, status)
+}
+
+// Get a copy of the wallet manager's transactor, optionally requiring approval before signing
+func (w *Wallet) getTransactor(requireApproval bool) (*bind.TransactOpts, error) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -192,6 +314,19 @@ func (w *Wallet) GetTransactor() (*bind.TransactOpts, error) {
 	if opts.GasTipCap != nil {
 		clone.GasFeeCap = big.NewInt(0).Set(opts.GasTipCap)
 	}
+
+	// Wait for the operator's approval before signing if required
+	if requireApproval && w.approvalQueue != nil {
+		queue := w.approvalQueue
+		signer := opts.Signer
+		clone.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			err := queue.WaitForApproval(address, tx)
+			if err != nil {
+				return nil, err
+			}
+			return signer(address, tx)
+		}
+	}
 	return clone, nil
 }
 
@@ -206,6 +341,40 @@ func (w *Wallet) SignMessage(message []byte) ([]byte, error) {
 	return w.walletManager.SignMessage(message)
 }
 
+// Answer a service's challenge by signing an attestation that the node controls the wallet's address. The service
+// can check the result with wallet.VerifyNodeAttestation.
+func (w *Wallet) AttestIdentity(service string, nonce []byte) (wallet.SignedNodeAttestation, error) {
+	err := wallet.ValidateNodeAttestationChallenge(service, nonce)
+	if err != nil {
+		return wallet.SignedNodeAttestation{}, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.walletManager == nil {
+		return wallet.SignedNodeAttestation{}, ErrWalletNotLoaded
+	}
+	address, err := w.walletManager.GetAddress()
+	if err != nil {
+		return wallet.SignedNodeAttestation{}, fmt.Errorf("error getting wallet address: %w", err)
+	}
+	attestation := wallet.NodeAttestation{
+		Service:  service,
+		ChainID:  w.chainID,
+		Address:  address,
+		Nonce:    nonce,
+		IssuedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	signature, err := w.walletManager.SignMessage(attestation.GetMessage())
+	if err != nil {
+		return wallet.SignedNodeAttestation{}, fmt.Errorf("error signing attestation: %w", err)
+	}
+	return wallet.SignedNodeAttestation{
+		Attestation: attestation,
+		Signature:   signature,
+	}, nil
+}
+
 // Sign a transaction with the wallet's private key
 func (w *Wallet) SignTransaction(serializedTx []byte) ([]byte, error) {
 	w.lock.Lock()
@@ -219,6 +388,7 @@ func (w *Wallet) SignTransaction(serializedTx []byte) ([]byte, error) {
 
 // Masquerade as another node address, running all node functions as that address (in read only mode)
 func (w *Wallet) MasqueradeAsAddress(newAddress common.Address) error {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -227,6 +397,7 @@ func (w *Wallet) MasqueradeAsAddress(newAddress common.Address) error {
 
 // End masquerading as another node address, and use the wallet's address (returning to read/write mode)
 func (w *Wallet) RestoreAddressToWallet() error {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -244,6 +415,7 @@ func (w *Wallet) RestoreAddressToWallet() error {
 
 // Initialize the wallet from a random seed
 func (w *Wallet) CreateNewLocalWallet(derivationPath string, walletIndex uint, password string, savePassword bool) (string, error) {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -267,6 +439,7 @@ func (w *Wallet) CreateNewLocalWallet(derivationPath string, walletIndex uint, p
 
 // Recover a local wallet from a mnemonic
 func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic string, password string, savePassword bool, testMode bool) error {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -282,8 +455,122 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 	return w.buildLocalWallet(derivationPath, walletIndex, mnemonic, password, savePassword, testMode)
 }
 
+// Initialize the wallet from the node account on a connected hardware wallet device. The device must be unlocked with
+// the Ethereum app open.
+func (w *Wallet) InitializeHardwareWallet(device wallet.HardwareWalletDevice, derivationPath string, walletIndex uint) error {
+	defer w.publishStatusChange()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.walletManager != nil {
+		return ErrKeystoreAlreadyPresent
+	}
+
+	// Connect to the device and get the node account
+	hardwareMgr := newHardwareWalletManager(w.ctx, w.chainID)
+	hardwareData, err := hardwareMgr.InitializeDevice(device, derivationPath, walletIndex)
+	if err != nil {
+		return fmt.Errorf("error initializing hardware wallet: %w", err)
+	}
+
+	// Save the wallet data
+	data := &wallet.WalletData{
+		Type:         wallet.WalletType_Hardware,
+		HardwareData: *hardwareData,
+	}
+	err = w.saveWalletData(data)
+	if err != nil {
+		return fmt.Errorf("error saving wallet data: %w", err)
+	}
+
+	// Update the address file
+	err = w.addressManager.SetAndSaveAddress(hardwareData.Address)
+	if err != nil {
+		return fmt.Errorf("error saving wallet address to node address file: %w", err)
+	}
+
+	w.walletManager = hardwareMgr
+	return nil
+}
+
+// Initialize the wallet from the node account on a remote signing service. If the data doesn't have an address, the
+// signing service must hold exactly one account.
+func (w *Wallet) InitializeRemoteSignerWallet(data wallet.RemoteSignerWalletData) error {
+	defer w.publishStatusChange()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.walletManager != nil {
+		return ErrKeystoreAlreadyPresent
+	}
+
+	// Connect to the signer and get the node account
+	remoteMgr := newRemoteSignerWalletManager(w.ctx, w.chainID)
+	remoteData, err := remoteMgr.InitializeSigner(data)
+	if err != nil {
+		return fmt.Errorf("error initializing remote signer wallet: %w", err)
+	}
+
+	// Save the wallet data
+	walletData := &wallet.WalletData{
+		Type:             wallet.WalletType_RemoteSigner,
+		RemoteSignerData: *remoteData,
+	}
+	err = w.saveWalletData(walletData)
+	if err != nil {
+		return fmt.Errorf("error saving wallet data: %w", err)
+	}
+
+	// Update the address file
+	err = w.addressManager.SetAndSaveAddress(remoteData.Address)
+	if err != nil {
+		return fmt.Errorf("error saving wallet address to node address file: %w", err)
+	}
+
+	w.walletManager = remoteMgr
+	return nil
+}
+
+// Initialize the wallet from a secp256k1 key in a cloud key management service
+func (w *Wallet) InitializeKmsWallet(data wallet.KmsWalletData) error {
+	defer w.publishStatusChange()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.walletManager != nil {
+		return ErrKeystoreAlreadyPresent
+	}
+
+	// Connect to the key and get the node account
+	kmsMgr := newKmsWalletManager(w.ctx, w.chainID)
+	err := kmsMgr.LoadWallet(&data)
+	if err != nil {
+		return fmt.Errorf("error initializing KMS wallet: %w", err)
+	}
+
+	// Save the wallet data
+	walletData := &wallet.WalletData{
+		Type:    wallet.WalletType_Kms,
+		KmsData: *kmsMgr.data,
+	}
+	err = w.saveWalletData(walletData)
+	if err != nil {
+		return fmt.Errorf("error saving wallet data: %w", err)
+	}
+
+	// Update the address file
+	err = w.addressManager.SetAndSaveAddress(kmsMgr.address)
+	if err != nil {
+		return fmt.Errorf("error saving wallet address to node address file: %w", err)
+	}
+
+	w.walletManager = kmsMgr
+	return nil
+}
+
 // Attempts to load the wallet keystore with the provided password if not set
 func (w *Wallet) SetPassword(password string, save bool) error {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -342,11 +629,12 @@ func (w *Wallet) GetPassword() (string, bool, error) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
-	return w.passwordManager.GetPasswordFromDisk()
+	return w.passwordManager.GetPassword()
 }
 
-// Delete the wallet password from disk, but retain it in memory if a local keystore is already loaded
+// Delete the wallet password from its source, but retain it in memory if a local keystore is already loaded
 func (w *Wallet) DeletePassword() error {
+	defer w.publishStatusChange()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -375,6 +663,92 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 	}
 }
 
+// Get the accounts derived from the local wallet's seed at the provided range of indices
+func (w *Wallet) GetDerivedAccounts(startIndex uint, count uint) ([]wallet.DerivedAccount, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	localMgr, err := w.getLocalWalletManager()
+	if err != nil {
+		return nil, err
+	}
+	return localMgr.GetDerivedAccounts(startIndex, count)
+}
+
+// Get the index of the local wallet's active account
+func (w *Wallet) GetWalletIndex() (uint, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	localMgr, err := w.getLocalWalletManager()
+	if err != nil {
+		return 0, err
+	}
+	return localMgr.data.WalletIndex, nil
+}
+
+// Switch the local wallet's node account to the one at the provided index, and save the change to disk. This also
+// changes the node address.
+func (w *Wallet) SetWalletIndex(index uint) error {
+	defer w.publishStatusChange()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	localMgr, err := w.getLocalWalletManager()
+	if err != nil {
+		return err
+	}
+	previousIndex := localMgr.data.WalletIndex
+	err = localMgr.SetWalletIndex(index)
+	if err != nil {
+		return fmt.Errorf("error switching to account %d: %w", index, err)
+	}
+
+	// Save the wallet data, switching back if it can't be saved
+	data := &wallet.WalletData{
+		Type:      wallet.WalletType_Local,
+		LocalData: *localMgr.data,
+	}
+	err = w.saveWalletData(data)
+	if err != nil {
+		_ = localMgr.SetWalletIndex(previousIndex)
+		return fmt.Errorf("error saving wallet data: %w", err)
+	}
+
+	// Update the address file
+	address, _ := localMgr.GetAddress()
+	err = w.addressManager.SetAndSaveAddress(address)
+	if err != nil {
+		return fmt.Errorf("error saving wallet address to node address file: %w", err)
+	}
+	return nil
+}
+
+// Sign a message with the local wallet's account at the provided index, which doesn't have to be the active account
+func (w *Wallet) SignMessageWithWalletIndex(index uint, message []byte) ([]byte, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	localMgr, err := w.getLocalWalletManager()
+	if err != nil {
+		return nil, err
+	}
+	return localMgr.SignMessageWithIndex(index, message)
+}
+
+// Sign a transaction with the local wallet's account at the provided index, which doesn't have to be the active
+// account
+func (w *Wallet) SignTransactionWithWalletIndex(index uint, serializedTx []byte) ([]byte, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	localMgr, err := w.getLocalWalletManager()
+	if err != nil {
+		return nil, err
+	}
+	return localMgr.SignTransactionWithIndex(index, serializedTx)
+}
+
 // Get the node account private key bytes
 func (w *Wallet) GetEthKeystore(password string) ([]byte, error) {
 	w.lock.Lock()
@@ -425,8 +799,8 @@ func (w *Wallet) GenerateValidatorKey(path string) ([]byte, error) {
 // Builds a local wallet keystore and saves its artifacts to disk
 func (w *Wallet) buildLocalWallet(derivationPath string, walletIndex uint, mnemonic string, password string, savePassword bool, testMode bool) error {
 	// Initialize the wallet with it
-	localMgr := newLocalWalletManager(w.chainID)
-	localData, err := localMgr.InitializeKeystore(derivationPath, walletIndex, mnemonic, password)
+	localMgr := newLocalWalletManager(w.ctx, w.chainID)
+	localData, err := localMgr.InitializeKeystore(derivationPath, walletIndex, mnemonic, password, w.kdfSettings)
 	if err != nil {
 		return fmt.Errorf("error initializing wallet keystore with recovered data: %w", err)
 	}
@@ -478,8 +852,8 @@ func (w *Wallet) isWalletDataOnDisk() (bool, error) {
 	return true, nil
 }
 
-// Load the wallet data from disk
-func (w *Wallet) loadWalletData(password string) (IWalletManager, error) {
+// Read the wallet data from disk
+func (w *Wallet) readWalletData() (*wallet.WalletData, error) {
 	// Read the file
 	bytes, err := os.ReadFile(w.walletDataPath)
 	if err != nil {
@@ -492,17 +866,71 @@ func (w *Wallet) loadWalletData(password string) (IWalletManager, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error deserializing wallet data at [%s]: %w", w.walletDataPath, err)
 	}
+	return data, nil
+}
+
+// Load the wallet data from disk if it's for a wallet that doesn't use a password, such as a hardware wallet. Returns
+// nil if there isn't any wallet data or it's for another type of wallet.
+func (w *Wallet) loadPasswordlessWalletData() (IWalletManager, error) {
+	isWalletOnDisk, err := w.isWalletDataOnDisk()
+	if err != nil {
+		return nil, fmt.Errorf("error checking if wallet data is on disk: %w", err)
+	}
+	if !isWalletOnDisk {
+		return nil, nil
+	}
+	data, err := w.readWalletData()
+	if err != nil {
+		return nil, err
+	}
+	if data.Type != wallet.WalletType_Hardware && data.Type != wallet.WalletType_RemoteSigner && data.Type != wallet.WalletType_Kms {
+		return nil, nil
+	}
+	return w.loadWalletData("")
+}
+
+// Load the wallet data from disk
+func (w *Wallet) loadWalletData(password string) (IWalletManager, error) {
+	data, err := w.readWalletData()
+	if err != nil {
+		return nil, err
+	}
 
 	// Load the proper type
 	var manager IWalletManager
 	switch data.Type {
 	case wallet.WalletType_Local:
-		localMgr := newLocalWalletManager(w.chainID)
+		localMgr := newLocalWalletManager(w.ctx, w.chainID)
 		err = localMgr.LoadWallet(&data.LocalData, password)
 		if err != nil {
 			return nil, fmt.Errorf("error loading local wallet data at %s: %w", w.walletDataPath, err)
 		}
+		w.migrateLegacyLocalWallet(localMgr, password)
 		manager = localMgr
+	case wallet.WalletType_Hardware:
+		// Hardware wallets don't use the password
+		hardwareMgr := newHardwareWalletManager(w.ctx, w.chainID)
+		err = hardwareMgr.LoadWallet(&data.HardwareData)
+		if err != nil {
+			return nil, fmt.Errorf("error loading hardware wallet data at %s: %w", w.walletDataPath, err)
+		}
+		manager = hardwareMgr
+	case wallet.WalletType_RemoteSigner:
+		// Remote signers don't use the password either
+		remoteMgr := newRemoteSignerWalletManager(w.ctx, w.chainID)
+		err = remoteMgr.LoadWallet(&data.RemoteSignerData)
+		if err != nil {
+			return nil, fmt.Errorf("error loading remote signer wallet data at %s: %w", w.walletDataPath, err)
+		}
+		manager = remoteMgr
+	case wallet.WalletType_Kms:
+		// KMS keys are protected by the cloud credentials instead of the password
+		kmsMgr := newKmsWalletManager(w.ctx, w.chainID)
+		err = kmsMgr.LoadWallet(&data.KmsData)
+		if err != nil {
+			return nil, fmt.Errorf("error loading KMS wallet data at %s: %w", w.walletDataPath, err)
+		}
+		manager = kmsMgr
 	default:
 		return nil, fmt.Errorf("unsupported wallet type: %s", data.Type)
 	}
@@ -511,6 +939,48 @@ func (w *Wallet) loadWalletData(password string) (IWalletManager, error) {
 	return manager, nil
 }
 
+// Get the wallet manager if it's for a local wallet. The lock must be held.
+func (w *Wallet) getLocalWalletManager() (*localWalletManager, error) {
+	if w.walletManager == nil {
+		return nil, ErrWalletNotLoaded
+	}
+	localMgr, ok := w.walletManager.(*localWalletManager)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return localMgr, nil
+}
+
+// Encrypt a local wallet's seed in the current format if it's still in the legacy keystore format. The legacy data
+// still works, so failures are only logged and the migration is tried again the next time the wallet is loaded.
+func (w *Wallet) migrateLegacyLocalWallet(localMgr *localWalletManager, password string) {
+	if !localMgr.IsLegacyFormat() {
+		return
+	}
+
+	// Keep a copy of the legacy data in case the migrated data can't be read
+	backupPath := w.walletDataPath + LegacyBackupSuffix
+	err := backupFile(w.walletDataPath, backupPath)
+	if err == nil {
+		err = localMgr.ReencryptSeed(password, w.kdfSettings)
+	}
+	if err == nil {
+		err = w.saveWalletData(&wallet.WalletData{
+			Type:      wallet.WalletType_Local,
+			LocalData: *localMgr.data,
+		})
+	}
+	if err != nil {
+		if w.logger != nil {
+			w.logger.Warn("Migrating wallet data to the current format failed", slog.String(log.PathKey, w.walletDataPath), log.Err(err))
+		}
+		return
+	}
+	if w.logger != nil {
+		w.logger.Info("Migrated wallet data to the current format", slog.String(log.PathKey, w.walletDataPath), slog.String(log.KdfKey, string(w.kdfSettings.Function)), slog.String(log.BackupPathKey, backupPath))
+	}
+}
+
 // Save the wallet data to disk
 func (w *Wallet) saveWalletData(data *wallet.WalletData) error {
 	// Serialize it
@@ -519,10 +989,11 @@ func (w *Wallet) saveWalletData(data *wallet.WalletData) error {
 		return fmt.Errorf("error serializing wallet data: %w", err)
 	}
 
-	// Write the file
-	err = os.WriteFile(w.walletDataPath, bytes, FileMode)
+	// Write it atomically, so an interrupted write (such as during a format migration) can't leave a corrupted wallet
+	// behind
+	err = writeFileAtomic(w.walletDataPath, bytes, FileMode)
 	if err != nil {
-		return fmt.Errorf("error writing wallet data to [%s]: %w", w.walletDataPath, err)
+		return fmt.Errorf("error saving wallet data: %w", err)
 	}
 	return nil
 }
@@ -536,6 +1007,67 @@ func (w *Wallet) masqueradeImpl(newAddress common.Address) error {
 // === Utils ===
 // =============
 
+// Write a file by writing a temporary file next to it and then moving it into place. The temporary file and the
+// directory are both synced, so the file has either its old or its new contents even if the system crashes.
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tempPath := path + ".tmp"
+	file, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("error creating [%s]: %w", tempPath, err)
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error writing [%s]: %w", tempPath, err)
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error moving [%s] to [%s]: %w", tempPath, path, err)
+	}
+
+	// Sync the directory so the rename itself is persisted
+	dirPath := filepath.Dir(path)
+	dir, err := os.Open(dirPath)
+	if err != nil {
+		return fmt.Errorf("error opening directory [%s]: %w", dirPath, err)
+	}
+	defer dir.Close()
+	err = dir.Sync()
+	if err != nil {
+		return fmt.Errorf("error syncing directory [%s]: %w", dirPath, err)
+	}
+	return nil
+}
+
+// Copy a file to a backup path, unless a backup is already there
+func backupFile(path string, backupPath string) error {
+	_, err := os.Stat(backupPath)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error checking backup [%s]: %w", backupPath, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	err = writeFileAtomic(backupPath, data, FileMode)
+	if err != nil {
+		return fmt.Errorf("error backing up [%s]: %w", path, err)
+	}
+	return nil
+}
+
 // Generate a new random mnemonic and seed
 func GenerateNewMnemonic() (string, error) {
 	// Generate random entropy for the mnemonic
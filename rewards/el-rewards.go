@@ -0,0 +1,130 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// The execution layer rewards earned by a block proposal
+type ProposalRewards struct {
+	Slot                 uint64
+	ProposerIndex        string
+	ExecutionBlockNumber uint64
+
+	// The fee recipient of the execution payload, which is the builder's address for most MEV-Boost blocks
+	PayloadFeeRecipient common.Address
+
+	// The address the proposer's rewards were sent to
+	ProposerFeeRecipient common.Address
+
+	// The change in the proposer's fee recipient balance over the block, in wei. This includes any other
+	// transactions to or from the fee recipient in the block, so it's only an approximation of the rewards.
+	FeeRecipientBalanceDelta *big.Int
+
+	// True if the block was delivered by one of the provided MEV-Boost relays
+	IsMevBoost bool
+
+	// The names of the relays that reported delivering the block
+	Relays []string
+
+	// The amount the relays reported the builder paid to the proposer, in wei
+	RelayReportedValue *big.Int
+
+	// The rewards attributed to the proposal, in wei. This is the relay-reported value for MEV-Boost blocks
+	// and the fee recipient's balance delta for locally built ones.
+	Rewards *big.Int
+}
+
+// Attribute execution layer rewards to the blocks proposed in the provided slots by looking up each block on the
+// Beacon node, the fee recipient's balance change over it on the Execution client, and whether any of the provided
+// relays delivered it. Slots without a block, or with a block that has no execution payload, are skipped.
+// The clients managed by the service provider can be used directly.
+func GetProposalRewards(ctx context.Context, ec eth.IExecutionClient, bc beacon.IBeaconClient, relays []*RelayClient, slots []uint64) ([]ProposalRewards, error) {
+	results := []ProposalRewards{}
+	for _, slot := range slots {
+		block, exists, err := bc.GetBeaconBlock(ctx, strconv.FormatUint(slot, 10))
+		if err != nil {
+			return nil, fmt.Errorf("error getting block for slot %d: %w", slot, err)
+		}
+		if !exists || !block.HasExecutionPayload {
+			continue
+		}
+		rewards := ProposalRewards{
+			Slot:                 slot,
+			ProposerIndex:        block.Header.ProposerIndex,
+			ExecutionBlockNumber: block.ExecutionBlockNumber,
+			PayloadFeeRecipient:  block.FeeRecipient,
+			ProposerFeeRecipient: block.FeeRecipient,
+			Relays:               []string{},
+		}
+
+		// Check if a relay delivered the block
+		for _, relay := range relays {
+			traces, err := relay.GetDeliveredPayloads(ctx, slot)
+			if err != nil {
+				return nil, fmt.Errorf("error getting delivered payloads for slot %d: %w", slot, err)
+			}
+			for _, trace := range traces {
+				if trace.BlockNumber != block.ExecutionBlockNumber {
+					continue
+				}
+				rewards.IsMevBoost = true
+				rewards.Relays = append(rewards.Relays, relay.GetName())
+				rewards.ProposerFeeRecipient = trace.ProposerFeeRecipient
+				rewards.RelayReportedValue = trace.Value
+				break
+			}
+		}
+
+		// Get the fee recipient's balance change over the block
+		blockNumber := new(big.Int).SetUint64(block.ExecutionBlockNumber)
+		balanceAfter, err := ec.BalanceAt(ctx, rewards.ProposerFeeRecipient, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error getting balance of %s at block %d: %w", rewards.ProposerFeeRecipient.Hex(), block.ExecutionBlockNumber, err)
+		}
+		balanceBefore, err := ec.BalanceAt(ctx, rewards.ProposerFeeRecipient, new(big.Int).Sub(blockNumber, big.NewInt(1)))
+		if err != nil {
+			return nil, fmt.Errorf("error getting balance of %s at block %d: %w", rewards.ProposerFeeRecipient.Hex(), block.ExecutionBlockNumber-1, err)
+		}
+		rewards.FeeRecipientBalanceDelta = new(big.Int).Sub(balanceAfter, balanceBefore)
+
+		if rewards.IsMevBoost {
+			rewards.Rewards = new(big.Int).Set(rewards.RelayReportedValue)
+		} else {
+			rewards.Rewards = new(big.Int).Set(rewards.FeeRecipientBalanceDelta)
+		}
+		results = append(results, rewards)
+	}
+	return results, nil
+}
+
+// Attribute execution layer rewards to the blocks the provided validators proposed in the provided range of
+// slots (inclusive). See GetProposalRewards for details.
+func GetProposalRewardsForValidators(ctx context.Context, ec eth.IExecutionClient, bc beacon.IBeaconClient, relays []*RelayClient, indices []string, startSlot uint64, endSlot uint64) ([]ProposalRewards, error) {
+	if endSlot < startSlot {
+		return nil, fmt.Errorf("end slot %d is before start slot %d", endSlot, startSlot)
+	}
+	owned := map[string]bool{}
+	for _, index := range indices {
+		owned[index] = true
+	}
+
+	// Find the slots with a block proposed by one of the validators
+	slots := []uint64{}
+	for slot := startSlot; slot <= endSlot; slot++ {
+		header, exists, err := bc.GetBeaconBlockHeader(ctx, strconv.FormatUint(slot, 10))
+		if err != nil {
+			return nil, fmt.Errorf("error getting block header for slot %d: %w", slot, err)
+		}
+		if exists && owned[header.ProposerIndex] {
+			slots = append(slots, slot)
+		}
+	}
+	return GetProposalRewards(ctx, ec, bc, relays, slots)
+}
@@ -0,0 +1,119 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The relay data API route for payloads that were delivered to proposers
+	deliveredPayloadsPath string = "/relay/v1/data/bidtraces/proposer_payload_delivered"
+
+	// The default timeout for relay requests
+	DefaultRelayTimeout time.Duration = 10 * time.Second
+)
+
+// A payload that a MEV-Boost relay delivered to a proposer, as reported by its data API
+type RelayBidTrace struct {
+	Slot                 uint64
+	BlockNumber          uint64
+	BlockHash            common.Hash
+	BuilderPubkey        beacon.ValidatorPubkey
+	ProposerPubkey       beacon.ValidatorPubkey
+	ProposerFeeRecipient common.Address
+
+	// The amount the builder paid to the proposer's fee recipient, in wei
+	Value *big.Int
+}
+
+// The format of bid traces returned by the relay data API
+type relayBidTraceResponse struct {
+	Slot                 utils.Uinteger         `json:"slot"`
+	BlockNumber          utils.Uinteger         `json:"block_number"`
+	BlockHash            common.Hash            `json:"block_hash"`
+	BuilderPubkey        beacon.ValidatorPubkey `json:"builder_pubkey"`
+	ProposerPubkey       beacon.ValidatorPubkey `json:"proposer_pubkey"`
+	ProposerFeeRecipient common.Address         `json:"proposer_fee_recipient"`
+	Value                eth.QuotedBigInt       `json:"value"`
+}
+
+// A client for the data API of a MEV-Boost relay
+type RelayClient struct {
+	name    string
+	baseUrl string
+	client  *http.Client
+}
+
+// Creates a new relay client for the relay at the provided URL
+func NewRelayClient(name string, baseUrl string, timeout time.Duration) *RelayClient {
+	return &RelayClient{
+		name:    name,
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+		client:  utils.NewIdentityHttpClient(timeout),
+	}
+}
+
+// Get the name of the relay
+func (c *RelayClient) GetName() string {
+	return c.name
+}
+
+// Get the payloads the relay delivered to the proposer of the provided slot. There is usually at most one.
+func (c *RelayClient) GetDeliveredPayloads(ctx context.Context, slot uint64) ([]RelayBidTrace, error) {
+	query := url.Values{}
+	query.Set("slot", strconv.FormatUint(slot, 10))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+deliveredPayloadsPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to relay %s: %w", c.name, err)
+	}
+	request.Header.Set("Accept", "application/json")
+
+	// Send the request
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error querying relay %s: %w", c.name, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from relay %s: %w", c.name, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("relay %s request failed with code %d: %s", c.name, response.StatusCode, string(body))
+	}
+
+	// Deserialize the response
+	var traces []relayBidTraceResponse
+	err = json.Unmarshal(body, &traces)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing response from relay %s: %w", c.name, err)
+	}
+	results := make([]RelayBidTrace, len(traces))
+	for i, trace := range traces {
+		results[i] = RelayBidTrace{
+			Slot:                 uint64(trace.Slot),
+			BlockNumber:          uint64(trace.BlockNumber),
+			BlockHash:            trace.BlockHash,
+			BuilderPubkey:        trace.BuilderPubkey,
+			ProposerPubkey:       trace.ProposerPubkey,
+			ProposerFeeRecipient: trace.ProposerFeeRecipient,
+			Value:                new(big.Int).Set(traces[i].Value.ToInt()),
+		}
+	}
+	return results, nil
+}
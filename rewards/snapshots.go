@@ -0,0 +1,316 @@
+package rewards
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/eth/contracts"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/store"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The store bucket that holds balance snapshots, keyed by their Unix timestamp
+	snapshotBucket string = "reward-snapshots"
+)
+
+// The balance of a validator at the time of a snapshot
+type ValidatorBalanceSnapshot struct {
+	Pubkey beacon.ValidatorPubkey `json:"pubkey"`
+	Index  string                 `json:"index"`
+
+	// The validator's balance, in gwei
+	Balance uint64 `json:"balance"`
+}
+
+// The balances of an address at the time of a snapshot
+type AddressBalanceSnapshot struct {
+	Address common.Address `json:"address"`
+
+	// The address's ETH balance, in wei
+	EthBalance *big.Int `json:"ethBalance"`
+
+	// The address's balance of each recorded token, keyed by the token's address, in the token's base units
+	TokenBalances map[common.Address]*big.Int `json:"tokenBalances"`
+}
+
+// A record of a set of validator, ETH, and token balances at a point in time
+type BalanceSnapshot struct {
+	Time        time.Time                  `json:"time"`
+	BlockNumber uint64                     `json:"blockNumber"`
+	Validators  []ValidatorBalanceSnapshot `json:"validators"`
+	Addresses   []AddressBalanceSnapshot   `json:"addresses"`
+}
+
+// Records periodic snapshots of validator balances, ETH balances, and token balances in a key-value store, so
+// earnings can be calculated over any period afterwards.
+type SnapshotRecorder struct {
+	kvStore    store.IKeyValueStore
+	ec         eth.IExecutionClient
+	bc         beacon.IBeaconClient
+	queryMgr   *eth.QueryManager
+	logger     *slog.Logger
+	validators []beacon.ValidatorPubkey
+	addresses  []common.Address
+	tokens     []contracts.IErc20Token
+	lock       sync.Mutex
+}
+
+// Creates a new snapshot recorder. The clients managed by the service provider can be used directly.
+func NewSnapshotRecorder(kvStore store.IKeyValueStore, ec eth.IExecutionClient, bc beacon.IBeaconClient, queryMgr *eth.QueryManager, logger *slog.Logger) *SnapshotRecorder {
+	return &SnapshotRecorder{
+		kvStore:    kvStore,
+		ec:         ec,
+		bc:         bc,
+		queryMgr:   queryMgr,
+		logger:     logger,
+		validators: []beacon.ValidatorPubkey{},
+		addresses:  []common.Address{},
+		tokens:     []contracts.IErc20Token{},
+	}
+}
+
+// Set the validators to record the balances of
+func (r *SnapshotRecorder) SetValidators(pubkeys []beacon.ValidatorPubkey) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.validators = append([]beacon.ValidatorPubkey{}, pubkeys...)
+}
+
+// Set the addresses to record the ETH and token balances of
+func (r *SnapshotRecorder) SetAddresses(addresses []common.Address) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.addresses = append([]common.Address{}, addresses...)
+}
+
+// Set the tokens to record the balances of for each address
+func (r *SnapshotRecorder) SetTokens(tokens []contracts.IErc20Token) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.tokens = append([]contracts.IErc20Token{}, tokens...)
+}
+
+// Get the current balances and save them as a new snapshot
+func (r *SnapshotRecorder) TakeSnapshot(ctx context.Context) (BalanceSnapshot, error) {
+	r.lock.Lock()
+	validators := r.validators
+	addresses := r.addresses
+	tokens := r.tokens
+	r.lock.Unlock()
+
+	// Pin the EL balances to a single block
+	blockNumber, err := r.ec.BlockNumber(ctx)
+	if err != nil {
+		return BalanceSnapshot{}, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	snapshot := BalanceSnapshot{
+		Time:        time.Now().UTC(),
+		BlockNumber: blockNumber,
+		Validators:  make([]ValidatorBalanceSnapshot, 0, len(validators)),
+		Addresses:   make([]AddressBalanceSnapshot, len(addresses)),
+	}
+
+	// Get the validator balances
+	if len(validators) > 0 {
+		statuses, err := r.bc.GetValidatorStatuses(ctx, validators, nil)
+		if err != nil {
+			return BalanceSnapshot{}, fmt.Errorf("error getting validator statuses: %w", err)
+		}
+		for _, pubkey := range validators {
+			status, exists := statuses[pubkey]
+			if !exists || !status.Exists {
+				continue
+			}
+			snapshot.Validators = append(snapshot.Validators, ValidatorBalanceSnapshot{
+				Pubkey:  pubkey,
+				Index:   status.Index,
+				Balance: status.Balance,
+			})
+		}
+	}
+
+	// Get the ETH balances
+	block := new(big.Int).SetUint64(blockNumber)
+	for i, address := range addresses {
+		balance, err := r.ec.BalanceAt(ctx, address, block)
+		if err != nil {
+			return BalanceSnapshot{}, fmt.Errorf("error getting ETH balance of %s: %w", address.Hex(), err)
+		}
+		snapshot.Addresses[i] = AddressBalanceSnapshot{
+			Address:       address,
+			EthBalance:    balance,
+			TokenBalances: make(map[common.Address]*big.Int, len(tokens)),
+		}
+	}
+
+	// Get the token balances
+	if len(tokens) > 0 && len(addresses) > 0 {
+		tokenBalances := make([][]*big.Int, len(addresses))
+		err = r.queryMgr.Query(func(mc *batch.MultiCaller) error {
+			for i, address := range addresses {
+				tokenBalances[i] = make([]*big.Int, len(tokens))
+				for j, token := range tokens {
+					token.BalanceOf(mc, &tokenBalances[i][j], address)
+				}
+			}
+			return nil
+		}, &bind.CallOpts{BlockNumber: block})
+		if err != nil {
+			return BalanceSnapshot{}, fmt.Errorf("error getting token balances: %w", err)
+		}
+		for i := range addresses {
+			for j, token := range tokens {
+				snapshot.Addresses[i].TokenBalances[token.Address()] = tokenBalances[i][j]
+			}
+		}
+	}
+
+	// Save the snapshot
+	err = r.kvStore.Update(func(tx store.IStoreTransaction) error {
+		return store.PutJson(tx, snapshotBucket, store.Uint64Key(uint64(snapshot.Time.Unix())), snapshot)
+	})
+	if err != nil {
+		return BalanceSnapshot{}, fmt.Errorf("error saving snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Take a snapshot at the provided interval until the context is cancelled
+func (r *SnapshotRecorder) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("snapshot interval must be positive")
+	}
+	for {
+		snapshot, err := r.TakeSnapshot(ctx)
+		if err != nil {
+			r.logger.Warn("Error taking balance snapshot.", log.Err(err))
+		} else {
+			r.logger.Debug("Took balance snapshot.", slog.Uint64(log.BlockKey, snapshot.BlockNumber))
+		}
+		if utils.SleepWithCancel(ctx, interval) {
+			return nil
+		}
+	}
+}
+
+// Get the snapshots taken in the provided time range (inclusive), in chronological order
+func (r *SnapshotRecorder) GetSnapshots(start time.Time, end time.Time) ([]BalanceSnapshot, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("end time %s is before start time %s", end, start)
+	}
+	snapshots := []BalanceSnapshot{}
+	startKey := store.Uint64Key(uint64(start.Unix()))
+	endKey := store.Uint64Key(uint64(end.Unix()) + 1)
+	err := r.kvStore.ForEach(snapshotBucket, startKey, endKey, func(key []byte, value []byte) error {
+		var snapshot BalanceSnapshot
+		err := json.Unmarshal(value, &snapshot)
+		if err != nil {
+			return fmt.Errorf("error deserializing snapshot %x: %w", key, err)
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Get the earliest and latest snapshots in the provided time range (inclusive), which bound the earnings over it.
+// Returns false if there are no snapshots in the range.
+func (r *SnapshotRecorder) GetSnapshotBounds(start time.Time, end time.Time) (BalanceSnapshot, BalanceSnapshot, bool, error) {
+	snapshots, err := r.GetSnapshots(start, end)
+	if err != nil {
+		return BalanceSnapshot{}, BalanceSnapshot{}, false, err
+	}
+	if len(snapshots) == 0 {
+		return BalanceSnapshot{}, BalanceSnapshot{}, false, nil
+	}
+	return snapshots[0], snapshots[len(snapshots)-1], true, nil
+}
+
+// Delete the snapshots taken before the provided time. Returns the number of snapshots that were deleted.
+func (r *SnapshotRecorder) PruneSnapshots(before time.Time) (int, error) {
+	count := 0
+	err := r.kvStore.Update(func(tx store.IStoreTransaction) error {
+		keys := [][]byte{}
+		err := tx.ForEach(snapshotBucket, nil, store.Uint64Key(uint64(before.Unix())), func(key []byte, value []byte) error {
+			keys = append(keys, key)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			err = tx.Delete(snapshotBucket, key)
+			if err != nil {
+				return err
+			}
+		}
+		count = len(keys)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error pruning snapshots: %w", err)
+	}
+	return count, nil
+}
+
+// Write snapshots as CSV, with one row per balance. Validator balances are in gwei, ETH balances are in wei, and
+// token balances are in the token's base units.
+func ExportSnapshotsCsv(writer io.Writer, snapshots []BalanceSnapshot) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{"time", "block_number", "kind", "subject", "token", "balance"})
+	if err != nil {
+		return fmt.Errorf("error writing CSV header: %w", err)
+	}
+	for _, snapshot := range snapshots {
+		timestamp := snapshot.Time.UTC().Format(time.RFC3339)
+		blockNumber := strconv.FormatUint(snapshot.BlockNumber, 10)
+		for _, validator := range snapshot.Validators {
+			err = csvWriter.Write([]string{timestamp, blockNumber, "validator", validator.Pubkey.HexWithPrefix(), "", strconv.FormatUint(validator.Balance, 10)})
+			if err != nil {
+				return fmt.Errorf("error writing CSV row: %w", err)
+			}
+		}
+		for _, address := range snapshot.Addresses {
+			err = csvWriter.Write([]string{timestamp, blockNumber, "eth", address.Address.Hex(), "", address.EthBalance.String()})
+			if err != nil {
+				return fmt.Errorf("error writing CSV row: %w", err)
+			}
+			// Sort the tokens so the rows are in a stable order
+			tokens := make([]common.Address, 0, len(address.TokenBalances))
+			for token := range address.TokenBalances {
+				tokens = append(tokens, token)
+			}
+			slices.SortFunc(tokens, func(a common.Address, b common.Address) int {
+				return bytes.Compare(a[:], b[:])
+			})
+			for _, token := range tokens {
+				err = csvWriter.Write([]string{timestamp, blockNumber, "token", address.Address.Hex(), token.Hex(), address.TokenBalances[token].String()})
+				if err != nil {
+					return fmt.Errorf("error writing CSV row: %w", err)
+				}
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
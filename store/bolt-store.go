@@ -0,0 +1,157 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// The permissions of the store's file
+	boltFileMode os.FileMode = 0600
+
+	// The time to wait for another process to release the store's file lock
+	boltOpenTimeout time.Duration = 5 * time.Second
+)
+
+// A key-value store backed by a BoltDB file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// Creates a new BoltDB store, creating the file (and its parent directory) if it doesn't exist.
+// Only one process can have the store open at a time.
+func NewBoltStore(path string) (*BoltStore, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, fmt.Errorf("error creating store directory: %w", err)
+	}
+	db, err := bolt.Open(path, boltFileMode, &bolt.Options{
+		Timeout: boltOpenTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error opening store at [%s]: %w", path, err)
+	}
+	return &BoltStore{
+		db: db,
+	}, nil
+}
+
+// Get the value of a key in a bucket, or nil if it doesn't exist
+func (s *BoltStore) Get(bucket string, key []byte) ([]byte, error) {
+	var value []byte
+	err := s.View(func(tx IStoreTransaction) error {
+		var err error
+		value, err = tx.Get(bucket, key)
+		return err
+	})
+	return value, err
+}
+
+// Set the value of a key in a bucket, creating the bucket if it doesn't exist
+func (s *BoltStore) Put(bucket string, key []byte, value []byte) error {
+	return s.Update(func(tx IStoreTransaction) error {
+		return tx.Put(bucket, key, value)
+	})
+}
+
+// Remove a key from a bucket; this does nothing if the key doesn't exist
+func (s *BoltStore) Delete(bucket string, key []byte) error {
+	return s.Update(func(tx IStoreTransaction) error {
+		return tx.Delete(bucket, key)
+	})
+}
+
+// Iterate over the entries in a bucket with keys in [start, end), in key order
+func (s *BoltStore) ForEach(bucket string, start []byte, end []byte, callback func(key []byte, value []byte) error) error {
+	return s.View(func(tx IStoreTransaction) error {
+		return tx.ForEach(bucket, start, end, callback)
+	})
+}
+
+// Run a function in a read-only transaction
+func (s *BoltStore) View(function func(tx IStoreTransaction) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return function(&boltTransaction{tx: tx})
+	})
+}
+
+// Run a function in a read-write transaction that's committed if the function succeeds
+func (s *BoltStore) Update(function func(tx IStoreTransaction) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return function(&boltTransaction{tx: tx})
+	})
+}
+
+// Close the store
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// A transaction against a BoltDB store
+type boltTransaction struct {
+	tx *bolt.Tx
+}
+
+// Get the value of a key in a bucket, or nil if it doesn't exist
+func (t *boltTransaction) Get(bucket string, key []byte) ([]byte, error) {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil, nil
+	}
+
+	// Values are only valid during the transaction, so they need to be copied
+	value := b.Get(key)
+	if value == nil {
+		return nil, nil
+	}
+	return bytes.Clone(value), nil
+}
+
+// Set the value of a key in a bucket, creating the bucket if it doesn't exist
+func (t *boltTransaction) Put(bucket string, key []byte, value []byte) error {
+	b, err := t.tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return fmt.Errorf("error creating bucket [%s]: %w", bucket, err)
+	}
+	return b.Put(key, value)
+}
+
+// Remove a key from a bucket; this does nothing if the key doesn't exist
+func (t *boltTransaction) Delete(bucket string, key []byte) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+	return b.Delete(key)
+}
+
+// Iterate over the entries in a bucket with keys in [start, end), in key order
+func (t *boltTransaction) ForEach(bucket string, start []byte, end []byte, callback func(key []byte, value []byte) error) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+
+	cursor := b.Cursor()
+	var key, value []byte
+	if start == nil {
+		key, value = cursor.First()
+	} else {
+		key, value = cursor.Seek(start)
+	}
+	for ; key != nil; key, value = cursor.Next() {
+		if end != nil && bytes.Compare(key, end) >= 0 {
+			break
+		}
+		err := callback(bytes.Clone(key), bytes.Clone(value))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
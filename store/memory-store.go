@@ -0,0 +1,191 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Returned when writing in a read-only transaction
+var errReadOnlyTransaction = errors.New("cannot write in a read-only transaction")
+
+// A key-value store held in memory, for tests and for daemons that don't need their state to survive restarts
+type MemoryStore struct {
+	buckets map[string]map[string][]byte
+	lock    sync.RWMutex
+}
+
+// Creates a new, empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]map[string][]byte{},
+	}
+}
+
+// Get the value of a key in a bucket, or nil if it doesn't exist
+func (s *MemoryStore) Get(bucket string, key []byte) ([]byte, error) {
+	var value []byte
+	err := s.View(func(tx IStoreTransaction) error {
+		var err error
+		value, err = tx.Get(bucket, key)
+		return err
+	})
+	return value, err
+}
+
+// Set the value of a key in a bucket, creating the bucket if it doesn't exist
+func (s *MemoryStore) Put(bucket string, key []byte, value []byte) error {
+	return s.Update(func(tx IStoreTransaction) error {
+		return tx.Put(bucket, key, value)
+	})
+}
+
+// Remove a key from a bucket; this does nothing if the key doesn't exist
+func (s *MemoryStore) Delete(bucket string, key []byte) error {
+	return s.Update(func(tx IStoreTransaction) error {
+		return tx.Delete(bucket, key)
+	})
+}
+
+// Iterate over the entries in a bucket with keys in [start, end), in key order
+func (s *MemoryStore) ForEach(bucket string, start []byte, end []byte, callback func(key []byte, value []byte) error) error {
+	return s.View(func(tx IStoreTransaction) error {
+		return tx.ForEach(bucket, start, end, callback)
+	})
+}
+
+// Run a function in a read-only transaction
+func (s *MemoryStore) View(function func(tx IStoreTransaction) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return function(&memoryTransaction{
+		store:    s,
+		readOnly: true,
+	})
+}
+
+// Run a function in a read-write transaction that's committed if the function succeeds
+func (s *MemoryStore) Update(function func(tx IStoreTransaction) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tx := &memoryTransaction{
+		store:   s,
+		pending: map[string]map[string][]byte{},
+	}
+	err := function(tx)
+	if err != nil {
+		return err
+	}
+
+	// Commit the changes
+	for bucket, changes := range tx.pending {
+		entries, exists := s.buckets[bucket]
+		if !exists {
+			entries = map[string][]byte{}
+			s.buckets[bucket] = entries
+		}
+		for key, value := range changes {
+			if value == nil {
+				delete(entries, key)
+			} else {
+				entries[key] = value
+			}
+		}
+	}
+	return nil
+}
+
+// Close the store; this does nothing for in-memory stores
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// A transaction against an in-memory store. Writes are staged until the transaction is committed; a nil staged
+// value is a deletion.
+type memoryTransaction struct {
+	store    *MemoryStore
+	pending  map[string]map[string][]byte
+	readOnly bool
+}
+
+// Get the value of a key in a bucket, or nil if it doesn't exist
+func (t *memoryTransaction) Get(bucket string, key []byte) ([]byte, error) {
+	if changes, exists := t.pending[bucket]; exists {
+		if value, exists := changes[string(key)]; exists {
+			return bytes.Clone(value), nil
+		}
+	}
+	value, exists := t.store.buckets[bucket][string(key)]
+	if !exists {
+		return nil, nil
+	}
+	return bytes.Clone(value), nil
+}
+
+// Stage a new value for a key in a bucket
+func (t *memoryTransaction) Put(bucket string, key []byte, value []byte) error {
+	if t.readOnly {
+		return errReadOnlyTransaction
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	t.stage(bucket, key, bytes.Clone(value))
+	return nil
+}
+
+// Stage the removal of a key from a bucket
+func (t *memoryTransaction) Delete(bucket string, key []byte) error {
+	if t.readOnly {
+		return errReadOnlyTransaction
+	}
+	t.stage(bucket, key, nil)
+	return nil
+}
+
+// Iterate over the entries in a bucket with keys in [start, end), in key order, including staged changes
+func (t *memoryTransaction) ForEach(bucket string, start []byte, end []byte, callback func(key []byte, value []byte) error) error {
+	// Merge the committed and staged entries
+	entries := map[string][]byte{}
+	for key, value := range t.store.buckets[bucket] {
+		entries[key] = value
+	}
+	for key, value := range t.pending[bucket] {
+		if value == nil {
+			delete(entries, key)
+		} else {
+			entries[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		if start != nil && bytes.Compare([]byte(key), start) < 0 {
+			continue
+		}
+		if end != nil && bytes.Compare([]byte(key), end) >= 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		err := callback([]byte(key), bytes.Clone(entries[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stage a change to a key
+func (t *memoryTransaction) stage(bucket string, key []byte, value []byte) {
+	changes, exists := t.pending[bucket]
+	if !exists {
+		changes = map[string][]byte{}
+		t.pending[bucket] = changes
+	}
+	changes[string(key)] = value
+}
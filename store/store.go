@@ -0,0 +1,79 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+// A transaction against a key-value store. Changes made in the transaction are only visible once it's committed.
+type IStoreTransaction interface {
+	// Get the value of a key in a bucket, or nil if it doesn't exist
+	Get(bucket string, key []byte) ([]byte, error)
+
+	// Set the value of a key in a bucket, creating the bucket if it doesn't exist
+	Put(bucket string, key []byte, value []byte) error
+
+	// Remove a key from a bucket; this does nothing if the key doesn't exist
+	Delete(bucket string, key []byte) error
+
+	// Iterate over the entries in a bucket with keys in [start, end), in key order. A nil start or end means the range
+	// is unbounded in that direction. Iteration stops at the first error returned by the callback.
+	ForEach(bucket string, start []byte, end []byte, callback func(key []byte, value []byte) error) error
+}
+
+// An embedded key-value store that daemons can use for persistent state, such as journals, scan cursors, and snapshots.
+// Entries are grouped into buckets, and keys within a bucket are sorted bytewise.
+type IKeyValueStore interface {
+	IStoreTransaction
+
+	// Run a function in a read-only transaction
+	View(function func(tx IStoreTransaction) error) error
+
+	// Run a function in a read-write transaction. The changes are committed atomically if the function succeeds,
+	// and discarded if it returns an error.
+	Update(function func(tx IStoreTransaction) error) error
+
+	// Close the store
+	Close() error
+}
+
+// Get a key for a uint64 (such as a timestamp, block number, or slot) that sorts in numerical order
+func Uint64Key(value uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, value)
+}
+
+// Parse a key created by Uint64Key
+func ParseUint64Key(key []byte) (uint64, error) {
+	if len(key) != 8 {
+		return 0, fmt.Errorf("key must be 8 bytes but was %d", len(key))
+	}
+	return binary.BigEndian.Uint64(key), nil
+}
+
+// Get a JSON-serialized value from a store. Returns false if the key doesn't exist.
+func GetJson[ValueType any](tx IStoreTransaction, bucket string, key []byte) (ValueType, bool, error) {
+	var value ValueType
+	bytes, err := tx.Get(bucket, key)
+	if err != nil {
+		return value, false, err
+	}
+	if bytes == nil {
+		return value, false, nil
+	}
+	err = json.Unmarshal(bytes, &value)
+	if err != nil {
+		return value, false, fmt.Errorf("error deserializing value of key %x in bucket [%s]: %w", key, bucket, err)
+	}
+	return value, true, nil
+}
+
+// Serialize a value to JSON and save it in a store
+func PutJson[ValueType any](tx IStoreTransaction, bucket string, key []byte, value ValueType) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error serializing value of key %x in bucket [%s]: %w", key, bucket, err)
+	}
+	return tx.Put(bucket, key, bytes)
+}
@@ -0,0 +1,74 @@
+package artifacts
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Get the hex-encoded SHA-256 checksum of the data provided by a reader
+func Sha256Hex(reader io.Reader) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", fmt.Errorf("error hashing data: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Verify that the data has the expected hex-encoded SHA-256 checksum
+func VerifySha256(data []byte, expectedChecksum string) error {
+	return VerifySha256Reader(bytes.NewReader(data), expectedChecksum)
+}
+
+// Verify that the data provided by a reader has the expected hex-encoded SHA-256 checksum
+func VerifySha256Reader(reader io.Reader, expectedChecksum string) error {
+	checksum, err := Sha256Hex(reader)
+	if err != nil {
+		return err
+	}
+	expectedChecksum = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expectedChecksum), "0x"))
+	if checksum != expectedChecksum {
+		return fmt.Errorf("checksum mismatch: expected %s but got %s", expectedChecksum, checksum)
+	}
+	return nil
+}
+
+// Verify that the file at the provided path has the expected hex-encoded SHA-256 checksum
+func VerifySha256File(path string, expectedChecksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening [%s]: %w", path, err)
+	}
+	defer file.Close()
+
+	err = VerifySha256Reader(file, expectedChecksum)
+	if err != nil {
+		return fmt.Errorf("error verifying [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Find the checksum for a file in the contents of a checksum list, such as the output of the sha256sum tool.
+// Each line is expected to be in the format "<checksum> <filename>", where the filename can optionally start with
+// a '*' to indicate binary mode.
+func FindChecksum(checksumList []byte, filename string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksumList))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") == filename {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading checksum list: %w", err)
+	}
+	return "", fmt.Errorf("checksum list does not contain an entry for [%s]", filename)
+}
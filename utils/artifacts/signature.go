@@ -0,0 +1,161 @@
+package artifacts
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	minisignAlgorithmLength   int    = 2
+	minisignKeyIDLength       int    = 8
+	minisignPureAlgorithm     string = "Ed"
+	minisignPrehashAlgorithm  string = "ED"
+	minisignTrustedCommentTag string = "trusted comment: "
+)
+
+// ===========
+// === PGP ===
+// ===========
+
+// Verify a detached PGP signature of the data using the provided public keys.
+// The keyring and signature can each be either ASCII-armored or binary.
+// Returns the ID of the key that made the signature.
+func VerifyPgpSignature(data []byte, signature []byte, keyring []byte) (uint64, error) {
+	var keys openpgp.EntityList
+	var err error
+	if isArmored(keyring) {
+		keys, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(keyring))
+	} else {
+		keys, err = openpgp.ReadKeyRing(bytes.NewReader(keyring))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error reading PGP keyring: %w", err)
+	}
+
+	var signer *openpgp.Entity
+	if isArmored(signature) {
+		signer, err = openpgp.CheckArmoredDetachedSignature(keys, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	} else {
+		signer, err = openpgp.CheckDetachedSignature(keys, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid PGP signature: %w", err)
+	}
+	return signer.PrimaryKey.KeyId, nil
+}
+
+// Check if PGP data is ASCII-armored instead of binary
+func isArmored(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN "))
+}
+
+// ================
+// === Minisign ===
+// ================
+
+// A minisign public key
+type MinisignPublicKey struct {
+	KeyID     [minisignKeyIDLength]byte
+	PublicKey ed25519.PublicKey
+}
+
+// Parse a minisign public key, either as the full contents of a .pub file or just its base64-encoded key line
+func ParseMinisignPublicKey(key string) (MinisignPublicKey, error) {
+	keyLine := ""
+	for _, line := range strings.Split(strings.TrimSpace(key), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") {
+			continue
+		}
+		keyLine = line
+		break
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(keyLine)
+	if err != nil {
+		return MinisignPublicKey{}, fmt.Errorf("error decoding minisign public key: %w", err)
+	}
+	expectedLength := minisignAlgorithmLength + minisignKeyIDLength + ed25519.PublicKeySize
+	if len(decoded) != expectedLength {
+		return MinisignPublicKey{}, fmt.Errorf("invalid minisign public key length: expected %d bytes but got %d", expectedLength, len(decoded))
+	}
+	if string(decoded[:minisignAlgorithmLength]) != minisignPureAlgorithm {
+		return MinisignPublicKey{}, fmt.Errorf("unsupported minisign public key algorithm [%s]", string(decoded[:minisignAlgorithmLength]))
+	}
+
+	publicKey := MinisignPublicKey{
+		PublicKey: ed25519.PublicKey(decoded[minisignAlgorithmLength+minisignKeyIDLength:]),
+	}
+	copy(publicKey.KeyID[:], decoded[minisignAlgorithmLength:minisignAlgorithmLength+minisignKeyIDLength])
+	return publicKey, nil
+}
+
+// Verify a minisign signature of the data, including its trusted comment.
+// The signature is the full contents of a .minisig file. Returns the trusted comment if verification succeeds.
+func VerifyMinisignSignature(data []byte, signature []byte, publicKey MinisignPublicKey) (string, error) {
+	// Read the lines of the signature file
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(signature))
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading minisign signature: %w", err)
+	}
+	if len(lines) < 4 {
+		return "", fmt.Errorf("minisign signature is incomplete: expected 4 lines but got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[2], minisignTrustedCommentTag) {
+		return "", fmt.Errorf("minisign signature is missing its trusted comment")
+	}
+	trustedComment := strings.TrimPrefix(lines[2], minisignTrustedCommentTag)
+
+	// Decode the signature
+	decoded, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil {
+		return "", fmt.Errorf("error decoding minisign signature: %w", err)
+	}
+	expectedLength := minisignAlgorithmLength + minisignKeyIDLength + ed25519.SignatureSize
+	if len(decoded) != expectedLength {
+		return "", fmt.Errorf("invalid minisign signature length: expected %d bytes but got %d", expectedLength, len(decoded))
+	}
+	algorithm := string(decoded[:minisignAlgorithmLength])
+	keyID := decoded[minisignAlgorithmLength : minisignAlgorithmLength+minisignKeyIDLength]
+	rawSignature := decoded[minisignAlgorithmLength+minisignKeyIDLength:]
+	if !bytes.Equal(keyID, publicKey.KeyID[:]) {
+		return "", fmt.Errorf("minisign signature was made with key %X, not the provided key %X", keyID, publicKey.KeyID)
+	}
+
+	// Verify the data signature
+	var message []byte
+	switch algorithm {
+	case minisignPureAlgorithm:
+		message = data
+	case minisignPrehashAlgorithm:
+		digest := blake2b.Sum512(data)
+		message = digest[:]
+	default:
+		return "", fmt.Errorf("unsupported minisign signature algorithm [%s]", algorithm)
+	}
+	if !ed25519.Verify(publicKey.PublicKey, message, rawSignature) {
+		return "", fmt.Errorf("invalid minisign signature")
+	}
+
+	// Verify the global signature, which covers the trusted comment
+	globalSignature, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil {
+		return "", fmt.Errorf("error decoding minisign global signature: %w", err)
+	}
+	globalMessage := append(append([]byte{}, rawSignature...), []byte(trustedComment)...)
+	if !ed25519.Verify(publicKey.PublicKey, globalMessage, globalSignature) {
+		return "", fmt.Errorf("invalid minisign trusted comment signature")
+	}
+	return trustedComment, nil
+}
@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// ================
+// === Duration ===
+// ================
+
+// A duration that is serialized as a human-readable string such as "90s" or "5m"
+type Duration time.Duration
+
+// Get the duration as a time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	value, err := time.ParseDuration(strings.TrimSpace(string(text)))
+	if err != nil {
+		return fmt.Errorf("invalid duration [%s]: %w", string(text), err)
+	}
+	*d = Duration(value)
+	return nil
+}
+
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.UnmarshalText([]byte(value.Value))
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var dataStr string
+	if err := json.Unmarshal(data, &dataStr); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(dataStr))
+}
+
+// ===================
+// === AddressList ===
+// ===================
+
+// A list of addresses that can be deserialized from either a comma-separated string or an array of strings,
+// and is always serialized with checksummed addresses. It's stored as its comma-separated string form so it's
+// comparable and can be used as a config parameter type; use ParseAddressList or NewAddressList to create one.
+type AddressList string
+
+// Create a list from a set of addresses
+func NewAddressList(addresses ...common.Address) AddressList {
+	entries := make([]string, len(addresses))
+	for i, address := range addresses {
+		entries[i] = address.Hex()
+	}
+	return AddressList(strings.Join(entries, ","))
+}
+
+// Parse a comma-separated list of addresses. Blank entries are ignored.
+func ParseAddressList(value string) (AddressList, error) {
+	addresses := []common.Address{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			return "", fmt.Errorf("invalid address [%s]", entry)
+		}
+		addresses = append(addresses, common.HexToAddress(entry))
+	}
+	return NewAddressList(addresses...), nil
+}
+
+// Get the addresses in the list. Entries that aren't valid addresses are skipped, though lists made by
+// ParseAddressList or NewAddressList never have any.
+func (l AddressList) Addresses() []common.Address {
+	addresses := []common.Address{}
+	for _, entry := range strings.Split(string(l), ",") {
+		entry = strings.TrimSpace(entry)
+		if common.IsHexAddress(entry) {
+			addresses = append(addresses, common.HexToAddress(entry))
+		}
+	}
+	return addresses
+}
+
+// Get the list as a comma-separated string of checksummed addresses
+func (l AddressList) String() string {
+	return string(l)
+}
+
+func (l AddressList) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *AddressList) UnmarshalText(text []byte) error {
+	list, err := ParseAddressList(string(text))
+	if err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+func (l AddressList) MarshalYAML() (interface{}, error) {
+	return l.toStrings(), nil
+}
+
+func (l *AddressList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		return l.UnmarshalText([]byte(value.Value))
+	case yaml.SequenceNode:
+		var entries []string
+		if err := value.Decode(&entries); err != nil {
+			return err
+		}
+		return l.UnmarshalText([]byte(strings.Join(entries, ",")))
+	default:
+		return fmt.Errorf("expected an address list string or sequence but got a YAML node of kind %d", value.Kind)
+	}
+}
+
+func (l AddressList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.toStrings())
+}
+
+func (l *AddressList) UnmarshalJSON(data []byte) error {
+	// Try the string form first
+	var dataStr string
+	if err := json.Unmarshal(data, &dataStr); err == nil {
+		return l.UnmarshalText([]byte(dataStr))
+	}
+
+	// Try the array form
+	var entries []string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("expected an address list string or array: %w", err)
+	}
+	return l.UnmarshalText([]byte(strings.Join(entries, ",")))
+}
+
+// Get the checksummed string representations of each address
+func (l AddressList) toStrings() []string {
+	addresses := l.Addresses()
+	entries := make([]string, len(addresses))
+	for i, address := range addresses {
+		entries[i] = address.Hex()
+	}
+	return entries
+}
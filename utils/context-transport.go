@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// An HTTP transport that cancels requests when a long-lived context is cancelled, in addition to when their own
+// contexts are. This lets clients whose callers don't pass the daemon's base context still stop on shutdown.
+type ContextTransport struct {
+	// The underlying transport; if nil, http.DefaultTransport is used
+	Base http.RoundTripper
+
+	// The context that cancels all of the transport's requests
+	Context context.Context
+}
+
+// Send the request using the underlying transport, cancelling it if either context is cancelled
+func (t *ContextTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Context == nil {
+		return base.RoundTrip(request)
+	}
+	if err := t.Context.Err(); err != nil {
+		if request.Body != nil {
+			_ = request.Body.Close()
+		}
+		return nil, err
+	}
+
+	// Cancel the request when the transport's context is, and stop watching it once the response body is closed
+	ctx, cancel := context.WithCancel(request.Context())
+	stop := context.AfterFunc(t.Context, cancel)
+	release := func() {
+		stop()
+		cancel()
+	}
+	response, err := base.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		release()
+		return nil, err
+	}
+	response.Body = &contextBody{
+		ReadCloser: response.Body,
+		release:    release,
+	}
+	return response, nil
+}
+
+// A response body that releases its request's context when it's closed
+type contextBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close the body and release the request's context
+func (b *contextBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Start a server whose requests hang until the client gives up on them, or the test ends
+func newHangingServer(t *testing.T, started chan<- struct{}) *httptest.Server {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if started != nil {
+			started <- struct{}{}
+		}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+	return server
+}
+
+func TestContextTransportCancelsInFlightRequest(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := newHangingServer(t, started)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &http.Client{
+		Transport: &ContextTransport{Context: ctx},
+	}
+
+	// Cancel the transport's context once the request reaches the server
+	go func() {
+		<-started
+		cancel()
+	}()
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = client.Do(request)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("request took %s to stop after cancellation", elapsed)
+	}
+}
+
+func TestContextTransportRejectsCancelledContext(t *testing.T) {
+	server := newHangingServer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := &http.Client{
+		Transport: &ContextTransport{Context: ctx},
+	}
+
+	_, err := client.Get(server.URL)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+}
+
+func TestContextTransportCancelsBodyRead(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Send the headers, then hang while sending the body
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &http.Client{
+		Transport: &ContextTransport{Context: ctx},
+	}
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	cancel()
+	_, err = io.ReadAll(response.Body)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected reading the body to fail with a context cancellation error, got %v", err)
+	}
+}
+
+func TestContextTransportCompletesRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &ContextTransport{Context: context.Background()},
+	}
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "ok" {
+		t.Fatalf("expected a body of \"ok\", got %q", body)
+	}
+}
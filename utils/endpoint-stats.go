@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// The default number of recent requests used to calculate endpoint statistics
+	DefaultEndpointStatsWindowSize int = 100
+)
+
+// Rolling latency and availability statistics for a single client endpoint
+type EndpointStats struct {
+	// The number of requests in the current window
+	Requests int `json:"requests"`
+
+	// The number of failed requests in the current window
+	Failures int `json:"failures"`
+
+	// The ratio of successful requests to total requests in the current window, from 0 to 1
+	SuccessRate float64 `json:"successRate"`
+
+	// The exponential moving average of the latency of successful requests
+	LatencyAverage time.Duration `json:"latencyAverage"`
+
+	// Latency percentiles of the recent successful requests
+	LatencyP50 time.Duration `json:"latencyP50"`
+	LatencyP90 time.Duration `json:"latencyP90"`
+	LatencyP99 time.Duration `json:"latencyP99"`
+
+	// The time of the most recent successful request
+	LastSuccess time.Time `json:"lastSuccess"`
+
+	// The time of the most recent failed request
+	LastFailure time.Time `json:"lastFailure"`
+
+	// The error message of the most recent failed request
+	LastError string `json:"lastError,omitempty"`
+
+	// The total number of requests since the tracker was created
+	TotalRequests uint64 `json:"totalRequests"`
+
+	// The total number of failed requests since the tracker was created
+	TotalFailures uint64 `json:"totalFailures"`
+}
+
+// Implemented by clients that can report statistics about their endpoint
+type IEndpointStatsProvider interface {
+	// Get the latency and availability statistics for the client's endpoint
+	GetEndpointStats() EndpointStats
+}
+
+// Tracks latency and availability statistics over a rolling window of recent requests
+type EndpointStatsTracker struct {
+	latency       *LatencyTracker
+	samples       []bool
+	next          int
+	count         int
+	lastSuccess   time.Time
+	lastFailure   time.Time
+	lastError     string
+	totalRequests uint64
+	totalFailures uint64
+	lock          sync.Mutex
+}
+
+// Creates a new tracker that keeps the provided number of recent requests.
+// If the window size isn't positive, DefaultEndpointStatsWindowSize is used.
+func NewEndpointStatsTracker(windowSize int) *EndpointStatsTracker {
+	if windowSize <= 0 {
+		windowSize = DefaultEndpointStatsWindowSize
+	}
+	return &EndpointStatsTracker{
+		latency: NewLatencyTracker(DefaultLatencyEmaAlpha, windowSize),
+		samples: make([]bool, windowSize),
+	}
+}
+
+// Record the result of a request. A nil error indicates it succeeded.
+func (t *EndpointStatsTracker) Record(latency time.Duration, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	success := (err == nil)
+	if success {
+		t.latency.Add(latency)
+	}
+	t.samples[t.next] = success
+	t.next = (t.next + 1) % len(t.samples)
+	if t.count < len(t.samples) {
+		t.count++
+	}
+
+	t.totalRequests++
+	if success {
+		t.lastSuccess = time.Now()
+	} else {
+		t.totalFailures++
+		t.lastFailure = time.Now()
+		t.lastError = err.Error()
+	}
+}
+
+// Get the statistics for the current window
+func (t *EndpointStatsTracker) GetStats() EndpointStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	stats := EndpointStats{
+		Requests:      t.count,
+		LastSuccess:   t.lastSuccess,
+		LastFailure:   t.lastFailure,
+		LastError:     t.lastError,
+		TotalRequests: t.totalRequests,
+		TotalFailures: t.totalFailures,
+	}
+	if t.count == 0 {
+		return stats
+	}
+
+	for _, success := range t.samples[:t.count] {
+		if !success {
+			stats.Failures++
+		}
+	}
+	stats.SuccessRate = float64(t.count-stats.Failures) / float64(t.count)
+
+	percentiles := t.latency.Percentiles(50, 90, 99)
+	stats.LatencyAverage = t.latency.Average()
+	stats.LatencyP50 = percentiles[0]
+	stats.LatencyP90 = percentiles[1]
+	stats.LatencyP99 = percentiles[2]
+	return stats
+}
+
+// An HTTP transport that records the latency and result of every request it sends.
+// Requests that fail to complete or return a 5xx status code are considered failures.
+type StatsTrackingTransport struct {
+	// The underlying transport; if nil, http.DefaultTransport is used
+	Base http.RoundTripper
+
+	// The tracker to record requests in
+	Tracker *EndpointStatsTracker
+}
+
+// Send the request using the underlying transport and record the result
+func (t *StatsTrackingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	response, err := base.RoundTrip(request)
+	latency := time.Since(start)
+
+	if err != nil {
+		t.Tracker.Record(latency, err)
+		return response, err
+	}
+	if response.StatusCode >= http.StatusInternalServerError {
+		t.Tracker.Record(latency, &httpStatusError{status: response.Status})
+	} else {
+		t.Tracker.Record(latency, nil)
+	}
+	return response, nil
+}
+
+// An error representing a server-side HTTP failure
+type httpStatusError struct {
+	status string
+}
+
+func (e *httpStatusError) Error() string {
+	return "HTTP status " + e.status
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A 32-byte value, such as a root or hash, that must be exactly 32 bytes when deserialized
+type Bytes32 [32]byte
+
+// A 48-byte value, such as a BLS public key, that must be exactly 48 bytes when deserialized
+type Bytes48 [48]byte
+
+// A 96-byte value, such as a BLS signature, that must be exactly 96 bytes when deserialized
+type Bytes96 [96]byte
+
+// ===============
+// === Bytes32 ===
+// ===============
+
+func (b Bytes32) MarshalYAML() (interface{}, error) {
+	return EncodeHexWithPrefix(b[:]), nil
+}
+
+func (b *Bytes32) UnmarshalYAML(value *yaml.Node) error {
+	return decodeFixedHex(value.Value, b[:])
+}
+
+func (b Bytes32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(EncodeHexWithPrefix(b[:]))
+}
+
+func (b *Bytes32) UnmarshalJSON(data []byte) error {
+	return decodeFixedHexJson(data, b[:])
+}
+
+// ===============
+// === Bytes48 ===
+// ===============
+
+func (b Bytes48) MarshalYAML() (interface{}, error) {
+	return EncodeHexWithPrefix(b[:]), nil
+}
+
+func (b *Bytes48) UnmarshalYAML(value *yaml.Node) error {
+	return decodeFixedHex(value.Value, b[:])
+}
+
+func (b Bytes48) MarshalJSON() ([]byte, error) {
+	return json.Marshal(EncodeHexWithPrefix(b[:]))
+}
+
+func (b *Bytes48) UnmarshalJSON(data []byte) error {
+	return decodeFixedHexJson(data, b[:])
+}
+
+// ===============
+// === Bytes96 ===
+// ===============
+
+func (b Bytes96) MarshalYAML() (interface{}, error) {
+	return EncodeHexWithPrefix(b[:]), nil
+}
+
+func (b *Bytes96) UnmarshalYAML(value *yaml.Node) error {
+	return decodeFixedHex(value.Value, b[:])
+}
+
+func (b Bytes96) MarshalJSON() ([]byte, error) {
+	return json.Marshal(EncodeHexWithPrefix(b[:]))
+}
+
+func (b *Bytes96) UnmarshalJSON(data []byte) error {
+	return decodeFixedHexJson(data, b[:])
+}
+
+// ========================
+// === Internal Helpers ===
+// ========================
+
+// Unmarshal a JSON string and decode it as hex into the destination, which must be filled exactly
+func decodeFixedHexJson(data []byte, destination []byte) error {
+	var dataStr string
+	if err := json.Unmarshal(data, &dataStr); err != nil {
+		return err
+	}
+	return decodeFixedHex(dataStr, destination)
+}
+
+// Decode a hex string into the destination, which must be filled exactly
+func decodeFixedHex(value string, destination []byte) error {
+	bytes, err := DecodeHex(value)
+	if err != nil {
+		return fmt.Errorf("error decoding hex string [%s]: %w", value, err)
+	}
+	if len(bytes) != len(destination) {
+		return fmt.Errorf("invalid length for hex string [%s]: expected %d bytes but got %d", value, len(destination), len(bytes))
+	}
+	copy(destination, bytes)
+	return nil
+}
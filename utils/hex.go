@@ -2,11 +2,43 @@ package utils
 
 import (
 	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 )
 
 const (
-	hexPrefix string = "0x"
+	hexPrefix      string = "0x"
+	hexPrefixUpper string = "0X"
+)
+
+// Options for decoding hex strings
+type HexDecodeOptions struct {
+	// If true, the string must start with a 0x prefix
+	RequirePrefix bool
+
+	// If true, strings with an odd number of characters are treated as if they had a leading zero
+	AllowOddLength bool
+
+	// If true, leading and trailing whitespace is removed before decoding
+	TrimSpace bool
+
+	// The maximum number of decoded bytes allowed; 0 means no limit
+	MaxLength int
+}
+
+var (
+	// Options for decoding hex strings that must be well-formed, such as values provided by clients
+	StrictHexDecodeOptions = HexDecodeOptions{
+		RequirePrefix: true,
+	}
+
+	// Options for decoding hex strings provided by users, which may be a little messy
+	LenientHexDecodeOptions = HexDecodeOptions{
+		AllowOddLength: true,
+		TrimSpace:      true,
+	}
 )
 
 // Encode bytes to a hex string with a 0x prefix
@@ -17,7 +49,36 @@ func EncodeHexWithPrefix(value []byte) string {
 
 // Convert a hex-encoded string to a byte array, removing the 0x prefix if present
 func DecodeHex(value string) ([]byte, error) {
-	value = strings.TrimPrefix(value, hexPrefix)
+	return DecodeHexWithOptions(value, HexDecodeOptions{})
+}
+
+// Convert a hex-encoded string to a byte array according to the provided options.
+// A 0x or 0X prefix is removed if present.
+func DecodeHexWithOptions(value string, opts HexDecodeOptions) ([]byte, error) {
+	if opts.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+
+	// Handle the prefix
+	hasPrefix := strings.HasPrefix(value, hexPrefix) || strings.HasPrefix(value, hexPrefixUpper)
+	if opts.RequirePrefix && !hasPrefix {
+		return nil, fmt.Errorf("hex string is missing the 0x prefix")
+	}
+	if hasPrefix {
+		value = value[len(hexPrefix):]
+	}
+
+	// Handle the length
+	if len(value)%2 != 0 {
+		if !opts.AllowOddLength {
+			return nil, fmt.Errorf("hex string has an odd length (%d characters)", len(value))
+		}
+		value = "0" + value
+	}
+	if opts.MaxLength > 0 && hex.DecodedLen(len(value)) > opts.MaxLength {
+		return nil, fmt.Errorf("hex string is %d bytes long, which is longer than the maximum of %d", hex.DecodedLen(len(value)), opts.MaxLength)
+	}
+
 	return hex.DecodeString(value)
 }
 
@@ -33,3 +94,59 @@ func AddPrefix(value string) string {
 func RemovePrefix(value string) string {
 	return strings.TrimPrefix(value, hexPrefix)
 }
+
+// Encode an integer as a quantity according to the Ethereum JSON-RPC spec (0x prefix, no leading zeros)
+func EncodeQuantity(value uint64) string {
+	return hexPrefix + strconv.FormatUint(value, 16)
+}
+
+// Encode a non-negative big integer as a quantity according to the Ethereum JSON-RPC spec (0x prefix, no leading zeros)
+func EncodeBigQuantity(value *big.Int) (string, error) {
+	if value.Sign() < 0 {
+		return "", fmt.Errorf("quantities cannot be negative")
+	}
+	return hexPrefix + value.Text(16), nil
+}
+
+// Decode a quantity according to the Ethereum JSON-RPC spec.
+// The value must have a 0x prefix, at least one digit, and no leading zeros.
+func DecodeQuantity(value string) (uint64, error) {
+	digits, err := getQuantityDigits(value)
+	if err != nil {
+		return 0, err
+	}
+	result, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity [%s]: %w", value, err)
+	}
+	return result, nil
+}
+
+// Decode a quantity of arbitrary size according to the Ethereum JSON-RPC spec.
+// The value must have a 0x prefix, at least one digit, and no leading zeros.
+func DecodeBigQuantity(value string) (*big.Int, error) {
+	digits, err := getQuantityDigits(value)
+	if err != nil {
+		return nil, err
+	}
+	result, success := new(big.Int).SetString(digits, 16)
+	if !success {
+		return nil, fmt.Errorf("invalid quantity [%s]: not a hex number", value)
+	}
+	return result, nil
+}
+
+// Validate a quantity's formatting and get its hex digits
+func getQuantityDigits(value string) (string, error) {
+	if !strings.HasPrefix(value, hexPrefix) {
+		return "", fmt.Errorf("invalid quantity [%s]: missing the 0x prefix", value)
+	}
+	digits := value[len(hexPrefix):]
+	if len(digits) == 0 {
+		return "", fmt.Errorf("invalid quantity [%s]: no digits", value)
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", fmt.Errorf("invalid quantity [%s]: leading zeros are not allowed", value)
+	}
+	return digits, nil
+}
@@ -10,9 +10,9 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/goccy/go-json"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils"
 	"github.com/tyler-smith/go-bip39"
 )
 
@@ -101,6 +101,18 @@ func ValidateEthAmount(name, value string) (float64, error) {
 	return val, nil
 }
 
+// Validate a non-negative ether amount exactly, returning it in wei
+func ValidateEthAmountAsWei(name, value string) (*big.Int, error) {
+	val, err := eth.ParseEthAmount(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s'", name, value)
+	}
+	if val.Sign() < 0 {
+		return nil, fmt.Errorf("Invalid %s '%s' - must not be negative", name, value)
+	}
+	return val, nil
+}
+
 // Validate a fraction
 func ValidateFraction(name, value string) (float64, error) {
 	val, err := strconv.ParseFloat(value, 64)
@@ -209,16 +221,13 @@ func ValidateTimezoneLocation(name, value string) (string, error) {
 
 // Validate a hash
 func ValidateHash(name, value string) (common.Hash, error) {
-	// Remove a 0x prefix if present
-	value = strings.TrimPrefix(value, "0x")
-
 	// Hash should be 64 characters long
-	if len(value) != hex.EncodedLen(common.HashLength) {
+	if len(utils.RemovePrefix(value)) != hex.EncodedLen(common.HashLength) {
 		return common.Hash{}, fmt.Errorf("Invalid %s '%s': it must have 64 characters.", name, value)
 	}
 
-	// Try to parse the string (removing the prefix)
-	bytes, err := hex.DecodeString(value)
+	// Try to parse the string
+	bytes, err := utils.DecodeHex(value)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
 	}
@@ -229,23 +238,20 @@ func ValidateHash(name, value string) (common.Hash, error) {
 
 // Validate TX info
 func ValidateTxInfo(name string, value string) (*eth.TransactionInfo, error) {
-	// Remove a 0x prefix if present
-	value = strings.TrimPrefix(value, "0x")
-
-	// Try to parse the string
-	bytes, err := hex.DecodeString(value)
+	info, err := eth.DeserializeTransactionInfo(value)
 	if err != nil {
 		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
 	}
+	return info, nil
+}
 
-	// Deserialize it
-	var info eth.TransactionInfo
-	err = json.Unmarshal(bytes, &info)
+// Validate a TX submission
+func ValidateTxSubmission(name string, value string) (*eth.TransactionSubmission, error) {
+	submission, err := eth.DeserializeTransactionSubmission(value)
 	if err != nil {
-		return nil, fmt.Errorf("Deserializing %s failed: %w", name, err)
+		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
 	}
-
-	return &info, nil
+	return submission, nil
 }
 
 // Validate a validator pubkey
@@ -259,11 +265,8 @@ func ValidatePubkey(name, value string) (beacon.ValidatorPubkey, error) {
 
 // Validate a hex-encoded byte array
 func ValidateByteArray(name, value string) ([]byte, error) {
-	// Remove a 0x prefix if present
-	value = strings.TrimPrefix(value, "0x")
-
 	// Try to parse the string (removing the prefix)
-	bytes, err := hex.DecodeString(value)
+	bytes, err := utils.DecodeHexWithOptions(value, utils.LenientHexDecodeOptions)
 	if err != nil {
 		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
 	}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// The default smoothing factor for the exponential moving average
+	DefaultLatencyEmaAlpha float64 = 0.2
+)
+
+// A thread-safe tracker for latency measurements that maintains an exponential moving average
+// along with a rolling window of recent samples for percentile calculations
+type LatencyTracker struct {
+	alpha    float64
+	ema      float64
+	hasValue bool
+	samples  []time.Duration
+	next     int
+	count    int
+	lock     sync.Mutex
+}
+
+// Creates a new latency tracker.
+// Alpha is the EMA smoothing factor between 0 and 1, where higher values weigh recent samples more heavily;
+// if it's out of range, DefaultLatencyEmaAlpha is used.
+// The window size is the number of recent samples to keep for percentiles; if it isn't positive,
+// DefaultEndpointStatsWindowSize is used.
+func NewLatencyTracker(alpha float64, windowSize int) *LatencyTracker {
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultLatencyEmaAlpha
+	}
+	if windowSize <= 0 {
+		windowSize = DefaultEndpointStatsWindowSize
+	}
+	return &LatencyTracker{
+		alpha:   alpha,
+		samples: make([]time.Duration, windowSize),
+	}
+}
+
+// Add a new latency sample
+func (t *LatencyTracker) Add(latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.hasValue {
+		t.ema = float64(latency)
+		t.hasValue = true
+	} else {
+		t.ema = t.alpha*float64(latency) + (1-t.alpha)*t.ema
+	}
+
+	t.samples[t.next] = latency
+	t.next = (t.next + 1) % len(t.samples)
+	if t.count < len(t.samples) {
+		t.count++
+	}
+}
+
+// Get the exponential moving average of the latency, or 0 if there aren't any samples yet
+func (t *LatencyTracker) Average() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return time.Duration(t.ema)
+}
+
+// Get the number of samples in the rolling window
+func (t *LatencyTracker) Count() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.count
+}
+
+// Get the latency at each of the provided percentiles (from 0 to 100) of the rolling window, using the nearest-rank method.
+// Returns zeroes if there aren't any samples yet.
+func (t *LatencyTracker) Percentiles(percentiles ...float64) []time.Duration {
+	t.lock.Lock()
+	sorted := make([]time.Duration, t.count)
+	copy(sorted, t.samples[:t.count])
+	t.lock.Unlock()
+
+	results := make([]time.Duration, len(percentiles))
+	if len(sorted) == 0 {
+		return results
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	for i, p := range percentiles {
+		rank := int(math.Ceil(p * float64(len(sorted)) / 100))
+		if rank < 1 {
+			rank = 1
+		}
+		if rank > len(sorted) {
+			rank = len(sorted)
+		}
+		results[i] = sorted[rank-1]
+	}
+	return results
+}
+
+// Clear all of the samples
+func (t *LatencyTracker) Reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.ema = 0
+	t.hasValue = false
+	t.next = 0
+	t.count = 0
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLatencyTrackerAverage(t *testing.T) {
+	tracker := NewLatencyTracker(0.5, 10)
+	if average := tracker.Average(); average != 0 {
+		t.Fatalf("expected an empty tracker to have an average of 0, got %s", average)
+	}
+
+	// The first sample seeds the average, then each one moves it halfway there
+	tracker.Add(100 * time.Millisecond)
+	tracker.Add(200 * time.Millisecond)
+	tracker.Add(400 * time.Millisecond)
+	expected := 275 * time.Millisecond
+	if average := tracker.Average(); average != expected {
+		t.Fatalf("expected an average of %s, got %s", expected, average)
+	}
+
+	tracker.Reset()
+	if average := tracker.Average(); average != 0 {
+		t.Fatalf("expected a reset tracker to have an average of 0, got %s", average)
+	}
+	tracker.Add(50 * time.Millisecond)
+	if average := tracker.Average(); average != 50*time.Millisecond {
+		t.Fatalf("expected the first sample after a reset to seed the average, got %s", average)
+	}
+}
+
+func TestLatencyTrackerDefaults(t *testing.T) {
+	tracker := NewLatencyTracker(2, 0)
+	if tracker.alpha != DefaultLatencyEmaAlpha {
+		t.Fatalf("expected an out of range alpha to use the default, got %f", tracker.alpha)
+	}
+	if len(tracker.samples) != DefaultEndpointStatsWindowSize {
+		t.Fatalf("expected a window size of %d, got %d", DefaultEndpointStatsWindowSize, len(tracker.samples))
+	}
+}
+
+func TestLatencyTrackerPercentiles(t *testing.T) {
+	tracker := NewLatencyTracker(DefaultLatencyEmaAlpha, 100)
+	percentiles := tracker.Percentiles(50, 99)
+	if percentiles[0] != 0 || percentiles[1] != 0 {
+		t.Fatalf("expected an empty tracker to have zero percentiles, got %v", percentiles)
+	}
+
+	// Add 1 to 100 ms out of order
+	for i := 100; i > 0; i-- {
+		tracker.Add(time.Duration(i) * time.Millisecond)
+	}
+	percentiles = tracker.Percentiles(0, 50, 90, 99, 100)
+	expected := []time.Duration{
+		1 * time.Millisecond,
+		50 * time.Millisecond,
+		90 * time.Millisecond,
+		99 * time.Millisecond,
+		100 * time.Millisecond,
+	}
+	for i := range expected {
+		if percentiles[i] != expected[i] {
+			t.Fatalf("expected percentiles %v, got %v", expected, percentiles)
+		}
+	}
+}
+
+func TestLatencyTrackerWindow(t *testing.T) {
+	tracker := NewLatencyTracker(DefaultLatencyEmaAlpha, 4)
+	for i := 1; i <= 6; i++ {
+		tracker.Add(time.Duration(i) * time.Second)
+	}
+	if count := tracker.Count(); count != 4 {
+		t.Fatalf("expected the window to hold 4 samples, got %d", count)
+	}
+
+	// Only 3 to 6 should be left
+	percentiles := tracker.Percentiles(0, 100)
+	if percentiles[0] != 3*time.Second || percentiles[1] != 6*time.Second {
+		t.Fatalf("expected the oldest samples to be dropped, got a range of %v", percentiles)
+	}
+}
+
+func TestLatencyTrackerConcurrency(t *testing.T) {
+	tracker := NewLatencyTracker(DefaultLatencyEmaAlpha, 50)
+	stats := NewEndpointStatsTracker(50)
+	sampleErr := errors.New("request failed")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				latency := time.Duration(worker*200+j+1) * time.Microsecond
+				tracker.Add(latency)
+				if j%4 == 0 {
+					stats.Record(latency, sampleErr)
+				} else {
+					stats.Record(latency, nil)
+				}
+			}
+		}(i)
+	}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				percentiles := tracker.Percentiles(50, 90, 99)
+				if percentiles[0] > percentiles[1] || percentiles[1] > percentiles[2] {
+					t.Errorf("percentiles out of order: %v", percentiles)
+					return
+				}
+				_ = tracker.Average()
+				_ = tracker.Count()
+				endpointStats := stats.GetStats()
+				if endpointStats.SuccessRate < 0 || endpointStats.SuccessRate > 1 {
+					t.Errorf("success rate out of range: %f", endpointStats.SuccessRate)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count := tracker.Count(); count != 50 {
+		t.Fatalf("expected a full window of 50 samples, got %d", count)
+	}
+	average := tracker.Average()
+	if average < 1*time.Microsecond || average > 1600*time.Microsecond {
+		t.Fatalf("expected the average to be within the range of the samples, got %s", average)
+	}
+	endpointStats := stats.GetStats()
+	if endpointStats.TotalRequests != 1600 || endpointStats.TotalFailures != 400 {
+		t.Fatalf("expected 1600 requests with 400 failures, got %d with %d", endpointStats.TotalRequests, endpointStats.TotalFailures)
+	}
+}
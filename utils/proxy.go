@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Credentials for HTTP basic authentication
+type BasicAuthCredentials struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+}
+
+// A rule for rewriting the path of outgoing requests
+type UrlRewriteRule struct {
+	// The path prefix to match
+	From string `json:"from" yaml:"from"`
+
+	// The prefix to replace the matched one with
+	To string `json:"to" yaml:"to"`
+}
+
+// Options for clients that connect to an endpoint behind a reverse proxy
+type ProxyOptions struct {
+	// A custom Host header to send with each request instead of the one derived from the URL
+	HostHeader string `json:"hostHeader,omitempty" yaml:"hostHeader,omitempty"`
+
+	// Rules for rewriting request paths before they're sent. Only the first matching rule is applied.
+	RewriteRules []UrlRewriteRule `json:"rewriteRules,omitempty" yaml:"rewriteRules,omitempty"`
+
+	// Static headers to send with each request, such as API keys for hosted providers
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// Credentials to send with each request using HTTP basic authentication; ignored if BearerToken is set
+	BasicAuth *BasicAuthCredentials `json:"basicAuth,omitempty" yaml:"basicAuth,omitempty"`
+
+	// A token to send with each request in the Authorization header
+	BearerToken string `json:"bearerToken,omitempty" yaml:"bearerToken,omitempty"`
+}
+
+// Get the headers the options add to each request, including the Authorization header if auth is configured
+func (o ProxyOptions) GetHeaders() http.Header {
+	headers := http.Header{}
+	for name, value := range o.Headers {
+		headers.Set(name, value)
+	}
+	if o.BearerToken != "" {
+		headers.Set("Authorization", "Bearer "+o.BearerToken)
+	} else if o.BasicAuth != nil {
+		request := http.Request{Header: http.Header{}}
+		request.SetBasicAuth(o.BasicAuth.Username, o.BasicAuth.Password)
+		headers.Set("Authorization", request.Header.Get("Authorization"))
+	}
+	return headers
+}
+
+// An HTTP transport that applies ProxyOptions to each request before sending it
+type ProxyTransport struct {
+	// The underlying transport; if nil, http.DefaultTransport is used
+	Base http.RoundTripper
+
+	// The options to apply
+	Options ProxyOptions
+}
+
+// Apply the proxy options and the daemon's identity headers to the request and send it using the underlying transport
+func (t *ProxyTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// RoundTrippers must not modify the original request
+	request = request.Clone(request.Context())
+	if t.Options.HostHeader != "" {
+		request.Host = t.Options.HostHeader
+	}
+	for name, values := range t.Options.GetHeaders() {
+		request.Header[name] = values
+	}
+	ApplyHttpIdentity(request)
+	for _, rule := range t.Options.RewriteRules {
+		if strings.HasPrefix(request.URL.Path, rule.From) {
+			request.URL.Path = rule.To + strings.TrimPrefix(request.URL.Path, rule.From)
+			request.URL.RawPath = ""
+			break
+		}
+	}
+	return base.RoundTrip(request)
+}
+
+// Join an API route onto a base URL, preserving any path prefix the base URL has
+func JoinUrlPath(baseUrl string, route string) string {
+	base := strings.TrimSuffix(baseUrl, "/")
+	if route != "" && !strings.HasPrefix(route, "/") {
+		route = "/" + route
+	}
+	return base + route
+}
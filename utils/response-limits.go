@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// The Accept-Encoding header sent when compression is enabled
+	compressionAcceptEncoding string = "gzip, deflate"
+)
+
+var (
+	// A response body was larger than the configured limit
+	ErrResponseTooLarge = errors.New("response exceeded the maximum allowed size")
+)
+
+// Settings for guarding against huge responses and for compressing them in transit
+type ResponseLimitSettings struct {
+	// The largest response body to accept, in bytes, after it's decompressed. Reading past this returns
+	// ErrResponseTooLarge. Use 0 for no limit.
+	MaxResponseSize int64 `json:"maxResponseSize,omitempty" yaml:"maxResponseSize,omitempty"`
+
+	// Whether to ask for gzip or deflate compressed responses
+	EnableCompression bool `json:"enableCompression,omitempty" yaml:"enableCompression,omitempty"`
+}
+
+// The response limit settings to use if none are configured. The limit is high enough for the full validator list and
+// committee responses on Mainnet, which are the largest responses the daemon normally gets.
+var DefaultResponseLimitSettings = ResponseLimitSettings{
+	MaxResponseSize:   2 * 1024 * 1024 * 1024,
+	EnableCompression: true,
+}
+
+// An HTTP transport that negotiates compressed responses, decompresses them, and stops reading responses that go past
+// the size limit. Since the limit applies to the decompressed body, compression bombs are caught too.
+type ResponseLimitTransport struct {
+	// The underlying transport; if nil, http.DefaultTransport is used
+	Base http.RoundTripper
+
+	// The settings to apply
+	Settings ResponseLimitSettings
+}
+
+// Send the request using the underlying transport, then decompress and limit its response
+func (t *ResponseLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// Ask for compression unless the caller already picked encodings. RoundTrippers must not modify the original request.
+	negotiated := false
+	if t.Settings.EnableCompression && request.Header.Get("Accept-Encoding") == "" && request.Header.Get("Range") == "" {
+		request = request.Clone(request.Context())
+		request.Header.Set("Accept-Encoding", compressionAcceptEncoding)
+		negotiated = true
+	}
+	response, err := base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail early if the server says the body is too large
+	maxSize := t.Settings.MaxResponseSize
+	if maxSize > 0 && response.ContentLength > maxSize {
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("%w (%d bytes, limit is %d)", ErrResponseTooLarge, response.ContentLength, maxSize)
+	}
+
+	// Decompress the body if the server compressed it
+	if negotiated {
+		encoding := strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding")))
+		var decompressed io.ReadCloser
+		switch encoding {
+		case "gzip":
+			decompressed, err = newGzipBody(response.Body)
+		case "deflate":
+			decompressed, err = newDeflateBody(response.Body)
+		}
+		if err != nil {
+			_ = response.Body.Close()
+			return nil, fmt.Errorf("error decompressing %s response: %w", encoding, err)
+		}
+		if decompressed != nil {
+			response.Body = decompressed
+			response.Header.Del("Content-Encoding")
+			response.Header.Del("Content-Length")
+			response.ContentLength = -1
+			response.Uncompressed = true
+		}
+	}
+
+	if maxSize > 0 {
+		response.Body = &limitedBody{
+			body:      response.Body,
+			remaining: maxSize,
+		}
+	}
+	return response, nil
+}
+
+// A response body that's decompressed as it's read
+type decompressedBody struct {
+	io.Reader
+	decompressor io.Closer
+	body         io.Closer
+}
+
+// Close the decompressor and the underlying body
+func (b *decompressedBody) Close() error {
+	_ = b.decompressor.Close()
+	return b.body.Close()
+}
+
+// Decompress a gzip body
+func newGzipBody(body io.ReadCloser) (io.ReadCloser, error) {
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	return &decompressedBody{
+		Reader:       reader,
+		decompressor: reader,
+		body:         body,
+	}, nil
+}
+
+// Decompress a deflate body. The spec says these are zlib streams, but some servers send raw deflate data instead,
+// so the zlib header is checked first.
+func newDeflateBody(body io.ReadCloser) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(body)
+	header, err := buffered.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		reader, err := zlib.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressedBody{
+			Reader:       reader,
+			decompressor: reader,
+			body:         body,
+		}, nil
+	}
+	reader := flate.NewReader(buffered)
+	return &decompressedBody{
+		Reader:       reader,
+		decompressor: reader,
+		body:         body,
+	}, nil
+}
+
+// A response body that fails with ErrResponseTooLarge once more than the allowed number of bytes are read
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+}
+
+// Read from the body, failing if it goes past the limit
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	// Read one byte past the limit so bodies that are exactly the limit still work
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// Close the underlying body
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Settings for retrying HTTP requests that fail with transient errors
+type HttpRetrySettings struct {
+	// The backoff policy to use between attempts. Set MaxAttempts to 1 to disable retries.
+	Policy RetryPolicy
+
+	// The HTTP status codes that indicate a transient failure, such as a load balancer that couldn't reach its
+	// backend. Responses with any other status are returned as-is.
+	RetryableStatusCodes []int
+}
+
+// The retry settings to use for HTTP requests if none are configured. These are short on purpose; if a client is
+// actually down, the client managers should fail over to the fallback instead of waiting on it.
+var DefaultHttpRetrySettings = HttpRetrySettings{
+	Policy: RetryPolicy{
+		InitialInterval: 250 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+		JitterFactor:    0.2,
+		MaxElapsedTime:  0,
+		MaxAttempts:     3,
+	},
+	RetryableStatusCodes: []int{
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// An HTTP transport that sends requests again if they fail with a transient error, like a dropped connection or a
+// 502 from a load balancer. Requests with a body are only retried if the body can be rewound, which is always the
+// case for requests created with a bytes.Reader, bytes.Buffer, or strings.Reader body.
+// Note that an http.Client timeout applies to the request as a whole, including its retries.
+type RetryTransport struct {
+	// The underlying transport; if nil, http.DefaultTransport is used
+	Base http.RoundTripper
+
+	// The settings to retry with
+	Settings HttpRetrySettings
+}
+
+// Send the request using the underlying transport, retrying it according to the settings. If every attempt gets a
+// retryable status code, the last response is returned as-is.
+func (t *RetryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := request.Context()
+	policy := t.Settings.Policy
+	canRewind := request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+	if !canRewind {
+		policy.MaxAttempts = 1
+	}
+
+	attempts := 0
+	var failedResponse *http.Response
+	response, err := RetryWithResult(ctx, policy, func() (*http.Response, error) {
+		// Discard the previous attempt's response and rewind the body for every attempt after the first
+		attemptRequest := request
+		if failedResponse != nil {
+			discardResponse(failedResponse)
+			failedResponse = nil
+		}
+		if attempts > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, Permanent(err)
+			}
+			attemptRequest = request.Clone(ctx)
+			attemptRequest.Body = body
+		}
+		attempts++
+
+		response, err := base.RoundTrip(attemptRequest)
+		if err != nil {
+			if !isTransientRequestError(ctx, err) {
+				return nil, Permanent(err)
+			}
+			return nil, err
+		}
+		if !slices.Contains(t.Settings.RetryableStatusCodes, response.StatusCode) {
+			return response, nil
+		}
+		failedResponse = response
+		err = &retryableStatusError{
+			statusCode: response.StatusCode,
+		}
+		return response, RetryAfter(err, getRetryAfterDelay(response, policy.MaxInterval))
+	})
+	if err == nil {
+		return response, nil
+	}
+
+	// Hand back the last response if the attempts ran out on a retryable status, rather than hiding it behind an error
+	var statusErr *retryableStatusError
+	if errors.As(err, &statusErr) && ctx.Err() == nil {
+		return failedResponse, nil
+	}
+	if failedResponse != nil {
+		discardResponse(failedResponse)
+	}
+	return nil, err
+}
+
+// A response with one of the retryable status codes
+type retryableStatusError struct {
+	statusCode int
+}
+
+func (e *retryableStatusError) Error() string {
+	return fmt.Sprintf("request failed with retryable status code %d", e.statusCode)
+}
+
+// Read a bit of a response that won't be used so its connection can be reused, then close it
+func discardResponse(response *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	_ = response.Body.Close()
+}
+
+// Check if an error from sending a request is likely to go away if the request is sent again. Refused connections
+// aren't retried since they mean the endpoint is down, which failover handles better.
+func isTransientRequestError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Get the delay a response asked for in its Retry-After header, capped at the provided limit. Returns 0 if the
+// response didn't ask for one.
+func getRetryAfterDelay(response *http.Response, limit time.Duration) time.Duration {
+	seconds, err := strconv.ParseUint(response.Header.Get("Retry-After"), 10, 32)
+	if err != nil {
+		return 0
+	}
+	delay := time.Duration(seconds) * time.Second
+	if limit > 0 && delay > limit {
+		delay = limit
+	}
+	return delay
+}
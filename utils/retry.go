@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Settings for retrying an operation with exponential backoff
+type RetryPolicy struct {
+	// The delay before the first retry; if 0, DefaultRetryPolicy's is used
+	InitialInterval time.Duration
+
+	// The upper limit for the delay between retries; if 0, DefaultRetryPolicy's is used
+	MaxInterval time.Duration
+
+	// The factor the delay is multiplied by after each retry; if less than 1, DefaultRetryPolicy's is used
+	Multiplier float64
+
+	// The fraction of the delay to randomize by, from 0 to 1. A value of 0.2 means each delay will be
+	// randomly chosen between 80% and 120% of its nominal value.
+	JitterFactor float64
+
+	// The total amount of time to spend retrying before giving up, including the time spent on attempts.
+	// Set to 0 for no limit.
+	MaxElapsedTime time.Duration
+
+	// The maximum number of attempts to make, including the first one. Set to 0 for no limit.
+	MaxAttempts int
+}
+
+// A reasonable default policy for retrying requests to clients and other services
+var DefaultRetryPolicy = RetryPolicy{
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     30 * time.Second,
+	Multiplier:      2,
+	JitterFactor:    0.2,
+	MaxElapsedTime:  2 * time.Minute,
+	MaxAttempts:     0,
+}
+
+// An error that should not be retried
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Wraps an error to indicate that the operation that returned it should not be retried
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{
+		Err: err,
+	}
+}
+
+// An error that should be retried, but not before the provided delay
+type RetryAfterError struct {
+	Err   error
+	Delay time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// Wraps an error to indicate that the operation that returned it should wait at least the provided delay before it's
+// retried, such as when a server asks for one
+func RetryAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryAfterError{
+		Err:   err,
+		Delay: delay,
+	}
+}
+
+// Run a function until it succeeds, returns a permanent error, exhausts the policy's limits, or the context is cancelled.
+// The last error returned by the function is returned if all attempts fail.
+func Retry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	_, err := RetryWithResult(ctx, policy, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// Run a function that returns a value until it succeeds, returns a permanent error, exhausts the policy's limits, or the context is cancelled.
+// The last error returned by the function is returned if all attempts fail.
+func RetryWithResult[ResultType any](ctx context.Context, policy RetryPolicy, fn func() (ResultType, error)) (ResultType, error) {
+	policy = policy.withDefaults()
+	start := time.Now()
+	interval := policy.InitialInterval
+	attempts := 0
+	for {
+		result, err := fn()
+		attempts++
+		if err == nil {
+			return result, nil
+		}
+
+		// Short-circuit on permanent errors
+		var permanentErr *PermanentError
+		if errors.As(err, &permanentErr) {
+			return result, permanentErr.Err
+		}
+
+		// Check the limits
+		if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+			return result, fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+		}
+		delay := applyJitter(interval, policy.JitterFactor)
+		var retryAfterErr *RetryAfterError
+		if errors.As(err, &retryAfterErr) {
+			delay = max(delay, retryAfterErr.Delay)
+		}
+		if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+			return result, fmt.Errorf("giving up after %s: %w", time.Since(start).Round(time.Millisecond), err)
+		}
+
+		// Wait for the next attempt
+		if SleepWithCancel(ctx, delay) {
+			return result, fmt.Errorf("context cancelled while retrying (%w): %w", ctx.Err(), err)
+		}
+
+		// Increase the interval for the next round
+		interval = time.Duration(float64(interval) * policy.Multiplier)
+		if interval > policy.MaxInterval {
+			interval = policy.MaxInterval
+		}
+	}
+}
+
+// Get a copy of the policy with its unset intervals and multiplier taken from DefaultRetryPolicy, so a zero-value
+// policy backs off instead of retrying in a tight loop
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = DefaultRetryPolicy.InitialInterval
+	}
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = max(DefaultRetryPolicy.MaxInterval, p.InitialInterval)
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultRetryPolicy.Multiplier
+	}
+	return p
+}
+
+// Randomize a delay by the provided jitter factor
+func applyJitter(interval time.Duration, jitterFactor float64) time.Duration {
+	if jitterFactor <= 0 || interval <= 0 {
+		return interval
+	}
+	if jitterFactor > 1 {
+		jitterFactor = 1
+	}
+	delta := jitterFactor * float64(interval)
+	lower := float64(interval) - delta
+	return time.Duration(lower + rand.Float64()*(2*delta))
+}
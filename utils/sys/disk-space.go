@@ -0,0 +1,18 @@
+package sys
+
+// The size of a filesystem and how much of it is free, in bytes
+type DiskSpace struct {
+	// The total size of the filesystem
+	Total uint64 `json:"total"`
+
+	// The space available to unprivileged users
+	Available uint64 `json:"available"`
+}
+
+// Get the space used on the filesystem, in bytes
+func (s DiskSpace) Used() uint64 {
+	if s.Available > s.Total {
+		return 0
+	}
+	return s.Total - s.Available
+}
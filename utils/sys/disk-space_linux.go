@@ -0,0 +1,20 @@
+package sys
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Get the size and free space of the filesystem that holds the provided path
+func GetDiskSpace(path string) (DiskSpace, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return DiskSpace{}, fmt.Errorf("error getting filesystem info for [%s]: %w", path, err)
+	}
+	blockSize := uint64(stat.Bsize)
+	return DiskSpace{
+		Total:     stat.Blocks * blockSize,
+		Available: stat.Bavail * blockSize,
+	}, nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package sys
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Get the size and free space of the filesystem that holds the provided path; this is only supported on Linux
+func GetDiskSpace(path string) (DiskSpace, error) {
+	return DiskSpace{}, fmt.Errorf("disk space checks aren't supported on %s", runtime.GOOS)
+}
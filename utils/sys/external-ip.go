@@ -0,0 +1,57 @@
+package sys
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The largest response an external IP provider can send; the address itself is far smaller than this
+	maxExternalIpResponseSize int64 = 256
+)
+
+// Services that respond to a plain GET request with the caller's public IP address as text
+var DefaultExternalIpProviders = []string{
+	"https://api.ipify.org",
+	"https://icanhazip.com",
+	"https://checkip.amazonaws.com",
+	"https://ifconfig.me/ip",
+}
+
+// Ask an external IP provider for the public IP address this machine's requests come from. The provider must respond
+// to a GET request with the address as plain text.
+func QueryExternalIp(ctx context.Context, provider string, timeout time.Duration) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, provider, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for external IP provider [%s]: %w", provider, err)
+	}
+	response, err := utils.NewIdentityHttpClient(0).Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error querying external IP provider [%s]: %w", provider, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("external IP provider [%s] responded with status %s", provider, response.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxExternalIpResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from external IP provider [%s]: %w", provider, err)
+	}
+	address := strings.TrimSpace(string(body))
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("external IP provider [%s] responded with an invalid address [%s]", provider, address)
+	}
+	return ip, nil
+}
@@ -0,0 +1,93 @@
+package sys
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// The size of an NTP packet without extensions
+	ntpPacketSize int = 48
+
+	// The first byte of a client request: no leap second warning, version 4, client mode
+	ntpClientHeader byte = 0x23
+
+	// The number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+	ntpEpochOffset uint64 = 2208988800
+
+	// The default port NTP servers listen on
+	ntpPort string = "123"
+)
+
+// Query an NTP server for the offset of the local clock from the server's clock, using a single SNTP request. A
+// positive offset means the local clock is behind the server's. The server can include a port; if it doesn't, the
+// standard NTP port is used.
+func QueryNtpOffset(ctx context.Context, server string, timeout time.Duration) (time.Duration, error) {
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, ntpPort)
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to NTP server [%s]: %w", server, err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return 0, fmt.Errorf("error setting deadline for NTP server [%s]: %w", server, err)
+	}
+
+	// Send the request, with the local send time as the transmit timestamp
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+	sendTime := time.Now()
+	putNtpTime(request[40:48], sendTime)
+	_, err = conn.Write(request)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request to NTP server [%s]: %w", server, err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	receiveTime := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("error reading response from NTP server [%s]: %w", server, err)
+	}
+	if n < ntpPacketSize {
+		return 0, fmt.Errorf("NTP server [%s] sent a response that was too short (%d bytes)", server, n)
+	}
+	if response[1] == 0 {
+		return 0, fmt.Errorf("NTP server [%s] is unsynchronized or refused the request", server)
+	}
+
+	// Offset = ((server receive - client send) + (server transmit - client receive)) / 2
+	serverReceive := getNtpTime(response[32:40])
+	serverTransmit := getNtpTime(response[40:48])
+	offset := (serverReceive.Sub(sendTime) + serverTransmit.Sub(receiveTime)) / 2
+	return offset, nil
+}
+
+// Write a time as an NTP timestamp
+func putNtpTime(buffer []byte, t time.Time) {
+	seconds := uint64(t.Unix()) + ntpEpochOffset
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	binary.BigEndian.PutUint32(buffer[0:4], uint32(seconds))
+	binary.BigEndian.PutUint32(buffer[4:8], uint32(fraction))
+}
+
+// Read an NTP timestamp
+func getNtpTime(buffer []byte) time.Time {
+	seconds := uint64(binary.BigEndian.Uint32(buffer[0:4]))
+	fraction := uint64(binary.BigEndian.Uint32(buffer[4:8]))
+	nanoseconds := (fraction * uint64(time.Second)) >> 32
+	return time.Unix(int64(seconds-ntpEpochOffset), int64(nanoseconds))
+}
@@ -1,13 +1,16 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"strconv"
 
 	"gopkg.in/yaml.v3"
 )
 
-// Unsigned integer type
+// Unsigned integer type.
+// This is serialized as a string, but can be deserialized from either a string or a raw number.
 type Uinteger uint64
 
 func (i Uinteger) MarshalYAML() (interface{}, error) {
@@ -15,6 +18,9 @@ func (i Uinteger) MarshalYAML() (interface{}, error) {
 }
 
 func (i *Uinteger) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("expected an unsigned integer scalar but got a YAML node of kind %d", value.Kind)
+	}
 	intVal, err := strconv.ParseUint(value.Value, 10, 64)
 	if err != nil {
 		return err
@@ -27,9 +33,9 @@ func (i Uinteger) MarshalJSON() ([]byte, error) {
 	return json.Marshal(strconv.FormatUint(uint64(i), 10))
 }
 func (i *Uinteger) UnmarshalJSON(data []byte) error {
-	// Unmarshal string
-	var dataStr string
-	if err := json.Unmarshal(data, &dataStr); err != nil {
+	// Get the string or number
+	dataStr, err := getJsonIntegerString(data)
+	if err != nil {
 		return err
 	}
 
@@ -44,6 +50,60 @@ func (i *Uinteger) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Signed integer type.
+// This is serialized as a string, but can be deserialized from either a string or a raw number.
+type SignedInteger int64
+
+func (i SignedInteger) MarshalYAML() (interface{}, error) {
+	return strconv.FormatInt(int64(i), 10), nil
+}
+
+func (i *SignedInteger) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("expected a signed integer scalar but got a YAML node of kind %d", value.Kind)
+	}
+	intVal, err := strconv.ParseInt(value.Value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = SignedInteger(intVal)
+	return nil
+}
+
+func (i SignedInteger) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(i), 10))
+}
+func (i *SignedInteger) UnmarshalJSON(data []byte) error {
+	// Get the string or number
+	dataStr, err := getJsonIntegerString(data)
+	if err != nil {
+		return err
+	}
+
+	// Parse integer value
+	value, err := strconv.ParseInt(dataStr, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	// Set value and return
+	*i = SignedInteger(value)
+	return nil
+}
+
+// Get the string representation of a JSON integer that may be either a quoted string or a raw number
+func getJsonIntegerString(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var dataStr string
+		if err := json.Unmarshal(data, &dataStr); err != nil {
+			return "", err
+		}
+		return dataStr, nil
+	}
+	return string(data), nil
+}
+
 // Byte array type
 type ByteArray []byte
 
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// The name NMC identifies itself with in User-Agent headers
+	nmcUserAgentName string = "node-manager-core"
+
+	// NMC's module path, used to find its version in the build info
+	nmcModulePath string = "github.com/rocket-pool/node-manager-core"
+
+	// The version reported if it can't be determined from the build info
+	unknownVersion string = "unknown"
+)
+
+// How the daemon identifies itself in outbound HTTP requests to Beacon nodes, Execution clients, gas oracles, and other
+// services, so hosted providers can tell where traffic comes from
+type HttpIdentity struct {
+	// The name of the project built on NMC, such as "Smartnode"; if it's empty, only NMC is named in the User-Agent
+	ProjectName string `json:"projectName,omitempty" yaml:"projectName,omitempty"`
+
+	// The version of the project built on NMC
+	ProjectVersion string `json:"projectVersion,omitempty" yaml:"projectVersion,omitempty"`
+
+	// Extra headers to send with every request, such as one that tags requests with the daemon instance's name. Headers
+	// configured for a specific endpoint (such as in its ProxyOptions) take precedence over these.
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+var (
+	// The identity outbound requests are sent with
+	httpIdentity     HttpIdentity
+	httpIdentityLock sync.RWMutex
+
+	// NMC's version, read from the build info once
+	getNmcVersion = sync.OnceValue(readNmcVersion)
+)
+
+// Set how the daemon identifies itself in outbound HTTP requests. This should be called when the daemon starts,
+// before any clients are created.
+func SetHttpIdentity(identity HttpIdentity) error {
+	for name, value := range identity.Headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("invalid header name [%s]", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("value of header [%s] cannot contain line breaks", name)
+		}
+	}
+
+	httpIdentityLock.Lock()
+	defer httpIdentityLock.Unlock()
+	httpIdentity = identity
+	return nil
+}
+
+// Get the User-Agent sent with outbound requests, such as "Smartnode/v1.2.3 node-manager-core/v0.5.0"
+func GetUserAgent() string {
+	httpIdentityLock.RLock()
+	defer httpIdentityLock.RUnlock()
+	return getUserAgentImpl(httpIdentity)
+}
+
+// Get the headers that identify the daemon, including the User-Agent
+func GetIdentityHeaders() http.Header {
+	httpIdentityLock.RLock()
+	defer httpIdentityLock.RUnlock()
+	headers := http.Header{}
+	headers.Set("User-Agent", getUserAgentImpl(httpIdentity))
+	for name, value := range httpIdentity.Headers {
+		headers.Set(name, value)
+	}
+	return headers
+}
+
+// Add the identity headers to a request, skipping any that it already has
+func ApplyHttpIdentity(request *http.Request) {
+	for name, values := range GetIdentityHeaders() {
+		if _, exists := request.Header[name]; !exists {
+			request.Header[name] = values
+		}
+	}
+}
+
+// An HTTP transport that adds the identity headers to each request before sending it
+type IdentityTransport struct {
+	// The underlying transport; if nil, http.DefaultTransport is used
+	Base http.RoundTripper
+}
+
+// Add the identity headers to the request and send it using the underlying transport
+func (t *IdentityTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// RoundTrippers must not modify the original request
+	request = request.Clone(request.Context())
+	ApplyHttpIdentity(request)
+	return base.RoundTrip(request)
+}
+
+// Creates an HTTP client that sends the identity headers with each request. Use a timeout of 0 for no timeout.
+func NewIdentityHttpClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &IdentityTransport{},
+	}
+}
+
+// Build the User-Agent for an identity
+func getUserAgentImpl(identity HttpIdentity) string {
+	nmcAgent := fmt.Sprintf("%s/%s", nmcUserAgentName, getNmcVersion())
+	if identity.ProjectName == "" {
+		return nmcAgent
+	}
+	version := identity.ProjectVersion
+	if version == "" {
+		version = unknownVersion
+	}
+	return fmt.Sprintf("%s/%s %s", identity.ProjectName, version, nmcAgent)
+}
+
+// Get NMC's version from the build info, whether it's the main module or a dependency
+func readNmcVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownVersion
+	}
+	if info.Main.Path == nmcModulePath {
+		return getModuleVersion(&info.Main)
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == nmcModulePath {
+			return getModuleVersion(dep)
+		}
+	}
+	return unknownVersion
+}
+
+// Get the version of a module, following its replacement if it has one
+func getModuleVersion(module *debug.Module) string {
+	if module.Replace != nil {
+		module = module.Replace
+	}
+	switch module.Version {
+	case "":
+		return unknownVersion
+	case "(devel)":
+		return "devel"
+	default:
+		return module.Version
+	}
+}
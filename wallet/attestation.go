@@ -0,0 +1,119 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// The first line of every attestation message, which separates attestations from any other message a node might
+	// sign so they can't be replayed as something else (or vice versa)
+	NodeAttestationHeader string = "Node Manager Core node attestation v1"
+
+	// The shortest and longest nonces a service can issue
+	MinNodeAttestationNonceLength int = 16
+	MaxNodeAttestationNonceLength int = 64
+
+	// How far in the future an attestation's issue time can be, to allow for clock drift between the node and service
+	nodeAttestationClockSkew time.Duration = time.Minute
+)
+
+// A node's statement that it controls its address, made in response to a service's challenge. The service picks the
+// nonce; the node fills in the rest and signs the message built from it with its node key.
+type NodeAttestation struct {
+	// The name of the service the attestation is for, such as its domain
+	Service string `json:"service"`
+
+	// The chain the node is on
+	ChainID uint `json:"chainId"`
+
+	// The node's address
+	Address common.Address `json:"address"`
+
+	// The service's random challenge
+	Nonce hexutil.Bytes `json:"nonce"`
+
+	// When the node signed the attestation
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// A node attestation and the node's signature of its message
+type SignedNodeAttestation struct {
+	Attestation NodeAttestation `json:"attestation"`
+	Signature   hexutil.Bytes   `json:"signature"`
+}
+
+// Check that a service name and nonce can be used in an attestation
+func ValidateNodeAttestationChallenge(service string, nonce []byte) error {
+	if service == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+	if strings.ContainsAny(service, "\r\n") {
+		return fmt.Errorf("service name cannot contain line breaks")
+	}
+	if len(nonce) < MinNodeAttestationNonceLength || len(nonce) > MaxNodeAttestationNonceLength {
+		return fmt.Errorf("nonce must be between %d and %d bytes, but it was %d", MinNodeAttestationNonceLength, MaxNodeAttestationNonceLength, len(nonce))
+	}
+	return nil
+}
+
+// Get the message a node signs for an attestation. It's signed as an EIP-191 personal message, like the node's other
+// signed messages, but starts with the attestation header and names the service so it's only valid for one purpose.
+func (a NodeAttestation) GetMessage() []byte {
+	return []byte(fmt.Sprintf("%s\nService: %s\nChain ID: %d\nAddress: %s\nNonce: %s\nIssued At: %s",
+		NodeAttestationHeader,
+		a.Service,
+		a.ChainID,
+		a.Address.Hex(),
+		a.Nonce.String(),
+		a.IssuedAt.UTC().Format(time.RFC3339),
+	))
+}
+
+// Verify an attestation in response to a service's challenge. The attestation must be for the service, chain, and
+// nonce the service expects, must have been issued within the max age, and must be signed by the address it claims.
+// Returns the node's address if it's valid. Services should only accept each nonce once.
+func VerifyNodeAttestation(signed SignedNodeAttestation, service string, chainID uint, nonce []byte, maxAge time.Duration) (common.Address, error) {
+	attestation := signed.Attestation
+	if attestation.Service != service {
+		return common.Address{}, fmt.Errorf("attestation is for service [%s], not [%s]", attestation.Service, service)
+	}
+	if attestation.ChainID != chainID {
+		return common.Address{}, fmt.Errorf("attestation is for chain %d, not %d", attestation.ChainID, chainID)
+	}
+	if !bytes.Equal(attestation.Nonce, nonce) {
+		return common.Address{}, fmt.Errorf("attestation doesn't have the expected nonce")
+	}
+	age := time.Since(attestation.IssuedAt)
+	if age > maxAge {
+		return common.Address{}, fmt.Errorf("attestation expired (issued %s ago)", age)
+	}
+	if age < -nodeAttestationClockSkew {
+		return common.Address{}, fmt.Errorf("attestation was issued in the future (%s)", attestation.IssuedAt)
+	}
+
+	// Recover the signer
+	if len(signed.Signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature must be %d bytes, but it was %d", crypto.SignatureLength, len(signed.Signature))
+	}
+	signature := bytes.Clone(signed.Signature)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash(attestation.GetMessage()), signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error recovering signer: %w", err)
+	}
+	signer := crypto.PubkeyToAddress(*pubkey)
+	if signer != attestation.Address {
+		return common.Address{}, fmt.Errorf("attestation claims to be from %s but was signed by %s", attestation.Address.Hex(), signer.Hex())
+	}
+	return signer, nil
+}
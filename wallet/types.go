@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 )
@@ -48,8 +50,81 @@ const (
 
 	// Indicator for hardware wallets that store the private key offline
 	WalletType_Hardware WalletType = "hardware"
+
+	// Indicator for wallets whose private key is held by a remote signing service
+	WalletType_RemoteSigner WalletType = "remote-signer"
+
+	// Indicator for wallets whose private key is held by a cloud key management service
+	WalletType_Kms WalletType = "kms"
+)
+
+// The key derivation function used to turn a local wallet's password into the key that encrypts its seed
+type KdfFunction string
+
+const (
+	// scrypt, as used by Ethereum keystores
+	KdfFunction_Scrypt KdfFunction = "scrypt"
+
+	// Argon2id, the memory-hard winner of the Password Hashing Competition
+	KdfFunction_Argon2id KdfFunction = "argon2id"
 )
 
+// Settings for encrypting a local wallet's seed
+type KdfSettings struct {
+	// The key derivation function to use
+	Function KdfFunction `json:"function"`
+
+	// The scrypt CPU/memory cost (a power of 2), block size, and parallelization parameters
+	ScryptN int `json:"scryptN,omitempty"`
+	ScryptR int `json:"scryptR,omitempty"`
+	ScryptP int `json:"scryptP,omitempty"`
+
+	// The Argon2id number of passes, memory in KiB, and number of threads
+	Argon2Time    uint32 `json:"argon2Time,omitempty"`
+	Argon2Memory  uint32 `json:"argon2Memory,omitempty"`
+	Argon2Threads uint8  `json:"argon2Threads,omitempty"`
+}
+
+// The seed encryption settings to use if none are configured; Argon2id with the parameters RFC 9106 recommends for
+// memory-constrained environments
+var DefaultKdfSettings = KdfSettings{
+	Function:      KdfFunction_Argon2id,
+	Argon2Time:    3,
+	Argon2Memory:  64 * 1024,
+	Argon2Threads: 4,
+}
+
+// The seed encryption settings for scrypt, using the same parameters as Ethereum's standard keystores
+var DefaultScryptKdfSettings = KdfSettings{
+	Function: KdfFunction_Scrypt,
+	ScryptN:  1 << 18,
+	ScryptR:  8,
+	ScryptP:  1,
+}
+
+// Make sure the settings are usable
+func (s KdfSettings) Validate() error {
+	switch s.Function {
+	case KdfFunction_Scrypt:
+		if s.ScryptN < 2 || s.ScryptN&(s.ScryptN-1) != 0 {
+			return fmt.Errorf("scrypt N must be a power of 2 greater than 1")
+		}
+		if s.ScryptR < 1 || s.ScryptP < 1 {
+			return fmt.Errorf("scrypt r and p must be at least 1")
+		}
+	case KdfFunction_Argon2id:
+		if s.Argon2Time < 1 || s.Argon2Threads < 1 {
+			return fmt.Errorf("argon2id time and threads must be at least 1")
+		}
+		if s.Argon2Memory < 8*uint32(s.Argon2Threads) {
+			return fmt.Errorf("argon2id memory must be at least 8 KiB per thread")
+		}
+	default:
+		return fmt.Errorf("unsupported key derivation function [%s]", s.Function)
+	}
+	return nil
+}
+
 // Keystore for local node wallets - note that this is NOT an EIP-2335 keystore.
 type LocalWalletData struct {
 	// Encrypted seed information
@@ -71,9 +146,100 @@ type LocalWalletData struct {
 	WalletIndex uint `json:"walletIndex,omitempty"`
 }
 
-// Placeholder for hardware wallets
+// An account derived from a local wallet's seed
+type DerivedAccount struct {
+	// The index used to format the wallet's derivation path
+	Index uint `json:"index"`
+
+	// The account's address
+	Address common.Address `json:"address"`
+}
+
+// The kind of device a hardware wallet is on
+type HardwareWalletDevice string
+
+const (
+	// A Ledger device running the Ethereum app
+	HardwareWalletDevice_Ledger HardwareWalletDevice = "ledger"
+
+	// A Trezor device
+	HardwareWalletDevice_Trezor HardwareWalletDevice = "trezor"
+)
+
+// Data for hardware wallets - the private key never leaves the device, so this only records how to find it
 type HardwareWalletData struct {
-	// NYI
+	// The kind of device the key is on
+	Device HardwareWalletDevice `json:"device,omitempty"`
+
+	// The path that should be used to derive the target key; assumes there's only one index that can be iterated on
+	DerivationPath string `json:"derivationPath,omitempty"`
+
+	// The index of the target wallet, used to format DerivationPath
+	WalletIndex uint `json:"walletIndex,omitempty"`
+
+	// The address of the derived key, used to make sure the connected device holds the expected key
+	Address common.Address `json:"address,omitempty"`
+}
+
+// Data for remote signer wallets - the private key is held by a signing service (such as Web3Signer) that exposes the
+// eth_sign and eth_signTransaction JSON-RPC methods, so this only records how to reach it
+type RemoteSignerWalletData struct {
+	// The URL of the signing service's JSON-RPC endpoint
+	Url string `json:"url"`
+
+	// The address of the node account on the signing service
+	Address common.Address `json:"address"`
+
+	// The path of the PEM-encoded CA certificate used to verify the signing service, if it doesn't use a publicly
+	// trusted certificate
+	CaCertPath string `json:"caCertPath,omitempty"`
+
+	// The paths of the PEM-encoded client certificate and key used to authenticate with the signing service, if it
+	// requires mutual TLS
+	ClientCertPath string `json:"clientCertPath,omitempty"`
+	ClientKeyPath  string `json:"clientKeyPath,omitempty"`
+
+	// Allow the URL to use plain HTTP instead of HTTPS. Signing requests and responses aren't protected then, so this
+	// should only be used for signers on the same machine.
+	AllowInsecureHttp bool `json:"allowInsecureHttp,omitempty"`
+}
+
+// The format of an AWS region name, such as us-east-1
+const AwsRegionRegex string = "^[a-z]{2}(-[a-z]+)+-[0-9]+$"
+
+// The cloud key management service that holds a KMS wallet's key
+type KmsProvider string
+
+const (
+	// AWS Key Management Service
+	KmsProvider_Aws KmsProvider = "aws"
+
+	// Google Cloud Key Management Service
+	KmsProvider_Gcp KmsProvider = "gcp"
+)
+
+// Data for KMS wallets - the private key is a secp256k1 key that never leaves the key management service, so this only
+// records how to reach it
+type KmsWalletData struct {
+	// The service that holds the key
+	Provider KmsProvider `json:"provider"`
+
+	// The ID or ARN of the key for AWS, or the resource name of the key version for GCP
+	KeyId string `json:"keyId"`
+
+	// The region the key is in (AWS only)
+	Region string `json:"region,omitempty"`
+
+	// The path of the shared credentials file for AWS, or the service account key file for GCP. If this is empty, the
+	// credentials come from the AWS SDK's default credential chain for AWS, or the instance's metadata server for GCP.
+	CredentialsPath string `json:"credentialsPath,omitempty"`
+
+	// The profile to use from the shared credentials file, or from the default shared config files if there isn't one
+	// (AWS only)
+	Profile string `json:"profile,omitempty"`
+
+	// The address of the key, used to make sure the key ID still refers to the expected key
+	Address common.Address `json:"address,omitempty"`
 }
 
 // Data storage for node wallets
@@ -86,4 +252,73 @@ type WalletData struct {
 
 	// Data about a hardware wallet
 	HardwareData HardwareWalletData `json:"hardwareData"`
+
+	// Data about a remote signer wallet
+	RemoteSignerData RemoteSignerWalletData `json:"remoteSignerData"`
+
+	// Data about a KMS wallet
+	KmsData KmsWalletData `json:"kmsData"`
+}
+
+// Where the daemon gets the wallet password from
+type PasswordSourceType string
+
+const (
+	// A file on disk; this is the only source the daemon can save the password to
+	PasswordSourceType_File PasswordSourceType = "file"
+
+	// An environment variable
+	PasswordSourceType_Env PasswordSourceType = "env"
+
+	// The output of an external command, such as a password manager's CLI
+	PasswordSourceType_Command PasswordSourceType = "command"
+
+	// A file descriptor inherited from the daemon's parent process, such as a pipe
+	PasswordSourceType_FileDescriptor PasswordSourceType = "fd"
+
+	// A secret in HashiCorp Vault's key-value engine
+	PasswordSourceType_Vault PasswordSourceType = "vault"
+)
+
+// Settings for the wallet password's source. Only the fields for the selected source type are used.
+type PasswordSourceSettings struct {
+	// The type of source
+	Type PasswordSourceType `json:"type"`
+
+	// The path of the password file, for file sources
+	FilePath string `json:"filePath,omitempty"`
+
+	// The name of the environment variable, for env sources
+	EnvVar string `json:"envVar,omitempty"`
+
+	// The command and its arguments, for command sources. It's run directly rather than through a shell, and its
+	// output (minus the trailing newline) is the password.
+	Command []string `json:"command,omitempty"`
+
+	// The number of the file descriptor, for fd sources. It's read once, the first time the password is needed.
+	FileDescriptor uint `json:"fileDescriptor,omitempty"`
+
+	// The secret's location, for Vault sources
+	Vault VaultPasswordSettings `json:"vault,omitempty"`
+}
+
+// The location of a wallet password stored in HashiCorp Vault
+type VaultPasswordSettings struct {
+	// The URL of the Vault server
+	Address string `json:"address"`
+
+	// The path of the secret to read, including the engine's mount, such as "secret/data/node" for KV version 2
+	Path string `json:"path"`
+
+	// The field of the secret that holds the password
+	Field string `json:"field"`
+
+	// The path of a file with the Vault token; if it's empty, the VAULT_TOKEN environment variable is used instead
+	TokenPath string `json:"tokenPath,omitempty"`
+
+	// The Vault Enterprise namespace, if there is one
+	Namespace string `json:"namespace,omitempty"`
+
+	// The path of the CA certificate for the server, if it isn't trusted by the system
+	CaCertPath string `json:"caCertPath,omitempty"`
 }